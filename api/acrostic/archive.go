@@ -0,0 +1,56 @@
+package acrostic
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/archive"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"time"
+)
+
+// ArchiveKey returns the key that should be used in redis to store a
+// particular channel's archive of past acrostic attempts.
+func ArchiveKey(name string) string {
+	return archive.Key("acrostic", name)
+}
+
+// NewAttempt creates a record of a channel's attempt at solving an acrostic
+// from the provided state and the log of answers submitted during the solve.
+// The attempt is considered to have ended at the provided time.
+func NewAttempt(channel string, state State, answers []model.LoggedAnswer, now time.Time) model.Attempt {
+	summary := state.Summary(answers)
+	return model.Attempt{
+		Channel:     channel,
+		Status:      state.Status,
+		Description: state.Puzzle.Description,
+		Puzzle: model.PuzzleSource{
+			Publisher:     state.Puzzle.Publisher,
+			PublishedDate: state.Puzzle.PublishedDate,
+		},
+		TotalSolveDuration: state.TotalSolveDuration,
+		EndTime:            now,
+		Summary:            &summary,
+		Card:               state.ShareCard(),
+	}
+}
+
+// ArchiveAttempt will add the provided attempt to the archive of attempts for
+// the provided channel name.  If the attempt can't be properly written then an
+// error will be returned.
+func ArchiveAttempt(conn db.Connection, channel string, attempt model.Attempt) error {
+	if testArchiveSaveError != nil {
+		return testArchiveSaveError
+	}
+
+	return archive.Add(conn, "acrostic", channel, attempt)
+}
+
+// GetLatestAttempt loads the most recent attempt from the archive of attempts
+// for the provided channel name.  If the channel hasn't archived any attempts
+// then nil is returned.
+func GetLatestAttempt(conn db.Connection, channel string) (*model.Attempt, error) {
+	if testArchiveLoadError != nil {
+		return nil, testArchiveLoadError
+	}
+
+	return archive.GetLatest(conn, "acrostic", channel)
+}
@@ -0,0 +1,20 @@
+package acrostic
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"strings"
+)
+
+// ShareCard renders the state as a compact text card that's suitable for
+// sharing.  The card includes a header describing the puzzle and the result of
+// the solve.  The card is built only from the state so the same state always
+// renders the same card.
+func (s *State) ShareCard() string {
+	header := model.ShareCardHeader("Acrostic", model.PuzzleSource{
+		Publisher:     s.Puzzle.Publisher,
+		PublishedDate: s.Puzzle.PublishedDate,
+	})
+	result := model.ShareCardResult(s.Status, s.TotalSolveDuration)
+
+	return strings.Join([]string{header, result}, "\n")
+}
@@ -0,0 +1,40 @@
+package acrostic
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestState_ShareCard(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   model.Status
+		expected string
+	}{
+		{
+			name:   "complete",
+			status: model.StatusComplete,
+			expected: "Acrostic · The New York Times · 2020-05-24\n" +
+				"✅ Solved in 1h2m3s",
+		},
+		{
+			name:   "given up",
+			status: model.StatusGivenUp,
+			expected: "Acrostic · The New York Times · 2020-05-24\n" +
+				"🏳️ Gave up after 1h2m3s",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			state.Status = test.status
+			state.LastStartTime = nil
+			state.TotalSolveDuration = model.Duration{Duration: time.Hour + 2*time.Minute + 3*time.Second}
+
+			assert.Equal(t, test.expected, state.ShareCard())
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package acrostic
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/answerlog"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/worker"
+	"github.com/gomodule/redigo/redis"
+	"time"
+)
+
+// ExpireSolves expires each acrostic solve in progress that has run past its
+// channel's time limit.
+func ExpireSolves(conn redis.Conn, registry *pubsub.Registry, now time.Time) error {
+	return worker.ForEachChannel(conn, StateKey, func(channel string) error {
+		return ExpireIfOutOfTime(conn, registry, channel, now)
+	})
+}
+
+// ExpireIfOutOfTime marks a channel's acrostic solve as expired when its
+// elapsed time has reached the time limit it was started with, and archives
+// the unfinished attempt.
+func ExpireIfOutOfTime(conn redis.Conn, registry *pubsub.Registry, channel string, now time.Time) error {
+	var state State
+	var answers []model.LoggedAnswer
+	read := func(conn db.Connection) error {
+		var err error
+		state, err = GetState(conn, channel)
+		if err != nil {
+			return err
+		}
+
+		// The answer log is only needed to archive the attempt when it expires.
+		if state.Status == model.StatusSolving && state.IsOutOfTime(now) {
+			answers, err = answerlog.Get(conn, "acrostic", channel)
+		}
+		return err
+	}
+
+	write := func(tx db.Connection) ([]pubsub.Event, error) {
+		if state.Status != model.StatusSolving || !state.IsOutOfTime(now) {
+			return nil, nil
+		}
+
+		state.Status = model.StatusExpired
+		state.LastStartTime = nil
+		state.TotalSolveDuration = state.TimeLimit
+
+		if err := SetState(tx, channel, state); err != nil {
+			return nil, err
+		}
+
+		if err := ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now)); err != nil {
+			return nil, err
+		}
+
+		// Broadcast to all of the clients that the solve has run out of time,
+		// making sure to not include the solution.
+		state.Puzzle = state.PublicPuzzle()
+
+		return []pubsub.Event{StateEvent(state), ExpiredEvent()}, nil
+	}
+
+	return worker.Update(conn, registry, ChannelID(channel), StateKey(channel), read, write)
+}
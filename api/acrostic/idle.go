@@ -0,0 +1,64 @@
+package acrostic
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/worker"
+	"github.com/gomodule/redigo/redis"
+	"time"
+)
+
+// PauseIdleSolves pauses each acrostic solve in progress that has sat idle for
+// longer than its channel allows.
+func PauseIdleSolves(conn redis.Conn, registry *pubsub.Registry, now time.Time) error {
+	return worker.ForEachChannel(conn, StateKey, func(channel string) error {
+		return PauseIfIdle(conn, registry, channel, now)
+	})
+}
+
+// PauseIfIdle pauses a channel's acrostic solve when nothing has been filled
+// in correctly since it was started or last made progress, whichever is more
+// recent, for the channel's configured idle timeout.  The time spent idle isn't
+// counted towards the solve.
+func PauseIfIdle(conn redis.Conn, registry *pubsub.Registry, channel string, now time.Time) error {
+	settings, err := GetSettings(conn, channel)
+	if err != nil {
+		return err
+	}
+
+	var state State
+	read := func(conn db.Connection) error {
+		var err error
+		state, err = GetState(conn, channel)
+		return err
+	}
+
+	write := func(tx db.Connection) ([]pubsub.Event, error) {
+		if state.LastStartTime == nil {
+			return nil, nil
+		}
+
+		last := state.LastActivityTime()
+		if !worker.IsStalled(state.Status, last, settings.IdleTimeout.Duration, now) {
+			return nil, nil
+		}
+
+		state.Status = model.StatusPaused
+		total := state.TotalSolveDuration.Nanoseconds() + last.Sub(*state.LastStartTime).Nanoseconds()
+		state.LastStartTime = nil
+		state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+		if err := SetState(tx, channel, state); err != nil {
+			return nil, err
+		}
+
+		// Broadcast to all of the clients that the puzzle status has been changed,
+		// making sure to not include the solution.
+		state.Puzzle = state.PublicPuzzle()
+
+		return []pubsub.Event{StateEvent(state)}, nil
+	}
+
+	return worker.Update(conn, registry, ChannelID(channel), StateKey(channel), read, write)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
+	"github.com/bbeck/puzzles-with-chat/api/cache"
 	"github.com/bbeck/puzzles-with-chat/api/web"
 	"html"
 	"io"
@@ -32,20 +33,19 @@ func LoadFromNewYorkTimes(date string) (*Puzzle, error) {
 		return nil, testPuzzleLoadError
 	}
 
+	var puzzle *Puzzle
 	url := fmt.Sprintf("https://www.xwordinfo.com/JSON/AcData.aspx?date=%s", date)
-	response, err := web.Get(url)
-	if response != nil {
-		defer func() { _ = response.Body.Close() }()
-	}
+	err := cache.Fetch("acrostic/new_york_times", date, url, nil, func(in io.Reader) error {
+		var err error
+		if puzzle, err = ParseXWordInfoPuzzleResponse(in); err != nil {
+			return fmt.Errorf("unable to parse xwordinfo.com response for date %s: %v", date, err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	puzzle, err := ParseXWordInfoPuzzleResponse(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse xwordinfo.com response for date %s: %v", date, err)
-	}
-
 	return puzzle, nil
 }
 
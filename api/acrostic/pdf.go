@@ -0,0 +1,121 @@
+package acrostic
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/pdf"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The width in points of each blank that an answer letter is written on beneath
+// a clue.
+const pdfBlankWidth = 20.0
+
+// The height in points of each row of blanks beneath a clue.
+const pdfBlankHeight = 24.0
+
+// PDF lays out the state's puzzle as a printable document.  The grid is drawn
+// at the top of the first page with its blocks, numbers and clue letters and
+// the lettered clues flow into columns beneath it, each followed by a numbered
+// blank for every letter of its answer.  When showLetters is true the letters
+// that have been filled in are drawn in the grid and on the blanks, otherwise
+// only the givens are.
+func (s *State) PDF(showLetters bool) *pdf.Document {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	var subtitle []string
+	if s.Puzzle.Publisher != "" {
+		subtitle = append(subtitle, s.Puzzle.Publisher)
+	}
+	if !s.Puzzle.PublishedDate.IsZero() {
+		subtitle = append(subtitle, s.Puzzle.PublishedDate.Format("Monday, January 2, 2006"))
+	}
+	top := page.Header("Acrostic", strings.Join(subtitle, " · "))
+
+	// letter returns the letter of a cell that should be drawn, if any.
+	letter := func(x, y int) string {
+		if showLetters || s.Puzzle.Givens[y][x] != "" {
+			return s.Cells[y][x]
+		}
+		return ""
+	}
+
+	// The grid spans the width of the page but is limited to roughly half of its
+	// height so that there's room for clues beneath it.
+	size := math.Min(
+		(pdf.PageWidth-2*pdf.Margin)/float64(s.Puzzle.Cols),
+		(pdf.PageHeight/2)/float64(s.Puzzle.Rows),
+	)
+	left := (pdf.PageWidth - size*float64(s.Puzzle.Cols)) / 2
+
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			cx, cy := left+float64(x)*size, top+float64(y)*size
+
+			if s.Puzzle.CellBlocks[y][x] {
+				page.FillRect(cx, cy, size, size, 0)
+			}
+			page.StrokeRect(cx, cy, size, size, 0.5)
+
+			if s.Puzzle.CellBlocks[y][x] {
+				continue
+			}
+
+			small := size * 0.25
+			if number := s.Puzzle.CellNumbers[y][x]; number != 0 {
+				page.Text(cx+1.5, cy+1.5+small, pdf.Regular, small, strconv.Itoa(number))
+			}
+
+			if clue := s.Puzzle.CellClueLetters[y][x]; clue != "" {
+				width := pdf.TextWidth(pdf.Regular, small, clue)
+				page.Text(cx+size-1.5-width, cy+1.5+small, pdf.Regular, small, clue)
+			}
+
+			if l := letter(x, y); l != "" {
+				height := size * 0.55
+				width := pdf.TextWidth(pdf.Regular, height, l)
+				page.Text(cx+(size-width)/2, cy+size-size*0.12, pdf.Regular, height, l)
+			}
+		}
+	}
+
+	flow := doc.NewFlow(top+size*float64(s.Puzzle.Rows)+18, 2)
+	flow.Heading("CLUES")
+	for _, clue := range ClueLetters {
+		text, ok := s.Puzzle.Clues[clue]
+		if !ok {
+			continue
+		}
+		flow.Paragraph(clue+".", text)
+
+		numbers := s.Puzzle.ClueNumbers[clue]
+		perRow := int((flow.ColumnWidth() - flow.Indent) / pdfBlankWidth)
+		for start := 0; start < len(numbers); start += perRow {
+			end := start + perRow
+			if end > len(numbers) {
+				end = len(numbers)
+			}
+
+			page, x, y := flow.Reserve(pdfBlankHeight)
+			x += flow.Indent
+			for i, number := range numbers[start:end] {
+				bx := x + float64(i)*pdfBlankWidth
+				page.Line(bx, y+14, bx+pdfBlankWidth-4, y+14, 0.5)
+
+				label := strconv.Itoa(number)
+				width := pdf.TextWidth(pdf.Regular, 6, label)
+				page.Text(bx+(pdfBlankWidth-4-width)/2, y+21, pdf.Regular, 6, label)
+
+				if cx, cy, err := s.Puzzle.GetCellCoordinates(number); err == nil {
+					if l := letter(cx, cy); l != "" {
+						width := pdf.TextWidth(pdf.Regular, 11, l)
+						page.Text(bx+(pdfBlankWidth-4-width)/2, y+12, pdf.Regular, 11, l)
+					}
+				}
+			}
+		}
+	}
+
+	return doc
+}
@@ -0,0 +1,35 @@
+package acrostic
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestState_PDF(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	require.NoError(t, state.ApplyClueAnswer("W", "ASSASSINS", false))
+
+	filled := string(state.PDF(true).Bytes())
+	assert.True(t, strings.HasPrefix(filled, "%PDF-"))
+	assert.Contains(t, filled, "(Acrostic) Tj")
+	assert.Contains(t, filled, "(W.) Tj")
+	assert.Contains(t, filled, "(Killer musical by Stephen Sondheim) Tj")
+	assert.Contains(t, filled, "(123) Tj")
+	assert.Regexp(t, blankLetter("S"), filled)
+
+	// A blank puzzle still has its numbers and clue letters but none of the
+	// filled in letters.
+	blank := string(state.PDF(false).Bytes())
+	assert.Contains(t, blank, "(123) Tj")
+	assert.Contains(t, blank, "(W) Tj")
+	assert.NotRegexp(t, blankLetter("S"), blank)
+}
+
+// blankLetter returns a regular expression that matches a letter written on
+// one of the blanks beneath a clue.
+func blankLetter(letter string) *regexp.Regexp {
+	return regexp.MustCompile(`/F1 11.00 Tf [0-9. ]+ Td \(` + letter + `\) Tj`)
+}
@@ -2,6 +2,7 @@ package acrostic
 
 import (
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/model"
 	"time"
 )
 
@@ -76,6 +77,17 @@ type Puzzle struct {
 	ClueNumbers map[string][]int `json:"clue_numbers"`
 }
 
+// Fingerprint computes a hash of the grid and clues of the puzzle.  Puzzles
+// with the same fingerprint are the same acrostic even if they were loaded from
+// different sources.
+func (p *Puzzle) Fingerprint() string {
+	return model.Fingerprint(struct {
+		Cells      [][]string
+		CellBlocks [][]bool
+		Clues      map[string]string
+	}{p.Cells, p.CellBlocks, p.Clues})
+}
+
 // WithoutSolution returns a copy of the puzzle that has the solution cells
 // missing.  This makes it suitable to pass to a client that shouldn't know the
 // answers to the puzzle.
@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestPuzzle_WithoutSolution(t *testing.T) {
@@ -55,6 +56,21 @@ func TestPuzzle_WithoutSolution(t *testing.T) {
 	}
 }
 
+func TestPuzzle_Fingerprint(t *testing.T) {
+	puzzle := LoadTestPuzzle(t, "xwordinfo-nyt-20200524.json")
+	fingerprint := puzzle.Fingerprint()
+	assert.NotEmpty(t, fingerprint)
+
+	// Where the puzzle came from doesn't change its fingerprint.
+	puzzle.Publisher = ""
+	puzzle.PublishedDate = time.Time{}
+	assert.Equal(t, fingerprint, puzzle.Fingerprint())
+
+	// Its clues do.
+	puzzle.Clues["A"] = "A different clue"
+	assert.NotEqual(t, fingerprint, puzzle.Fingerprint())
+}
+
 func TestPuzzle_GetCellCoordinates(t *testing.T) {
 	tests := []struct {
 		name      string
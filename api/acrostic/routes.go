@@ -3,8 +3,14 @@ package acrostic
 import (
 	"compress/flate"
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/answerlog"
+	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pdf"
 	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/snapshot"
+	"github.com/bbeck/puzzles-with-chat/api/team"
+	"github.com/bbeck/puzzles-with-chat/api/web"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
@@ -21,10 +27,19 @@ import (
 func RegisterRoutes(r chi.Router, pool *redis.Pool, registry *pubsub.Registry) {
 	r.Route("/acrostic/{channel}", func(r chi.Router) {
 		r.Put("/", UpdatePuzzle(pool, registry))
+		r.Put("/upload", UploadPuzzle(pool, registry))
 		r.Get("/events", GetEvents(pool, registry))
+		r.Get("/state", GetCurrentState(pool))
+		r.Get("/settings", GetCurrentSettings(pool))
+		r.Get("/solution", GetSolution(pool))
+		r.Get("/share", GetShareCard(pool))
+		r.Get("/snapshot", GetSnapshot(pool))
+		r.Get("/pdf", GetPDF(pool))
 		r.Put("/setting/{setting}", UpdateSetting(pool, registry))
 		r.Get("/show/{clue}", ShowClue(registry))
 		r.Put("/status", ToggleStatus(pool, registry))
+		r.Put("/reset", ResetState(pool, registry))
+		r.Put("/giveup", GiveUp(pool, registry))
 		r.Put("/answer/{clue}", UpdateAnswer(pool, registry))
 	})
 
@@ -70,39 +85,310 @@ func UpdatePuzzle(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		conn := pool.Get()
 		defer func() { _ = conn.Close() }()
 
-		// Initialize the cell values.  Most cells will be empty with the exception
-		// of cells containing givens.
-		cells := make([][]string, puzzle.Rows)
-		for row := 0; row < puzzle.Rows; row++ {
-			cells[row] = make([]string, puzzle.Cols)
-			for col := 0; col < puzzle.Cols; col++ {
-				if puzzle.Givens[row][col] != "" {
-					cells[row][col] = puzzle.Givens[row][col]
-				}
-			}
+		if err := SelectPuzzle(conn, registry, channel, puzzle); err != nil {
+			log.Printf("unable to save state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
 
-		state := State{
-			Status:      model.StatusSelected,
-			Puzzle:      puzzle,
-			Cells:       cells,
-			CluesFilled: make(map[string]bool),
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// UploadPuzzle changes the acrostic puzzle that's currently being solved for a
+// channel to one provided in the request body in the same JSON format as a
+// Puzzle.  The puzzle is validated before it's selected, and the validation
+// report is returned to the uploader.  If the puzzle has errors then it's
+// rejected with a 422 status.
+func UploadPuzzle(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		var puzzle Puzzle
+		if err := render.DecodeJSON(r.Body, &puzzle); err != nil {
+			log.Printf("unable to read request body: %+v", err)
+			report := model.NewValidationReport()
+			report.Errorf("unable to parse puzzle: %v", err)
+			report.Render(w, r, http.StatusBadRequest)
+			return
+		}
+
+		puzzle.Normalize()
+
+		report := puzzle.Validate()
+		if !report.Valid() {
+			log.Printf("rejected uploaded acrostic for channel %s: %v", channel, report.Errors)
+			report.Render(w, r, http.StatusUnprocessableEntity)
+			return
 		}
-		if err := SetState(conn, channel, state); err != nil {
+
+		if puzzle.Description == "" {
+			puzzle.Description = "Acrostic uploaded by the streamer"
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		if err := SelectPuzzle(conn, registry, channel, &puzzle); err != nil {
 			log.Printf("unable to save state for channel %s: %+v", channel, err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		// Broadcast to all of the clients that the puzzle has been selected, making
-		// sure to not include the answers.  It's okay to overwrite the puzzle
-		// attribute because we just wrote this state instance to the database
-		// and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutSolution()
+		report.Render(w, r, http.StatusOK)
+	}
+}
 
-		registry.Publish(ChannelID(channel), StateEvent(state))
+// SelectPuzzle starts a new solve of a puzzle for a channel, replacing any
+// solve that was already in progress.  Clients watching the channel are sent
+// the new state without the puzzle's answers.
+func SelectPuzzle(conn redis.Conn, registry *pubsub.Registry, channel string, puzzle *Puzzle) error {
+	// Initialize the cell values.  Most cells will be empty with the exception
+	// of cells containing givens.
+	cells := make([][]string, puzzle.Rows)
+	for row := 0; row < puzzle.Rows; row++ {
+		cells[row] = make([]string, puzzle.Cols)
+		for col := 0; col < puzzle.Cols; col++ {
+			if puzzle.Givens[row][col] != "" {
+				cells[row][col] = puzzle.Givens[row][col]
+			}
+		}
+	}
 
-		w.WriteHeader(http.StatusOK)
+	state := State{
+		Status:      model.StatusSelected,
+		Puzzle:      puzzle,
+		Cells:       cells,
+		CluesFilled: make(map[string]bool),
+	}
+	if err := SetState(conn, channel, state); err != nil {
+		return err
+	}
+
+	// The answers submitted during the previous solve don't apply to this one.
+	if err := answerlog.Clear(conn, "acrostic", channel); err != nil {
+		return err
+	}
+
+	// Broadcast to all of the clients that the puzzle has been selected, making
+	// sure to not include the answers.  It's okay to overwrite the puzzle
+	// attribute because we just wrote this state instance to the database
+	// and will be discarding it immediately publishing.
+	state.Puzzle = state.PublicPuzzle()
+
+	registry.Publish(ChannelID(channel), StateEvent(state))
+	return nil
+}
+
+// GetCurrentState returns the current state of a channel's acrostic solve with
+// the solution to the puzzle removed.  The response includes an ETag so that
+// clients polling for the state can avoid downloading it when it hasn't
+// changed.
+func GetCurrentState(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		// The version is loaded before the state so that if the state is written
+		// in between the two the client will just download it again next time.
+		version, err := GetStateVersion(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state version for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to read state for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Return the same payload that would be published for the state, making
+		// sure to not include the solution to the puzzle.
+		state.Puzzle = state.PublicPuzzle()
+
+		if err := web.RenderJSONWithVersion(w, r, version, StateEvent(state).Payload); err != nil {
+			log.Printf("unable to render state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetCurrentSettings returns the acrostic settings of a channel.  The response
+// includes an ETag so that clients polling for the settings can avoid
+// downloading them when they haven't changed.
+func GetCurrentSettings(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		settings, err := GetSettings(conn, channel)
+		if err != nil {
+			log.Printf("unable to read settings for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.RenderJSONWithETag(w, r, settings); err != nil {
+			log.Printf("unable to render settings for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetSolution returns a channel's acrostic puzzle including its solution.  The
+// solution is only available once the solve has finished.
+func GetSolution(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to read solution for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !state.Status.IsFinished() {
+			log.Printf("unable to read solution for channel %s, status is %s", channel, state.Status)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		render.JSON(w, r, state.Puzzle)
+	}
+}
+
+// GetShareCard returns the share card of the channel's most recently archived
+// acrostic attempt as plain text.
+func GetShareCard(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		attempt, err := GetLatestAttempt(conn, channel)
+		if err != nil {
+			log.Printf("unable to read latest attempt for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if attempt == nil || attempt.Card == "" {
+			log.Printf("unable to read share card for channel %s, no archived attempt", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(attempt.Card))
+	}
+}
+
+// GetSnapshot renders the channel's current acrostic grid as an SVG or PNG image.
+// The image format is chosen with the format query parameter and the filled in letters can be
+// hidden by setting the letters query parameter to false.
+func GetSnapshot(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		options, err := snapshot.ParseOptions(r)
+		if err != nil {
+			log.Printf("unable to parse snapshot options for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to render snapshot for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err := snapshot.Write(w, state.Snapshot(options.ShowLetters), options.Format); err != nil {
+			log.Printf("unable to write snapshot for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetPDF returns a printable PDF of the channel's current acrostic as a
+// download.  By default the puzzle is blank, setting the filled query
+// parameter to true includes the letters that the channel has filled in.
+func GetPDF(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		var filled bool
+		if param := r.URL.Query().Get("filled"); param != "" {
+			var err error
+			if filled, err = strconv.ParseBool(param); err != nil {
+				log.Printf("unable to parse filled parameter %s for channel %s: %+v", param, channel, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to render pdf for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		filename := "acrostic.pdf"
+		if !state.Puzzle.PublishedDate.IsZero() {
+			filename = fmt.Sprintf("acrostic-%s.pdf", state.Puzzle.PublishedDate.Format("2006-01-02"))
+		}
+
+		if err := pdf.Write(w, filename, state.PDF(filled)); err != nil {
+			log.Printf("unable to write pdf for channel %s: %+v", channel, err)
+			return
+		}
 	}
 }
 
@@ -144,7 +430,7 @@ func GetEvents(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 			return
 		}
 		if state.Puzzle != nil {
-			state.Puzzle = state.Puzzle.WithoutSolution()
+			state.Puzzle = state.PublicPuzzle()
 			stream <- StateEvent(state)
 		}
 
@@ -202,6 +488,34 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 			}
 			settings.ClueFontSize = value
 
+		case "idle_timeout":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse acrostic idle timeout setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid acrostic idle timeout setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.IdleTimeout = value
+
+		case "time_limit":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse acrostic time limit setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid acrostic time limit setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.TimeLimit = value
+
 		default:
 			log.Printf("unrecognized acrostic setting name %s", setting)
 			w.WriteHeader(http.StatusBadRequest)
@@ -220,29 +534,44 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		// was an error earlier we don't modify the solve's state.
 		var updatedState *State
 		if shouldClearIncorrectCells {
-			state, err := GetState(conn, channel)
-			if err != nil {
-				log.Printf("unable to load state for channel %s: %+v", channel, err)
-				w.WriteHeader(http.StatusNotFound)
-				return
-			}
+			var state State
+			var changed bool
+			read := func(conn db.Connection) error {
+				var err error
+				state, err = GetState(conn, channel)
+				if err != nil {
+					return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+				}
+
+				// There's no need to update cells if the puzzle hasn't been selected or
+				// started or has already ended.
+				status := state.Status
+				changed = status != model.StatusCreated && status != model.StatusSelected && status != model.StatusComplete && status != model.StatusGivenUp && status != model.StatusExpired
+				if !changed {
+					return nil
+				}
 
-			// There's no need to update cells if the puzzle hasn't been selected or
-			// started or is already complete.
-			status := state.Status
-			if status != model.StatusCreated && status != model.StatusSelected && status != model.StatusComplete {
 				if err := state.ClearIncorrectCells(); err != nil {
-					log.Printf("unable to clear incorrect cells for channel: %s: %+v", channel, err)
-					w.WriteHeader(http.StatusInternalServerError)
-					return
+					return fmt.Errorf("unable to clear incorrect cells: %v", err)
 				}
+				return nil
+			}
 
-				if err := SetState(conn, channel, state); err != nil {
-					log.Printf("unable to save state for channel %s: %+v", channel, err)
-					w.WriteHeader(http.StatusInternalServerError)
-					return
+			write := func(tx db.Connection) error {
+				if !changed {
+					return nil
 				}
 
+				return SetState(tx, channel, state)
+			}
+
+			if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+				log.Printf("unable to clear incorrect cells for channel %s: %+v", channel, err)
+				w.WriteHeader(web.StatusCode(err))
+				return
+			}
+
+			if changed {
 				updatedState = &state
 			}
 		}
@@ -253,7 +582,7 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		if updatedState != nil {
 			// Broadcast the updated state to all of the clients, making sure to not
 			// include the answers.
-			updatedState.Puzzle = updatedState.Puzzle.WithoutSolution()
+			updatedState.Puzzle = updatedState.PublicPuzzle()
 
 			registry.Publish(ChannelID(channel), StateEvent(*updatedState))
 		}
@@ -292,44 +621,60 @@ func ToggleStatus(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		conn := pool.Get()
 		defer func() { _ = conn.Close() }()
 
-		state, err := GetState(conn, channel)
-		if err != nil {
-			log.Printf("unable to load state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
+		var state State
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
 
-		now := time.Now()
+			settings, err := GetSettings(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load settings: %v", err)
+			}
 
-		switch state.Status {
-		case model.StatusCreated:
-			log.Printf("unable to toggle status for channel %s, no puzzle selected", channel)
-			w.WriteHeader(http.StatusBadRequest)
-			return
+			now := time.Now()
 
-		case model.StatusSelected:
-			state.Status = model.StatusSolving
-			state.LastStartTime = &now
+			switch state.Status {
+			case model.StatusCreated:
+				return web.Errorf(http.StatusBadRequest, "no puzzle selected")
 
-		case model.StatusPaused:
-			state.Status = model.StatusSolving
-			state.LastStartTime = &now
+			case model.StatusSelected:
+				state.Status = model.StatusSolving
+				state.LastStartTime = &now
+				state.TimeLimit = settings.TimeLimit
 
-		case model.StatusSolving:
-			state.Status = model.StatusPaused
-			total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
-			state.LastStartTime = nil
-			state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+			case model.StatusPaused:
+				state.Status = model.StatusSolving
+				state.LastStartTime = &now
 
-		case model.StatusComplete:
-			log.Printf("unable to toggle status for channel %s, puzzle is already solved", channel)
-			w.WriteHeader(http.StatusBadRequest)
-			return
+			case model.StatusSolving:
+				state.Status = model.StatusPaused
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+			case model.StatusComplete:
+				return web.Errorf(http.StatusBadRequest, "puzzle is already solved")
+
+			case model.StatusGivenUp:
+				return web.Errorf(http.StatusBadRequest, "puzzle was given up")
+
+			case model.StatusExpired:
+				return web.Errorf(http.StatusBadRequest, "puzzle ran out of time")
+			}
+
+			return nil
 		}
 
-		if err := SetState(conn, channel, state); err != nil {
-			log.Printf("unable to save state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
+		write := func(tx db.Connection) error {
+			return SetState(tx, channel, state)
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to toggle status for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
 			return
 		}
 
@@ -337,8 +682,128 @@ func ToggleStatus(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		// making sure to not include the answers.  It's okay to overwrite the
 		// puzzle attribute because we just wrote this state instance to the
 		// database and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutSolution()
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ResetState clears all progress made on the current acrostic solve while
+// keeping the puzzle.  The solve is returned to the selected status and its
+// timer is reset.
+func ResetState(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			if state.Status == model.StatusCreated {
+				return web.Errorf(http.StatusBadRequest, "no puzzle selected")
+			}
+
+			state.Reset()
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			return answerlog.Clear(tx, "acrostic", channel)
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to reset state for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
+
+		// Broadcast to all of the clients that the puzzle has been reset, making
+		// sure to not include the answers.  It's okay to overwrite the puzzle
+		// attribute because we just wrote this state instance to the database
+		// and will be discarding it immediately publishing.
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// GiveUp ends the current acrostic solve without completing it.  The solution
+// is revealed to all of the clients and the attempt is archived as unfinished.
+func GiveUp(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		var answers []model.LoggedAnswer
+		var now time.Time
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			now = time.Now()
+
+			switch state.Status {
+			case model.StatusSelected, model.StatusPaused:
+
+			case model.StatusSolving:
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+			default:
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
+
+			if err := state.RevealSolution(); err != nil {
+				return fmt.Errorf("unable to reveal solution: %v", err)
+			}
+			state.Status = model.StatusGivenUp
+
+			answers, err = answerlog.Get(conn, "acrostic", channel)
+			if err != nil {
+				return fmt.Errorf("unable to load answer log: %v", err)
+			}
+
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			return ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now))
+		}
 
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to give up for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
+
+		// Broadcast to all of the clients that the puzzle has been given up on.
+		// The solution is intentionally included so that it can be displayed.
 		registry.Publish(ChannelID(channel), StateEvent(state))
 
 		w.WriteHeader(http.StatusOK)
@@ -346,11 +811,14 @@ func ToggleStatus(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 }
 
 // UpdateAnswer applies an answer to either a given clue or given set of cells
-// in the current acrostic solve.
+// in the current acrostic solve.  The optional user query parameter names the
+// chatter that provided the answer so that their team can be credited with any
+// completed clues.
 func UpdateAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		channel := chi.URLParam(r, "channel")
 		clue := strings.ToUpper(chi.URLParam(r, "clue"))
+		user := r.URL.Query().Get("user")
 
 		if r.ContentLength > 1024 {
 			w.WriteHeader(http.StatusRequestEntityTooLarge)
@@ -372,52 +840,124 @@ func UpdateAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		conn := pool.Get()
 		defer func() { _ = conn.Close() }()
 
-		state, err := GetState(conn, channel)
-		if err != nil {
-			log.Printf("unable to load state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
+		var state State
+		var logged model.LoggedAnswer
+		var answers []model.LoggedAnswer
+		var rejected error
+		var scored bool
+		var now time.Time
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
 
-		if state.Status != model.StatusSolving {
-			w.WriteHeader(http.StatusConflict)
-			return
-		}
+			// Nothing can be changed once the time limit has been reached, even if
+			// the solve hasn't been marked as expired yet.
+			if state.Status != model.StatusSolving || state.IsOutOfTime(time.Now()) {
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
 
-		settings, err := GetSettings(conn, channel)
-		if err != nil {
-			log.Printf("unable to load settings for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
+			settings, err := GetSettings(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load settings: %v", err)
+			}
+
+			roster, err := team.GetRoster(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load team roster: %v", err)
+			}
+
+			// Save the completion percentage so that we can determine if the answer
+			// made any progress on the solve.
+			complete := state.PercentComplete()
+
+			// Determine if the user specified a clue letter or cell numbers.  Rejected
+			// answers still count towards the answers submitted during the solve, so
+			// they're logged without changing the state.
+			if start, err := strconv.Atoi(clue); err == nil {
+				rejected = state.ApplyCellAnswer(start, answer, settings.OnlyAllowCorrectAnswers)
+			} else {
+				rejected = state.ApplyClueAnswer(clue, answer, settings.OnlyAllowCorrectAnswers)
+			}
+			if rejected != nil {
+				logged = state.NewLoggedAnswer(user, clue, answer, time.Now())
+				return nil
+			}
+
+			now = time.Now()
+			logged = state.NewLoggedAnswer(user, clue, answer, now)
+			logged.Applied = true
+			logged.Accepted = state.PercentComplete() > complete
+			if logged.Accepted {
+				state.LastProgressTime = &now
+			}
+
+			// When chatters have joined teams the solve becomes a race between them,
+			// every clue this answer completed is credited to the answerer's team.
+			scored = false
+			if len(roster) > 0 {
+				name := roster.TeamOf(user)
+				credited, err := state.CreditTeam(name)
+				if err != nil {
+					return fmt.Errorf("unable to credit team %s: %v", name, err)
+				}
+
+				scored = name != "" && credited > 0
+			}
+
+			// If we just solved the puzzle then we should stop the timer.
+			if state.Status == model.StatusComplete {
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+				// The answer log is needed to archive the attempt, it won't contain the
+				// answer that solved the puzzle until it's written.
+				answers, err = answerlog.Get(conn, "acrostic", channel)
+				if err != nil {
+					return fmt.Errorf("unable to load answer log: %v", err)
+				}
+				answers = append(answers, logged)
+			}
+
+			return nil
 		}
 
-		// Determine if the user specified a clue letter or cell numbers.
-		if start, err := strconv.Atoi(clue); err == nil {
-			if err := state.ApplyCellAnswer(start, answer, settings.OnlyAllowCorrectAnswers); err != nil {
-				log.Printf("unable to apply answer %s for cell %d for channel %s: %+v", answer, start, channel, err)
-				w.WriteHeader(http.StatusBadRequest)
-				return
+		write := func(tx db.Connection) error {
+			if rejected != nil {
+				if err := answerlog.Add(tx, "acrostic", channel, logged, StateTTL); err != nil {
+					log.Printf("unable to log answer for channel %s: %+v", channel, err)
+				}
+				return nil
 			}
-		} else {
-			if err := state.ApplyClueAnswer(clue, answer, settings.OnlyAllowCorrectAnswers); err != nil {
-				log.Printf("unable to apply answer %s for clue %s for channel %s: %+v", answer, clue, channel, err)
-				w.WriteHeader(http.StatusBadRequest)
-				return
+
+			if err := answerlog.Add(tx, "acrostic", channel, logged, StateTTL); err != nil {
+				return fmt.Errorf("unable to log answer: %v", err)
+			}
+
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			// If we just solved the puzzle then the attempt should be archived.
+			if state.Status == model.StatusComplete {
+				return ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now))
 			}
+
+			return nil
 		}
 
-		// If we just solved the puzzle then we should stop the timer.
-		if state.Status == model.StatusComplete {
-			now := time.Now()
-			total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
-			state.LastStartTime = nil
-			state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to apply answer %s for clue %s for channel %s: %+v", answer, clue, channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
 		}
 
-		// Save the updated state.
-		if err := SetState(conn, channel, state); err != nil {
-			log.Printf("unable to save state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
+		if rejected != nil {
+			log.Printf("unable to apply answer %s for clue %s for channel %s: %+v", answer, clue, channel, rejected)
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
@@ -431,13 +971,19 @@ func UpdateAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		// sure to not include the answers.  It's okay to overwrite the puzzle
 		// attribute because we just wrote this state instance to the database
 		// and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutSolution()
+		state.Puzzle = state.PublicPuzzle()
 
 		registry.Publish(ChannelID(channel), StateEvent(state))
 
-		// If we've just finished the solve then send a complete event as well.
+		// If a team scored then let the clients know the new scores.
+		if scored {
+			registry.Publish(ChannelID(channel), ScoreEvent(state.Scores))
+		}
+
+		// If we've just finished the solve then send complete and summary events as well.
 		if state.Status == model.StatusComplete {
 			registry.Publish(ChannelID(channel), CompleteEvent(author, title, quote))
+			registry.Publish(ChannelID(channel), SummaryEvent(state.Summary(answers)))
 		}
 
 		w.WriteHeader(http.StatusOK)
@@ -499,12 +1045,23 @@ func ChannelID(channel string) pubsub.Channel {
 }
 
 func StateEvent(state State) pubsub.Event {
+	if state.TimeLimit.Duration > 0 {
+		remaining := model.Duration{Duration: state.RemainingTime(time.Now())}
+		state.TimeRemaining = &remaining
+	}
+
 	return pubsub.Event{
 		Kind:    "state",
 		Payload: state,
 	}
 }
 
+func ExpiredEvent() pubsub.Event {
+	return pubsub.Event{
+		Kind: "expired",
+	}
+}
+
 func CompleteEvent(author, title, text string) pubsub.Event {
 	return pubsub.Event{
 		Kind: "complete",
@@ -516,6 +1073,20 @@ func CompleteEvent(author, title, text string) pubsub.Event {
 	}
 }
 
+func SummaryEvent(summary model.Summary) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "summary",
+		Payload: summary,
+	}
+}
+
+func ScoreEvent(scores map[string]int) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "score",
+		Payload: scores,
+	}
+}
+
 func SettingsEvent(settings Settings) pubsub.Event {
 	return pubsub.Event{
 		Kind:    "settings",
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/model"
 	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/team"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
 	"github.com/gomodule/redigo/redis"
@@ -134,6 +135,77 @@ func TestRoute_UpdatePuzzle_LoadSaveError(t *testing.T) {
 	}
 }
 
+func TestRoute_UploadPuzzle(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	// Upload the puzzle without its optional grids.
+	puzzle := NewUploadedPuzzle()
+	puzzle.Title = ""
+	puzzle.CellNumbers = nil
+
+	bs, err := json.Marshal(puzzle)
+	require.NoError(t, err)
+
+	response := Channel.PUT("/upload", string(bs), router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var report model.ValidationReport
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &report))
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, []string{
+		"puzzle doesn't have a title",
+		"the first letters of the answers spell CD instead of the author and title",
+	}, report.Warnings)
+
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSelected, state.Status)
+		assert.Equal(t, "Acrostic uploaded by the streamer", state.Puzzle.Description)
+		assert.Equal(t, [][]int{{1, 2, 3, 0, 4, 5, 6, 0}}, state.Puzzle.CellNumbers)
+		assert.Equal(t, "!", state.Cells[0][7])
+	})
+}
+
+func TestRoute_UploadPuzzle_Invalid(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+
+	puzzle := NewUploadedPuzzle()
+	delete(puzzle.Clues, "B")
+
+	bs, err := json.Marshal(puzzle)
+	require.NoError(t, err)
+
+	response := Channel.PUT("/upload", string(bs), router)
+	require.Equal(t, http.StatusUnprocessableEntity, response.Code)
+
+	var report model.ValidationReport
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &report))
+	assert.Equal(t, []string{"clue B is missing"}, report.Errors)
+}
+
+func TestRoute_UploadPuzzle_JSONError(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+
+	response := Channel.PUT("/upload", `{"rows": "one"}`, router)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	var report model.ValidationReport
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &report))
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "unable to parse puzzle")
+}
+
+func TestRoute_UploadPuzzle_SaveError(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+	ForceErrorDuringStateSave(t, errors.New("forced error"))
+
+	bs, err := json.Marshal(NewUploadedPuzzle())
+	require.NoError(t, err)
+
+	response := Channel.PUT("/upload", string(bs), router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
 func TestRoute_ShowClue(t *testing.T) {
 	// This acts as a small integration test requesting clues to be shown and
 	// making sure events are properly emitted.
@@ -226,6 +298,126 @@ func TestRoute_ToggleStatus(t *testing.T) {
 }
 
 func TestRoute_ToggleStatus_Error(t *testing.T) {
+	tests := []struct {
+		name              string
+		initialStatus     model.Status
+		loadStateError    error
+		saveStateError    error
+		loadSettingsError error
+	}{
+		{
+			name:          "status created",
+			initialStatus: model.StatusCreated,
+		},
+		{
+			name:          "status given up",
+			initialStatus: model.StatusGivenUp,
+		},
+		{
+			name:          "status expired",
+			initialStatus: model.StatusExpired,
+		},
+		{
+			name:              "error loading settings",
+			initialStatus:     model.StatusSelected,
+			loadSettingsError: errors.New("forced error"),
+		},
+		{
+			name:           "error loading state",
+			initialStatus:  model.StatusSelected,
+			loadStateError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			initialStatus:  model.StatusSelected,
+			saveStateError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			state.Status = test.initialStatus
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			if test.loadStateError != nil {
+				ForceErrorDuringStateLoad(t, test.loadStateError)
+			}
+
+			if test.saveStateError != nil {
+				ForceErrorDuringStateSave(t, test.saveStateError)
+			}
+
+			if test.loadSettingsError != nil {
+				ForceErrorDuringSettingsLoad(t, test.loadSettingsError)
+			}
+
+			response := Channel.PUT("/status", "", router)
+			assert.NotEqual(t, http.StatusOK, response.Code)
+		})
+	}
+}
+
+func TestRoute_ToggleStatus_TimeLimit(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	settings := Settings{TimeLimit: model.Duration{Duration: 15 * time.Minute}}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Starting the solve should copy the time limit into the state.
+	response := Channel.PUT("/status", ``, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSolving, state.Status)
+		assert.Equal(t, 15*time.Minute, state.TimeLimit.Duration)
+	})
+
+	// Changing the setting mid-solve shouldn't move the deadline.
+	settings.TimeLimit = model.Duration{Duration: time.Hour}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	response = Channel.PUT("/status", ``, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusPaused, state.Status)
+		assert.Equal(t, 15*time.Minute, state.TimeLimit.Duration)
+	})
+}
+
+func TestRoute_ResetState(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	// Set a state that has made some progress on the solve.
+	now := time.Now()
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &now
+	state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, state.ApplyClueAnswer("A", "WHALES", false))
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.PUT("/reset", ``, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSelected, state.Status)
+		assert.Equal(t, "", state.Cells[1][10])
+		assert.False(t, state.CluesFilled["A"])
+		assert.Nil(t, state.LastStartTime)
+		assert.Equal(t, time.Duration(0), state.TotalSolveDuration.Duration)
+	})
+}
+
+func TestRoute_ResetState_Error(t *testing.T) {
 	tests := []struct {
 		name           string
 		initialStatus  model.Status
@@ -238,12 +430,12 @@ func TestRoute_ToggleStatus_Error(t *testing.T) {
 		},
 		{
 			name:           "error loading state",
-			initialStatus:  model.StatusSelected,
+			initialStatus:  model.StatusSolving,
 			loadStateError: errors.New("forced error"),
 		},
 		{
 			name:           "error saving state",
-			initialStatus:  model.StatusSelected,
+			initialStatus:  model.StatusSolving,
 			saveStateError: errors.New("forced error"),
 		},
 	}
@@ -265,12 +457,148 @@ func TestRoute_ToggleStatus_Error(t *testing.T) {
 				ForceErrorDuringStateSave(t, test.saveStateError)
 			}
 
-			response := Channel.PUT("/status", "", router)
+			response := Channel.PUT("/reset", "", router)
 			assert.NotEqual(t, http.StatusOK, response.Code)
 		})
 	}
 }
 
+func TestRoute_GiveUp(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialStatus model.Status
+	}{
+		{
+			name:          "selected",
+			initialStatus: model.StatusSelected,
+		},
+		{
+			name:          "paused",
+			initialStatus: model.StatusPaused,
+		},
+		{
+			name:          "solving",
+			initialStatus: model.StatusSolving,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+			events := NewEventSubscription(t, registry, Channel.name)
+
+			start := time.Now().Add(-time.Minute)
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			state.Status = test.initialStatus
+			state.LastStartTime = nil
+			if test.initialStatus == model.StatusSolving {
+				state.LastStartTime = &start
+			}
+			require.NoError(t, state.ApplyClueAnswer("A", "WHALES", false))
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			response := Channel.PUT("/giveup", ``, router)
+			require.Equal(t, http.StatusOK, response.Code)
+
+			// The state event should include the solution.
+			found := Events(events, "state")
+			require.Equal(t, 1, len(found))
+			state = found[0].Payload.(State)
+			assert.NotNil(t, state.Puzzle.Cells)
+
+			// The solution should have been filled in.
+			state, err := GetState(conn, Channel.name)
+			require.NoError(t, err)
+			assert.Equal(t, model.StatusGivenUp, state.Status)
+			assert.Nil(t, state.LastStartTime)
+			assert.Equal(t, state.Puzzle.Cells, state.Cells)
+			assert.True(t, state.CluesFilled["B"])
+			if test.initialStatus == model.StatusSolving {
+				assert.True(t, state.TotalSolveDuration.Duration >= time.Minute)
+			}
+
+			// The attempt should have been archived as unfinished.
+			attempts := LoadArchive(t, pool)
+			require.Equal(t, 1, len(attempts))
+			assert.Equal(t, Channel.name, attempts[0].Channel)
+			assert.Equal(t, model.StatusGivenUp, attempts[0].Status)
+			assert.Equal(t, "New York Times puzzle from 2020-05-24", attempts[0].Description)
+		})
+	}
+}
+
+func TestRoute_GiveUp_Error(t *testing.T) {
+	tests := []struct {
+		name             string
+		initialStatus    model.Status
+		loadStateError   error
+		saveStateError   error
+		saveArchiveError error
+		expected         int
+	}{
+		{
+			name:          "status created",
+			initialStatus: model.StatusCreated,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:          "status complete",
+			initialStatus: model.StatusComplete,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:          "status given up",
+			initialStatus: model.StatusGivenUp,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:           "error loading state",
+			initialStatus:  model.StatusPaused,
+			loadStateError: errors.New("forced error"),
+			expected:       http.StatusNotFound,
+		},
+		{
+			name:           "error saving state",
+			initialStatus:  model.StatusPaused,
+			saveStateError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+		{
+			name:             "error saving archive",
+			initialStatus:    model.StatusPaused,
+			saveArchiveError: errors.New("forced error"),
+			expected:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			state.Status = test.initialStatus
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			if test.loadStateError != nil {
+				ForceErrorDuringStateLoad(t, test.loadStateError)
+			}
+
+			if test.saveStateError != nil {
+				ForceErrorDuringStateSave(t, test.saveStateError)
+			}
+
+			if test.saveArchiveError != nil {
+				ForceErrorDuringArchiveSave(t, test.saveArchiveError)
+			}
+
+			response := Channel.PUT("/giveup", "", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
 func TestRoute_UpdateAnswer_AllowIncorrectAnswers(t *testing.T) {
 	// This acts as a small integration test of applying answers to an acrostic
 	// being solved.
@@ -344,6 +672,45 @@ func TestRoute_UpdateAnswer_AllowIncorrectAnswers(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, response.Code)
 }
 
+func TestRoute_UpdateAnswer_Teams(t *testing.T) {
+	// This acts as a small integration test of a team solve where each clue is
+	// credited to the team of the chatter that answered it.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	roster := team.Roster{"alice": "red", "bob": "blue"}
+	require.NoError(t, team.SetRoster(conn, Channel.name, roster))
+
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Alice answers a clue for the red team.
+	response := Channel.PUT("/answer/A?user=Alice", `"WHALES"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	scores := Events(events, "score")
+	require.Len(t, scores, 1)
+	assert.Equal(t, map[string]int{"red": 1, "blue": 0}, scores[0].Payload)
+
+	// Bob answers an incorrect clue, nobody scores.
+	response = Channel.PUT("/answer/B?user=bob", `"METALLICA"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Empty(t, Events(events, "score"))
+
+	// Bob answers a correct clue for the blue team.
+	response = Channel.PUT("/answer/W?user=bob", `"ASSASSINS"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	scores = Events(events, "score")
+	require.Len(t, scores, 1)
+	assert.Equal(t, map[string]int{"red": 1, "blue": 1}, scores[0].Payload)
+
+	state, err := GetState(conn, Channel.name)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "red", "W": "blue"}, state.ClueTeams)
+	assert.Equal(t, map[string]int{"red": 1, "blue": 1}, state.Scores)
+}
+
 func TestRoute_UpdateAnswer_OnlyAllowCorrectAnswers(t *testing.T) {
 	// This acts as a small integration test toggling the status of an acrostic
 	// being solved.
@@ -438,6 +805,77 @@ func TestRoute_UpdateAnswer_SolvedPuzzleStopsTimer(t *testing.T) {
 		assert.Nil(t, state.LastStartTime)
 		assert.True(t, state.TotalSolveDuration.Seconds() > 0)
 	})
+
+	// The completed attempt should have been archived.
+	attempts := LoadArchive(t, pool)
+	require.Equal(t, 1, len(attempts))
+	assert.Equal(t, model.StatusComplete, attempts[0].Status)
+	assert.True(t, attempts[0].TotalSolveDuration.Seconds() > 0)
+}
+
+func TestRoute_UpdateAnswer_ArchiveError(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Setup a state that has every cell correct except for the last answer.
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	for y := range state.Cells {
+		copy(state.Cells[y], state.Puzzle.Cells[y])
+	}
+	require.NoError(t, state.ApplyClueAnswer("W", "AAAAAAAAA", false))
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	ForceErrorDuringArchiveSave(t, errors.New("forced error"))
+
+	response := Channel.PUT("/answer/W", `"ASSASSINS"`, router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestRoute_UpdateAnswer_Summary(t *testing.T) {
+	// This acts as a small integration test ensuring that a summary built from
+	// the submitted answers is published and archived once the acrostic has
+	// been solved.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	settings := Settings{OnlyAllowCorrectAnswers: true}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	// Setup a state that has every cell correct except for the last answer.
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	for y := range state.Cells {
+		copy(state.Cells[y], state.Puzzle.Cells[y])
+	}
+	require.NoError(t, state.ApplyClueAnswer("W", ".........", false))
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.PUT("/answer/W?user=bob", `"ASSASSINX"`, router)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	response = Channel.PUT("/answer/W?user=alice", `"ASSASSINS"`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	found := Events(events, "summary")
+	require.Len(t, found, 1)
+
+	summary := found[0].Payload.(model.Summary)
+	assert.Equal(t, 2, summary.AnswersSubmitted)
+	assert.Equal(t, 1, summary.AnswersAccepted)
+	assert.Equal(t, []model.Contributor{{User: "alice", Answers: 1}}, summary.TopContributors)
+	assert.Equal(t, "alice", summary.FirstBlood)
+	require.NotNil(t, summary.LastClue)
+	assert.Equal(t, "W", summary.LastClue.Clue)
+	assert.Equal(t, "ASSASSINS", summary.LastClue.Answer)
+
+	// The summary should have been archived with the attempt.
+	attempts := LoadArchive(t, pool)
+	require.Equal(t, 1, len(attempts))
+	require.NotNil(t, attempts[0].Summary)
+	assert.Equal(t, 2, attempts[0].Summary.AnswersSubmitted)
+	assert.Equal(t, 1, attempts[0].Summary.AnswersAccepted)
 }
 
 func TestRoute_UpdateAnswer_Error(t *testing.T) {
@@ -478,12 +916,30 @@ func TestRoute_UpdateAnswer_Error(t *testing.T) {
 	}
 }
 
+func TestRoute_UpdateAnswer_OutOfTime(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// The solve has run past its time limit but hasn't been marked as expired
+	// yet, answers should no longer be accepted.
+	start := time.Now().Add(-time.Hour)
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	state.Status = model.StatusSolving
+	state.TimeLimit = model.Duration{Duration: 15 * time.Minute}
+	state.LastStartTime = &start
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.PUT("/answer/A", `"AAAA"`, router)
+	assert.Equal(t, http.StatusConflict, response.Code)
+}
+
 func TestRoute_UpdateAnswer_LoadSaveError(t *testing.T) {
 	tests := []struct {
 		name              string
 		loadSettingsError error
 		loadStateError    error
 		saveStateError    error
+		loadRosterError   error
 	}{
 		{
 			name:              "error loading settings",
@@ -497,6 +953,10 @@ func TestRoute_UpdateAnswer_LoadSaveError(t *testing.T) {
 			name:           "error saving state",
 			saveStateError: errors.New("forced error"),
 		},
+		{
+			name:            "error loading team roster",
+			loadRosterError: errors.New("forced error"),
+		},
 	}
 
 	for _, test := range tests {
@@ -511,6 +971,7 @@ func TestRoute_UpdateAnswer_LoadSaveError(t *testing.T) {
 			ForceErrorDuringSettingsLoad(t, test.loadSettingsError)
 			ForceErrorDuringStateLoad(t, test.loadStateError)
 			ForceErrorDuringStateSave(t, test.saveStateError)
+			team.ForceErrorDuringRosterLoad(t, test.loadRosterError)
 
 			response := Channel.PUT("/answer/A", `"WHALES"`, router)
 			assert.NotEqual(t, http.StatusOK, response.Code)
@@ -537,6 +998,18 @@ func TestRoute_UpdateSetting(t *testing.T) {
 	VerifySettings(t, pool, events, func(s Settings) {
 		assert.Equal(t, model.FontSizeXLarge, s.ClueFontSize)
 	})
+
+	response = Channel.PUT("/setting/idle_timeout", `"30m0s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 30*time.Minute, s.IdleTimeout.Duration)
+	})
+
+	response = Channel.PUT("/setting/time_limit", `"15m0s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 15*time.Minute, s.TimeLimit.Duration)
+	})
 }
 
 func TestRoute_UpdateSetting_ClearsIncorrectCells(t *testing.T) {
@@ -586,6 +1059,26 @@ func TestRoute_UpdateSetting_JSONError(t *testing.T) {
 			setting: "clue_font_size",
 			json:    `{`,
 		},
+		{
+			name:    "idle_timeout",
+			setting: "idle_timeout",
+			json:    `{`,
+		},
+		{
+			name:    "negative idle_timeout",
+			setting: "idle_timeout",
+			json:    `"-30m"`,
+		},
+		{
+			name:    "time_limit",
+			setting: "time_limit",
+			json:    `{`,
+		},
+		{
+			name:    "negative time_limit",
+			setting: "time_limit",
+			json:    `"-15m"`,
+		},
 		{
 			name:    "invalid setting name",
 			setting: "foo_bar_baz",
@@ -649,6 +1142,400 @@ func TestRoute_UpdateSettings_LoadSaveError(t *testing.T) {
 	}
 }
 
+func TestRoute_GetCurrentState(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/state", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	var actual State
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, state.Status, actual.Status)
+	assert.Nil(t, actual.Puzzle.Cells) // Solution should never be sent
+
+	// Asking again with the ETag shouldn't return the state since it hasn't
+	// changed.
+	response = ConditionalGET(path.Join("/acrostic", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Empty(t, response.Body.String())
+
+	// Once the state changes it should be returned again with a new ETag.
+	require.NoError(t, state.ApplyClueAnswer("A", "WHALES", false))
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response = ConditionalGET(path.Join("/acrostic", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.NotEqual(t, etag, response.Header().Get("ETag"))
+}
+
+func TestRoute_GetCurrentState_TimeLimit(t *testing.T) {
+	// The time remaining in a timed solve changes on every request, but the
+	// state's ETag should only change when the state is saved.
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Now()
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &now
+	state.TimeLimit = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/state", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+
+	time.Sleep(10 * time.Millisecond)
+
+	response = ConditionalGET(path.Join("/acrostic", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Equal(t, etag, response.Header().Get("ETag"))
+}
+
+func TestRoute_GetCurrentState_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			if test.state != nil {
+				require.NoError(t, SetState(conn, Channel.name, *test.state))
+			}
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/state", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetCurrentSettings(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	settings, err := GetSettings(conn, Channel.name)
+	require.NoError(t, err)
+
+	response := Channel.GET("/settings", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	var actual Settings
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, settings, actual)
+
+	// Asking again with the ETag shouldn't return the settings since they haven't
+	// changed.
+	response = ConditionalGET(path.Join("/acrostic", Channel.name, "settings"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+
+	// Once the settings change they should be returned again with a new ETag.
+	settings.TimeLimit = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	response = ConditionalGET(path.Join("/acrostic", Channel.name, "settings"), etag, router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.NotEqual(t, etag, response.Header().Get("ETag"))
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, settings, actual)
+}
+
+func TestRoute_GetCurrentSettings_Error(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+	ForceErrorDuringSettingsLoad(t, errors.New("forced error"))
+
+	response := Channel.GET("/settings", router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestRoute_GetSolution(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	state.Status = model.StatusGivenUp
+	state.LastStartTime = nil
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/solution", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var puzzle Puzzle
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &puzzle))
+	assert.Equal(t, state.Puzzle.Cells, puzzle.Cells)
+}
+
+func TestRoute_GetSolution_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "solve in progress",
+			expected: http.StatusConflict,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			state.Status = model.StatusSolving
+			if test.state != nil {
+				state = *test.state
+			}
+			require.NoError(t, SetState(conn, Channel.name, state))
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/solution", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetShareCard(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Archive two attempts, the card of the most recent one should be returned.
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	state.Status = model.StatusGivenUp
+	state.LastStartTime = nil
+	state.TotalSolveDuration = model.Duration{Duration: 5 * time.Minute}
+	require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+
+	state.Status = model.StatusComplete
+	state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+
+	response := Channel.GET("/share", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.Equal(t, state.ShareCard(), response.Body.String())
+	assert.True(t, strings.HasPrefix(response.Body.String(), "Acrostic"))
+	assert.Contains(t, response.Body.String(), "✅ Solved in 10m0s")
+}
+
+func TestRoute_GetShareCard_Error(t *testing.T) {
+	tests := []struct {
+		name             string
+		archive          bool
+		archiveLoadError error
+		expected         int
+	}{
+		{
+			name:     "no archived attempts",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:             "error loading archive",
+			archive:          true,
+			archiveLoadError: errors.New("forced error"),
+			expected:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			if test.archive {
+				state := NewState(t, "xwordinfo-nyt-20200524.json")
+				state.Status = model.StatusComplete
+				require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+			}
+			ForceErrorDuringArchiveLoad(t, test.archiveLoadError)
+
+			response := Channel.GET("/share", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		letters     bool
+	}{
+		{
+			name:        "svg",
+			contentType: "image/svg+xml",
+			letters:     true,
+		},
+		{
+			name:        "svg without letters",
+			query:       "?letters=false",
+			contentType: "image/svg+xml",
+		},
+		{
+			name:        "png",
+			query:       "?format=png",
+			contentType: "image/png",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			require.NoError(t, state.ApplyClueAnswer("W", "ASSASSINS", false))
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			response := Channel.GET("/snapshot"+test.query, router)
+			require.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, test.contentType, response.Header().Get("Content-Type"))
+
+			if test.contentType == "image/svg+xml" {
+				assert.Equal(t, string(state.Snapshot(test.letters).SVG()), response.Body.String())
+			}
+		})
+	}
+}
+
+func TestRoute_GetSnapshot_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "unsupported format",
+			query:    "?format=gif",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			if test.state != nil {
+				state = *test.state
+			}
+			require.NoError(t, SetState(conn, Channel.name, state))
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/snapshot"+test.query, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetPDF(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/pdf?filled=true", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/pdf", response.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="acrostic-2020-05-24.pdf"`, response.Header().Get("Content-Disposition"))
+	assert.Equal(t, state.PDF(true).Bytes(), response.Body.Bytes())
+}
+
+func TestRoute_GetPDF_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "invalid filled parameter",
+			query:    "?filled=maybe",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			if test.state != nil {
+				state = *test.state
+			}
+			require.NoError(t, SetState(conn, Channel.name, state))
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/pdf"+test.query, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
 func TestRoute_GetEvents(t *testing.T) {
 	// This acts as a small integration test ensuring that the event stream
 	// receives the events put into a registry.
@@ -707,8 +1594,9 @@ func TestRoute_GetEvents(t *testing.T) {
 	assert.Equal(t, http.StatusOK, response.Code)
 
 	events = flush()
-	assert.Equal(t, 2, len(events)) // last state update event and complete event
+	assert.Equal(t, 3, len(events)) // last state update, complete and summary events
 	assert.Equal(t, "complete", events[1].Kind)
+	assert.Equal(t, "summary", events[2].Kind)
 
 	complete := map[string]interface{}{
 		"author": "MABEL WAGNALLS",
@@ -855,7 +1743,11 @@ func VerifyState(t *testing.T, pool *redis.Pool, events <-chan pubsub.Event, fn
 	require.Equal(t, 1, len(found), "incorrect number of events found")
 
 	state := found[0].Payload.(State)
-	assert.Nil(t, state.Puzzle.Cells) // Events should never have the solution
+	if state.Status.IsFinished() {
+		assert.NotNil(t, state.Puzzle.Cells) // Finished solves include the solution
+	} else {
+		assert.Nil(t, state.Puzzle.Cells) // Events should never have the solution
+	}
 	fn(state)
 
 	// Next check that the database has a valid state object
@@ -886,6 +1778,25 @@ func VerifySettings(t *testing.T, pool *redis.Pool, events <-chan pubsub.Event,
 	fn(settings)
 }
 
+// LoadArchive reads all of the attempts that have been archived for the test
+// channel.
+func LoadArchive(t *testing.T, pool *redis.Pool) []model.Attempt {
+	t.Helper()
+
+	conn := NewRedisConnection(t, pool)
+	bss, err := redis.ByteSlices(conn.Do("LRANGE", ArchiveKey(Channel.name), 0, -1))
+	require.NoError(t, err)
+
+	var attempts []model.Attempt
+	for _, bs := range bss {
+		var attempt model.Attempt
+		require.NoError(t, json.Unmarshal(bs, &attempt))
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts
+}
+
 // VerifyShowClue performs common verifications for show clue events.
 func VerifyShowClue(t *testing.T, events <-chan pubsub.Event, fn func(clue string)) {
 	t.Helper()
@@ -925,6 +1836,16 @@ func GET(url string, router chi.Router) *httptest.ResponseRecorder {
 	return recorder
 }
 
+// ConditionalGET performs a GET request that includes an If-None-Match header
+// with the provided ETag.
+func ConditionalGET(url, etag string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	request.Header.Set("If-None-Match", etag)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
 // ChannelClient is a client that makes requests against the URL of a particular
 // user's channel.
 type ChannelClient struct {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
-	"github.com/gomodule/redigo/redis"
 )
 
 // Settings represents the optional behaviors that can be enabled or disabled
@@ -15,6 +14,15 @@ type Settings struct {
 
 	// What font size should the clues be rendered with.
 	ClueFontSize model.FontSize `json:"clue_font_size"`
+
+	// How long the solve can go without any answers being submitted before it is
+	// automatically paused.  A value of zero disables automatic pausing.
+	IdleTimeout model.Duration `json:"idle_timeout"`
+
+	// How long the solve may take before it runs out of time.  Changes only
+	// apply to solves that are started afterwards.  A value of zero disables the
+	// time limit.
+	TimeLimit model.Duration `json:"time_limit"`
 }
 
 // SettingsKey returns the key that should be used in redis to store a
@@ -25,7 +33,7 @@ func SettingsKey(name string) string {
 
 // GetSettings will load settings for the provided channel name.  If the
 // settings can't be properly loaded then an error will be returned.
-func GetSettings(conn redis.Conn, channel string) (Settings, error) {
+func GetSettings(conn db.Connection, channel string) (Settings, error) {
 	var settings Settings
 
 	if testSettingsLoadError != nil {
@@ -38,7 +46,7 @@ func GetSettings(conn redis.Conn, channel string) (Settings, error) {
 
 // SetSettings will write settings for the provided channel name.  If the
 // settings can't be properly written then an error will be returned.
-func SetSettings(conn redis.Conn, channel string, settings Settings) error {
+func SetSettings(conn db.Connection, channel string, settings Settings) error {
 	if testSettingsSaveError != nil {
 		return testSettingsSaveError
 	}
@@ -0,0 +1,45 @@
+package acrostic
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/snapshot"
+	"strconv"
+)
+
+// The size in pixels of each cell of an acrostic snapshot.
+const snapshotCellSize = 36
+
+// Snapshot draws the state's grid as an image.  The image includes the blocks,
+// numbers and clue letters of the puzzle as well as the letters that have been
+// filled in, unless showLetters is false.  Givens are always drawn since
+// they're part of the puzzle.
+func (s *State) Snapshot(showLetters bool) *snapshot.Image {
+	const size = snapshotCellSize
+	img := snapshot.New(s.Puzzle.Cols*size+1, s.Puzzle.Rows*size+1)
+
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			left, top := float64(x*size), float64(y*size)
+
+			if s.Puzzle.CellBlocks[y][x] {
+				img.Rect(left, top, size, size, snapshot.Black, snapshot.Black)
+				continue
+			}
+			img.Rect(left, top, size, size, snapshot.White, snapshot.Black)
+
+			if number := s.Puzzle.CellNumbers[y][x]; number != 0 {
+				img.Text(left+2, top+2, 7, snapshot.AnchorTopLeft, strconv.Itoa(number), snapshot.Black)
+			}
+
+			if letter := s.Puzzle.CellClueLetters[y][x]; letter != "" {
+				img.Text(left+size-2, top+2, 7, snapshot.AnchorTopRight, letter, snapshot.Black)
+			}
+
+			given := s.Puzzle.Givens[y][x] != ""
+			if letter := s.Cells[y][x]; letter != "" && (showLetters || given) {
+				img.Text(left+size/2, top+size/2+3, 21, snapshot.AnchorCenter, letter, snapshot.Black)
+			}
+		}
+	}
+
+	return img
+}
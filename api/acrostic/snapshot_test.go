@@ -0,0 +1,31 @@
+package acrostic
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestState_Snapshot(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	require.NoError(t, state.ApplyClueAnswer("W", "ASSASSINS", false))
+
+	img := state.Snapshot(true)
+	assert.Equal(t, state.Puzzle.Cols*snapshotCellSize+1, img.Width)
+	assert.Equal(t, state.Puzzle.Rows*snapshotCellSize+1, img.Height)
+
+	svg := string(img.SVG())
+	assert.Contains(t, svg, `dominant-baseline="hanging" fill="#000000">1</text>`)
+	assert.Contains(t, svg, `text-anchor="end" dominant-baseline="hanging" fill="#000000">W</text>`)
+	assert.Contains(t, svg, `dominant-baseline="central" fill="#000000">S</text>`)
+
+	// Hiding the letters should keep the numbers and clue letters but drop the
+	// answers.
+	svg = string(state.Snapshot(false).SVG())
+	assert.Contains(t, svg, `dominant-baseline="hanging" fill="#000000">1</text>`)
+	assert.False(t, strings.Contains(svg, `dominant-baseline="central"`))
+
+	_, err := img.PNG()
+	assert.NoError(t, err)
+}
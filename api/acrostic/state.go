@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/team"
 	"sort"
 	"strings"
 	"time"
@@ -32,6 +33,29 @@ type State struct {
 
 	// The total time spent on solving the puzzle up to the last start time.
 	TotalSolveDuration model.Duration `json:"total_solve_duration"`
+
+	// The time that a correct value was last added to a cell of the puzzle.  If
+	// no correct values have been added yet then this will be nil.
+	LastProgressTime *time.Time `json:"last_progress_time,omitempty"`
+
+	// The time limit for the solve, copied from the settings when the solve is
+	// started.  A value of zero means that the solve doesn't have a time limit.
+	TimeLimit model.Duration `json:"time_limit"`
+
+	// The amount of time remaining before the time limit is reached as of when
+	// the state was published.  This is only populated for published states of
+	// solves with a time limit, it is never stored.
+	TimeRemaining *model.Duration `json:"time_remaining,omitempty"`
+
+	// The team that has been credited with each correctly answered clue when
+	// chatters in the channel have joined teams, indexed by the clue letter.
+	// Clues answered by a chatter that isn't on a team are credited to the empty
+	// team name.
+	ClueTeams map[string]string `json:"clue_teams,omitempty"`
+
+	// The number of clues credited to each team, indexed by the team name.  This
+	// is only present when chatters in the channel have joined teams.
+	Scores map[string]int `json:"scores,omitempty"`
 }
 
 // ApplyClueAnswer applies an answer for a clue to the state.  If the clue
@@ -268,11 +292,204 @@ func (s *State) ClearIncorrectCells() error {
 	return s.UpdateFilledClues()
 }
 
+// RevealSolution fills every cell of the puzzle with its correct value.  The
+// status of the solve is left unchanged.
+func (s *State) RevealSolution() error {
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			s.Cells[y][x] = s.Puzzle.Cells[y][x]
+		}
+	}
+
+	return s.UpdateFilledClues()
+}
+
+// Reset clears all progress made on the solve while keeping the puzzle.  Any
+// cells that were given as part of the puzzle are kept.  The solve is returned
+// to the selected status so that it can be started again.
+func (s *State) Reset() {
+	s.Status = model.StatusSelected
+	s.Cells = make([][]string, s.Puzzle.Rows)
+	for row := 0; row < s.Puzzle.Rows; row++ {
+		s.Cells[row] = make([]string, s.Puzzle.Cols)
+		for col := 0; col < s.Puzzle.Cols; col++ {
+			if s.Puzzle.Givens[row][col] != "" {
+				s.Cells[row][col] = s.Puzzle.Givens[row][col]
+			}
+		}
+	}
+	s.CluesFilled = make(map[string]bool)
+	s.LastStartTime = nil
+	s.TotalSolveDuration = model.Duration{}
+	s.LastProgressTime = nil
+	s.TimeLimit = model.Duration{}
+	s.ClueTeams = nil
+	s.Scores = nil
+}
+
+// CreditTeam credits the named team with every clue that is correctly answered
+// but hasn't yet been credited to a team.  Each credited clue adds a point to
+// the team's score.  If the team name is empty the clues are still marked as
+// credited, but no points are awarded.  The number of clues that were credited
+// is returned.
+func (s *State) CreditTeam(name string) (int, error) {
+	if s.ClueTeams == nil {
+		s.ClueTeams = make(map[string]string)
+	}
+
+	if s.Scores == nil {
+		s.Scores = make(map[string]int)
+	}
+	for _, n := range team.Names {
+		if _, found := s.Scores[n]; !found {
+			s.Scores[n] = 0
+		}
+	}
+
+	var credited int
+	for clue, nums := range s.Puzzle.ClueNumbers {
+		if _, found := s.ClueTeams[clue]; found {
+			continue
+		}
+
+		correct := true
+		for _, num := range nums {
+			x, y, err := s.Puzzle.GetCellCoordinates(num)
+			if err != nil {
+				return 0, err
+			}
+
+			if s.Cells[y][x] != s.Puzzle.Cells[y][x] {
+				correct = false
+				break
+			}
+		}
+
+		if correct {
+			s.ClueTeams[clue] = name
+			credited++
+		}
+	}
+
+	if name != "" {
+		s.Scores[name] += credited
+	}
+
+	return credited, nil
+}
+
+// PublicPuzzle returns the puzzle of the state in a form that's suitable to
+// send to clients.  While the solve is in progress the solution is removed,
+// but once the solve has finished the solution is included so that it can
+// be displayed.
+func (s *State) PublicPuzzle() *Puzzle {
+	if s.Status.IsFinished() {
+		return s.Puzzle
+	}
+
+	return s.Puzzle.WithoutSolution()
+}
+
+// PercentComplete returns the percentage of the cells of the puzzle that are
+// filled in with their correct value.  Cells that are givens aren't counted
+// since they're always filled in.
+func (s *State) PercentComplete() float64 {
+	var correct, total int
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if s.Puzzle.CellBlocks[y][x] || s.Puzzle.Givens[y][x] != "" {
+				continue
+			}
+
+			total++
+			if s.Cells[y][x] == s.Puzzle.Cells[y][x] {
+				correct++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(correct) / float64(total)
+}
+
+// PercentFilled returns the percentage of the cells of the puzzle that are
+// filled in with any value, correct or not.  Cells that are givens aren't
+// counted since they're always filled in.
+func (s *State) PercentFilled() float64 {
+	var filled, total int
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if s.Puzzle.CellBlocks[y][x] || s.Puzzle.Givens[y][x] != "" {
+				continue
+			}
+
+			total++
+			if s.Cells[y][x] != "" {
+				filled++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(filled) / float64(total)
+}
+
+// NewLoggedAnswer creates a record of an answer that was submitted by a
+// chatter at the provided time for the solve's answer log.  The answer is
+// neither applied nor accepted, callers set these once the answer has been
+// applied to the state.
+func (s *State) NewLoggedAnswer(user, clue, answer string, now time.Time) model.LoggedAnswer {
+	return model.LoggedAnswer{
+		User:        user,
+		Clue:        clue,
+		Answer:      answer,
+		ElapsedTime: model.Duration{Duration: s.ElapsedTime(now)},
+	}
+}
+
+// Summary builds the summary of the solve from its log of answers.
+func (s *State) Summary(answers []model.LoggedAnswer) model.Summary {
+	return model.Summarize(answers, s.TotalSolveDuration)
+}
+
+// ElapsedTime returns the total amount of time that has been spent solving the
+// puzzle as of the provided time.
+func (s *State) ElapsedTime(now time.Time) time.Duration {
+	return model.ElapsedTime(s.TotalSolveDuration.Duration, s.LastStartTime, now)
+}
+
+// RemainingTime returns the amount of time remaining as of the provided time
+// before the solve's time limit is reached.  If the solve doesn't have a time
+// limit then zero is returned.
+func (s *State) RemainingTime(now time.Time) time.Duration {
+	return model.RemainingTime(s.TimeLimit.Duration, s.ElapsedTime(now))
+}
+
+// IsOutOfTime returns whether or not the solve has a time limit that has been
+// reached as of the provided time.
+func (s *State) IsOutOfTime(now time.Time) bool {
+	return model.IsOutOfTime(s.TimeLimit.Duration, s.ElapsedTime(now))
+}
+
+// LastActivityTime returns the most recent time that the solve was either
+// started, resumed or had a correct value added to it.  If none of these have
+// happened yet then nil is returned.
+func (s *State) LastActivityTime() *time.Time {
+	return model.LastActivityTime(s.LastStartTime, s.LastProgressTime)
+}
+
 // GetAllChannels returns a slice of model.Channel instances for each acrostic
 // that contains state in the database.  If there are no active channels then an
 // empty slice is returned.  This method does not update the expiration times
-// of any state instance.
-func GetAllChannels(conn db.Connection) ([]model.Channel, error) {
+// of any state instance.  The elapsed time of each solve is computed as of the
+// provided time.
+func GetAllChannels(conn db.Connection, now time.Time) ([]model.Channel, error) {
 	keys, err := db.ScanKeys(conn, StateKey("*"))
 	if err != nil {
 		return nil, err
@@ -292,24 +509,23 @@ func GetAllChannels(conn db.Connection) ([]model.Channel, error) {
 			return nil, fmt.Errorf("unable to convert value to State: %v", value)
 		}
 
-		var description string
-		var publisher string
-		var published time.Time
+		channel := model.Channel{
+			Name:        name,
+			Status:      state.Status,
+			ElapsedTime: model.Duration{Duration: state.ElapsedTime(now)},
+		}
+
 		if state.Puzzle != nil {
-			description = state.Puzzle.Description
-			publisher = state.Puzzle.Publisher
-			published = state.Puzzle.PublishedDate
+			channel.Description = state.Puzzle.Description
+			channel.Puzzle = model.PuzzleSource{
+				Publisher:     state.Puzzle.Publisher,
+				PublishedDate: state.Puzzle.PublishedDate,
+			}
+			channel.PercentCorrect = state.PercentComplete()
+			channel.PercentFilled = state.PercentFilled()
 		}
 
-		channels = append(channels, model.Channel{
-			Name:        name,
-			Status:      state.Status,
-			Description: description,
-			Puzzle: model.PuzzleSource{
-				Publisher:     publisher,
-				PublishedDate: published,
-			},
-		})
+		channels = append(channels, channel)
 	}
 
 	sort.Slice(channels, func(i, j int) bool {
@@ -351,5 +567,28 @@ func SetState(conn db.Connection, channel string, state State) error {
 		return testStateSaveError
 	}
 
-	return db.SetWithTTL(conn, StateKey(channel), state, StateTTL)
+	if err := db.SetWithTTL(conn, StateKey(channel), state, StateTTL); err != nil {
+		return err
+	}
+
+	return db.IncrementVersion(conn, StateVersionKey(channel), StateTTL)
+}
+
+// StateVersionKey returns the key that should be used in redis to store the
+// version of a particular acrostic solve's state.  The version is increased
+// every time the state is written.
+func StateVersionKey(name string) string {
+	return fmt.Sprintf("%s:acrostic:version", name)
+}
+
+// GetStateVersion loads the version of the state for a acrostic solve from
+// redis.  If the state has never been written then zero will be returned.  The
+// version should be loaded before the state so that it is never newer than the
+// state it's used with.
+func GetStateVersion(conn db.Connection, channel string) (int64, error) {
+	if testStateLoadError != nil {
+		return 0, testStateLoadError
+	}
+
+	return db.GetVersion(conn, StateVersionKey(channel))
 }
@@ -1207,6 +1207,91 @@ func TestState_ClearIncorrectCells(t *testing.T) {
 	}
 }
 
+func TestState_CreditTeam(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+
+	// Nothing has been answered yet.
+	credited, err := state.CreditTeam("red")
+	require.NoError(t, err)
+	assert.Equal(t, 0, credited)
+	assert.Equal(t, map[string]int{"red": 0, "blue": 0}, state.Scores)
+
+	require.NoError(t, state.ApplyClueAnswer("A", "WHALES", false))
+	credited, err = state.CreditTeam("red")
+	require.NoError(t, err)
+	assert.Equal(t, 1, credited)
+
+	// A clue that was already credited isn't credited again.
+	credited, err = state.CreditTeam("blue")
+	require.NoError(t, err)
+	assert.Equal(t, 0, credited)
+
+	// Incorrect answers aren't credited.
+	require.NoError(t, state.ApplyClueAnswer("B", "METALLICA", false))
+	credited, err = state.CreditTeam("blue")
+	require.NoError(t, err)
+	assert.Equal(t, 0, credited)
+
+	assert.Equal(t, map[string]string{"A": "red"}, state.ClueTeams)
+	assert.Equal(t, map[string]int{"red": 1, "blue": 0}, state.Scores)
+
+	// Resetting the state clears the scores.
+	state.Reset()
+	assert.Nil(t, state.ClueTeams)
+	assert.Nil(t, state.Scores)
+}
+
+func TestState_PublicPuzzle(t *testing.T) {
+	tests := []struct {
+		status   model.Status
+		expected bool
+	}{
+		{status: model.StatusSelected, expected: false},
+		{status: model.StatusPaused, expected: false},
+		{status: model.StatusSolving, expected: false},
+		{status: model.StatusComplete, expected: true},
+		{status: model.StatusGivenUp, expected: true},
+		{status: model.StatusExpired, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status.String(), func(t *testing.T) {
+			state := NewState(t, "xwordinfo-nyt-20200524.json")
+			state.Status = test.status
+
+			puzzle := state.PublicPuzzle()
+			assert.Equal(t, test.expected, puzzle.Cells != nil)
+			assert.NotNil(t, state.Puzzle.Cells) // The state's puzzle is unchanged
+		})
+	}
+}
+
+func TestState_PercentComplete(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	assert.Equal(t, 0.0, state.PercentComplete())
+
+	// This puzzle has 177 cells that need to be filled in.
+	require.NoError(t, state.ApplyClueAnswer("A", "WHALES", false))
+	assert.InDelta(t, 100*6.0/177, state.PercentComplete(), 1e-9)
+
+	require.NoError(t, state.RevealSolution())
+	assert.Equal(t, 100.0, state.PercentComplete())
+}
+
+func TestState_PercentFilled(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	assert.Equal(t, 0.0, state.PercentFilled())
+
+	// This puzzle has 177 cells that need to be filled in.  Incorrect values
+	// count as being filled in.
+	require.NoError(t, state.ApplyClueAnswer("A", "WHALEX", false))
+	assert.InDelta(t, 100*6.0/177, state.PercentFilled(), 1e-9)
+	assert.InDelta(t, 100*5.0/177, state.PercentComplete(), 1e-9)
+
+	require.NoError(t, state.RevealSolution())
+	assert.Equal(t, 100.0, state.PercentFilled())
+}
+
 func TestGetAllChannels(t *testing.T) {
 	type ChannelToCreate struct {
 		name     string
@@ -1308,16 +1393,39 @@ func TestGetAllChannels(t *testing.T) {
 				}
 
 				state.Status = create.status
+				state.LastStartTime = nil
 				require.NoError(t, SetState(conn, create.name, state))
 			}
 
-			channels, err := GetAllChannels(conn)
+			channels, err := GetAllChannels(conn, time.Now())
 			require.NoError(t, err)
 			assert.ElementsMatch(t, test.expected, channels)
 		})
 	}
 }
 
+func TestGetAllChannels_Progress(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Minute)
+
+	state := NewState(t, "xwordinfo-nyt-20200524.json")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &start
+	state.TotalSolveDuration = model.Duration{Duration: 2 * time.Minute}
+	require.NoError(t, state.ApplyClueAnswer("A", "WHALEX", false))
+	require.NoError(t, SetState(conn, "channel", state))
+
+	channels, err := GetAllChannels(conn, now)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.InDelta(t, 100*5.0/177, channels[0].PercentCorrect, 1e-9)
+	assert.InDelta(t, 100*6.0/177, channels[0].PercentFilled, 1e-9)
+	assert.Equal(t, 3*time.Minute, channels[0].ElapsedTime.Duration)
+}
+
 func TestGetAllChannels_Error(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1352,7 +1460,7 @@ func TestGetAllChannels_Error(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, err := GetAllChannels(test.connection)
+			_, err := GetAllChannels(test.connection, time.Now())
 			assert.Error(t, err)
 			assert.Equal(t, "forced error", err.Error())
 		})
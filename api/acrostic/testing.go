@@ -47,6 +47,12 @@ var testAvailableDates []time.Time = nil
 // test cases to force an error to be returned instead of making a network call.
 var testAvailableDatesLoadError error = nil
 
+// A cached error to use instead of reading an attempt from the archive.
+var testArchiveLoadError error = nil
+
+// A cached error to use instead of writing an attempt to the archive.
+var testArchiveSaveError error = nil
+
 // load will read a file from the testdata directory.
 func load(t *testing.T, filename string) io.ReadCloser {
 	t.Helper()
@@ -185,6 +191,24 @@ func ForceErrorDuringAvailableDatesLoad(t *testing.T, err error) {
 	t.Cleanup(func() { testAvailableDatesLoadError = nil })
 }
 
+// ForceErrorDuringArchiveLoad sets up an error to be returned when an attempt
+// is made to read an attempt from the archive.
+func ForceErrorDuringArchiveLoad(t *testing.T, err error) {
+	t.Helper()
+
+	testArchiveLoadError = err
+	t.Cleanup(func() { testArchiveLoadError = nil })
+}
+
+// ForceErrorDuringArchiveSave sets up an error to be returned when an attempt
+// is made to save an attempt to the archive.
+func ForceErrorDuringArchiveSave(t *testing.T, err error) {
+	t.Helper()
+
+	testArchiveSaveError = err
+	t.Cleanup(func() { testArchiveSaveError = nil })
+}
+
 // NewTestRouter will return a router configured with a redis pool and pubsub
 // registry and wired together along with all of the routes for a spelling bee
 // puzzle.
@@ -0,0 +1,277 @@
+package acrostic
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The largest number of rows or columns that an uploaded acrostic may have.
+const MaxUploadSize = 50
+
+// The shortest answer length that doesn't cause a warning when validating.
+const MinAnswerLength = 3
+
+// A regular expression that matches the valid contents of a cell.
+var validCell = regexp.MustCompile(`^[A-Z]$`)
+
+// A regular expression that matches the characters that aren't letters.
+var nonLetters = regexp.MustCompile(`[^A-Z]+`)
+
+// Normalize fills in the optional parts of an acrostic that was uploaded by a
+// user.  Missing givens are treated as not being present, missing cell numbers
+// are derived from the blocks and givens, cells with givens are filled in and
+// cell values and clue letters are uppercased.
+func (p *Puzzle) Normalize() {
+	if p.Givens == nil {
+		p.Givens = make([][]string, p.Rows)
+		for y := range p.Givens {
+			p.Givens[y] = make([]string, p.Cols)
+		}
+	}
+
+	for y, row := range p.Cells {
+		for x := range row {
+			row[x] = strings.ToUpper(strings.TrimSpace(row[x]))
+			if y < len(p.Givens) && x < len(p.Givens[y]) && p.Givens[y][x] != "" {
+				row[x] = p.Givens[y][x]
+			}
+		}
+	}
+
+	for _, row := range p.CellClueLetters {
+		for x := range row {
+			row[x] = strings.ToUpper(strings.TrimSpace(row[x]))
+		}
+	}
+
+	if p.CellNumbers == nil && len(p.CellBlocks) == p.Rows && len(p.Givens) == p.Rows {
+		p.CellNumbers = DeriveCellNumbers(p.CellBlocks, p.Givens)
+	}
+}
+
+// DeriveCellNumbers computes the numbering of an acrostic grid.  Every cell
+// that isn't a block or a given is numbered in order from left to right and
+// top to bottom.
+func DeriveCellNumbers(blocks [][]bool, givens [][]string) [][]int {
+	numbers := make([][]int, len(blocks))
+	var next int
+	for y := range blocks {
+		numbers[y] = make([]int, len(blocks[y]))
+		for x := range blocks[y] {
+			if blocks[y][x] || (y < len(givens) && x < len(givens[y]) && givens[y][x] != "") {
+				continue
+			}
+
+			next++
+			numbers[y][x] = next
+		}
+	}
+
+	return numbers
+}
+
+// Validate checks that an acrostic uploaded by a user can be solved.  The grids
+// must match the dimensions of the puzzle, the cells must be numbered in order,
+// every cell must belong to exactly one clue's answer and every clue must have
+// an answer.  Incomplete metadata, short answers and answers whose first
+// letters don't spell the author and title are reported as warnings.
+func (p *Puzzle) Validate() *model.ValidationReport {
+	report := model.NewValidationReport()
+
+	if p.Rows < 1 || p.Cols < 1 || p.Rows > MaxUploadSize || p.Cols > MaxUploadSize {
+		report.Errorf("grid must be between 1x1 and %dx%d, but is %dx%d", MaxUploadSize, MaxUploadSize, p.Cols, p.Rows)
+		return report
+	}
+
+	// Every grid must have the puzzle's dimensions before any of the cells can be
+	// examined.
+	sizes := map[string][]int{
+		"cells":             stringGridSize(p.Cells),
+		"givens":            stringGridSize(p.Givens),
+		"cell_blocks":       boolGridSize(p.CellBlocks),
+		"cell_clue_numbers": intGridSize(p.CellNumbers),
+		"cell_clue_letters": stringGridSize(p.CellClueLetters),
+	}
+	for _, name := range []string{"cells", "givens", "cell_blocks", "cell_clue_numbers", "cell_clue_letters"} {
+		if !sameSize(sizes[name], p.Rows, p.Cols) {
+			report.Errorf("%s must have %d rows of %d cells", name, p.Rows, p.Cols)
+		}
+	}
+	if !report.Valid() {
+		return report
+	}
+
+	// The cells of the grid indexed by their number.
+	type cell struct {
+		letter string
+		clue   string
+	}
+	cells := make(map[int]cell)
+
+	numbers := DeriveCellNumbers(p.CellBlocks, p.Givens)
+	for y := 0; y < p.Rows; y++ {
+		for x := 0; x < p.Cols; x++ {
+			value, given, number, letter := p.Cells[y][x], p.Givens[y][x], p.CellNumbers[y][x], p.CellClueLetters[y][x]
+
+			switch {
+			case p.CellBlocks[y][x]:
+				if value != "" || given != "" || number != 0 || letter != "" {
+					report.Errorf("cell at row %d, column %d is a block but has a value, given, number or clue letter", y+1, x+1)
+				}
+
+			case given != "":
+				if number != 0 || letter != "" {
+					report.Errorf("cell at row %d, column %d is a given but has a number or clue letter", y+1, x+1)
+				}
+
+			default:
+				if !validCell.MatchString(value) {
+					report.Errorf("cell at row %d, column %d must contain a single letter, but contains %q", y+1, x+1, value)
+				}
+				if number != numbers[y][x] {
+					report.Errorf("cell at row %d, column %d is numbered %d but should be numbered %d", y+1, x+1, number, numbers[y][x])
+				}
+				if letter == "" {
+					report.Errorf("cell at row %d, column %d isn't part of any answer", y+1, x+1)
+				}
+
+				cells[number] = cell{letter: value, clue: letter}
+			}
+		}
+	}
+	if !report.Valid() {
+		return report
+	}
+
+	// Every clue letter used in the grid or the clue numbers must have a clue.
+	letters := make(map[string]bool)
+	for letter := range p.Clues {
+		letters[letter] = true
+	}
+	for letter := range p.ClueNumbers {
+		letters[letter] = true
+	}
+	for _, c := range cells {
+		letters[c.clue] = true
+	}
+
+	var sorted []string
+	for letter := range letters {
+		sorted = append(sorted, letter)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) < len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	var firsts strings.Builder
+	used := make(map[int]string)
+	for _, letter := range sorted {
+		if strings.TrimSpace(p.Clues[letter]) == "" {
+			report.Errorf("clue %s is missing", letter)
+		}
+
+		answer := p.ClueNumbers[letter]
+		if len(answer) == 0 {
+			report.Errorf("clue %s doesn't have an answer", letter)
+			continue
+		}
+
+		for _, number := range answer {
+			c, ok := cells[number]
+			switch {
+			case !ok:
+				report.Errorf("clue %s uses cell %d which isn't in the grid", letter, number)
+			case c.clue != letter:
+				report.Errorf("clue %s uses cell %d which is labeled with clue %s", letter, number, c.clue)
+			case used[number] != "":
+				report.Errorf("clue %s uses cell %d which is already used by clue %s", letter, number, used[number])
+			default:
+				used[number] = letter
+			}
+		}
+
+		if len(answer) < MinAnswerLength {
+			report.Warnf("clue %s's answer is only %d letters long", letter, len(answer))
+		}
+
+		firsts.WriteString(cells[answer[0]].letter)
+	}
+
+	var missing []int
+	for number := range cells {
+		if used[number] == "" {
+			missing = append(missing, number)
+		}
+	}
+	sort.Ints(missing)
+	for _, number := range missing {
+		report.Errorf("cell %d isn't used by clue %s's answer", number, cells[number].clue)
+	}
+
+	if strings.TrimSpace(p.Author) == "" {
+		report.Warnf("puzzle doesn't have an author")
+	}
+	if strings.TrimSpace(p.Title) == "" {
+		report.Warnf("puzzle doesn't have a title")
+	}
+
+	// The first letters of the answers traditionally spell the author's name, or
+	// just their last name, followed by the title.
+	onlyLetters := func(s string) string { return nonLetters.ReplaceAllString(strings.ToUpper(s), "") }
+	names := strings.Fields(p.Author)
+	full := onlyLetters(p.Author + p.Title)
+	short := full
+	if len(names) > 0 {
+		short = onlyLetters(names[len(names)-1] + p.Title)
+	}
+	if full != "" && report.Valid() && firsts.String() != full && firsts.String() != short {
+		report.Warnf("the first letters of the answers spell %s instead of the author and title", firsts.String())
+	}
+
+	return report
+}
+
+// The sizes of each row of a grid.
+func stringGridSize(grid [][]string) []int {
+	sizes := make([]int, len(grid))
+	for i, row := range grid {
+		sizes[i] = len(row)
+	}
+	return sizes
+}
+
+func boolGridSize(grid [][]bool) []int {
+	sizes := make([]int, len(grid))
+	for i, row := range grid {
+		sizes[i] = len(row)
+	}
+	return sizes
+}
+
+func intGridSize(grid [][]int) []int {
+	sizes := make([]int, len(grid))
+	for i, row := range grid {
+		sizes[i] = len(row)
+	}
+	return sizes
+}
+
+// sameSize determines if the row sizes of a grid match the given dimensions.
+func sameSize(sizes []int, rows, cols int) bool {
+	if len(sizes) != rows {
+		return false
+	}
+
+	for _, size := range sizes {
+		if size != cols {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,191 @@
+package acrostic
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPuzzle_Normalize(t *testing.T) {
+	puzzle := NewUploadedPuzzle()
+	puzzle.Cells[0][0] = " c"
+	puzzle.Cells[0][7] = ""
+	puzzle.CellClueLetters[0][0] = "a"
+	puzzle.CellNumbers = nil
+
+	puzzle.Normalize()
+	assert.Equal(t, []string{"C", "A", "T", "", "D", "O", "G", "!"}, puzzle.Cells[0])
+	assert.Equal(t, "A", puzzle.CellClueLetters[0][0])
+	assert.Equal(t, [][]int{{1, 2, 3, 0, 4, 5, 6, 0}}, puzzle.CellNumbers)
+
+	puzzle.Givens = nil
+	puzzle.Normalize()
+	assert.Equal(t, [][]string{{"", "", "", "", "", "", "", ""}}, puzzle.Givens)
+}
+
+func TestDeriveCellNumbers(t *testing.T) {
+	puzzle := LoadTestPuzzle(t, "xwordinfo-nyt-20200524.json")
+	assert.Equal(t, puzzle.CellNumbers, DeriveCellNumbers(puzzle.CellBlocks, puzzle.Givens))
+}
+
+func TestPuzzle_Validate(t *testing.T) {
+	puzzle := NewUploadedPuzzle()
+
+	report := puzzle.Validate()
+	assert.Empty(t, report.Errors)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestPuzzle_Validate_PublishedPuzzle(t *testing.T) {
+	puzzle := LoadTestPuzzle(t, "xwordinfo-nyt-20200524.json")
+
+	report := puzzle.Validate()
+	assert.Empty(t, report.Errors)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestPuzzle_Validate_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(p *Puzzle)
+		expected []string
+	}{
+		{
+			name: "too large",
+			modify: func(p *Puzzle) {
+				p.Cols = 51
+			},
+			expected: []string{"grid must be between 1x1 and 50x50, but is 51x1"},
+		},
+		{
+			name: "grid with wrong size",
+			modify: func(p *Puzzle) {
+				p.Givens = nil
+			},
+			expected: []string{"givens must have 1 rows of 8 cells"},
+		},
+		{
+			name: "block with a value",
+			modify: func(p *Puzzle) {
+				p.Cells[0][3] = "X"
+			},
+			expected: []string{"cell at row 1, column 4 is a block but has a value, given, number or clue letter"},
+		},
+		{
+			name: "given with a number",
+			modify: func(p *Puzzle) {
+				p.CellNumbers[0][7] = 7
+			},
+			expected: []string{"cell at row 1, column 8 is a given but has a number or clue letter"},
+		},
+		{
+			name: "invalid cell",
+			modify: func(p *Puzzle) {
+				p.Cells[0][1] = "AB"
+			},
+			expected: []string{`cell at row 1, column 2 must contain a single letter, but contains "AB"`},
+		},
+		{
+			name: "out of order numbers",
+			modify: func(p *Puzzle) {
+				p.CellNumbers[0][4] = 5
+				p.CellNumbers[0][5] = 4
+			},
+			expected: []string{
+				"cell at row 1, column 5 is numbered 5 but should be numbered 4",
+				"cell at row 1, column 6 is numbered 4 but should be numbered 5",
+			},
+		},
+		{
+			name: "cell without a clue letter",
+			modify: func(p *Puzzle) {
+				p.CellClueLetters[0][2] = ""
+			},
+			expected: []string{"cell at row 1, column 3 isn't part of any answer"},
+		},
+		{
+			name: "missing clue",
+			modify: func(p *Puzzle) {
+				delete(p.Clues, "B")
+			},
+			expected: []string{"clue B is missing"},
+		},
+		{
+			name: "clue without an answer",
+			modify: func(p *Puzzle) {
+				p.Clues["C"] = "Extra"
+			},
+			expected: []string{"clue C doesn't have an answer"},
+		},
+		{
+			name: "answer with a cell that doesn't exist",
+			modify: func(p *Puzzle) {
+				p.ClueNumbers["B"] = []int{4, 5, 6, 7}
+			},
+			expected: []string{"clue B uses cell 7 which isn't in the grid"},
+		},
+		{
+			name: "answer with a cell from another clue",
+			modify: func(p *Puzzle) {
+				p.ClueNumbers["A"] = []int{1, 2, 3, 4}
+			},
+			expected: []string{"clue A uses cell 4 which is labeled with clue B"},
+		},
+		{
+			name: "answer using a cell twice",
+			modify: func(p *Puzzle) {
+				p.ClueNumbers["A"] = []int{1, 2, 2}
+			},
+			expected: []string{
+				"clue A uses cell 2 which is already used by clue A",
+				"cell 3 isn't used by clue A's answer",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			puzzle := NewUploadedPuzzle()
+			test.modify(puzzle)
+
+			report := puzzle.Validate()
+			assert.Equal(t, test.expected, report.Errors)
+		})
+	}
+}
+
+func TestPuzzle_Validate_Warnings(t *testing.T) {
+	puzzle := NewUploadedPuzzle()
+	puzzle.Author = ""
+	puzzle.Title = "Doggo"
+	puzzle.CellClueLetters[0][2] = "B"
+	puzzle.ClueNumbers["A"] = []int{1, 2}
+	puzzle.ClueNumbers["B"] = []int{3, 4, 5, 6}
+
+	report := puzzle.Validate()
+	require.Empty(t, report.Errors)
+	assert.Equal(t, []string{
+		"clue A's answer is only 2 letters long",
+		"puzzle doesn't have an author",
+		"the first letters of the answers spell CT instead of the author and title",
+	}, report.Warnings)
+}
+
+// NewUploadedPuzzle returns a small valid acrostic in the form it would be
+// uploaded in.
+func NewUploadedPuzzle() *Puzzle {
+	return &Puzzle{
+		Rows:            1,
+		Cols:            8,
+		Author:          "C.",
+		Title:           "D.",
+		Quote:           "CAT DOG!",
+		Cells:           [][]string{{"C", "A", "T", "", "D", "O", "G", "!"}},
+		Givens:          [][]string{{"", "", "", "", "", "", "", "!"}},
+		CellBlocks:      [][]bool{{false, false, false, true, false, false, false, false}},
+		CellNumbers:     [][]int{{1, 2, 3, 0, 4, 5, 6, 0}},
+		CellClueLetters: [][]string{{"A", "A", "A", "", "B", "B", "B", ""}},
+		Clues:           map[string]string{"A": "Feline", "B": "Canine"},
+		ClueNumbers:     map[string][]int{"A": {1, 2, 3}, "B": {4, 5, 6}},
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"crypto/subtle"
+	"github.com/bbeck/puzzles-with-chat/api/acrostic"
+	"github.com/bbeck/puzzles-with-chat/api/cache"
+	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/spellingbee"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/gomodule/redigo/redis"
+	"log"
+	"net/http"
+	"time"
+)
+
+// RegisterAdminRoutes registers the endpoints used to administer the server.
+// Every request to them must present the admin token as a bearer token.
+func RegisterAdminRoutes(r chi.Router, pool *redis.Pool, token string) {
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(RequireAdminToken(token))
+
+		r.Get("/cache", GetCacheEntries(pool))
+		r.Post("/cache/warm", WarmCache())
+		r.Delete("/cache", PurgeCache(pool))
+	})
+}
+
+// RequireAdminToken is middleware that rejects requests that don't have an
+// Authorization header containing the admin token.
+func RequireAdminToken(token string) func(http.Handler) http.Handler {
+	expected := []byte("Bearer " + token)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actual := []byte(r.Header.Get("Authorization"))
+			if token == "" || subtle.ConstantTimeCompare(actual, expected) != 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CacheEntry describes an entry of the cache of fetched puzzles without
+// including its content.
+type CacheEntry struct {
+	Source    string    `json:"source"`
+	Key       string    `json:"key"`
+	URL       string    `json:"url"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Size      int       `json:"size"`
+}
+
+// GetCacheEntries lists the entries of the cache of fetched puzzles.  The
+// entries can be limited to those of a single source using the source query
+// parameter.
+func GetCacheEntries(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		entries, err := cache.GetAll(conn, r.URL.Query().Get("source"))
+		if err != nil {
+			log.Printf("unable to load cache entries: %+v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]CacheEntry, 0, len(entries))
+		for _, entry := range entries {
+			response = append(response, CacheEntry{
+				Source:    entry.Source,
+				Key:       entry.Key,
+				URL:       entry.URL,
+				FetchedAt: entry.FetchedAt,
+				Size:      len(entry.Content),
+			})
+		}
+
+		render.JSON(w, r, response)
+	}
+}
+
+// WarmCache loads puzzles from a source so that they're in the cache before
+// any channel selects them.  The response lists the keys that were loaded
+// along with the reason each of the other keys couldn't be.
+func WarmCache() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Source string   `json:"source"`
+			Keys   []string `json:"keys"`
+		}
+		if err := render.DecodeJSON(r.Body, &request); err != nil {
+			log.Printf("unable to read request body: %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		load, ok := CacheWarmers()[request.Source]
+		if !ok {
+			log.Printf("unable to warm cache, unrecognized source: %s", request.Source)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		warmed := make([]string, 0)
+		failed := make(map[string]string)
+		for _, key := range request.Keys {
+			if err := load(key); err != nil {
+				log.Printf("unable to warm cache for %s %s: %+v", request.Source, key, err)
+				failed[key] = err.Error()
+				continue
+			}
+
+			warmed = append(warmed, key)
+		}
+
+		render.JSON(w, r, map[string]interface{}{
+			"warmed": warmed,
+			"failed": failed,
+		})
+	}
+}
+
+// PurgeCache removes entries from the cache of fetched puzzles.  The source
+// and key query parameters limit which entries are removed, without them the
+// entire cache is purged.
+func PurgeCache(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		source := r.URL.Query().Get("source")
+		key := r.URL.Query().Get("key")
+		if key != "" && source == "" {
+			log.Printf("unable to purge cache, key %s given without a source", key)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		purged, err := cache.Purge(conn, source, key)
+		if err != nil {
+			log.Printf("unable to purge cache: %+v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		render.JSON(w, r, map[string]int{"purged": purged})
+	}
+}
+
+// CacheWarmers returns a function for each source of fetched puzzles that
+// loads one of its puzzles, indexed by the name of the source in the cache.
+func CacheWarmers() map[string]func(key string) error {
+	warmers := map[string]func(string) error{
+		"acrostic/new_york_times": func(date string) error {
+			_, err := acrostic.LoadFromNewYorkTimes(date)
+			return err
+		},
+		"spellingbee/new_york_times": func(date string) error {
+			_, err := spellingbee.LoadFromNYTBee(date)
+			return err
+		},
+	}
+
+	for _, source := range crossword.Sources() {
+		switch source.(type) {
+		case crossword.NewYorkTimesSource, *crossword.PuzSource:
+		default:
+			continue
+		}
+
+		source := source
+		warmers["crossword/"+source.ID()] = func(key string) error {
+			_, err := source.Load(key)
+			return err
+		}
+	}
+
+	return warmers
+}
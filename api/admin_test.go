@@ -0,0 +1,286 @@
+package main
+
+import (
+	"errors"
+	"github.com/alicebob/miniredis"
+	"github.com/bbeck/puzzles-with-chat/api/cache"
+	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRoute_Admin_Unauthorized(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{
+			name: "missing token",
+		},
+		{
+			name:          "incorrect token",
+			authorization: "Bearer incorrect",
+		},
+		{
+			name:          "token without bearer",
+			authorization: "token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, _ := NewTestAdminRouter(t)
+
+			request := httptest.NewRequest(http.MethodDelete, "/admin/cache", nil)
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+			assert.Equal(t, http.StatusUnauthorized, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetCacheEntries(t *testing.T) {
+	router, pool := NewTestAdminRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	fetched := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	SetCacheEntry(t, conn, cache.Entry{Source: "crossword/new_york_times", Key: "2020-01-01", URL: "url1", FetchedAt: fetched, Content: []byte("abc")})
+	SetCacheEntry(t, conn, cache.Entry{Source: "acrostic/new_york_times", Key: "2020-01-05", URL: "url2", FetchedAt: fetched, Content: []byte("a")})
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []CacheEntry
+	}{
+		{
+			name: "all sources",
+			expected: []CacheEntry{
+				{Source: "acrostic/new_york_times", Key: "2020-01-05", URL: "url2", FetchedAt: fetched, Size: 1},
+				{Source: "crossword/new_york_times", Key: "2020-01-01", URL: "url1", FetchedAt: fetched, Size: 3},
+			},
+		},
+		{
+			name:  "single source",
+			query: "?source=crossword/new_york_times",
+			expected: []CacheEntry{
+				{Source: "crossword/new_york_times", Key: "2020-01-01", URL: "url1", FetchedAt: fetched, Size: 3},
+			},
+		},
+		{
+			name:     "no entries",
+			query:    "?source=spellingbee/new_york_times",
+			expected: []CacheEntry{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := Admin(http.MethodGet, "/admin/cache"+test.query, "", router)
+			require.Equal(t, http.StatusOK, response.Code)
+
+			var entries []CacheEntry
+			require.NoError(t, render.DecodeJSON(response.Result().Body, &entries))
+			assert.Equal(t, test.expected, entries)
+		})
+	}
+}
+
+func TestRoute_WarmCache(t *testing.T) {
+	router, _ := NewTestAdminRouter(t)
+	crossword.ForcePuzzleToBeLoaded(t, "xwordinfo-nyt-20181231.json")
+
+	response := Admin(http.MethodPost, "/admin/cache/warm", `{"source": "crossword/new_york_times", "keys": ["2018-12-30", "2018-12-31"]}`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var result struct {
+		Warmed []string          `json:"warmed"`
+		Failed map[string]string `json:"failed"`
+	}
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &result))
+	assert.Equal(t, []string{"2018-12-30", "2018-12-31"}, result.Warmed)
+	assert.Empty(t, result.Failed)
+}
+
+func TestRoute_WarmCache_LoadError(t *testing.T) {
+	router, _ := NewTestAdminRouter(t)
+	crossword.ForceErrorDuringPuzzleLoad(t, errors.New("forced error"))
+
+	response := Admin(http.MethodPost, "/admin/cache/warm", `{"source": "crossword/new_york_times", "keys": ["2018-12-31"]}`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var result struct {
+		Warmed []string          `json:"warmed"`
+		Failed map[string]string `json:"failed"`
+	}
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &result))
+	assert.Empty(t, result.Warmed)
+	assert.Equal(t, map[string]string{"2018-12-31": "forced error"}, result.Failed)
+}
+
+func TestRoute_WarmCache_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "bad json",
+			body: `{"source": }`,
+		},
+		{
+			name: "unrecognized source",
+			body: `{"source": "crossword/unknown", "keys": ["2020-01-01"]}`,
+		},
+		{
+			name: "source that isn't fetched",
+			body: `{"source": "crossword/puz_file_bytes", "keys": ["abc"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, _ := NewTestAdminRouter(t)
+
+			response := Admin(http.MethodPost, "/admin/cache/warm", test.body, router)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	}
+}
+
+func TestRoute_PurgeCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		purged    int
+		remaining []string
+	}{
+		{
+			name:      "everything",
+			purged:    3,
+			remaining: []string{},
+		},
+		{
+			name:      "single source",
+			query:     "?source=crossword/new_york_times",
+			purged:    2,
+			remaining: []string{"acrostic/new_york_times 2020-01-05"},
+		},
+		{
+			name:   "single key",
+			query:  "?source=crossword/new_york_times&key=2020-01-01",
+			purged: 1,
+			remaining: []string{
+				"acrostic/new_york_times 2020-01-05",
+				"crossword/new_york_times 2020-01-02",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool := NewTestAdminRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			SetCacheEntry(t, conn, cache.Entry{Source: "crossword/new_york_times", Key: "2020-01-01"})
+			SetCacheEntry(t, conn, cache.Entry{Source: "crossword/new_york_times", Key: "2020-01-02"})
+			SetCacheEntry(t, conn, cache.Entry{Source: "acrostic/new_york_times", Key: "2020-01-05"})
+
+			response := Admin(http.MethodDelete, "/admin/cache"+test.query, "", router)
+			require.Equal(t, http.StatusOK, response.Code)
+
+			var result map[string]int
+			require.NoError(t, render.DecodeJSON(response.Result().Body, &result))
+			assert.Equal(t, test.purged, result["purged"])
+
+			entries, err := cache.GetAll(conn, "")
+			require.NoError(t, err)
+
+			remaining := make([]string, 0)
+			for _, entry := range entries {
+				remaining = append(remaining, entry.Source+" "+entry.Key)
+			}
+			assert.Equal(t, test.remaining, remaining)
+		})
+	}
+}
+
+func TestRoute_PurgeCache_KeyWithoutSource(t *testing.T) {
+	router, _ := NewTestAdminRouter(t)
+
+	response := Admin(http.MethodDelete, "/admin/cache?key=2020-01-01", "", router)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestCacheWarmers(t *testing.T) {
+	crossword.ForcePuzSourcesToBeRegistered(t, "puz-sources.json")
+
+	var sources []string
+	for source := range CacheWarmers() {
+		sources = append(sources, source)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"acrostic/new_york_times",
+		"crossword/new_york_times",
+		"crossword/wall_street_journal",
+		"spellingbee/new_york_times",
+	}, sources)
+}
+
+// The admin token used by the test router.
+const TestAdminToken = "test-token"
+
+// NewTestAdminRouter returns a router with only the admin routes registered,
+// along with the redis pool that they use.
+func NewTestAdminRouter(t *testing.T) (chi.Router, *redis.Pool) {
+	t.Helper()
+
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", server.Addr())
+		},
+	}
+
+	router := chi.NewRouter()
+	RegisterAdminRoutes(router, pool, TestAdminToken)
+
+	return router, pool
+}
+
+// Admin performs an authorized request against the admin routes.
+func Admin(method, url, body string, router chi.Router) *httptest.ResponseRecorder {
+	var in io.Reader
+	if body != "" {
+		in = strings.NewReader(body)
+	}
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, url, in)
+	request.Header.Set("Authorization", "Bearer "+TestAdminToken)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// SetCacheEntry writes an entry directly to the cache.
+func SetCacheEntry(t *testing.T, conn redis.Conn, entry cache.Entry) {
+	t.Helper()
+
+	require.NoError(t, db.Set(conn, cache.EntryKey(entry.Source, entry.Key), entry))
+}
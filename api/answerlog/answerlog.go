@@ -0,0 +1,70 @@
+package answerlog
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"time"
+)
+
+// Key returns the key that should be used in redis to store the log of answers
+// submitted during a channel's current solve of a type of puzzle.  The log is
+// kept separately from the solve's state so that it doesn't have to be read and
+// written as part of every change to the state.
+func Key(kind, channel string) string {
+	return fmt.Sprintf("%s:%s:answers", channel, kind)
+}
+
+// Add appends an answer to the log of answers for a channel's current solve of
+// a type of puzzle.  The log expires after the provided TTL unless another
+// answer is added to it first, callers should use the same TTL as the solve's
+// state.  If the answer can't be properly written then an error will be
+// returned.
+func Add(conn db.Connection, kind, channel string, answer model.LoggedAnswer, ttl time.Duration) error {
+	if testSaveError != nil {
+		return testSaveError
+	}
+
+	if err := db.Append(conn, Key(kind, channel), answer); err != nil {
+		return err
+	}
+
+	_, err := conn.Do("EXPIRE", Key(kind, channel), int(ttl.Seconds()))
+	return err
+}
+
+// Get loads every answer from the log of answers for a channel's current solve
+// of a type of puzzle in the order they were submitted.  If no answers have been
+// logged then an empty slice is returned.
+func Get(conn db.Connection, kind, channel string) ([]model.LoggedAnswer, error) {
+	if testLoadError != nil {
+		return nil, testLoadError
+	}
+
+	values, err := db.GetList(conn, Key(kind, channel), model.LoggedAnswer{})
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make([]model.LoggedAnswer, 0, len(values))
+	for _, value := range values {
+		answer, ok := value.(model.LoggedAnswer)
+		if !ok {
+			return nil, fmt.Errorf("unable to convert value to LoggedAnswer: %v", value)
+		}
+		answers = append(answers, answer)
+	}
+
+	return answers, nil
+}
+
+// Clear removes the log of answers for a channel's solve of a type of puzzle.
+// This should be done whenever a new solve is started.
+func Clear(conn db.Connection, kind, channel string) error {
+	if testSaveError != nil {
+		return testSaveError
+	}
+
+	_, err := conn.Do("DEL", Key(kind, channel))
+	return err
+}
@@ -0,0 +1,88 @@
+package answerlog
+
+import (
+	"errors"
+	"github.com/alicebob/miniredis"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "channel:crossword:answers", Key("crossword", "channel"))
+}
+
+func TestAdd_Get(t *testing.T) {
+	conn := NewRedisConnection(t)
+
+	// Nothing has been logged yet.
+	answers, err := Get(conn, "crossword", "channel")
+	require.NoError(t, err)
+	assert.Empty(t, answers)
+
+	first := model.LoggedAnswer{User: "alice", Clue: "1a", Answer: "QANDA", Applied: true, Accepted: true}
+	second := model.LoggedAnswer{User: "bob", Clue: "6a", Answer: "ATTIX"}
+	require.NoError(t, Add(conn, "crossword", "channel", first, time.Hour))
+	require.NoError(t, Add(conn, "crossword", "channel", second, time.Hour))
+
+	answers, err = Get(conn, "crossword", "channel")
+	require.NoError(t, err)
+	assert.Equal(t, []model.LoggedAnswer{first, second}, answers)
+
+	// The log should expire along with the solve's state.
+	ttl, err := redis.Int(conn.Do("TTL", Key("crossword", "channel")))
+	require.NoError(t, err)
+	assert.Equal(t, 3600, ttl)
+
+	// Each type of puzzle has its own log.
+	answers, err = Get(conn, "spellingbee", "channel")
+	require.NoError(t, err)
+	assert.Empty(t, answers)
+}
+
+func TestClear(t *testing.T) {
+	conn := NewRedisConnection(t)
+
+	answer := model.LoggedAnswer{User: "alice", Clue: "1a", Answer: "QANDA"}
+	require.NoError(t, Add(conn, "crossword", "channel", answer, time.Hour))
+	require.NoError(t, Clear(conn, "crossword", "channel"))
+
+	answers, err := Get(conn, "crossword", "channel")
+	require.NoError(t, err)
+	assert.Empty(t, answers)
+}
+
+func TestAdd_Error(t *testing.T) {
+	conn := NewRedisConnection(t)
+	ForceErrorDuringSave(t, errors.New("forced error"))
+
+	assert.Error(t, Add(conn, "crossword", "channel", model.LoggedAnswer{}, time.Hour))
+}
+
+func TestGet_Error(t *testing.T) {
+	conn := NewRedisConnection(t)
+	ForceErrorDuringLoad(t, errors.New("forced error"))
+
+	_, err := Get(conn, "crossword", "channel")
+	assert.Error(t, err)
+}
+
+// NewRedisConnection creates a connection to a new miniredis server.  Both are
+// closed when the test finishes.
+func NewRedisConnection(t *testing.T) redis.Conn {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+
+	conn, err := redis.Dial("tcp", server.Addr())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Close()
+	})
+
+	return conn
+}
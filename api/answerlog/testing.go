@@ -0,0 +1,27 @@
+package answerlog
+
+import "testing"
+
+// A cached error to use instead of reading the answer log.
+var testLoadError error = nil
+
+// A cached error to use instead of writing the answer log.
+var testSaveError error = nil
+
+// ForceErrorDuringLoad sets up an error to be returned when an attempt is made
+// to read the answer log.
+func ForceErrorDuringLoad(t *testing.T, err error) {
+	t.Helper()
+
+	testLoadError = err
+	t.Cleanup(func() { testLoadError = nil })
+}
+
+// ForceErrorDuringSave sets up an error to be returned when an attempt is made
+// to write the answer log.
+func ForceErrorDuringSave(t *testing.T, err error) {
+	t.Helper()
+
+	testSaveError = err
+	t.Cleanup(func() { testSaveError = nil })
+}
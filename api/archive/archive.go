@@ -0,0 +1,90 @@
+package archive
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"time"
+)
+
+// Key returns the key that should be used in redis to store a particular
+// channel's archive of past attempts at solving a type of puzzle.
+func Key(kind, channel string) string {
+	return fmt.Sprintf("%s:%s:archive", channel, kind)
+}
+
+// CompletedKey returns the key that should be used in redis to store every
+// channel's completed attempts at solving a type of puzzle that was published
+// on a particular date.
+func CompletedKey(kind string, date time.Time) string {
+	return fmt.Sprintf("archive:%s:%s", kind, date.Format("2006-01-02"))
+}
+
+// CompletedTTL is the amount of time the completed attempts for a date are kept
+// after the most recent one was added.
+var CompletedTTL = 30 * 24 * time.Hour
+
+// Add will add the provided attempt to a channel's archive of attempts at
+// solving a type of puzzle.  Attempts that completed a puzzle with a published
+// date are also added to the completed attempts for that date.  If the attempt
+// can't be properly written then an error will be returned.
+func Add(conn db.Connection, kind, channel string, attempt model.Attempt) error {
+	if testSaveError != nil {
+		return testSaveError
+	}
+
+	if err := db.Append(conn, Key(kind, channel), attempt); err != nil {
+		return err
+	}
+
+	if attempt.Status != model.StatusComplete || attempt.Puzzle.PublishedDate.IsZero() {
+		return nil
+	}
+
+	// The completed attempts are ordered by when they ended.
+	key := CompletedKey(kind, attempt.Puzzle.PublishedDate)
+	if err := db.AddSorted(conn, key, float64(attempt.EndTime.UnixNano()), attempt); err != nil {
+		return err
+	}
+
+	_, err := conn.Do("EXPIRE", key, int(CompletedTTL.Seconds()))
+	return err
+}
+
+// GetLatest loads the most recent attempt from a channel's archive of attempts
+// at solving a type of puzzle.  If the channel hasn't archived any attempts
+// then nil is returned.
+func GetLatest(conn db.Connection, kind, channel string) (*model.Attempt, error) {
+	if testLoadError != nil {
+		return nil, testLoadError
+	}
+
+	var attempt *model.Attempt
+	err := db.GetLast(conn, Key(kind, channel), &attempt)
+	return attempt, err
+}
+
+// GetCompleted loads every channel's completed attempts at solving a type of
+// puzzle that was published on the provided date in the order that they ended.
+// If no attempts have been completed then an empty slice is returned.
+func GetCompleted(conn db.Connection, kind string, date time.Time) ([]model.Attempt, error) {
+	if testLoadError != nil {
+		return nil, testLoadError
+	}
+
+	values, err := db.GetSorted(conn, CompletedKey(kind, date), model.Attempt{})
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]model.Attempt, 0, len(values))
+	for _, value := range values {
+		attempt, ok := value.(model.Attempt)
+		if !ok {
+			return nil, fmt.Errorf("unable to convert value to Attempt: %v", value)
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, nil
+}
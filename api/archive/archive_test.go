@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"errors"
+	"github.com/alicebob/miniredis"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "channel:crossword:archive", Key("crossword", "channel"))
+}
+
+func TestAdd_GetLatest(t *testing.T) {
+	conn := NewRedisConnection(t)
+
+	// Nothing has been archived yet.
+	attempt, err := GetLatest(conn, "crossword", "channel")
+	require.NoError(t, err)
+	assert.Nil(t, attempt)
+
+	first := model.Attempt{Channel: "channel", Status: model.StatusGivenUp}
+	second := model.Attempt{Channel: "channel", Status: model.StatusComplete}
+	require.NoError(t, Add(conn, "crossword", "channel", first))
+	require.NoError(t, Add(conn, "crossword", "channel", second))
+
+	attempt, err = GetLatest(conn, "crossword", "channel")
+	require.NoError(t, err)
+	assert.Equal(t, &second, attempt)
+
+	// Each type of puzzle has its own archive.
+	attempt, err = GetLatest(conn, "spellingbee", "channel")
+	require.NoError(t, err)
+	assert.Nil(t, attempt)
+}
+
+func TestAdd_GetCompleted(t *testing.T) {
+	conn := NewRedisConnection(t)
+
+	date := time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2018, time.December, 31, 20, 0, 0, 0, time.UTC)
+	puzzle := model.PuzzleSource{Publisher: "The New York Times", PublishedDate: date}
+
+	// Nothing has been completed yet.
+	attempts, err := GetCompleted(conn, "crossword", date)
+	require.NoError(t, err)
+	assert.Empty(t, attempts)
+
+	second := model.Attempt{Channel: "b", Status: model.StatusComplete, Puzzle: puzzle, EndTime: end.Add(time.Minute)}
+	first := model.Attempt{Channel: "a", Status: model.StatusComplete, Puzzle: puzzle, EndTime: end}
+	require.NoError(t, Add(conn, "crossword", "b", second))
+	require.NoError(t, Add(conn, "crossword", "a", first))
+
+	// Attempts that weren't completed or don't have a published date aren't
+	// included.
+	require.NoError(t, Add(conn, "crossword", "c", model.Attempt{Channel: "c", Status: model.StatusGivenUp, Puzzle: puzzle}))
+	require.NoError(t, Add(conn, "crossword", "d", model.Attempt{Channel: "d", Status: model.StatusComplete}))
+
+	// The attempts should be ordered by when they ended.
+	attempts, err = GetCompleted(conn, "crossword", date)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Attempt{first, second}, attempts)
+
+	// The completed attempts should expire.
+	ttl, err := redis.Int(conn.Do("TTL", CompletedKey("crossword", date)))
+	require.NoError(t, err)
+	assert.Equal(t, int(CompletedTTL.Seconds()), ttl)
+
+	// Each type of puzzle has its own completed attempts.
+	attempts, err = GetCompleted(conn, "spellingbee", date)
+	require.NoError(t, err)
+	assert.Empty(t, attempts)
+}
+
+func TestAdd_Error(t *testing.T) {
+	conn := NewRedisConnection(t)
+	ForceErrorDuringSave(t, errors.New("forced error"))
+
+	assert.Error(t, Add(conn, "crossword", "channel", model.Attempt{}))
+}
+
+func TestGetCompleted_Error(t *testing.T) {
+	conn := NewRedisConnection(t)
+	ForceErrorDuringLoad(t, errors.New("forced error"))
+
+	_, err := GetCompleted(conn, "crossword", time.Now())
+	assert.Error(t, err)
+}
+
+func TestGetLatest_Error(t *testing.T) {
+	conn := NewRedisConnection(t)
+	ForceErrorDuringLoad(t, errors.New("forced error"))
+
+	_, err := GetLatest(conn, "crossword", "channel")
+	assert.Error(t, err)
+}
+
+// NewRedisConnection creates a connection to a new miniredis server.  Both are
+// closed when the test finishes.
+func NewRedisConnection(t *testing.T) redis.Conn {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+
+	conn, err := redis.Dial("tcp", server.Addr())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Close()
+	})
+
+	return conn
+}
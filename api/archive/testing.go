@@ -0,0 +1,27 @@
+package archive
+
+import "testing"
+
+// A cached error to use instead of reading an attempt from the archive.
+var testLoadError error = nil
+
+// A cached error to use instead of writing an attempt to the archive.
+var testSaveError error = nil
+
+// ForceErrorDuringLoad sets up an error to be returned when an attempt is made
+// to read an attempt from the archive.
+func ForceErrorDuringLoad(t *testing.T, err error) {
+	t.Helper()
+
+	testLoadError = err
+	t.Cleanup(func() { testLoadError = nil })
+}
+
+// ForceErrorDuringSave sets up an error to be returned when an attempt is made
+// to save an attempt to the archive.
+func ForceErrorDuringSave(t *testing.T, err error) {
+	t.Helper()
+
+	testSaveError = err
+	t.Cleanup(func() { testSaveError = nil })
+}
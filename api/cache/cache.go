@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/web"
+	"github.com/gomodule/redigo/redis"
+	"io"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is the content that was downloaded from a third party site for a
+// single puzzle.  Entries are identified by the source they were fetched for,
+// e.g. crossword/new_york_times, along with a key within that source, usually
+// the date of the puzzle.
+type Entry struct {
+	Source    string    `json:"source"`
+	Key       string    `json:"key"`
+	URL       string    `json:"url"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Content   []byte    `json:"content"`
+}
+
+// The pool of database connections that entries are stored in.  When there's
+// no pool then content isn't cached and every fetch downloads its URL.
+var pool *redis.Pool
+var mutex sync.RWMutex
+
+// Enable turns on caching of fetched content, storing entries in the database
+// that the pool connects to.  Passing a nil pool turns caching off.
+func Enable(p *redis.Pool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	pool = p
+}
+
+// EntryTTL determines how long a fetched entry should remain cached in redis.
+// Once it expires the content is downloaded again the next time it's needed.
+var EntryTTL = 7 * 24 * time.Hour
+
+// EntryKey returns the key in the database that the entry for a source and key
+// is stored at.
+func EntryKey(source, key string) string {
+	return fmt.Sprintf("cache:%s:%s", source, key)
+}
+
+// Fetch obtains the content identified by a source and key and passes it to a
+// parse function.  If the content is cached then the cached copy is used,
+// otherwise the URL is downloaded using the provided headers.  Downloaded
+// content is only cached once it has been parsed successfully, and cached
+// content that no longer parses is downloaded again.
+//
+// Problems talking to the database are logged but never cause a fetch to fail,
+// in the worst case the URL is downloaded just as if there was no cache.
+func Fetch(source, key, url string, headers map[string]string, parse func(io.Reader) error) error {
+	mutex.RLock()
+	p := pool
+	mutex.RUnlock()
+
+	var conn redis.Conn
+	if p != nil {
+		conn = p.Get()
+		defer func() { _ = conn.Close() }()
+
+		entry, err := Get(conn, source, key)
+		if err != nil {
+			log.Printf("unable to read cached content for %s %s: %+v", source, key, err)
+		}
+
+		if entry != nil {
+			if err := parse(bytes.NewReader(entry.Content)); err == nil {
+				return nil
+			}
+
+			log.Printf("unable to parse cached content for %s %s, fetching it again", source, key)
+		}
+	}
+
+	response, err := web.GetWithHeaders(url, headers)
+	if response != nil {
+		defer func() { _ = response.Body.Close() }()
+	}
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response from url %s: %v", url, err)
+	}
+
+	if err := parse(bytes.NewReader(content)); err != nil {
+		return err
+	}
+
+	if conn != nil {
+		entry := Entry{
+			Source:    source,
+			Key:       key,
+			URL:       url,
+			FetchedAt: time.Now(),
+			Content:   content,
+		}
+		if err := db.SetWithTTL(conn, EntryKey(source, key), entry, EntryTTL); err != nil {
+			log.Printf("unable to cache content for %s %s: %+v", source, key, err)
+		}
+	}
+
+	return nil
+}
+
+// Get loads the cached entry for a source and key.  If there's no entry then
+// nil is returned.
+func Get(conn db.Connection, source, key string) (*Entry, error) {
+	var entry *Entry
+	if err := db.Get(conn, EntryKey(source, key), &entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetAll loads every cached entry belonging to a source.  If the source is
+// empty then the entries of all sources are loaded.  Entries are ordered by
+// their source and then their key.
+func GetAll(conn db.Connection, source string) ([]Entry, error) {
+	keys, err := scan(conn, source)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded, err := db.GetAll(conn, keys, Entry{})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(loaded))
+	for _, value := range loaded {
+		// An entry that was purged after the scan comes back empty.
+		if entry := value.(Entry); entry.Source != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Source != entries[j].Source {
+			return entries[i].Source < entries[j].Source
+		}
+		return entries[i].Key < entries[j].Key
+	})
+
+	return entries, nil
+}
+
+// Purge removes cached entries and returns how many were removed.  If the key
+// is empty then every entry of the source is removed, and if the source is also
+// empty then every entry is removed.
+func Purge(conn db.Connection, source, key string) (int, error) {
+	var keys []string
+	if source != "" && key != "" {
+		keys = []string{EntryKey(source, key)}
+	} else {
+		var err error
+		if keys, err = scan(conn, source); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, key := range keys {
+		args = append(args, key)
+	}
+
+	return redis.Int(conn.Do("DEL", args...))
+}
+
+// scan finds the database keys of the entries belonging to a source, or of
+// every entry when the source is empty.
+func scan(conn db.Connection, source string) ([]string, error) {
+	pattern := "cache:*"
+	if source != "" {
+		pattern = EntryKey(escape(source), "*")
+	}
+
+	return db.ScanKeys(conn, pattern)
+}
+
+// escape protects the characters of a string that have special meanings in a
+// redis key pattern.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`).Replace(s)
+}
@@ -0,0 +1,296 @@
+package cache
+
+import (
+	"errors"
+	"github.com/alicebob/miniredis"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetch(t *testing.T) {
+	pool := NewTestPool(t)
+	server, requests := NewTestServer(t, "content")
+
+	// The first fetch downloads the content and caches it.
+	content, err := FetchString("source", "key", server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "content", content)
+	assert.Equal(t, 1, *requests)
+
+	conn := pool.Get()
+	defer func() { _ = conn.Close() }()
+
+	entry, err := Get(conn, "source", "key")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "source", entry.Source)
+	assert.Equal(t, "key", entry.Key)
+	assert.Equal(t, server.URL, entry.URL)
+	assert.Equal(t, "content", string(entry.Content))
+	assert.False(t, entry.FetchedAt.IsZero())
+
+	// The second fetch uses the cached content.
+	content, err = FetchString("source", "key", server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "content", content)
+	assert.Equal(t, 1, *requests)
+
+	// A different key downloads again.
+	_, err = FetchString("source", "other", server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, 2, *requests)
+}
+
+func TestFetch_TTL(t *testing.T) {
+	pool := NewTestPool(t)
+	server, _ := NewTestServer(t, "content")
+
+	_, err := FetchString("source", "key", server.URL)
+	require.NoError(t, err)
+
+	conn := pool.Get()
+	defer func() { _ = conn.Close() }()
+
+	ttl, err := redis.Int(conn.Do("TTL", EntryKey("source", "key")))
+	require.NoError(t, err)
+	assert.Equal(t, int(EntryTTL.Seconds()), ttl)
+}
+
+func TestFetch_Disabled(t *testing.T) {
+	server, requests := NewTestServer(t, "content")
+
+	for i := 1; i <= 2; i++ {
+		content, err := FetchString("source", "key", server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "content", content)
+		assert.Equal(t, i, *requests)
+	}
+}
+
+func TestFetch_ParseError(t *testing.T) {
+	pool := NewTestPool(t)
+	server, _ := NewTestServer(t, "content")
+
+	err := Fetch("source", "key", server.URL, nil, func(io.Reader) error {
+		return errors.New("forced error")
+	})
+	assert.Error(t, err)
+
+	// Content that can't be parsed isn't cached.
+	conn := pool.Get()
+	defer func() { _ = conn.Close() }()
+
+	entry, err := Get(conn, "source", "key")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestFetch_InvalidCachedContent(t *testing.T) {
+	pool := NewTestPool(t)
+	server, requests := NewTestServer(t, "content")
+
+	conn := pool.Get()
+	defer func() { _ = conn.Close() }()
+	SetTestEntry(t, conn, "source", "key", "invalid")
+
+	// Cached content that the parser rejects is downloaded again.
+	var parsed string
+	err := Fetch("source", "key", server.URL, nil, func(in io.Reader) error {
+		bs, err := ioutil.ReadAll(in)
+		if err != nil || string(bs) == "invalid" {
+			return errors.New("invalid content")
+		}
+
+		parsed = string(bs)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "content", parsed)
+	assert.Equal(t, 1, *requests)
+
+	entry, err := Get(conn, "source", "key")
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(entry.Content))
+}
+
+func TestFetch_DownloadError(t *testing.T) {
+	NewTestPool(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := FetchString("source", "key", server.URL)
+	assert.Error(t, err)
+}
+
+func TestGetAll(t *testing.T) {
+	pool := NewTestPool(t)
+	conn := pool.Get()
+	defer func() { _ = conn.Close() }()
+
+	SetTestEntry(t, conn, "b", "2", "b2")
+	SetTestEntry(t, conn, "a", "2", "a2")
+	SetTestEntry(t, conn, "b", "1", "b1")
+	SetTestEntry(t, conn, "a", "1", "a1")
+
+	tests := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{
+			name:     "all sources",
+			expected: []string{"a1", "a2", "b1", "b2"},
+		},
+		{
+			name:     "single source",
+			source:   "b",
+			expected: []string{"b1", "b2"},
+		},
+		{
+			name:     "unknown source",
+			source:   "c",
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries, err := GetAll(conn, test.source)
+			require.NoError(t, err)
+
+			contents := make([]string, 0)
+			for _, entry := range entries {
+				contents = append(contents, string(entry.Content))
+			}
+			assert.Equal(t, test.expected, contents)
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		key       string
+		purged    int
+		remaining []string
+	}{
+		{
+			name:      "everything",
+			purged:    3,
+			remaining: []string{},
+		},
+		{
+			name:      "single source",
+			source:    "a",
+			purged:    2,
+			remaining: []string{"b1"},
+		},
+		{
+			name:      "single key",
+			source:    "a",
+			key:       "2",
+			purged:    1,
+			remaining: []string{"a1", "b1"},
+		},
+		{
+			name:      "missing key",
+			source:    "b",
+			key:       "2",
+			purged:    0,
+			remaining: []string{"a1", "a2", "b1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := NewTestPool(t)
+			conn := pool.Get()
+			defer func() { _ = conn.Close() }()
+
+			SetTestEntry(t, conn, "a", "1", "a1")
+			SetTestEntry(t, conn, "a", "2", "a2")
+			SetTestEntry(t, conn, "b", "1", "b1")
+
+			purged, err := Purge(conn, test.source, test.key)
+			require.NoError(t, err)
+			assert.Equal(t, test.purged, purged)
+
+			entries, err := GetAll(conn, "")
+			require.NoError(t, err)
+
+			remaining := make([]string, 0)
+			for _, entry := range entries {
+				remaining = append(remaining, string(entry.Content))
+			}
+			assert.Equal(t, test.remaining, remaining)
+		})
+	}
+}
+
+// NewTestPool enables the cache for the duration of a test, storing entries in
+// an in-memory redis.
+func NewTestPool(t *testing.T) *redis.Pool {
+	t.Helper()
+
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", server.Addr())
+		},
+	}
+
+	Enable(pool)
+	t.Cleanup(func() { Enable(nil) })
+
+	return pool
+}
+
+// NewTestServer starts a HTTP server that responds to every request with the
+// same content and counts the number of requests it receives.
+func NewTestServer(t *testing.T, content string) (*httptest.Server, *int) {
+	t.Helper()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+// SetTestEntry writes an entry directly to the cache.
+func SetTestEntry(t *testing.T, conn redis.Conn, source, key, content string) {
+	t.Helper()
+
+	entry := Entry{Source: source, Key: key, URL: "http://example.com", Content: []byte(content)}
+	require.NoError(t, db.Set(conn, EntryKey(source, key), entry))
+}
+
+// FetchString fetches a URL through the cache and returns its content as a
+// string.
+func FetchString(source, key, url string) (string, error) {
+	var content string
+	err := Fetch(source, key, url, nil, func(in io.Reader) error {
+		bs, err := ioutil.ReadAll(in)
+		content = string(bs)
+		return err
+	})
+
+	return content, err
+}
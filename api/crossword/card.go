@@ -0,0 +1,183 @@
+package crossword
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The emoji used to draw a cell that's a block in a share card.
+const cardBlock = "⬛"
+
+// The emoji used to draw a cell that wasn't solved by the channel in a share
+// card, either because it was revealed or never correctly filled in.
+const cardUnsolved = "⬜"
+
+// The emoji used to draw the solved cells of a share card based on when they
+// were filled in.  The solve is split into equal length periods of time, one
+// for each emoji, from the start of the solve to the end.
+var cardFillOrder = []string{"🟩", "🟨", "🟧", "🟥"}
+
+// The emoji used to draw the solved cells of a share card when chatters have
+// joined teams, indexed by the name of the team that solved the cell.
+var cardTeams = map[string]string{
+	"red":  "🟥",
+	"blue": "🟦",
+}
+
+// ShareCard renders the state as a compact text card that's suitable for
+// sharing.  The card includes a header describing the puzzle, the result of the
+// solve and the grid drawn with emoji.  When chatters have joined teams the
+// grid's cells are colored by the team that solved them, otherwise they're
+// colored by when they were solved.  The card is built only from the state and
+// its answer log so the same solve always renders the same card.
+func (s *State) ShareCard(answers []model.LoggedAnswer) string {
+	header := model.ShareCardHeader("Crossword", model.PuzzleSource{
+		Publisher:     s.Puzzle.Publisher,
+		PublishedDate: s.Puzzle.PublishedDate,
+	})
+
+	result := model.ShareCardResult(s.Status, s.TotalSolveDuration)
+	if revealed := s.CountRevealedCells(); revealed > 0 {
+		result = fmt.Sprintf("%s (%d revealed)", result, revealed)
+	}
+
+	var colors [][]string
+	if len(s.ClueTeams) > 0 {
+		colors = s.teamColors()
+	} else {
+		colors = s.fillOrderColors(answers)
+	}
+
+	lines := []string{header, result}
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		var row strings.Builder
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			switch {
+			case s.Puzzle.CellBlocks[y][x]:
+				row.WriteString(cardBlock)
+			case colors[y][x] != "":
+				row.WriteString(colors[y][x])
+			default:
+				row.WriteString(cardUnsolved)
+			}
+		}
+		lines = append(lines, row.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// teamColors determines the share card emoji of each cell that was solved by a
+// team.  Cells belonging to an across clue that was credited to a team are
+// colored by that team, remaining cells are colored by their down clue.
+func (s *State) teamColors() [][]string {
+	colors := make([][]string, s.Puzzle.Rows)
+	for y := range colors {
+		colors[y] = make([]string, s.Puzzle.Cols)
+	}
+
+	// Order the clues so that across clues are considered first and the colors
+	// don't depend on the map's iteration order.
+	clues := make([]string, 0, len(s.ClueTeams))
+	for clue := range s.ClueTeams {
+		clues = append(clues, clue)
+	}
+	sort.Slice(clues, func(i, j int) bool {
+		ni, di, _ := ParseClue(clues[i])
+		nj, dj, _ := ParseClue(clues[j])
+		if di != dj {
+			return di < dj
+		}
+		return ni < nj
+	})
+
+	for _, clue := range clues {
+		color := cardTeams[s.ClueTeams[clue]]
+		if color == "" {
+			continue
+		}
+
+		xs, ys, err := s.getAnswerCells(clue)
+		if err != nil {
+			continue
+		}
+
+		for i := range xs {
+			if colors[ys[i]][xs[i]] == "" {
+				colors[ys[i]][xs[i]] = color
+			}
+		}
+	}
+
+	return colors
+}
+
+// fillOrderColors determines the share card emoji of each solved cell based on
+// when it was solved.  The time a cell was solved is determined by replaying
+// the answers that were applied to the puzzle and finding the first one that
+// made the cell correct.  Reveal penalties aren't part of the time spent
+// filling in cells so they're excluded from the length of the solve.
+func (s *State) fillOrderColors(answers []model.LoggedAnswer) [][]string {
+	colors := make([][]string, s.Puzzle.Rows)
+	for y := range colors {
+		colors[y] = make([]string, s.Puzzle.Cols)
+	}
+
+	s.ensureCellMarks()
+
+	replay := State{Puzzle: s.Puzzle}
+	replay.Reset()
+
+	total := s.TotalSolveDuration.Duration - s.RevealPenalty.Duration
+	for _, answer := range answers {
+		if !answer.Applied {
+			continue
+		}
+
+		if err := replay.ApplyAnswer(answer.Clue, answer.Answer, false); err != nil {
+			continue
+		}
+
+		color := cardFillOrder[cardPeriod(answer.ElapsedTime.Duration, total)]
+		for y := 0; y < s.Puzzle.Rows; y++ {
+			for x := 0; x < s.Puzzle.Cols; x++ {
+				if colors[y][x] == "" && replay.Cells[y][x] != "" && s.Puzzle.IsCorrect(x, y, replay.Cells[y][x]) {
+					colors[y][x] = color
+				}
+			}
+		}
+	}
+
+	// Only cells that ended the solve correct without being revealed count as
+	// solved.
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if !s.Puzzle.IsCorrect(x, y, s.Cells[y][x]) || s.CellsRevealed[y][x] {
+				colors[y][x] = ""
+			}
+		}
+	}
+
+	return colors
+}
+
+// cardPeriod determines which of the share card's fill order periods the
+// provided elapsed time falls into for a solve of the provided total length.
+func cardPeriod(elapsed, total time.Duration) int {
+	if total <= 0 {
+		return 0
+	}
+
+	period := int(int64(elapsed) * int64(len(cardFillOrder)) / int64(total))
+	if period < 0 {
+		return 0
+	}
+	if period >= len(cardFillOrder) {
+		return len(cardFillOrder) - 1
+	}
+
+	return period
+}
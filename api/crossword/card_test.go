@@ -0,0 +1,160 @@
+package crossword
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestState_ShareCard(t *testing.T) {
+	// Helper to build a logged answer that was applied to the puzzle after the
+	// specified number of seconds of solving.
+	answer := func(clue, answer string, seconds int) model.LoggedAnswer {
+		return model.LoggedAnswer{
+			Clue:        clue,
+			Answer:      answer,
+			Applied:     true,
+			ElapsedTime: model.Duration{Duration: time.Duration(seconds) * time.Second},
+		}
+	}
+
+	// Helper to build a logged answer that was rejected after the specified
+	// number of seconds of solving.
+	rejected := func(clue, answer string, seconds int) model.LoggedAnswer {
+		return model.LoggedAnswer{
+			Clue:        clue,
+			Answer:      answer,
+			ElapsedTime: model.Duration{Duration: time.Duration(seconds) * time.Second},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		setup    func(*testing.T, *State) []model.LoggedAnswer
+		expected []string // the first lines of the card
+	}{
+		{
+			name: "colored by fill order",
+			setup: func(t *testing.T, s *State) []model.LoggedAnswer {
+				require.NoError(t, s.ApplyAnswer("1a", "QANDA", false))
+				require.NoError(t, s.ApplyAnswer("6a", "ATTIC", false))
+				return []model.LoggedAnswer{
+					answer("1a", "QANDA", 30),
+					answer("6a", "ATTIX", 60),
+					answer("6a", "ATTIC", 210),
+				}
+			},
+			expected: []string{
+				"Crossword · The New York Times · 2018-12-31",
+				"✅ Solved in 4m0s",
+				"🟩🟩🟩🟩🟩⬛🟨🟨🟨🟨🟥⬛⬜⬜⬜",
+				"⬜⬜⬜⬜⬜⬛⬜⬜⬜⬜⬜⬜⬜⬜⬜",
+			},
+		},
+		{
+			name: "incorrect cells aren't colored",
+			setup: func(t *testing.T, s *State) []model.LoggedAnswer {
+				require.NoError(t, s.ApplyAnswer("1a", "QANDX", false))
+				return []model.LoggedAnswer{
+					answer("1a", "QANDA", 30),
+					answer("1a", "QANDX", 60),
+				}
+			},
+			expected: []string{
+				"Crossword · The New York Times · 2018-12-31",
+				"✅ Solved in 4m0s",
+				"🟩🟩🟩🟩⬜⬛⬜⬜⬜⬜⬜⬛⬜⬜⬜",
+			},
+		},
+		{
+			name: "rejected answers aren't replayed",
+			setup: func(t *testing.T, s *State) []model.LoggedAnswer {
+				require.NoError(t, s.ApplyAnswer("1a", "QANDA", false))
+				return []model.LoggedAnswer{
+					rejected("1a", "QANDX", 30),
+					answer("1a", "QANDA", 210),
+				}
+			},
+			expected: []string{
+				"Crossword · The New York Times · 2018-12-31",
+				"✅ Solved in 4m0s",
+				"🟥🟥🟥🟥🟥⬛⬜⬜⬜⬜⬜⬛⬜⬜⬜",
+			},
+		},
+		{
+			name: "reveal penalties aren't part of the fill order",
+			setup: func(t *testing.T, s *State) []model.LoggedAnswer {
+				require.NoError(t, s.ApplyAnswer("1a", "QANDA", false))
+				s.RevealPenalty = model.Duration{Duration: 2 * time.Minute}
+				return []model.LoggedAnswer{
+					answer("1a", "QANDA", 90),
+				}
+			},
+			expected: []string{
+				"Crossword · The New York Times · 2018-12-31",
+				"✅ Solved in 4m0s",
+				"🟥🟥🟥🟥🟥⬛⬜⬜⬜⬜⬜⬛⬜⬜⬜",
+			},
+		},
+		{
+			name: "revealed cells aren't colored",
+			setup: func(t *testing.T, s *State) []model.LoggedAnswer {
+				require.NoError(t, s.ApplyAnswer("1a", "QANDA", false))
+				_, err := s.RevealAnswer("11a")
+				require.NoError(t, err)
+				return []model.LoggedAnswer{
+					answer("1a", "QANDA", 30),
+					answer("11a", "HON", 60),
+				}
+			},
+			expected: []string{
+				"Crossword · The New York Times · 2018-12-31",
+				"✅ Solved in 4m0s (3 revealed)",
+				"🟩🟩🟩🟩🟩⬛⬜⬜⬜⬜⬜⬛⬜⬜⬜",
+			},
+		},
+		{
+			name: "colored by team",
+			setup: func(t *testing.T, s *State) []model.LoggedAnswer {
+				require.NoError(t, s.ApplyAnswer("1a", "QANDA", false))
+				require.NoError(t, s.ApplyAnswer("6a", "ATTIC", false))
+				require.NoError(t, s.ApplyAnswer("1d", "QTIP", false))
+				require.NoError(t, s.ApplyAnswer("11a", "HON", false))
+				s.ClueTeams = map[string]string{
+					"1a":  "red",
+					"6a":  "blue",
+					"1d":  "blue",
+					"11a": "",
+				}
+				return nil
+			},
+			expected: []string{
+				"Crossword · The New York Times · 2018-12-31",
+				"✅ Solved in 4m0s",
+				"🟥🟥🟥🟥🟥⬛🟦🟦🟦🟦🟦⬛⬜⬜⬜",
+				"🟦⬜⬜⬜⬜⬛⬜⬜⬜⬜⬜⬜⬜⬜⬜",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = model.StatusComplete
+			state.LastStartTime = nil
+			state.TotalSolveDuration = model.Duration{Duration: 4 * time.Minute}
+			answers := test.setup(t, &state)
+
+			card := state.ShareCard(answers)
+			lines := strings.Split(card, "\n")
+			require.Len(t, lines, 2+state.Puzzle.Rows)
+			assert.Equal(t, test.expected, lines[:len(test.expected)])
+
+			// The same state should always render the same card.
+			assert.Equal(t, card, state.ShareCard(answers))
+		})
+	}
+}
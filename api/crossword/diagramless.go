@@ -0,0 +1,153 @@
+package crossword
+
+import "fmt"
+
+// PlaceClue places the starting square of the answer for a clue in a
+// diagramless solve.  The square is identified by its 1-based row and column.
+// When the square is where the answer starts the clue is marked as placed,
+// which allows answers to be given for it, and the square along with the block
+// or edge before it are discovered.  If the puzzle isn't diagramless, the clue
+// cannot be identified or the square isn't where the answer starts then an
+// error will be returned.
+func (s *State) PlaceClue(clue string, row, col int) error {
+	if !s.Puzzle.Diagramless {
+		return fmt.Errorf("unable to place %s, puzzle isn't diagramless", clue)
+	}
+
+	num, direction, err := ParseClue(clue)
+	if err != nil {
+		return err
+	}
+
+	minX, minY, _, _, err := s.Puzzle.GetAnswerCoordinates(num, direction)
+	if err != nil {
+		return err
+	}
+
+	if row != minY+1 || col != minX+1 {
+		return fmt.Errorf("unable to place %s at row %d, column %d, incorrect", clue, row, col)
+	}
+
+	dx, dy := directionDelta(direction)
+
+	s.ensureDiscovery()
+	s.CluesPlaced[fmt.Sprintf("%d%s", num, direction)] = true
+	s.discover(minX, minY)
+	s.discover(minX-dx, minY-dy)
+
+	return nil
+}
+
+// IsPlaced returns whether or not the starting square of the answer for a clue
+// has been placed.  Clues of puzzles that aren't diagramless are always placed.
+func (s *State) IsPlaced(clue string) bool {
+	if !s.Puzzle.Diagramless {
+		return true
+	}
+
+	num, direction, err := ParseClue(clue)
+	if err != nil {
+		return false
+	}
+
+	return s.CluesPlaced[fmt.Sprintf("%d%s", num, direction)]
+}
+
+// discoverAnswer discovers every cell of the answer for a clue along with the
+// blocks or edges on either side of it and marks the clue as placed.  Nothing
+// happens if the puzzle isn't diagramless.  If the clue cannot be identified
+// then an error will be returned.
+func (s *State) discoverAnswer(clue string) error {
+	if !s.Puzzle.Diagramless {
+		return nil
+	}
+
+	num, direction, err := ParseClue(clue)
+	if err != nil {
+		return err
+	}
+
+	minX, minY, maxX, maxY, err := s.Puzzle.GetAnswerCoordinates(num, direction)
+	if err != nil {
+		return err
+	}
+
+	dx, dy := directionDelta(direction)
+
+	s.ensureDiscovery()
+	s.CluesPlaced[fmt.Sprintf("%d%s", num, direction)] = true
+	for x, y := minX-dx, minY-dy; x <= maxX+dx && y <= maxY+dy; x, y = x+dx, y+dy {
+		s.discover(x, y)
+	}
+
+	return nil
+}
+
+// discover marks the structure of a cell as discovered in a diagramless solve.
+// Coordinates that are outside of the grid are ignored.
+func (s *State) discover(x, y int) {
+	if !s.Puzzle.Diagramless || x < 0 || y < 0 || x >= s.Puzzle.Cols || y >= s.Puzzle.Rows {
+		return
+	}
+
+	s.ensureDiscovery()
+	s.CellsDiscovered[y][x] = true
+}
+
+// ensureDiscovery makes sure that the CellsDiscovered and CluesPlaced fields
+// are allocated for a diagramless solve.
+func (s *State) ensureDiscovery() {
+	if s.CellsDiscovered == nil {
+		s.CellsDiscovered = NewBoolGrid(s.Puzzle.Rows, s.Puzzle.Cols)
+	}
+
+	if s.CluesPlaced == nil {
+		s.CluesPlaced = make(map[string]bool)
+	}
+}
+
+// hideUndiscoveredStructure removes the structure of the grid that hasn't been
+// discovered yet from a copy of the puzzle that's going to be sent to clients.
+// Cells that haven't been discovered appear as open cells without a number,
+// circle or shade.  Clue numbers only appear once one of their clues has been
+// placed.
+func (s *State) hideUndiscoveredStructure(puzzle *Puzzle) {
+	s.ensureDiscovery()
+
+	puzzle.CellBlocks = NewBoolGrid(s.Puzzle.Rows, s.Puzzle.Cols)
+	puzzle.CellCircles = NewBoolGrid(s.Puzzle.Rows, s.Puzzle.Cols)
+	puzzle.CellShades = NewBoolGrid(s.Puzzle.Rows, s.Puzzle.Cols)
+	puzzle.CellClueNumbers = make([][]int, s.Puzzle.Rows)
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		puzzle.CellClueNumbers[y] = make([]int, s.Puzzle.Cols)
+
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if !s.CellsDiscovered[y][x] {
+				continue
+			}
+
+			puzzle.CellBlocks[y][x] = s.Puzzle.CellBlocks[y][x]
+			if s.Puzzle.CellCircles != nil {
+				puzzle.CellCircles[y][x] = s.Puzzle.CellCircles[y][x]
+			}
+			if s.Puzzle.CellShades != nil {
+				puzzle.CellShades[y][x] = s.Puzzle.CellShades[y][x]
+			}
+
+			num := s.Puzzle.CellClueNumbers[y][x]
+			if num != 0 && (s.CluesPlaced[fmt.Sprintf("%da", num)] || s.CluesPlaced[fmt.Sprintf("%dd", num)]) {
+				puzzle.CellClueNumbers[y][x] = num
+			}
+		}
+	}
+}
+
+// directionDelta returns the amount to step in the x and y directions to move
+// through the cells of an answer in a direction.
+func directionDelta(direction string) (int, int) {
+	if direction == "a" {
+		return 1, 0
+	}
+
+	return 0, 1
+}
@@ -0,0 +1,134 @@
+package crossword
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestState_PlaceClue(t *testing.T) {
+	state := NewDiagramlessState(t)
+
+	require.NoError(t, state.PlaceClue("6a", 1, 7))
+	assert.True(t, state.CluesPlaced["6a"])
+	assert.True(t, state.IsPlaced("6A"))
+	assert.False(t, state.IsPlaced("1a"))
+
+	// The starting square and the block before it are discovered.
+	assert.Equal(t, []bool{false, false, false, false, false, true, true, false}, state.CellsDiscovered[0][:8])
+}
+
+func TestState_PlaceClue_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		diagramless bool
+		clue        string
+		row, col    int
+	}{
+		{
+			name:        "not diagramless",
+			diagramless: false,
+			clue:        "1a",
+			row:         1,
+			col:         1,
+		},
+		{
+			name:        "invalid clue",
+			diagramless: true,
+			clue:        "2a",
+			row:         1,
+			col:         2,
+		},
+		{
+			name:        "incorrect square",
+			diagramless: true,
+			clue:        "6a",
+			row:         1,
+			col:         6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewDiagramlessState(t)
+			state.Puzzle.Diagramless = test.diagramless
+
+			assert.Error(t, state.PlaceClue(test.clue, test.row, test.col))
+			assert.Empty(t, state.CluesPlaced)
+		})
+	}
+}
+
+func TestState_ApplyAnswer_Diagramless(t *testing.T) {
+	state := NewDiagramlessState(t)
+
+	// An answer can't be given until its clue has been placed.
+	assert.Error(t, state.ApplyAnswer("1a", "QANDA", false))
+	_, err := state.CheckAnswer("1a")
+	assert.Error(t, err)
+
+	require.NoError(t, state.PlaceClue("1a", 1, 1))
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+	assert.Equal(t, []string{"Q", "A", "N", "D", "A"}, state.Cells[0][:5])
+
+	// The answer along with the block after it are discovered.
+	assert.Equal(t, []bool{true, true, true, true, true, true, false}, state.CellsDiscovered[0][:7])
+}
+
+func TestState_RevealAnswer_Diagramless(t *testing.T) {
+	state := NewDiagramlessState(t)
+
+	// Revealing an answer places its clue.
+	_, err := state.RevealAnswer("1d")
+	require.NoError(t, err)
+	assert.True(t, state.IsPlaced("1d"))
+
+	for y, expected := range []bool{true, true, true, true, true, false} {
+		assert.Equal(t, expected, state.CellsDiscovered[y][0])
+	}
+}
+
+func TestState_PublicPuzzle_Diagramless(t *testing.T) {
+	state := NewDiagramlessState(t)
+	state.Status = model.StatusSolving
+	require.NoError(t, state.PlaceClue("1a", 1, 1))
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+
+	puzzle := state.PublicPuzzle()
+	assert.True(t, puzzle.Diagramless)
+	assert.Nil(t, puzzle.Cells)
+
+	// Only the discovered structure of the grid is present.
+	assert.Equal(t, []bool{false, false, false, false, false, true, false}, puzzle.CellBlocks[0][:7])
+	assert.False(t, puzzle.CellBlocks[4][0])
+	assert.Equal(t, []int{1, 0, 0, 0, 0, 0, 0}, puzzle.CellClueNumbers[0][:7])
+
+	// The original puzzle isn't modified.
+	assert.True(t, state.Puzzle.CellBlocks[4][0])
+	assert.Equal(t, 2, state.Puzzle.CellClueNumbers[0][1])
+
+	// Once the solve is finished the entire grid is shown.
+	state.Status = model.StatusComplete
+	assert.Equal(t, state.Puzzle, state.PublicPuzzle())
+}
+
+func TestState_Reset_Diagramless(t *testing.T) {
+	state := NewDiagramlessState(t)
+	require.NoError(t, state.PlaceClue("1a", 1, 1))
+
+	state.Reset()
+	assert.Empty(t, state.CluesPlaced)
+	assert.Equal(t, NewBoolGrid(state.Puzzle.Rows, state.Puzzle.Cols), state.CellsDiscovered)
+}
+
+// NewDiagramlessState creates a new crossword puzzle state for a diagramless
+// solve of a puzzle that hasn't had any of its structure discovered.
+func NewDiagramlessState(t *testing.T) State {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Puzzle.Diagramless = true
+	state.CellsDiscovered = NewBoolGrid(state.Puzzle.Rows, state.Puzzle.Cols)
+	state.CluesPlaced = make(map[string]bool)
+
+	return state
+}
@@ -0,0 +1,176 @@
+package crossword
+
+import (
+	"errors"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestExpireIfOutOfTime(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name          string
+		status        model.Status
+		limit         time.Duration
+		total         time.Duration
+		lastStartTime *time.Time
+		expectExpired bool
+	}{
+		{
+			name:          "no time limit",
+			status:        model.StatusSolving,
+			lastStartTime: before(time.Hour),
+		},
+		{
+			name:   "paused",
+			status: model.StatusPaused,
+			limit:  15 * time.Minute,
+			total:  time.Hour,
+		},
+		{
+			name:          "time remaining",
+			status:        model.StatusSolving,
+			limit:         15 * time.Minute,
+			total:         5 * time.Minute,
+			lastStartTime: before(5 * time.Minute),
+		},
+		{
+			name:          "out of time",
+			status:        model.StatusSolving,
+			limit:         15 * time.Minute,
+			total:         5 * time.Minute,
+			lastStartTime: before(10 * time.Minute),
+			expectExpired: true,
+		},
+		{
+			name:          "out of time long ago",
+			status:        model.StatusSolving,
+			limit:         15 * time.Minute,
+			lastStartTime: before(24 * time.Hour),
+			expectExpired: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+			events := NewEventSubscription(t, registry, "channel")
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.status
+			state.TimeLimit = model.Duration{Duration: test.limit}
+			state.TotalSolveDuration = model.Duration{Duration: test.total}
+			state.LastStartTime = test.lastStartTime
+			require.NoError(t, SetState(conn, "channel", state))
+
+			require.NoError(t, ExpireIfOutOfTime(conn, registry, "channel", now))
+
+			state, err := GetState(conn, "channel")
+			require.NoError(t, err)
+
+			if !test.expectExpired {
+				assert.Equal(t, test.status, state.Status)
+				assert.Empty(t, events)
+				return
+			}
+
+			assert.Equal(t, model.StatusExpired, state.Status)
+			assert.Nil(t, state.LastStartTime)
+			assert.Equal(t, test.limit, state.TotalSolveDuration.Duration)
+
+			require.Len(t, events, 2)
+			event := <-events
+			assert.Equal(t, "state", event.Kind)
+			assert.Equal(t, model.StatusExpired, event.Payload.(State).Status)
+			assert.NotNil(t, event.Payload.(State).Puzzle.Cells) // Finished solves include the solution
+
+			event = <-events
+			assert.Equal(t, "expired", event.Kind)
+		})
+	}
+}
+
+func TestExpireIfOutOfTime_Error(t *testing.T) {
+	tests := []struct {
+		name             string
+		stateLoadError   error
+		stateSaveError   error
+		archiveSaveError error
+	}{
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			stateSaveError: errors.New("forced error"),
+		},
+		{
+			name:             "error saving archive",
+			archiveSaveError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			start := time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC)
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = model.StatusSolving
+			state.TimeLimit = model.Duration{Duration: 15 * time.Minute}
+			state.LastStartTime = &start
+			require.NoError(t, SetState(conn, "channel", state))
+
+			if test.stateLoadError != nil {
+				ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+			if test.stateSaveError != nil {
+				ForceErrorDuringStateSave(t, test.stateSaveError)
+			}
+			if test.archiveSaveError != nil {
+				ForceErrorDuringArchiveSave(t, test.archiveSaveError)
+			}
+
+			err := ExpireIfOutOfTime(conn, registry, "channel", start.Add(time.Hour))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestExpireSolves(t *testing.T) {
+	_, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Hour)
+
+	// Only channel a has a time limit.
+	for channel, limit := range map[string]time.Duration{"a": 15 * time.Minute, "b": 0} {
+		state := NewState(t, "xwordinfo-nyt-20181231.json")
+		state.Status = model.StatusSolving
+		state.TimeLimit = model.Duration{Duration: limit}
+		state.LastStartTime = &start
+		require.NoError(t, SetState(conn, channel, state))
+	}
+
+	require.NoError(t, ExpireSolves(conn, registry, now))
+
+	a, err := GetState(conn, "a")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusExpired, a.Status)
+
+	b, err := GetState(conn, "b")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusSolving, b.Status)
+}
@@ -0,0 +1,100 @@
+package crossword
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/answerlog"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/worker"
+	"github.com/gomodule/redigo/redis"
+	"time"
+)
+
+// GiveHints gives a hint to every channel's crossword solve that has stalled.
+func GiveHints(conn redis.Conn, registry *pubsub.Registry, now time.Time) error {
+	return worker.ForEachChannel(conn, StateKey, func(channel string) error {
+		return GiveHint(conn, registry, channel, now)
+	})
+}
+
+// GiveHint reveals a single letter of a channel's crossword solve if the
+// channel has hints enabled and the solve hasn't made any progress within its
+// configured hint interval.  The hinted cell is marked as revealed, so like any
+// other reveal it adds the channel's reveal penalty to the solve's duration.
+// The updated state is published along with a hint event identifying the clue
+// that was hinted.
+func GiveHint(conn redis.Conn, registry *pubsub.Registry, channel string, now time.Time) error {
+	settings, err := GetSettings(conn, channel)
+	if err != nil {
+		return err
+	}
+
+	var state State
+	var answers []model.LoggedAnswer
+	var stalled bool
+	read := func(conn db.Connection) error {
+		var err error
+		state, err = GetState(conn, channel)
+		if err != nil {
+			return err
+		}
+
+		// The answer log is only needed to archive the attempt if the hint ends up
+		// solving the puzzle, so it's only loaded when a hint is going to be given.
+		stalled = worker.IsStalled(state.Status, state.LastActivityTime(), settings.HintInterval.Duration, now)
+		if stalled {
+			answers, err = answerlog.Get(conn, "crossword", channel)
+		}
+		return err
+	}
+
+	write := func(tx db.Connection) ([]pubsub.Event, error) {
+		if !stalled {
+			return nil, nil
+		}
+
+		clue, err := state.RevealHint()
+		if err != nil {
+			return nil, err
+		}
+		state.LastProgressTime = &now
+
+		// Apply the penalty for the revealed cell.
+		penalty := settings.RevealPenalty.Duration
+		state.TotalSolveDuration = model.Duration{Duration: state.TotalSolveDuration.Duration + penalty}
+		state.RevealPenalty = model.Duration{Duration: state.RevealPenalty.Duration + penalty}
+
+		// If the hint solved the puzzle then we should stop the timer.
+		if state.Status == model.StatusComplete {
+			total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+			state.LastStartTime = nil
+			state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+		}
+
+		if err := SetState(tx, channel, state); err != nil {
+			return nil, err
+		}
+
+		// If the hint solved the puzzle then the attempt should be archived.
+		if state.Status == model.StatusComplete {
+			if err := ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now)); err != nil {
+				return nil, err
+			}
+		}
+
+		// Broadcast the updated state to all of the clients, making sure to not
+		// include the answers.
+		state.Puzzle = state.PublicPuzzle()
+		events := []pubsub.Event{StateEvent(state), HintEvent(clue)}
+
+		// If we've just finished the solve then send complete and summary events as
+		// well.
+		if state.Status == model.StatusComplete {
+			events = append(events, CompleteEvent(), SummaryEvent(state.Summary(answers)))
+		}
+
+		return events, nil
+	}
+
+	return worker.Update(conn, registry, ChannelID(channel), StateKey(channel), read, write)
+}
@@ -0,0 +1,263 @@
+package crossword
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGiveHint(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name             string
+		status           model.Status
+		interval         time.Duration
+		lastStartTime    *time.Time
+		lastProgressTime *time.Time
+		expectHint       bool
+	}{
+		{
+			name:          "hints disabled",
+			status:        model.StatusSolving,
+			lastStartTime: before(time.Hour),
+		},
+		{
+			name:          "not solving",
+			status:        model.StatusPaused,
+			interval:      5 * time.Minute,
+			lastStartTime: before(time.Hour),
+		},
+		{
+			name:          "started recently",
+			status:        model.StatusSolving,
+			interval:      5 * time.Minute,
+			lastStartTime: before(time.Minute),
+		},
+		{
+			name:             "recent progress",
+			status:           model.StatusSolving,
+			interval:         5 * time.Minute,
+			lastStartTime:    before(time.Hour),
+			lastProgressTime: before(time.Minute),
+		},
+		{
+			name:          "stalled since start",
+			status:        model.StatusSolving,
+			interval:      5 * time.Minute,
+			lastStartTime: before(10 * time.Minute),
+			expectHint:    true,
+		},
+		{
+			name:             "stalled since progress",
+			status:           model.StatusSolving,
+			interval:         5 * time.Minute,
+			lastStartTime:    before(time.Hour),
+			lastProgressTime: before(5 * time.Minute),
+			expectHint:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+			events := NewEventSubscription(t, registry, "channel")
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.status
+			state.LastStartTime = test.lastStartTime
+			state.LastProgressTime = test.lastProgressTime
+			require.NoError(t, SetState(conn, "channel", state))
+
+			settings := Settings{HintInterval: model.Duration{Duration: test.interval}}
+			require.NoError(t, SetSettings(conn, "channel", settings))
+
+			require.NoError(t, GiveHint(conn, registry, "channel", now))
+
+			state, err := GetState(conn, "channel")
+			require.NoError(t, err)
+
+			if !test.expectHint {
+				assert.Equal(t, 0, state.CountCorrectCells())
+				assert.Equal(t, test.lastProgressTime, state.LastProgressTime)
+				assert.Empty(t, events)
+				return
+			}
+
+			assert.Equal(t, 1, state.CountCorrectCells())
+			assert.Equal(t, &now, state.LastProgressTime)
+			require.Len(t, events, 2)
+
+			event := <-events
+			assert.Equal(t, "state", event.Kind)
+
+			event = <-events
+			assert.Equal(t, "hint", event.Kind)
+			assert.Regexp(t, "^[0-9]+[ad]$", event.Payload)
+		})
+	}
+}
+
+func TestGiveHint_Complete(t *testing.T) {
+	_, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, "channel")
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-10 * time.Minute)
+
+	// Solve every cell except for the first one.
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &start
+	for num := range state.Puzzle.CluesAcross {
+		_, err := state.RevealAnswer(fmt.Sprintf("%da", num))
+		require.NoError(t, err)
+	}
+	state.Cells[0][0] = ""
+	state.CellsRevealed[0][0] = false
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, "channel", state))
+
+	settings := Settings{HintInterval: model.Duration{Duration: 5 * time.Minute}}
+	require.NoError(t, SetSettings(conn, "channel", settings))
+
+	require.NoError(t, GiveHint(conn, registry, "channel", now))
+
+	state, err := GetState(conn, "channel")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusComplete, state.Status)
+	assert.Nil(t, state.LastStartTime)
+	assert.Equal(t, 10*time.Minute, state.TotalSolveDuration.Duration)
+
+	require.Len(t, events, 4)
+	assert.Equal(t, "state", (<-events).Kind)
+	assert.Equal(t, "hint", (<-events).Kind)
+	assert.Equal(t, "complete", (<-events).Kind)
+	assert.Equal(t, "summary", (<-events).Kind)
+
+	// The completed attempt should have been archived.
+	attempts := LoadArchive(t, pool)
+	require.Equal(t, 1, len(attempts))
+	assert.Equal(t, model.StatusComplete, attempts[0].Status)
+	assert.Equal(t, state.CountRevealedCells(), attempts[0].CellsRevealed)
+}
+
+func TestGiveHint_RevealPenalty(t *testing.T) {
+	_, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-10 * time.Minute)
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &start
+	state.TotalSolveDuration = model.Duration{Duration: time.Minute}
+	require.NoError(t, SetState(conn, "channel", state))
+
+	settings := Settings{
+		HintInterval:  model.Duration{Duration: 5 * time.Minute},
+		RevealPenalty: model.Duration{Duration: 30 * time.Second},
+	}
+	require.NoError(t, SetSettings(conn, "channel", settings))
+
+	require.NoError(t, GiveHint(conn, registry, "channel", now))
+
+	// The hinted cell is a revealed cell and is penalized like one.
+	state, err := GetState(conn, "channel")
+	require.NoError(t, err)
+	assert.Equal(t, 1, state.CountRevealedCells())
+	assert.Equal(t, 90*time.Second, state.TotalSolveDuration.Duration)
+	assert.Equal(t, 30*time.Second, state.RevealPenalty.Duration)
+}
+
+func TestGiveHint_Error(t *testing.T) {
+	tests := []struct {
+		name              string
+		settingsLoadError error
+		stateLoadError    error
+		stateSaveError    error
+	}{
+		{
+			name:              "error loading settings",
+			settingsLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			stateSaveError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			start := time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC)
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = model.StatusSolving
+			state.LastStartTime = &start
+			require.NoError(t, SetState(conn, "channel", state))
+
+			settings := Settings{HintInterval: model.Duration{Duration: 5 * time.Minute}}
+			require.NoError(t, SetSettings(conn, "channel", settings))
+
+			if test.settingsLoadError != nil {
+				ForceErrorDuringSettingsLoad(t, test.settingsLoadError)
+			}
+			if test.stateLoadError != nil {
+				ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+			if test.stateSaveError != nil {
+				ForceErrorDuringStateSave(t, test.stateSaveError)
+			}
+
+			err := GiveHint(conn, registry, "channel", start.Add(time.Hour))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGiveHints(t *testing.T) {
+	_, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Hour)
+
+	for _, channel := range []string{"a", "b"} {
+		state := NewState(t, "xwordinfo-nyt-20181231.json")
+		state.Status = model.StatusSolving
+		state.LastStartTime = &start
+		require.NoError(t, SetState(conn, channel, state))
+	}
+
+	// Only channel a has hints enabled.
+	settings := Settings{HintInterval: model.Duration{Duration: 5 * time.Minute}}
+	require.NoError(t, SetSettings(conn, "a", settings))
+	require.NoError(t, SetSettings(conn, "b", Settings{}))
+
+	require.NoError(t, GiveHints(conn, registry, now))
+
+	a, err := GetState(conn, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, a.CountCorrectCells())
+
+	b, err := GetState(conn, "b")
+	require.NoError(t, err)
+	assert.Equal(t, 0, b.CountCorrectCells())
+}
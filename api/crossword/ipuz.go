@@ -0,0 +1,344 @@
+package crossword
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IPuzFile is a representation of the parts of an ipuz file that are needed
+// to load a crossword.  The full specification of the format can be found at
+// http://www.ipuz.org.
+type IPuzFile struct {
+	Kind       []string `json:"kind"`
+	Title      string   `json:"title"`
+	Author     string   `json:"author"`
+	Publisher  string   `json:"publisher"`
+	Date       string   `json:"date"`
+	Notes      string   `json:"notes"`
+	Intro      string   `json:"intro"`
+	Block      *string  `json:"block"`
+	Dimensions struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"dimensions"`
+	Puzzle   [][]json.RawMessage          `json:"puzzle"`
+	Solution [][]json.RawMessage          `json:"solution"`
+	Clues    map[string][]json.RawMessage `json:"clues"`
+}
+
+// ParseIPuz loads an ipuz file into a Puzzle object.  Both plain JSON files
+// and files wrapped in the ipuz(...) callback are supported.
+func ParseIPuz(in io.Reader) (*Puzzle, error) {
+	bs, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ipuz file: %v", err)
+	}
+
+	bs = bytes.TrimSpace(bs)
+	if bytes.HasPrefix(bs, []byte("ipuz(")) && bytes.HasSuffix(bs, []byte(")")) {
+		bs = bs[len("ipuz(") : len(bs)-1]
+	}
+
+	var f IPuzFile
+	if err := json.Unmarshal(bs, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse ipuz file: %v", err)
+	}
+
+	return f.Convert()
+}
+
+// Convert takes the in-memory representation of an ipuz file and converts it
+// into a Puzzle object.
+func (f *IPuzFile) Convert() (*Puzzle, error) {
+	var isCrossword bool
+	for _, kind := range f.Kind {
+		isCrossword = isCrossword || strings.Contains(kind, "ipuz.org/crossword")
+	}
+	if !isCrossword {
+		return nil, fmt.Errorf("ipuz file is not a crossword: %v", f.Kind)
+	}
+
+	rows, cols := f.Dimensions.Height, f.Dimensions.Width
+	if rows <= 0 || cols <= 0 || len(f.Puzzle) != rows || len(f.Solution) != rows {
+		return nil, fmt.Errorf("ipuz file has invalid dimensions %dx%d", cols, rows)
+	}
+
+	block := "#"
+	if f.Block != nil {
+		block = *f.Block
+	}
+
+	var puzzle Puzzle
+	puzzle.Description = "Crossword loaded from ipuz file"
+	puzzle.Rows = rows
+	puzzle.Cols = cols
+	puzzle.Title = f.Title
+	puzzle.Publisher = f.Publisher
+	puzzle.Notes = strings.TrimSpace(f.Notes + " " + f.Intro)
+
+	puzzle.Author = strings.TrimSpace(f.Author)
+	if strings.HasPrefix(puzzle.Author, "by ") || strings.HasPrefix(puzzle.Author, "By ") {
+		puzzle.Author = puzzle.Author[3:]
+	}
+
+	if f.Date != "" {
+		date, err := time.Parse("01/02/2006", f.Date)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ipuz date %s: %v", f.Date, err)
+		}
+		puzzle.PublishedDate = date
+	}
+
+	for y := 0; y < rows; y++ {
+		if len(f.Puzzle[y]) != cols || len(f.Solution[y]) != cols {
+			return nil, fmt.Errorf("row %d of ipuz file has the wrong number of cells", y)
+		}
+
+		puzzle.Cells = append(puzzle.Cells, make([]string, cols))
+		puzzle.CellBlocks = append(puzzle.CellBlocks, make([]bool, cols))
+		puzzle.CellClueNumbers = append(puzzle.CellClueNumbers, make([]int, cols))
+		puzzle.CellCircles = append(puzzle.CellCircles, make([]bool, cols))
+		puzzle.CellShades = append(puzzle.CellShades, make([]bool, cols))
+
+		for x := 0; x < cols; x++ {
+			cell, err := parseIPuzPuzzleCell(f.Puzzle[y][x], block)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse cell (%d, %d) of ipuz file: %v", x, y, err)
+			}
+
+			value, err := parseIPuzSolutionCell(f.Solution[y][x])
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse solution (%d, %d) of ipuz file: %v", x, y, err)
+			}
+
+			if cell.block || value == block || value == "" {
+				puzzle.CellBlocks[y][x] = true
+				continue
+			}
+
+			puzzle.Cells[y][x] = strings.ToUpper(value)
+			puzzle.CellClueNumbers[y][x] = cell.number
+			puzzle.CellCircles[y][x] = cell.circle
+			puzzle.CellShades[y][x] = cell.shade
+		}
+	}
+
+	puzzle.CluesAcross = make(map[int]string)
+	puzzle.CluesDown = make(map[int]string)
+	for direction, clues := range f.Clues {
+		var target map[int]string
+		switch strings.SplitN(direction, ":", 2)[0] {
+		case "Across":
+			target = puzzle.CluesAcross
+		case "Down":
+			target = puzzle.CluesDown
+		default:
+			continue
+		}
+
+		for _, raw := range clues {
+			clue, err := parseIPuzClue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse %s clue of ipuz file: %v", direction, err)
+			}
+
+			target[clue.number] = clue.text
+
+			// A clue that's continued in other clues is the start of a group of
+			// linked clues.
+			if len(clue.continued) > 0 {
+				id := fmt.Sprintf("%d%s", clue.number, strings.ToLower(direction[:1]))
+				puzzle.LinkedClues = append(puzzle.LinkedClues, append([]string{id}, clue.continued...))
+			}
+		}
+	}
+
+	sort.Slice(puzzle.LinkedClues, func(i, j int) bool {
+		ni, di, _ := ParseClue(puzzle.LinkedClues[i][0])
+		nj, dj, _ := ParseClue(puzzle.LinkedClues[j][0])
+		if di != dj {
+			return di < dj
+		}
+		return ni < nj
+	})
+
+	return &puzzle, nil
+}
+
+// ipuzCell is the parsed version of a cell from the puzzle section of an ipuz
+// file.
+type ipuzCell struct {
+	block  bool
+	number int
+	circle bool
+	shade  bool
+}
+
+// parseIPuzPuzzleCell parses a cell of the puzzle section of an ipuz file.  A
+// cell is either null (an omitted cell), the block string, a clue number (as a
+// number or a string) or an object containing a cell and its style.
+func parseIPuzPuzzleCell(raw json.RawMessage, block string) (ipuzCell, error) {
+	var cell ipuzCell
+
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return cell, err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		cell.block = true
+
+	case float64:
+		cell.number = int(v)
+
+	case string:
+		if v == block {
+			cell.block = true
+		} else if n, err := strconv.Atoi(v); err == nil {
+			cell.number = n
+		}
+
+	case map[string]interface{}:
+		var styled struct {
+			Cell  json.RawMessage `json:"cell"`
+			Style struct {
+				ShapeBG   string `json:"shapebg"`
+				Highlight bool   `json:"highlight"`
+				Color     string `json:"color"`
+			} `json:"style"`
+		}
+		if err := json.Unmarshal(raw, &styled); err != nil {
+			return cell, err
+		}
+
+		if styled.Cell != nil {
+			inner, err := parseIPuzPuzzleCell(styled.Cell, block)
+			if err != nil {
+				return cell, err
+			}
+			cell = inner
+		}
+
+		cell.circle = styled.Style.ShapeBG == "circle"
+		cell.shade = styled.Style.Highlight || styled.Style.Color != ""
+
+	default:
+		return cell, fmt.Errorf("unsupported cell: %s", raw)
+	}
+
+	return cell, nil
+}
+
+// parseIPuzSolutionCell parses a cell of the solution section of an ipuz file.
+// A cell is either null, a string or an object containing the value.
+func parseIPuzSolutionCell(raw json.RawMessage) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+
+	case string:
+		return v, nil
+
+	case map[string]interface{}:
+		s, _ := v["value"].(string)
+		return s, nil
+
+	default:
+		return "", fmt.Errorf("unsupported solution: %s", raw)
+	}
+}
+
+// ipuzClue is the parsed version of a clue from an ipuz file.
+type ipuzClue struct {
+	number    int
+	text      string
+	continued []string
+}
+
+// parseIPuzClue parses a clue of an ipuz file.  A clue is either a two element
+// list of the clue number and text or an object with number and clue fields.
+// An object may also list the clues that its answer is continued in, these are
+// returned as clue identifiers (e.g. 23a).
+func parseIPuzClue(raw json.RawMessage) (ipuzClue, error) {
+	var clue ipuzClue
+	var number interface{}
+
+	var list []interface{}
+	if err := json.Unmarshal(raw, &list); err == nil {
+		if len(list) != 2 {
+			return clue, fmt.Errorf("unsupported clue: %s", raw)
+		}
+
+		number = list[0]
+		clue.text, _ = list[1].(string)
+	} else {
+		var object struct {
+			Number    interface{} `json:"number"`
+			Clue      string      `json:"clue"`
+			Continued []struct {
+				Direction string      `json:"direction"`
+				Number    interface{} `json:"number"`
+			} `json:"continued"`
+		}
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return clue, fmt.Errorf("unsupported clue: %s", raw)
+		}
+
+		number = object.Number
+		clue.text = object.Clue
+
+		for _, continued := range object.Continued {
+			n, err := parseIPuzClueNumber(continued.Number)
+			if err != nil {
+				return clue, err
+			}
+
+			direction := strings.ToLower(continued.Direction)
+			if direction != "across" && direction != "down" {
+				return clue, fmt.Errorf("unsupported continued clue direction: %s", continued.Direction)
+			}
+
+			clue.continued = append(clue.continued, fmt.Sprintf("%d%s", n, direction[:1]))
+		}
+	}
+
+	n, err := parseIPuzClueNumber(number)
+	if err != nil {
+		return clue, err
+	}
+	clue.number = n
+
+	return clue, nil
+}
+
+// parseIPuzClueNumber parses the number of a clue of an ipuz file, which may
+// be either a number or a string.
+func parseIPuzClueNumber(number interface{}) (int, error) {
+	switch n := number.(type) {
+	case float64:
+		return int(n), nil
+
+	case string:
+		i, err := strconv.Atoi(n)
+		if err != nil {
+			return 0, fmt.Errorf("unsupported clue number: %s", n)
+		}
+		return i, nil
+
+	default:
+		return 0, fmt.Errorf("unsupported clue number: %v", number)
+	}
+}
@@ -0,0 +1,103 @@
+package crossword
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIPuz(t *testing.T) {
+	in, err := os.Open(path.Join("testdata", "ipuz", "example-20200102.ipuz"))
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	puzzle, err := ParseIPuz(in)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Example Puzzle", puzzle.Title)
+	assert.Equal(t, "Jane Doe", puzzle.Author)
+	assert.Equal(t, "Example Press", puzzle.Publisher)
+	assert.Equal(t, time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC), puzzle.PublishedDate)
+	assert.Equal(t, "A small example.", puzzle.Notes)
+	assert.Equal(t, 3, puzzle.Rows)
+	assert.Equal(t, 3, puzzle.Cols)
+	assert.Equal(t, [][]string{{"C", "A", "T"}, {"A", "G", "O"}, {"B", "O", ""}}, puzzle.Cells)
+	assert.Equal(t, [][]bool{{false, false, false}, {false, false, false}, {false, false, true}}, puzzle.CellBlocks)
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 0, 0}, {5, 0, 0}}, puzzle.CellClueNumbers)
+	assert.Equal(t, [][]bool{{false, false, true}, {false, false, false}, {false, false, false}}, puzzle.CellCircles)
+	assert.Equal(t, [][]bool{{false, false, false}, {true, false, false}, {false, false, false}}, puzzle.CellShades)
+	assert.Equal(t, map[int]string{1: "Feline", 4: "In the past", 5: "Yoga ___"}, puzzle.CluesAcross)
+	assert.Equal(t, map[int]string{1: "Taxi", 2: "Board game", 3: "Digit"}, puzzle.CluesDown)
+}
+
+func TestParseIPuz_LinkedClues(t *testing.T) {
+	input := `{
+	  "kind": ["http://ipuz.org/crossword#1"],
+	  "dimensions": {"width": 3, "height": 3},
+	  "puzzle": [[1, 2, 3], [4, 0, 0], [5, 0, 0]],
+	  "solution": [["C", "A", "T"], ["A", "G", "O"], ["B", "O", "W"]],
+	  "clues": {
+	    "Across": [
+	      {"number": 1, "clue": "With 5-Across, feline ribbon", "continued": [{"direction": "Across", "number": "5"}]},
+	      [4, "In the past"],
+	      [5, "See 1-Across"]
+	    ],
+	    "Down": [
+	      {"number": 3, "clue": "With 2-Down, ...", "continued": [{"direction": "Down", "number": 2}]},
+	      [1, "Taxi"],
+	      [2, "See 3-Down"]
+	    ]
+	  }
+	}`
+
+	puzzle, err := ParseIPuz(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"1a", "5a"}, {"3d", "2d"}}, puzzle.LinkedClues)
+}
+
+func TestParseIPuz_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "invalid json",
+			input: `ipuz({`,
+		},
+		{
+			name:  "not a crossword",
+			input: `{"kind": ["http://ipuz.org/sudoku#1"]}`,
+		},
+		{
+			name:  "invalid dimensions",
+			input: `{"kind": ["http://ipuz.org/crossword#1"], "dimensions": {"width": 1, "height": 1}}`,
+		},
+		{
+			name: "invalid date",
+			input: `{"kind": ["http://ipuz.org/crossword#1"], "date": "yesterday",
+			         "dimensions": {"width": 1, "height": 1}, "puzzle": [[1]], "solution": [["A"]]}`,
+		},
+		{
+			name: "invalid clue",
+			input: `{"kind": ["http://ipuz.org/crossword#1"], "dimensions": {"width": 1, "height": 1},
+			         "puzzle": [[1]], "solution": [["A"]], "clues": {"Across": [["one", "A"]]}}`,
+		},
+		{
+			name: "invalid continued clue",
+			input: `{"kind": ["http://ipuz.org/crossword#1"], "dimensions": {"width": 1, "height": 1},
+			         "puzzle": [[1]], "solution": [["A"]],
+			         "clues": {"Across": [{"number": 1, "clue": "A", "continued": [{"direction": "Up", "number": 1}]}]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseIPuz(strings.NewReader(test.input))
+			assert.Error(t, err)
+		})
+	}
+}
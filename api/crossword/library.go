@@ -0,0 +1,252 @@
+package crossword
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LibraryEntry describes a single puzzle file within a library.
+type LibraryEntry struct {
+	// The identifier of the puzzle.  This is derived from the path of the file
+	// so it remains the same across refreshes of the library.
+	ID string `json:"id"`
+
+	// The path of the file relative to the library's directory.
+	Filename string `json:"filename"`
+
+	// The format of the file, either puz or ipuz.
+	Format string `json:"format"`
+
+	// The title of the puzzle.
+	Title string `json:"title"`
+
+	// The name of the author(s) of the puzzle.
+	Author string `json:"author"`
+
+	// The publisher of the puzzle.
+	Publisher string `json:"publisher"`
+
+	// The date that the puzzle was published, if known.
+	PublishedDate time.Time `json:"published"`
+
+	// The dimensions of the puzzle's grid.
+	Rows int `json:"rows"`
+	Cols int `json:"cols"`
+
+	// The modification time and size of the file when it was indexed, used to
+	// determine if the file needs to be indexed again.
+	modTime time.Time
+	size    int64
+}
+
+// Library is a source of crossword puzzles from the .puz and ipuz files within
+// a directory.  The directory is indexed when the library is refreshed,
+// recording the metadata of each puzzle so that the library can be browsed and
+// searched without reading every file.
+type Library struct {
+	dir string
+
+	mutex   sync.RWMutex
+	entries map[string]LibraryEntry
+}
+
+// The file extensions of the puzzle files in a library mapped to their
+// formats.
+var libraryFormats = map[string]string{
+	".puz":  "puz",
+	".ipuz": "ipuz",
+}
+
+// A regular expression that matches a date in a filename, e.g. 2020-01-02 or
+// 20200102.
+var libraryFilenameDate = regexp.MustCompile(`(\d{4})-?(\d{2})-?(\d{2})`)
+
+// NewLibrary creates a library of the puzzles in a directory.  The library is
+// empty until it's refreshed.
+func NewLibrary(dir string) *Library {
+	return &Library{
+		dir:     dir,
+		entries: make(map[string]LibraryEntry),
+	}
+}
+
+// Refresh updates the index of the library to match the files currently in
+// its directory.  Files that are new or have changed since the last refresh are
+// indexed, and files that have been removed are dropped.  Files that can't be
+// parsed are logged and skipped.
+func (l *Library) Refresh() error {
+	l.mutex.RLock()
+	existing := l.entries
+	l.mutex.RUnlock()
+
+	entries := make(map[string]LibraryEntry)
+	err := filepath.Walk(l.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		format := libraryFormats[strings.ToLower(filepath.Ext(path))]
+		if info.IsDir() || format == "" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		id := LibraryID(rel)
+
+		if entry, ok := existing[id]; ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+			entries[id] = entry
+			return nil
+		}
+
+		puzzle, err := l.parse(rel, format)
+		if err != nil {
+			log.Printf("unable to index library file %s: %+v", rel, err)
+			return nil
+		}
+
+		entries[id] = LibraryEntry{
+			ID:            id,
+			Filename:      rel,
+			Format:        format,
+			Title:         puzzle.Title,
+			Author:        puzzle.Author,
+			Publisher:     puzzle.Publisher,
+			PublishedDate: puzzle.PublishedDate,
+			Rows:          puzzle.Rows,
+			Cols:          puzzle.Cols,
+			modTime:       info.ModTime(),
+			size:          info.Size(),
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to index library %s: %v", l.dir, err)
+	}
+
+	l.mutex.Lock()
+	l.entries = entries
+	l.mutex.Unlock()
+
+	return nil
+}
+
+// LibraryID returns the identifier of the library puzzle at a path relative to
+// the library's directory.
+func LibraryID(filename string) string {
+	sum := sha1.Sum([]byte(filename))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// Entries returns every entry in the library, most recently published first.
+func (l *Library) Entries() []LibraryEntry {
+	return l.Search("")
+}
+
+// Search returns the entries of the library whose title, author, publisher or
+// filename contain the query, ignoring case.  Entries are ordered with the
+// most recently published first.
+func (l *Library) Search(query string) []LibraryEntry {
+	query = strings.ToLower(query)
+
+	l.mutex.RLock()
+	var entries []LibraryEntry
+	for _, entry := range l.entries {
+		fields := []string{entry.Title, entry.Author, entry.Publisher, entry.Filename}
+		if query == "" || strings.Contains(strings.ToLower(strings.Join(fields, "\n")), query) {
+			entries = append(entries, entry)
+		}
+	}
+	l.mutex.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].PublishedDate.Equal(entries[j].PublishedDate) {
+			return entries[i].PublishedDate.After(entries[j].PublishedDate)
+		}
+		return entries[i].Filename < entries[j].Filename
+	})
+
+	return entries
+}
+
+// Get returns the entry of the library with the given identifier.
+func (l *Library) Get(id string) (LibraryEntry, bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	entry, ok := l.entries[id]
+	return entry, ok
+}
+
+func (l *Library) ID() string   { return "library" }
+func (l *Library) Name() string { return "Puzzle library" }
+func (l *Library) Key() string  { return "library_id" }
+
+// AvailableDates returns nil because library puzzles are selected by their id
+// rather than a date.  The catalog lists the library's entries instead.
+func (l *Library) AvailableDates() []time.Time { return nil }
+
+// Load loads the puzzle with the given identifier from the library.
+func (l *Library) Load(id string) (*Puzzle, error) {
+	entry, ok := l.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("no puzzle with id %s in library", id)
+	}
+
+	puzzle, err := l.parse(entry.Filename, entry.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	puzzle.Description = fmt.Sprintf("Crossword loaded from library file %s", entry.Filename)
+	return puzzle, nil
+}
+
+// parse reads a puzzle file from the library's directory.  Metadata that's
+// missing from the file is filled in from its location when possible, the
+// publisher from the name of the directory containing the file and the
+// published date from a date in the filename.
+func (l *Library) parse(filename, format string) (*Puzzle, error) {
+	in, err := os.Open(filepath.Join(l.dir, filepath.FromSlash(filename)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = in.Close() }()
+
+	var puzzle *Puzzle
+	switch format {
+	case "puz":
+		puzzle, err = LoadPuzFile(in)
+	case "ipuz":
+		puzzle, err = ParseIPuz(in)
+	default:
+		err = fmt.Errorf("unsupported library format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if dir := filepath.Base(filepath.Dir(filepath.FromSlash(filename))); puzzle.Publisher == "" && dir != "." {
+		puzzle.Publisher = dir
+	}
+
+	if match := libraryFilenameDate.FindStringSubmatch(filepath.Base(filename)); puzzle.PublishedDate.IsZero() && match != nil {
+		if date, err := time.Parse("20060102", match[1]+match[2]+match[3]); err == nil {
+			puzzle.PublishedDate = date
+		}
+	}
+
+	return puzzle, nil
+}
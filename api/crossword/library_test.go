@@ -0,0 +1,162 @@
+package crossword
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLibrary_Refresh(t *testing.T) {
+	dir := NewTestLibraryDir(t)
+	CopyTestFile(t, filepath.Join("puz", "nyt-20081006-nonsquare.puz"), filepath.Join(dir, "nyt", "2008-10-06.puz"))
+	CopyTestFile(t, filepath.Join("ipuz", "example-20200102.ipuz"), filepath.Join(dir, "example.ipuz"))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.txt"), []byte("not a puzzle"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "broken.puz"), []byte("not a puzzle"), 0644))
+
+	library := NewLibrary(dir)
+	require.NoError(t, library.Refresh())
+
+	entries := library.Entries()
+	require.Len(t, entries, 2)
+
+	// The most recently published puzzle is first.
+	assert.Equal(t, LibraryID("example.ipuz"), entries[0].ID)
+	assert.Equal(t, "example.ipuz", entries[0].Filename)
+	assert.Equal(t, "ipuz", entries[0].Format)
+	assert.Equal(t, "Example Puzzle", entries[0].Title)
+	assert.Equal(t, "Jane Doe", entries[0].Author)
+	assert.Equal(t, "Example Press", entries[0].Publisher)
+	assert.Equal(t, time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC), entries[0].PublishedDate)
+	assert.Equal(t, 3, entries[0].Rows)
+	assert.Equal(t, 3, entries[0].Cols)
+
+	// The publisher and date of the .puz file come from its location.
+	assert.Equal(t, LibraryID("nyt/2008-10-06.puz"), entries[1].ID)
+	assert.Equal(t, "nyt/2008-10-06.puz", entries[1].Filename)
+	assert.Equal(t, "puz", entries[1].Format)
+	assert.Equal(t, "Patrick Blindauer / Will Shortz", entries[1].Author)
+	assert.Equal(t, "nyt", entries[1].Publisher)
+	assert.Equal(t, time.Date(2008, time.October, 6, 0, 0, 0, 0, time.UTC), entries[1].PublishedDate)
+	assert.Equal(t, 9, entries[1].Rows)
+	assert.Equal(t, 24, entries[1].Cols)
+}
+
+func TestLibrary_Refresh_AddedAndRemovedFiles(t *testing.T) {
+	dir := NewTestLibraryDir(t)
+	CopyTestFile(t, filepath.Join("ipuz", "example-20200102.ipuz"), filepath.Join(dir, "example.ipuz"))
+
+	library := NewLibrary(dir)
+	require.NoError(t, library.Refresh())
+	require.Len(t, library.Entries(), 1)
+
+	CopyTestFile(t, filepath.Join("puz", "nyt-20081006-nonsquare.puz"), filepath.Join(dir, "nyt.puz"))
+	require.NoError(t, library.Refresh())
+	require.Len(t, library.Entries(), 2)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "example.ipuz")))
+	require.NoError(t, library.Refresh())
+
+	entries := library.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "nyt.puz", entries[0].Filename)
+
+	_, ok := library.Get(LibraryID("example.ipuz"))
+	assert.False(t, ok)
+}
+
+func TestLibrary_Refresh_Error(t *testing.T) {
+	library := NewLibrary(filepath.Join(NewTestLibraryDir(t), "missing"))
+	assert.Error(t, library.Refresh())
+}
+
+func TestLibrary_Search(t *testing.T) {
+	dir := NewTestLibraryDir(t)
+	CopyTestFile(t, filepath.Join("puz", "nyt-20081006-nonsquare.puz"), filepath.Join(dir, "nyt", "2008-10-06.puz"))
+	CopyTestFile(t, filepath.Join("ipuz", "example-20200102.ipuz"), filepath.Join(dir, "example.ipuz"))
+
+	library := NewLibrary(dir)
+	require.NoError(t, library.Refresh())
+
+	tests := []struct {
+		name      string
+		query     string
+		filenames []string
+	}{
+		{
+			name:      "empty query",
+			query:     "",
+			filenames: []string{"example.ipuz", "nyt/2008-10-06.puz"},
+		},
+		{
+			name:      "title",
+			query:     "example puzzle",
+			filenames: []string{"example.ipuz"},
+		},
+		{
+			name:      "author",
+			query:     "BLINDAUER",
+			filenames: []string{"nyt/2008-10-06.puz"},
+		},
+		{
+			name:      "publisher",
+			query:     "press",
+			filenames: []string{"example.ipuz"},
+		},
+		{
+			name:      "filename",
+			query:     "2008-10",
+			filenames: []string{"nyt/2008-10-06.puz"},
+		},
+		{
+			name:  "no matches",
+			query: "sunday",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var filenames []string
+			for _, entry := range library.Search(test.query) {
+				filenames = append(filenames, entry.Filename)
+			}
+
+			assert.Equal(t, test.filenames, filenames)
+		})
+	}
+}
+
+func TestLibrary_Load(t *testing.T) {
+	dir := NewTestLibraryDir(t)
+	CopyTestFile(t, filepath.Join("puz", "nyt-20081006-nonsquare.puz"), filepath.Join(dir, "nyt", "2008-10-06.puz"))
+
+	library := NewLibrary(dir)
+	require.NoError(t, library.Refresh())
+
+	puzzle, err := library.Load(LibraryID("nyt/2008-10-06.puz"))
+	require.NoError(t, err)
+	assert.Equal(t, "Crossword loaded from library file nyt/2008-10-06.puz", puzzle.Description)
+	assert.Equal(t, "nyt", puzzle.Publisher)
+	assert.Equal(t, time.Date(2008, time.October, 6, 0, 0, 0, 0, time.UTC), puzzle.PublishedDate)
+	assert.Equal(t, 9, puzzle.Rows)
+	assert.Equal(t, 24, puzzle.Cols)
+}
+
+func TestLibrary_Load_Error(t *testing.T) {
+	dir := NewTestLibraryDir(t)
+	CopyTestFile(t, filepath.Join("puz", "nyt-20081006-nonsquare.puz"), filepath.Join(dir, "nyt.puz"))
+
+	library := NewLibrary(dir)
+	require.NoError(t, library.Refresh())
+
+	_, err := library.Load("unknown")
+	assert.Error(t, err)
+
+	// A file that's removed after being indexed can no longer be loaded.
+	require.NoError(t, os.Remove(filepath.Join(dir, "nyt.puz")))
+	_, err = library.Load(LibraryID("nyt.puz"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package crossword
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A regular expression that matches the clue at the start of a group of linked
+// answers, for example "With 23- and 40-Across, classic saying".  The first
+// group contains the references to the other clues of the group.
+var linkedHeadClue = regexp.MustCompile(`(?i)^\s*with\s+([^,:;]+)[,:;]`)
+
+// A regular expression that matches the clue of an answer that's continued
+// from another clue, for example "See 17-Across".  The first group contains the
+// reference to the clue that the answer is continued from.
+var linkedContinuationClue = regexp.MustCompile(`(?i)^\s*see\s+(\d+-\s*(?:across|down))\s*\.?\s*$`)
+
+// A regular expression that matches a single reference to a clue within the
+// text of another clue.  When several clues in the same direction are listed
+// together the direction may only appear on the last one, for example "23- and
+// 40-Across".
+var clueReference = regexp.MustCompile(`(?i)(\d+)-\s*(across|down)?`)
+
+// FindLinkedClues detects the groups of clues whose answers are linked together
+// into a single answer from the text of the clues.  A group begins with a clue
+// of the form "With 23-Across, ..." and each of the other clues of the group
+// must be of the form "See 17-Across".  Requiring both halves of the link
+// avoids mistaking an ordinary cross-reference for a linked answer.  Each group
+// is returned as a list of clue identifiers (e.g. 17a) in the order that the
+// answers are read.
+func FindLinkedClues(across, down map[int]string) [][]string {
+	// All of the clues of the puzzle indexed by their identifier, in the order
+	// that they appear in the puzzle.
+	clues := make(map[string]string)
+	var ids []string
+	for _, c := range []struct {
+		clues     map[int]string
+		direction string
+	}{
+		{across, "a"},
+		{down, "d"},
+	} {
+		var numbers []int
+		for number := range c.clues {
+			numbers = append(numbers, number)
+		}
+		sort.Ints(numbers)
+
+		for _, number := range numbers {
+			id := fmt.Sprintf("%d%s", number, c.direction)
+			clues[id] = c.clues[number]
+			ids = append(ids, id)
+		}
+	}
+
+	var groups [][]string
+	linked := make(map[string]bool)
+	for _, head := range ids {
+		if linked[head] {
+			continue
+		}
+
+		match := linkedHeadClue.FindStringSubmatch(clues[head])
+		if match == nil {
+			continue
+		}
+
+		group := []string{head}
+		for _, id := range parseClueReferences(match[1]) {
+			if linked[id] || id == head {
+				continue
+			}
+
+			continuation := linkedContinuationClue.FindStringSubmatch(clues[id])
+			if continuation == nil {
+				continue
+			}
+
+			if refs := parseClueReferences(continuation[1]); len(refs) != 1 || refs[0] != head {
+				continue
+			}
+
+			group = append(group, id)
+		}
+
+		if len(group) < 2 {
+			continue
+		}
+
+		for _, id := range group {
+			linked[id] = true
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// parseClueReferences parses the references to other clues within the text of
+// a clue into clue identifiers (e.g. 17a).  References without a direction take
+// the direction of the next reference that has one.  References that never
+// receive a direction are ignored.
+func parseClueReferences(text string) []string {
+	matches := clueReference.FindAllStringSubmatch(text, -1)
+
+	ids := make([]string, len(matches))
+	var direction string
+	for i := len(matches) - 1; i >= 0; i-- {
+		if matches[i][2] != "" {
+			direction = strings.ToLower(matches[i][2])[:1]
+		}
+		if direction == "" {
+			continue
+		}
+
+		number, err := strconv.Atoi(matches[i][1])
+		if err != nil {
+			continue
+		}
+
+		ids[i] = fmt.Sprintf("%d%s", number, direction)
+	}
+
+	var refs []string
+	for _, id := range ids {
+		if id != "" {
+			refs = append(refs, id)
+		}
+	}
+
+	return refs
+}
+
+// GetLinkedClues returns the group of linked clues that a clue belongs to.  If
+// the clue isn't linked to any other clues then nil is returned.
+func (p *Puzzle) GetLinkedClues(clue string) []string {
+	num, direction, err := ParseClue(clue)
+	if err != nil {
+		return nil
+	}
+
+	id := fmt.Sprintf("%d%s", num, direction)
+	for _, group := range p.LinkedClues {
+		for _, member := range group {
+			if member == id {
+				return group
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package crossword
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFindLinkedClues(t *testing.T) {
+	tests := []struct {
+		name     string
+		across   map[int]string
+		down     map[int]string
+		expected [][]string
+	}{
+		{
+			name:     "two linked clues",
+			across:   map[int]string{17: "With 23-Across, classic saying", 20: "Feline", 23: "See 17-Across"},
+			expected: [][]string{{"17a", "23a"}},
+		},
+		{
+			name:     "three linked clues",
+			across:   map[int]string{17: "With 23- and 40-Across, famous quote", 23: "See 17-Across", 40: "See 17-Across."},
+			expected: [][]string{{"17a", "23a", "40a"}},
+		},
+		{
+			name:     "linked clues in both directions",
+			across:   map[int]string{17: "See 3-Down"},
+			down:     map[int]string{3: "With 17-Across: 1950s hit"},
+			expected: [][]string{{"3d", "17a"}},
+		},
+		{
+			name: "ordinary cross-reference",
+			down: map[int]string{10: "Shady spot in a 52-Down", 52: "See 10-Down"},
+		},
+		{
+			name:   "head without continuation",
+			across: map[int]string{17: "With 23-Across, classic saying", 23: "Ocean"},
+		},
+		{
+			name:   "continuation of a different clue",
+			across: map[int]string{1: "See 5-Across", 17: "With 1-Across, classic saying"},
+		},
+		{
+			name:   "reference to a missing clue",
+			across: map[int]string{17: "With 23-Across, classic saying"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FindLinkedClues(test.across, test.down))
+		})
+	}
+}
+
+func TestFindLinkedClues_PublishedPuzzle(t *testing.T) {
+	// This puzzle has a "See 10-Down" clue that is only a cross-reference.
+	puzzle := LoadTestPuzzle(t, "puzzle-nyt-20080912-notes.json")
+	assert.Nil(t, FindLinkedClues(puzzle.CluesAcross, puzzle.CluesDown))
+}
+
+func TestPuzzle_GetLinkedClues(t *testing.T) {
+	puzzle := &Puzzle{LinkedClues: [][]string{{"17a", "23a"}, {"3d", "40a"}}}
+
+	assert.Equal(t, []string{"17a", "23a"}, puzzle.GetLinkedClues("17a"))
+	assert.Equal(t, []string{"17a", "23a"}, puzzle.GetLinkedClues("23A"))
+	assert.Equal(t, []string{"3d", "40a"}, puzzle.GetLinkedClues("40a"))
+	assert.Nil(t, puzzle.GetLinkedClues("17d"))
+	assert.Nil(t, puzzle.GetLinkedClues("xyz"))
+}
@@ -3,7 +3,7 @@ package crossword
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/bbeck/puzzles-with-chat/api/web"
+	"github.com/bbeck/puzzles-with-chat/api/cache"
 	"html"
 	"io"
 	"strconv"
@@ -33,20 +33,19 @@ func LoadFromNewYorkTimes(date string) (*Puzzle, error) {
 		return nil, testPuzzleLoadError
 	}
 
+	var puzzle *Puzzle
 	url := fmt.Sprintf("https://www.xwordinfo.com/JSON/Data.aspx?date=%s", date)
-	response, err := web.GetWithHeaders(url, XWordInfoHeaders)
-	if response != nil {
-		defer func() { _ = response.Body.Close() }()
-	}
+	err := cache.Fetch("crossword/new_york_times", date, url, XWordInfoHeaders, func(in io.Reader) error {
+		var err error
+		if puzzle, err = ParseXWordInfoResponse(in); err != nil {
+			return fmt.Errorf("unable to parse xwordinfo.com response for date %s: %v", date, err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	puzzle, err := ParseXWordInfoResponse(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse xwordinfo.com response for date %s: %v", date, err)
-	}
-
 	return puzzle, nil
 }
 
@@ -98,13 +97,26 @@ func ParseXWordInfoResponse(in io.Reader) (*Puzzle, error) {
 		return nil, fmt.Errorf("unable to parse date (%s) from JSON response: %v", raw.Date, err)
 	}
 
+	// Cells that accept several values list each of them separated by a slash,
+	// the first of which is used as the value of the cell.
 	var cells [][]string
+	var alternatives [][][]string
 	for row := 0; row < raw.Size.Rows; row++ {
 		cells = append(cells, make([]string, raw.Size.Cols))
 		for col := 0; col < raw.Size.Cols; col++ {
 			index := row*raw.Size.Cols + col
-			if raw.Grid[index] != "." {
-				cells[row][col] = raw.Grid[index]
+			if raw.Grid[index] == "." {
+				continue
+			}
+
+			values := strings.Split(raw.Grid[index], "/")
+			cells[row][col] = values[0]
+
+			if len(values) > 1 {
+				if alternatives == nil {
+					alternatives = NewAlternativesGrid(raw.Size.Rows, raw.Size.Cols)
+				}
+				alternatives[row][col] = values[1:]
 			}
 		}
 	}
@@ -181,6 +193,7 @@ func ParseXWordInfoResponse(in io.Reader) (*Puzzle, error) {
 	puzzle.PublishedDate = published
 	puzzle.Author = raw.Author
 	puzzle.Cells = cells
+	puzzle.CellAlternatives = alternatives
 	puzzle.CellBlocks = blocks
 	puzzle.CellClueNumbers = numbers
 	puzzle.CellCircles = circles
@@ -246,3 +259,16 @@ func LoadAvailableNYTDates() []time.Time {
 
 	return dates
 }
+
+// NewYorkTimesSource is the source of crossword puzzles from The New York
+// Times.  Puzzles are identified by their publication date.
+type NewYorkTimesSource struct{}
+
+func (NewYorkTimesSource) ID() string                  { return "new_york_times" }
+func (NewYorkTimesSource) Name() string                { return "The New York Times" }
+func (NewYorkTimesSource) Key() string                 { return "new_york_times_date" }
+func (NewYorkTimesSource) AvailableDates() []time.Time { return LoadAvailableNYTDates() }
+
+func (NewYorkTimesSource) Load(date string) (*Puzzle, error) {
+	return LoadFromNewYorkTimes(date)
+}
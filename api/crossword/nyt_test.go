@@ -520,6 +520,30 @@ func TestParseXWordInfoResponse(t *testing.T) {
 	}
 }
 
+func TestParseXWordInfoResponse_Alternatives(t *testing.T) {
+	input := `{
+	  "date": "11/5/1996",
+	  "size": {"rows": 2, "cols": 2},
+	  "grid": ["C/B", "A", "O", "RED/ROUGE"],
+	  "gridnums": [1, 2, 3, 0],
+	  "clues": {
+	    "across": ["1. Feline or cot", "3. Oh"],
+	    "down": ["1. Chess piece", "2. Hunt"]
+	  }
+	}`
+
+	puzzle, err := ParseXWordInfoResponse(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"C", "A"}, {"O", "RED"}}, puzzle.Cells)
+	assert.Equal(t, [][][]string{{{"B"}, {}}, {{}, {"ROUGE"}}}, puzzle.CellAlternatives)
+}
+
+func TestParseXWordInfoResponse_NoAlternatives(t *testing.T) {
+	puzzle, err := ParseXWordInfoResponse(load(t, "xwordinfo-nyt-20181231.json"))
+	require.NoError(t, err)
+	assert.Nil(t, puzzle.CellAlternatives)
+}
+
 func TestParseXWordInfoResponse_Error(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,103 @@
+package crossword
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/pdf"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PDF lays out the state's puzzle as a printable document.  The grid is drawn
+// at the top of the first page with its blocks, circles, shades and clue numbers
+// and the across and down clues flow into columns beneath it.  When showLetters
+// is true the letters that have been filled in are drawn in the grid, otherwise
+// the grid is blank.
+func (s *State) PDF(showLetters bool) *pdf.Document {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	title := s.Puzzle.Title
+	if title == "" {
+		title = "Crossword"
+	}
+
+	var subtitle []string
+	if s.Puzzle.Author != "" {
+		subtitle = append(subtitle, "by "+s.Puzzle.Author)
+	}
+	if s.Puzzle.Publisher != "" {
+		subtitle = append(subtitle, s.Puzzle.Publisher)
+	}
+	if !s.Puzzle.PublishedDate.IsZero() {
+		subtitle = append(subtitle, s.Puzzle.PublishedDate.Format("Monday, January 2, 2006"))
+	}
+	top := page.Header(title, strings.Join(subtitle, " · "))
+
+	// The grid spans the width of the page but is limited to roughly half of its
+	// height so that there's room for clues beneath it.
+	size := math.Min(
+		(pdf.PageWidth-2*pdf.Margin)/float64(s.Puzzle.Cols),
+		(pdf.PageHeight/2)/float64(s.Puzzle.Rows),
+	)
+	left := (pdf.PageWidth - size*float64(s.Puzzle.Cols)) / 2
+
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			cx, cy := left+float64(x)*size, top+float64(y)*size
+
+			switch {
+			case s.Puzzle.CellBlocks[y][x]:
+				page.FillRect(cx, cy, size, size, 0)
+			case s.Puzzle.CellShades != nil && s.Puzzle.CellShades[y][x]:
+				page.FillRect(cx, cy, size, size, 0.85)
+			}
+			page.StrokeRect(cx, cy, size, size, 0.5)
+
+			if s.Puzzle.CellBlocks[y][x] {
+				continue
+			}
+
+			if s.Puzzle.CellCircles != nil && s.Puzzle.CellCircles[y][x] {
+				page.Circle(cx+size/2, cy+size/2, size/2-0.5, 0.5)
+			}
+
+			if number := s.Puzzle.CellClueNumbers[y][x]; number != 0 {
+				page.Text(cx+1.5, cy+1.5+size*0.28, pdf.Regular, size*0.28, strconv.Itoa(number))
+			}
+
+			if letters := s.Cells[y][x]; showLetters && letters != "" {
+				// Rebus entries shrink so that all of their letters fit in the cell.
+				height := size * 0.6
+				if width := pdf.TextWidth(pdf.Regular, height, letters); width > size-4 {
+					height = height * (size - 4) / width
+				}
+
+				width := pdf.TextWidth(pdf.Regular, height, letters)
+				page.Text(cx+(size-width)/2, cy+size-size*0.15, pdf.Regular, height, letters)
+			}
+		}
+	}
+
+	flow := doc.NewFlow(top+size*float64(s.Puzzle.Rows)+18, 4)
+	for _, section := range []struct {
+		heading string
+		clues   map[int]string
+	}{
+		{"ACROSS", s.Puzzle.CluesAcross},
+		{"DOWN", s.Puzzle.CluesDown},
+	} {
+		numbers := make([]int, 0, len(section.clues))
+		for number := range section.clues {
+			numbers = append(numbers, number)
+		}
+		sort.Ints(numbers)
+
+		flow.Heading(section.heading)
+		for _, number := range numbers {
+			flow.Paragraph(strconv.Itoa(number), section.clues[number])
+		}
+	}
+
+	return doc
+}
@@ -0,0 +1,27 @@
+package crossword
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestState_PDF(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+
+	filled := string(state.PDF(true).Bytes())
+	assert.True(t, strings.HasPrefix(filled, "%PDF-"))
+	assert.Contains(t, filled, "(NY Times, Mon, Dec 31, 2018) Tj")
+	assert.Contains(t, filled, "(ACROSS) Tj")
+	assert.Contains(t, filled, "(Exchange after")
+	assert.Contains(t, filled, "(DOWN) Tj")
+	assert.Contains(t, filled, "(Brand of swabs) Tj")
+	assert.Contains(t, filled, "(Q) Tj")
+
+	// A blank puzzle still has its numbers but none of the filled in letters.
+	blank := string(state.PDF(false).Bytes())
+	assert.Contains(t, blank, "(1) Tj")
+	assert.NotContains(t, blank, "(Q) Tj")
+}
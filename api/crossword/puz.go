@@ -7,10 +7,12 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/cache"
 	"github.com/bbeck/puzzles-with-chat/api/web"
 	"golang.org/x/text/encoding/charmap"
 	"io"
 	"strings"
+	"time"
 )
 
 //
@@ -47,7 +49,7 @@ type PuzFile struct {
 		Width               uint8
 		Height              uint8
 		NumClues            uint16
-		UnknownBitmask      uint16
+		PuzzleType          uint16
 		ScrambledTag        uint16
 	}
 
@@ -63,6 +65,9 @@ type PuzFile struct {
 
 var MagicNumber = []byte("ACROSS&DOWN\000")
 
+// The value of the puzzle type field of the header for a diagramless puzzle.
+const PuzzleTypeDiagramless = 0x0401
+
 // LoadFromEncodedPuzFile will base64 decode the input and then attempt to load
 // the resulting binary as a .puz file into a Puzzle object.
 func LoadFromEncodedPuzFile(encoded string) (*Puzzle, error) {
@@ -110,6 +115,57 @@ func LoadFromPuzFileURL(url string) (*Puzzle, error) {
 	return LoadPuzFile(response.Body)
 }
 
+// loadFromCachedPuzFileURL is like LoadFromPuzFileURL except that the .puz file
+// is fetched through the cache where it's identified by a source and key.
+func loadFromCachedPuzFileURL(source, key, url string) (*Puzzle, error) {
+	if testPuzzle != nil {
+		return testPuzzle, nil
+	}
+
+	if testPuzzleLoadError != nil {
+		return nil, testPuzzleLoadError
+	}
+
+	var puzzle *Puzzle
+	err := cache.Fetch(source, key, url, nil, func(in io.Reader) error {
+		var err error
+		puzzle, err = LoadPuzFile(in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return puzzle, nil
+}
+
+// PuzFileURLSource is the source of crossword puzzles from .puz files that are
+// hosted at a URL.  Puzzles are identified by their URL.
+type PuzFileURLSource struct{}
+
+func (PuzFileURLSource) ID() string                  { return "puz_file_url" }
+func (PuzFileURLSource) Name() string                { return ".puz file URL" }
+func (PuzFileURLSource) Key() string                 { return "puz_file_url" }
+func (PuzFileURLSource) AvailableDates() []time.Time { return nil }
+
+func (PuzFileURLSource) Load(url string) (*Puzzle, error) {
+	return LoadFromPuzFileURL(url)
+}
+
+// PuzFileBytesSource is the source of crossword puzzles from .puz files that
+// are uploaded directly.  Puzzles are identified by the base64 encoded bytes of
+// the file.
+type PuzFileBytesSource struct{}
+
+func (PuzFileBytesSource) ID() string                  { return "puz_file_bytes" }
+func (PuzFileBytesSource) Name() string                { return ".puz file upload" }
+func (PuzFileBytesSource) Key() string                 { return "puz_file_bytes" }
+func (PuzFileBytesSource) AvailableDates() []time.Time { return nil }
+
+func (PuzFileBytesSource) Load(encoded string) (*Puzzle, error) {
+	return LoadFromEncodedPuzFile(encoded)
+}
+
 // LoadPuzFile parses a binary .puz file into a Puzzle object.
 func LoadPuzFile(in io.Reader) (*Puzzle, error) {
 	var err error
@@ -211,11 +267,11 @@ func LoadPuzFile(in io.Reader) (*Puzzle, error) {
 	}
 
 	// Diagram-less puzzles often use : characters to indicate that a square is a
-	// block that shouldn't be rendered to the user.  We currently don't support
-	// rendering these diagram-less puzzles properly so for the time being just
-	// convert it into a normal puzzle.  We wait until after verifying checksums
-	// to do this because some of the checksum calculations require the :
-	// characters in their computations.
+	// block that shouldn't be rendered to the user.  The puzzle is marked as
+	// diagramless when it's converted and the structure of the grid is hidden
+	// while it's being solved, so convert these into normal blocks.  We wait
+	// until after verifying checksums to do this because some of the checksum
+	// calculations require the : characters in their computations.
 	f.Solution = bytes.ReplaceAll(f.Solution, []byte(":"), []byte("."))
 
 	// If the puzzle is scrambled then unscramble it now.  We do this after
@@ -291,7 +347,7 @@ func (f *PuzFile) HeaderChecksum() uint16 {
 	crc = crc.Write8(f.Header.Width)
 	crc = crc.Write8(f.Header.Height)
 	crc = crc.Write16(f.Header.NumClues)
-	crc = crc.Write16(f.Header.UnknownBitmask)
+	crc = crc.Write16(f.Header.PuzzleType)
 	crc = crc.Write16(f.Header.ScrambledTag)
 	return uint16(crc)
 }
@@ -605,6 +661,7 @@ func (f *PuzFile) Convert() (*Puzzle, error) {
 	}
 
 	puzzle.Notes = strings.TrimSpace(decode(f.Notes))
+	puzzle.Diagramless = f.Header.PuzzleType == PuzzleTypeDiagramless
 
 	// Parse the entries of the rebus table if one exists.
 	var rebusTable = make(map[int]string)
@@ -637,16 +694,24 @@ func (f *PuzFile) Convert() (*Puzzle, error) {
 		}
 	}
 
-	// Determine the value for each cell and whether or not it is a block.
+	// Determine the value for each cell and whether or not it is a block.  The
+	// solution of a rebus cell contains a single letter that's also accepted,
+	// when it isn't the first letter of the rebus it's an alternative value for
+	// the cell.
 	for y := 0; y < puzzle.Rows; y++ {
 		puzzle.Cells = append(puzzle.Cells, make([]string, puzzle.Cols))
 		puzzle.CellBlocks = append(puzzle.CellBlocks, make([]bool, puzzle.Cols))
 		for x := 0; x < puzzle.Cols; x++ {
-			var cell string
+			cell := string(f.Solution[y*puzzle.Cols+x])
 			if rebusCells != nil && rebusCells[y][x] != 0 {
-				cell = rebusTable[rebusCells[y][x]-1]
-			} else {
-				cell = string(f.Solution[y*puzzle.Cols+x])
+				rebus := rebusTable[rebusCells[y][x]-1]
+				if rebus != "" && cell != "." && cell != rebus[:1] {
+					if puzzle.CellAlternatives == nil {
+						puzzle.CellAlternatives = NewAlternativesGrid(puzzle.Rows, puzzle.Cols)
+					}
+					puzzle.CellAlternatives[y][x] = []string{cell}
+				}
+				cell = rebus
 			}
 
 			if cell != "." {
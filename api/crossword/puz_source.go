@@ -0,0 +1,311 @@
+package crossword
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PuzSourceConfig describes a publisher that regularly publishes crossword
+// puzzles as .puz files at a URL that can be determined from the date of the
+// puzzle.  Configs are read from a JSON file so that new publishers can be
+// added without changing any code.
+type PuzSourceConfig struct {
+	// The unique identifier of the source.
+	ID string `json:"id"`
+
+	// The name of the field in a puzzle selection payload whose value is the
+	// date of the puzzle to load.
+	Key string `json:"key"`
+
+	// The name of the publisher of the puzzles.
+	Publisher string `json:"publisher"`
+
+	// The template of the URL of a puzzle's .puz file.  The placeholders {yyyy},
+	// {yy}, {mm} and {dd} are replaced with the parts of the puzzle's date.
+	URL string `json:"url"`
+
+	// The periods of time that the publisher published puzzles along with the
+	// days of the week that they were published on.
+	Schedules []PuzScheduleConfig `json:"schedules"`
+
+	// The names of the holidays that the publisher doesn't publish a puzzle on
+	// even when they fall on a scheduled day, e.g. "thanksgiving".  See Holidays
+	// for the supported names.
+	Holidays []string `json:"holidays,omitempty"`
+}
+
+// PuzScheduleConfig describes a period of time during which a publisher
+// published a puzzle on the same days of every week.
+type PuzScheduleConfig struct {
+	// The days of the week that puzzles were published on, e.g. "Monday".
+	Weekdays []string `json:"weekdays"`
+
+	// The date of the first puzzle of the period, formatted as YYYY-MM-DD.
+	Start string `json:"start"`
+
+	// The date of the last puzzle of the period, formatted as YYYY-MM-DD.  If
+	// omitted then the publisher is still publishing puzzles on this schedule.
+	End string `json:"end,omitempty"`
+}
+
+// PuzSource is a source of crossword puzzles that's created from a
+// PuzSourceConfig.  Puzzles are identified by their publication date.
+type PuzSource struct {
+	id        string
+	key       string
+	publisher string
+	url       string
+	schedules []puzSchedule
+	holidays  []Holiday
+}
+
+// puzSchedule is the parsed version of a PuzScheduleConfig.
+type puzSchedule struct {
+	weekdays map[time.Weekday]bool
+	start    time.Time
+	end      *time.Time
+}
+
+// The days of the week indexed by their names.
+var weekdays = map[string]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// A Holiday computes the date that a holiday is observed on in a year.
+type Holiday func(year int) time.Time
+
+// Holidays contains the holidays that a publisher can take off from its
+// schedule, indexed by the name used for them in a config.
+var Holidays = map[string]Holiday{
+	"new_years_day":    fixedHoliday(time.January, 1),
+	"mlk_day":          nthWeekdayHoliday(time.January, time.Monday, 3),
+	"presidents_day":   nthWeekdayHoliday(time.February, time.Monday, 3),
+	"memorial_day":     nthWeekdayHoliday(time.May, time.Monday, -1),
+	"independence_day": fixedHoliday(time.July, 4),
+	"labor_day":        nthWeekdayHoliday(time.September, time.Monday, 1),
+	"thanksgiving":     nthWeekdayHoliday(time.November, time.Thursday, 4),
+	"christmas":        fixedHoliday(time.December, 25),
+}
+
+// fixedHoliday returns a holiday that's on the same day of every year.  When
+// the day is a Sunday the holiday is observed on the following Monday.
+func fixedHoliday(month time.Month, day int) Holiday {
+	return func(year int) time.Time {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if date.Weekday() == time.Sunday {
+			date = date.AddDate(0, 0, 1)
+		}
+		return date
+	}
+}
+
+// nthWeekdayHoliday returns a holiday that's on the nth occurrence of a weekday
+// in a month, for example the 4th Thursday of November.  A negative n counts
+// from the end of the month instead.
+func nthWeekdayHoliday(month time.Month, weekday time.Weekday, n int) Holiday {
+	return func(year int) time.Time {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+			return last.AddDate(0, 0, -offset+7*(n+1))
+		}
+
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, offset+7*(n-1))
+	}
+}
+
+// NewPuzSource validates a config and creates the source that it describes.
+func NewPuzSource(config PuzSourceConfig) (*PuzSource, error) {
+	if config.ID == "" || config.Key == "" || config.URL == "" {
+		return nil, fmt.Errorf(".puz source %q must have an id, key and url", config.ID)
+	}
+
+	source := &PuzSource{
+		id:        config.ID,
+		key:       config.Key,
+		publisher: config.Publisher,
+		url:       config.URL,
+	}
+
+	for _, sc := range config.Schedules {
+		var schedule puzSchedule
+
+		schedule.weekdays = make(map[time.Weekday]bool)
+		for _, name := range sc.Weekdays {
+			weekday, ok := weekdays[name]
+			if !ok {
+				return nil, fmt.Errorf("invalid weekday %s for .puz source %s", name, config.ID)
+			}
+			schedule.weekdays[weekday] = true
+		}
+
+		start, err := time.Parse("2006-01-02", sc.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date %s for .puz source %s: %v", sc.Start, config.ID, err)
+		}
+		schedule.start = start
+
+		if sc.End != "" {
+			end, err := time.Parse("2006-01-02", sc.End)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end date %s for .puz source %s: %v", sc.End, config.ID, err)
+			}
+			schedule.end = &end
+		}
+
+		source.schedules = append(source.schedules, schedule)
+	}
+
+	for _, name := range config.Holidays {
+		holiday, ok := Holidays[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid holiday %s for .puz source %s", name, config.ID)
+		}
+		source.holidays = append(source.holidays, holiday)
+	}
+
+	return source, nil
+}
+
+// ParsePuzSources reads a JSON list of source configs and creates the sources
+// that they describe.
+func ParsePuzSources(in io.Reader) ([]*PuzSource, error) {
+	var configs []PuzSourceConfig
+	if err := json.NewDecoder(in).Decode(&configs); err != nil {
+		return nil, fmt.Errorf("unable to parse .puz source configs: %v", err)
+	}
+
+	var sources []*PuzSource
+	for _, config := range configs {
+		source, err := NewPuzSource(config)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// LoadPuzSources reads the source configs from a JSON file and creates the
+// sources that they describe.
+func LoadPuzSources(filename string) ([]*PuzSource, error) {
+	in, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open .puz source configs %s: %v", filename, err)
+	}
+	defer func() { _ = in.Close() }()
+
+	return ParsePuzSources(in)
+}
+
+func (s *PuzSource) ID() string   { return s.id }
+func (s *PuzSource) Name() string { return s.publisher }
+func (s *PuzSource) Key() string  { return s.key }
+
+// AvailableDates computes the dates that puzzles were published on from the
+// source's schedules.  Holidays and dates in the future are never included.
+func (s *PuzSource) AvailableDates() []time.Time {
+	now := time.Now().UTC()
+
+	dates := make([]time.Time, 0)
+	for _, schedule := range s.schedules {
+		end := now
+		if schedule.end != nil && schedule.end.Before(now) {
+			end = *schedule.end
+		}
+
+		for date := schedule.start; !date.After(end); date = date.AddDate(0, 0, 1) {
+			if schedule.weekdays[date.Weekday()] && !s.isHoliday(date) {
+				dates = append(dates, date)
+			}
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// IsScheduled determines if the source published a puzzle on a date.
+func (s *PuzSource) IsScheduled(date time.Time) bool {
+	if s.isHoliday(date) {
+		return false
+	}
+
+	for _, schedule := range s.schedules {
+		if date.Before(schedule.start) || (schedule.end != nil && date.After(*schedule.end)) {
+			continue
+		}
+
+		if schedule.weekdays[date.Weekday()] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isHoliday determines if a date is one of the holidays that the source doesn't
+// publish a puzzle on.
+func (s *PuzSource) isHoliday(date time.Time) bool {
+	for _, holiday := range s.holidays {
+		if holiday(date.Year()).Equal(date) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Load downloads the .puz file of the puzzle published on a date and loads it
+// into a Puzzle object.
+//
+// If the puzzle cannot be loaded or parsed then an error is returned.
+func (s *PuzSource) Load(date string) (*Puzzle, error) {
+	published, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse date %s: %+v", date, err)
+	}
+
+	if !s.IsScheduled(published) {
+		return nil, fmt.Errorf("no puzzle from %s is scheduled for %s", s.id, date)
+	}
+
+	puzzle, err := loadFromCachedPuzFileURL(fmt.Sprintf("crossword/%s", s.id), date, s.URLForDate(published))
+	if err != nil {
+		return nil, err
+	}
+
+	// Normally .puz files don't have puzzle dates recorded in them, but we
+	// happen to know the date for this puzzle, so fill it in.
+	puzzle.Description = fmt.Sprintf("%s puzzle from %s", s.publisher, date)
+	puzzle.PublishedDate = published
+	puzzle.Publisher = s.publisher
+
+	return puzzle, nil
+}
+
+// URLForDate returns the URL of the .puz file of the puzzle published on a
+// date.
+func (s *PuzSource) URLForDate(date time.Time) string {
+	return strings.NewReplacer(
+		"{yyyy}", fmt.Sprintf("%04d", date.Year()),
+		"{yy}", fmt.Sprintf("%02d", date.Year()%100),
+		"{mm}", fmt.Sprintf("%02d", date.Month()),
+		"{dd}", fmt.Sprintf("%02d", date.Day()),
+	).Replace(s.url)
+}
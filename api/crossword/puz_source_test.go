@@ -0,0 +1,227 @@
+package crossword
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePuzSources(t *testing.T) {
+	in := load(t, "puz-sources.json")
+	defer func() { _ = in.Close() }()
+
+	sources, err := ParsePuzSources(in)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+
+	source := sources[0]
+	assert.Equal(t, "wall_street_journal", source.ID())
+	assert.Equal(t, "wall_street_journal_date", source.Key())
+	assert.Equal(t, "The Wall Street Journal", source.Name())
+}
+
+func TestParsePuzSources_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{
+			name:   "invalid json",
+			config: `{`,
+		},
+		{
+			name:   "missing url",
+			config: `[{"id": "a", "key": "a_date"}]`,
+		},
+		{
+			name:   "invalid weekday",
+			config: `[{"id": "a", "key": "a_date", "url": "u", "schedules": [{"weekdays": ["Funday"], "start": "2020-01-01"}]}]`,
+		},
+		{
+			name:   "invalid start",
+			config: `[{"id": "a", "key": "a_date", "url": "u", "schedules": [{"weekdays": ["Monday"], "start": "yesterday"}]}]`,
+		},
+		{
+			name:   "invalid end",
+			config: `[{"id": "a", "key": "a_date", "url": "u", "schedules": [{"weekdays": ["Monday"], "start": "2020-01-01", "end": "tomorrow"}]}]`,
+		},
+		{
+			name:   "invalid holiday",
+			config: `[{"id": "a", "key": "a_date", "url": "u", "holidays": ["2019-12-25"]}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePuzSources(strings.NewReader(test.config))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPuzSource_AvailableDates(t *testing.T) {
+	source := LoadTestPuzSource(t, "puz-sources.json", "wall_street_journal")
+
+	tests := []struct {
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			after:    time.Date(2013, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2013, time.January, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			after:    time.Date(2014, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2014, time.January, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			after:    time.Date(2015, time.September, 12, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2015, time.September, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			after:    time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2016, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			after:    time.Date(2016, time.January, 3, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2016, time.January, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			after:    time.Date(2020, time.January, 5, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2020, time.January, 6, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	dates := source.AvailableDates()
+	require.True(t, sort.SliceIsSorted(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	}))
+	assert.False(t, dates[len(dates)-1].After(time.Now()))
+
+	for _, test := range tests {
+		t.Run(test.after.Format("2006-01-02"), func(t *testing.T) {
+			index := sort.Search(len(dates), func(i int) bool {
+				return !dates[i].Before(test.after)
+			})
+			require.True(t, index < len(dates))
+			assert.Equal(t, test.expected, dates[index])
+		})
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	tests := []struct {
+		holiday  string
+		year     int
+		expected string
+	}{
+		{holiday: "new_years_day", year: 2020, expected: "2020-01-01"},
+		{holiday: "new_years_day", year: 2017, expected: "2017-01-02"}, // sunday
+		{holiday: "mlk_day", year: 2021, expected: "2021-01-18"},
+		{holiday: "presidents_day", year: 2016, expected: "2016-02-15"},
+		{holiday: "memorial_day", year: 2021, expected: "2021-05-31"},
+		{holiday: "memorial_day", year: 2020, expected: "2020-05-25"},
+		{holiday: "independence_day", year: 2020, expected: "2020-07-04"}, // saturday
+		{holiday: "independence_day", year: 2021, expected: "2021-07-05"}, // sunday
+		{holiday: "labor_day", year: 2020, expected: "2020-09-07"},
+		{holiday: "thanksgiving", year: 2019, expected: "2019-11-28"},
+		{holiday: "thanksgiving", year: 2026, expected: "2026-11-26"},
+		{holiday: "christmas", year: 2016, expected: "2016-12-26"}, // sunday
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			holiday, ok := Holidays[test.holiday]
+			require.True(t, ok)
+			assert.Equal(t, test.expected, holiday(test.year).Format("2006-01-02"))
+		})
+	}
+}
+
+func TestPuzSource_IsScheduled(t *testing.T) {
+	source := LoadTestPuzSource(t, "puz-sources.json", "wall_street_journal")
+
+	tests := []struct {
+		date     string
+		expected bool
+	}{
+		{date: "2012-12-28", expected: false}, // before the first schedule
+		{date: "2013-01-04", expected: true},  // friday
+		{date: "2013-01-05", expected: false}, // saturday
+		{date: "2015-09-19", expected: true},  // saturday
+		{date: "2015-09-25", expected: false}, // friday
+		{date: "2020-01-06", expected: true},  // monday
+		{date: "2020-01-05", expected: false}, // sunday
+		{date: "2019-12-25", expected: false}, // christmas
+		{date: "2019-12-24", expected: true},  // tuesday before christmas
+		{date: "2016-12-26", expected: false}, // christmas observed on monday
+		{date: "2030-11-28", expected: false}, // thanksgiving
+	}
+
+	for _, test := range tests {
+		t.Run(test.date, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", test.date)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, source.IsScheduled(date))
+		})
+	}
+}
+
+func TestPuzSource_URLForDate(t *testing.T) {
+	source, err := NewPuzSource(PuzSourceConfig{
+		ID:  "test",
+		Key: "test_date",
+		URL: "https://example.com/{yyyy}/{mm}/puzzle{yy}{mm}{dd}.puz",
+	})
+	require.NoError(t, err)
+
+	date := time.Date(2019, time.January, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "https://example.com/2019/01/puzzle190102.puz", source.URLForDate(date))
+}
+
+func TestPuzSource_Load(t *testing.T) {
+	source := LoadTestPuzSource(t, "puz-sources.json", "wall_street_journal")
+	ForcePuzzleToBeLoaded(t, "puzzle-wsj-20190102.json")
+
+	puzzle, err := source.Load("2019-01-02")
+	require.NoError(t, err)
+	assert.Equal(t, "The Wall Street Journal", puzzle.Publisher)
+	assert.Equal(t, time.Date(2019, time.January, 2, 0, 0, 0, 0, time.UTC), puzzle.PublishedDate)
+	assert.Equal(t, "The Wall Street Journal puzzle from 2019-01-02", puzzle.Description)
+}
+
+func TestPuzSource_Load_Error(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      string
+		loadError error
+	}{
+		{
+			name: "invalid date",
+			date: "yesterday",
+		},
+		{
+			name: "unscheduled date",
+			date: "2019-01-06",
+		},
+		{
+			name:      "error loading puzzle",
+			date:      "2019-01-02",
+			loadError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := LoadTestPuzSource(t, "puz-sources.json", "wall_street_journal")
+			ForceErrorDuringPuzzleLoad(t, test.loadError)
+
+			_, err := source.Load(test.date)
+			assert.Error(t, err)
+		})
+	}
+}
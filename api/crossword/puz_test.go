@@ -135,3 +135,23 @@ func loadJson(t *testing.T, filename string) *Puzzle {
 
 	return &puzzle
 }
+
+func TestPuzFile_Convert_RebusAlternatives(t *testing.T) {
+	var f PuzFile
+	f.Header.Width = 2
+	f.Header.Height = 2
+	f.Solution = []byte("ABCD")
+	f.Clues = [][]byte{[]byte("1a"), []byte("1d"), []byte("2d"), []byte("3a")}
+	f.Extensions = map[string]*PuzFileExtension{
+		"GRBS": {Data: []byte{1, 2, 0, 0}},
+		"RTBL": {Data: []byte(" 0:HEART; 1:BAD;")},
+	}
+
+	puzzle, err := f.Convert()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"HEART", "BAD"}, {"C", "D"}}, puzzle.Cells)
+
+	// The letter of the first rebus isn't its first letter so it's accepted as
+	// an alternative, while the letter of the second rebus is already accepted.
+	assert.Equal(t, [][][]string{{{"A"}, {}}, {{}, {}}}, puzzle.CellAlternatives)
+}
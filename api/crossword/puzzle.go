@@ -2,6 +2,7 @@ package crossword
 
 import (
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/model"
 	"time"
 )
 
@@ -41,6 +42,14 @@ type Puzzle struct {
 	// of the cell.
 	Cells [][]string `json:"cells,omitempty"`
 
+	// The values other than the one in Cells that are also accepted as correct
+	// for each of the cells of the crossword as a 3D list.  Some puzzles accept
+	// either of two letters in a cell, or several spellings of a rebus.  Cells
+	// without any alternatives contain an empty list.  Like cells the list is
+	// first indexed by the row coordinate of the cell and then by the column
+	// coordinate.  Puzzles that don't have any alternatives omit the list.
+	CellAlternatives [][][]string `json:"cell_alternatives,omitempty"`
+
 	// The block attribute for each of the cells in the crossword as a 2D list.
 	// Cells that cannot be inputted into will contain an entry of true, all other
 	// cells will contain an entry of false.  Like cells the 2D list is first
@@ -74,11 +83,33 @@ type Puzzle struct {
 	// The clues for the down answers indexed by the clue number.
 	CluesDown map[int]string `json:"clues_down"`
 
+	// The groups of clues whose answers are linked together into a single
+	// answer, for example "17-Across and 23-Across".  Each group lists the clue
+	// identifiers (e.g. 17a) in the order that the answers are read.
+	LinkedClues [][]string `json:"linked_clues,omitempty"`
+
 	// The notes for the clues of this crossword.  Often there is something
 	// visually done when the crossword is published in a newspaper but that can't
 	// be done online.  These notes describe the visual change so that the
 	// crossword can be solved online.
 	Notes string `json:"notes"`
+
+	// Whether or not the crossword is diagramless.  The blocks and clue numbers
+	// of a diagramless crossword are hidden from the solvers and are discovered
+	// as answers are placed and filled in.
+	Diagramless bool `json:"diagramless,omitempty"`
+}
+
+// Fingerprint computes a hash of the grid and clues of the puzzle.  Puzzles
+// with the same fingerprint are the same crossword even if they were loaded
+// from different sources.
+func (p *Puzzle) Fingerprint() string {
+	return model.Fingerprint(struct {
+		Cells       [][]string
+		CellBlocks  [][]bool
+		CluesAcross map[int]string
+		CluesDown   map[int]string
+	}{p.Cells, p.CellBlocks, p.CluesAcross, p.CluesDown})
 }
 
 // WithoutSolution returns a copy of the puzzle that has the solution cells
@@ -94,17 +125,60 @@ func (p *Puzzle) WithoutSolution() *Puzzle {
 	puzzle.PublishedDate = p.PublishedDate
 	puzzle.Author = p.Author
 	puzzle.Cells = nil
+	puzzle.CellAlternatives = nil
 	puzzle.CellBlocks = p.CellBlocks
 	puzzle.CellClueNumbers = p.CellClueNumbers
 	puzzle.CellCircles = p.CellCircles
 	puzzle.CellShades = p.CellShades
 	puzzle.CluesAcross = p.CluesAcross
 	puzzle.CluesDown = p.CluesDown
+	puzzle.LinkedClues = p.LinkedClues
 	puzzle.Notes = p.Notes
+	puzzle.Diagramless = p.Diagramless
 
 	return &puzzle
 }
 
+// IsCorrect determines if a value is accepted as the correct value of a cell.
+// Besides the value of the cell in Cells any of its alternatives is accepted,
+// as is the first letter of a rebus.
+func (p *Puzzle) IsCorrect(x, y int, value string) bool {
+	if value == p.Cells[y][x] {
+		return true
+	}
+	if value == "" {
+		return false
+	}
+
+	accepted := []string{p.Cells[y][x]}
+	if y < len(p.CellAlternatives) && x < len(p.CellAlternatives[y]) {
+		accepted = append(accepted, p.CellAlternatives[y][x]...)
+	}
+
+	for _, answer := range accepted {
+		if value == answer || (len(answer) > 1 && value == answer[:1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewAlternativesGrid creates a 3D list of alternative cell values with the
+// provided dimensions where every cell initially has no alternatives.  The list
+// is first indexed by the row coordinate and then by the column coordinate.
+func NewAlternativesGrid(rows, cols int) [][][]string {
+	grid := make([][][]string, rows)
+	for row := 0; row < rows; row++ {
+		grid[row] = make([][]string, cols)
+		for col := 0; col < cols; col++ {
+			grid[row][col] = make([]string, 0)
+		}
+	}
+
+	return grid
+}
+
 // GetAnswerCoordinates returns the min/max x/y coordinates for a clue.  If the
 // clue doesn't exist then an error is returned.
 func (p *Puzzle) GetAnswerCoordinates(num int, direction string) (int, int, int, int, error) {
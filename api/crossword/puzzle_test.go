@@ -371,12 +371,67 @@ func TestPuzzle_WithoutSolution(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			puzzle := &Puzzle{Cells: test.cells}
+			puzzle := &Puzzle{Cells: test.cells, CellAlternatives: NewAlternativesGrid(len(test.cells), 3)}
 			assert.Nil(t, puzzle.WithoutSolution().Cells)
+			assert.Nil(t, puzzle.WithoutSolution().CellAlternatives)
 		})
 	}
 }
 
+func TestPuzzle_Fingerprint(t *testing.T) {
+	puzzle := LoadTestPuzzle(t, "xwordinfo-nyt-20181231.json")
+	fingerprint := puzzle.Fingerprint()
+	assert.NotEmpty(t, fingerprint)
+
+	// Where the puzzle came from doesn't change its fingerprint.
+	puzzle.Publisher = ""
+	puzzle.PublishedDate = time.Time{}
+	assert.Equal(t, fingerprint, puzzle.Fingerprint())
+
+	// Its clues do.
+	puzzle.CluesAcross[1] = "A different clue"
+	assert.NotEqual(t, fingerprint, puzzle.Fingerprint())
+}
+
+func TestPuzzle_IsCorrect(t *testing.T) {
+	puzzle := &Puzzle{
+		Cells:            [][]string{{"C", "RED", "T", ""}},
+		CellAlternatives: [][][]string{{{"B"}, {"ROUGE"}, {}, {}}},
+	}
+
+	tests := []struct {
+		name     string
+		x        int
+		value    string
+		expected bool
+	}{
+		{name: "value", x: 0, value: "C", expected: true},
+		{name: "alternative", x: 0, value: "B", expected: true},
+		{name: "incorrect", x: 0, value: "D", expected: false},
+		{name: "empty", x: 0, value: "", expected: false},
+		{name: "rebus", x: 1, value: "RED", expected: true},
+		{name: "rebus first letter", x: 1, value: "R", expected: true},
+		{name: "rebus alternative", x: 1, value: "ROUGE", expected: true},
+		{name: "rebus partial", x: 1, value: "RE", expected: false},
+		{name: "no alternatives", x: 2, value: "B", expected: false},
+		{name: "block", x: 3, value: "", expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, puzzle.IsCorrect(test.x, 0, test.value))
+		})
+	}
+}
+
+func TestPuzzle_IsCorrect_WithoutAlternatives(t *testing.T) {
+	puzzle := &Puzzle{Cells: [][]string{{"C", "RED"}}}
+
+	assert.True(t, puzzle.IsCorrect(0, 0, "C"))
+	assert.False(t, puzzle.IsCorrect(0, 0, "B"))
+	assert.True(t, puzzle.IsCorrect(1, 0, "R"))
+}
+
 func TestPuzzle_GetAnswerCoordinates(t *testing.T) {
 	tests := []struct {
 		name                       string
@@ -0,0 +1,134 @@
+package crossword
+
+import (
+	"errors"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestStartSolve(t *testing.T) {
+	_, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, "channel")
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSelected
+	require.NoError(t, SetState(conn, "channel", state))
+
+	settings := Settings{TimeLimit: model.Duration{Duration: 30 * time.Minute}}
+	require.NoError(t, SetSettings(conn, "channel", settings))
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, StartSolve(conn, registry, "channel", now))
+
+	state, err := GetState(conn, "channel")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusSolving, state.Status)
+	require.NotNil(t, state.LastStartTime)
+	assert.Equal(t, now, *state.LastStartTime)
+	assert.Equal(t, 30*time.Minute, state.TimeLimit.Duration)
+
+	require.Len(t, events, 1)
+	event := <-events
+	assert.Equal(t, "state", event.Kind)
+	assert.Equal(t, model.StatusSolving, event.Payload.(State).Status)
+}
+
+func TestStartSolve_Error(t *testing.T) {
+	tests := []struct {
+		name              string
+		status            model.Status
+		settingsLoadError error
+		stateLoadError    error
+		stateSaveError    error
+	}{
+		{
+			name:              "error loading settings",
+			status:            model.StatusSelected,
+			settingsLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error loading state",
+			status:         model.StatusSelected,
+			stateLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			status:         model.StatusSelected,
+			stateSaveError: errors.New("forced error"),
+		},
+		{
+			name:   "already solving",
+			status: model.StatusSolving,
+		},
+		{
+			name:   "complete",
+			status: model.StatusComplete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.status
+			require.NoError(t, SetState(conn, "channel", state))
+
+			if test.settingsLoadError != nil {
+				ForceErrorDuringSettingsLoad(t, test.settingsLoadError)
+			}
+			if test.stateLoadError != nil {
+				ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+			if test.stateSaveError != nil {
+				ForceErrorDuringStateSave(t, test.stateSaveError)
+			}
+
+			err := StartSolve(conn, registry, "channel", time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGetProgress(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// A channel without a puzzle only has a status.
+	progress, err := GetProgress(conn, "channel", now)
+	require.NoError(t, err)
+	assert.Equal(t, model.Progress{}, progress)
+
+	start := now.Add(-5 * time.Minute)
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &start
+	state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+	require.NoError(t, SetState(conn, "channel", state))
+
+	progress, err = GetProgress(conn, "channel", now)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusSolving, progress.Status)
+	assert.Equal(t, "The New York Times", progress.Puzzle.Publisher)
+	assert.Equal(t, time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC), progress.Puzzle.PublishedDate)
+	assert.Equal(t, state.PercentComplete(), progress.PercentComplete)
+	assert.Equal(t, 15*time.Minute, progress.ElapsedTime.Duration)
+}
+
+func TestGetProgress_Error(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	ForceErrorDuringStateLoad(t, errors.New("forced error"))
+
+	_, err := GetProgress(conn, "channel", time.Now())
+	assert.Error(t, err)
+}
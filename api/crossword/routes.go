@@ -3,30 +3,51 @@ package crossword
 import (
 	"compress/flate"
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/answerlog"
+	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pdf"
 	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/snapshot"
+	"github.com/bbeck/puzzles-with-chat/api/team"
+	"github.com/bbeck/puzzles-with-chat/api/web"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
 	"github.com/gomodule/redigo/redis"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 func RegisterRoutes(r chi.Router, pool *redis.Pool, registry *pubsub.Registry) {
 	r.Route("/crossword/{channel}", func(r chi.Router) {
 		r.Put("/", UpdatePuzzle(pool, registry))
+		r.Put("/upload", UploadPuzzle(pool, registry))
 		r.Put("/setting/{setting}", UpdateSetting(pool, registry))
 		r.Put("/status", ToggleStatus(pool, registry))
+		r.Put("/reset", ResetState(pool, registry))
+		r.Put("/giveup", GiveUp(pool, registry))
 		r.Put("/answer/{clue}", UpdateAnswer(pool, registry))
+		r.Put("/check/{clue}", CheckAnswer(pool, registry))
+		r.Put("/place/{clue}/{row}/{col}", PlaceClue(pool, registry))
+		r.Put("/reveal/{clue}", RevealAnswer(pool, registry))
+		r.Put("/reveal/{clue}/{square}", RevealAnswer(pool, registry))
 		r.Get("/show/{clue}", ShowClue(registry))
 		r.Get("/events", GetEvents(pool, registry))
+		r.Get("/state", GetCurrentState(pool))
+		r.Get("/settings", GetCurrentSettings(pool))
+		r.Get("/solution", GetSolution(pool))
+		r.Get("/share", GetShareCard(pool))
+		r.Get("/snapshot", GetSnapshot(pool))
+		r.Get("/pdf", GetPDF(pool))
 	})
 
 	// When possible compress the dates response since it's so large.
 	compressor := middleware.NewCompressor(flate.BestCompression, "application/json")
 	r.With(compressor.Handler()).Get("/crossword/dates", GetAvailableDates())
+	r.Get("/crossword/library", GetLibrary())
 }
 
 // UpdatePuzzle changes the crossword puzzle that's currently being solved for a
@@ -44,93 +65,134 @@ func UpdatePuzzle(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 			return
 		}
 
+		// Load the puzzle from the first registered source whose key is present in
+		// the payload.
 		var puzzle *Puzzle
+		for _, source := range Sources() {
+			key := payload[source.Key()]
+			if key == "" {
+				continue
+			}
 
-		// New York Times date
-		if date := payload["new_york_times_date"]; date != "" {
-			p, err := LoadFromNewYorkTimes(date)
+			p, err := source.Load(key)
 			if err != nil {
-				log.Printf("unable to load NYT puzzle for date %s: %+v", date, err)
+				log.Printf("unable to load puzzle from %s for key %s: %+v", source.Name(), key, err)
 				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
 
 			puzzle = p
+			break
 		}
 
-		// Wall Street Journal date
-		if date := payload["wall_street_journal_date"]; date != "" {
-			p, err := LoadFromWallStreetJournal(date)
-			if err != nil {
-				log.Printf("unable to load WSJ puzzle for date %s: %+v", date, err)
-				w.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-
-			puzzle = p
+		if puzzle == nil {
+			log.Printf("unable to determine puzzle from payload: %+v", payload)
+			w.WriteHeader(http.StatusBadRequest)
+			return
 		}
 
-		if url := payload["puz_file_url"]; url != "" {
-			p, err := LoadFromPuzFileURL(url)
-			if err != nil {
-				log.Printf("unable to load puzzle from url %s: %+v", url, err)
-				w.WriteHeader(http.StatusInternalServerError)
-				return
-			}
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
 
-			puzzle = p
+		if err := SelectPuzzle(conn, registry, channel, puzzle); err != nil {
+			log.Printf("unable to save state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
 
-		// .puz file upload
-		if encoded := payload["puz_file_bytes"]; encoded != "" {
-			p, err := LoadFromEncodedPuzFile(encoded)
-			if err != nil {
-				log.Printf("unable to load puzzle from bytes: %+v", err)
-				w.WriteHeader(http.StatusInternalServerError)
-				return
-			}
+		w.WriteHeader(http.StatusOK)
+	}
+}
 
-			puzzle = p
-		}
+// UploadPuzzle changes the crossword puzzle that's currently being solved for
+// a channel to one provided in the request body in the same JSON format as a
+// Puzzle.  The puzzle is validated before it's selected, and the validation
+// report is returned to the uploader.  If the puzzle has errors then it's
+// rejected with a 422 status.
+func UploadPuzzle(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
 
-		if puzzle == nil {
-			log.Printf("unable to determine puzzle from payload: %+v", payload)
-			w.WriteHeader(http.StatusBadRequest)
+		var puzzle Puzzle
+		if err := render.DecodeJSON(r.Body, &puzzle); err != nil {
+			log.Printf("unable to read request body: %+v", err)
+			report := model.NewValidationReport()
+			report.Errorf("unable to parse puzzle: %v", err)
+			report.Render(w, r, http.StatusBadRequest)
 			return
 		}
 
-		conn := pool.Get()
-		defer func() { _ = conn.Close() }()
+		puzzle.Normalize()
 
-		// Save the puzzle to this channel's state
-		cells := make([][]string, puzzle.Rows)
-		for row := 0; row < puzzle.Rows; row++ {
-			cells[row] = make([]string, puzzle.Cols)
+		report := puzzle.Validate()
+		if !report.Valid() {
+			log.Printf("rejected uploaded puzzle for channel %s: %v", channel, report.Errors)
+			report.Render(w, r, http.StatusUnprocessableEntity)
+			return
 		}
 
-		state := State{
-			Status:            model.StatusSelected,
-			Puzzle:            puzzle,
-			Cells:             cells,
-			AcrossCluesFilled: make(map[int]bool),
-			DownCluesFilled:   make(map[int]bool),
+		if puzzle.Description == "" {
+			puzzle.Description = "Crossword uploaded by the streamer"
 		}
-		if err := SetState(conn, channel, state); err != nil {
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		if err := SelectPuzzle(conn, registry, channel, &puzzle); err != nil {
 			log.Printf("unable to save state for channel %s: %+v", channel, err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		// Broadcast to all of the clients that the puzzle has been selected, making
-		// sure to not include the answers.  It's okay to overwrite the puzzle
-		// attribute because we just wrote this state instance to the database
-		// and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutSolution()
+		report.Render(w, r, http.StatusOK)
+	}
+}
 
-		registry.Publish(ChannelID(channel), StateEvent(state))
+// SelectPuzzle starts a new solve of a puzzle for a channel, replacing any
+// solve that was already in progress.  Clients watching the channel are sent
+// the new state without the puzzle's answers.
+func SelectPuzzle(conn redis.Conn, registry *pubsub.Registry, channel string, puzzle *Puzzle) error {
+	// Sources that don't supply the linked clues of a puzzle have them detected
+	// from the text of the clues.
+	if puzzle.LinkedClues == nil {
+		puzzle.LinkedClues = FindLinkedClues(puzzle.CluesAcross, puzzle.CluesDown)
+	}
 
-		w.WriteHeader(http.StatusOK)
+	cells := make([][]string, puzzle.Rows)
+	for row := 0; row < puzzle.Rows; row++ {
+		cells[row] = make([]string, puzzle.Cols)
+	}
+
+	state := State{
+		Status:            model.StatusSelected,
+		Puzzle:            puzzle,
+		Cells:             cells,
+		CellsIncorrect:    NewBoolGrid(puzzle.Rows, puzzle.Cols),
+		CellsRevealed:     NewBoolGrid(puzzle.Rows, puzzle.Cols),
+		AcrossCluesFilled: make(map[int]bool),
+		DownCluesFilled:   make(map[int]bool),
+	}
+	if puzzle.Diagramless {
+		state.CellsDiscovered = NewBoolGrid(puzzle.Rows, puzzle.Cols)
+		state.CluesPlaced = make(map[string]bool)
+	}
+	if err := SetState(conn, channel, state); err != nil {
+		return err
 	}
+
+	// The answers submitted during the previous solve don't apply to this one.
+	if err := answerlog.Clear(conn, "crossword", channel); err != nil {
+		return err
+	}
+
+	// Broadcast to all of the clients that the puzzle has been selected, making
+	// sure to not include the answers.  It's okay to overwrite the puzzle
+	// attribute because we just wrote this state instance to the database
+	// and will be discarding it immediately publishing.
+	state.Puzzle = state.PublicPuzzle()
+
+	registry.Publish(ChannelID(channel), StateEvent(state))
+	return nil
 }
 
 // UpdateSetting changes a specified crossword setting to a new value.
@@ -191,6 +253,80 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 			}
 			settings.ShowNotes = value
 
+		case "allow_checks":
+			var value bool
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse crossword allow checks setting json %v: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.AllowChecks = value
+
+		case "allow_reveals":
+			var value bool
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse crossword allow reveals setting json %v: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.AllowReveals = value
+
+		case "reveal_penalty":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse crossword reveal penalty setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid crossword reveal penalty setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.RevealPenalty = value
+
+		case "hint_interval":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse crossword hint interval setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid crossword hint interval setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.HintInterval = value
+
+		case "idle_timeout":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse crossword idle timeout setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid crossword idle timeout setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.IdleTimeout = value
+
+		case "time_limit":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse crossword time limit setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid crossword time limit setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.TimeLimit = value
+
 		default:
 			log.Printf("unrecognized crossword setting name %s", setting)
 			w.WriteHeader(http.StatusBadRequest)
@@ -209,29 +345,44 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		// was an error earlier we don't modify the solve's state.
 		var updatedState *State
 		if shouldClearIncorrectCells {
-			state, err := GetState(conn, channel)
-			if err != nil {
-				log.Printf("unable to load state for channel %s: %+v", channel, err)
-				w.WriteHeader(http.StatusNotFound)
-				return
-			}
+			var state State
+			var changed bool
+			read := func(conn db.Connection) error {
+				var err error
+				state, err = GetState(conn, channel)
+				if err != nil {
+					return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+				}
+
+				// There's no need to update cells if the puzzle hasn't been selected or
+				// started or has already ended.
+				status := state.Status
+				changed = status != model.StatusCreated && status != model.StatusSelected && status != model.StatusComplete && status != model.StatusGivenUp && status != model.StatusExpired
+				if !changed {
+					return nil
+				}
 
-			// There's no need to update cells if the puzzle hasn't been selected or
-			// started or is already complete.
-			status := state.Status
-			if status != model.StatusCreated && status != model.StatusSelected && status != model.StatusComplete {
 				if err := state.ClearIncorrectCells(); err != nil {
-					log.Printf("unable to clear incorrect cells for channel: %s: %+v", channel, err)
-					w.WriteHeader(http.StatusInternalServerError)
-					return
+					return fmt.Errorf("unable to clear incorrect cells: %v", err)
 				}
+				return nil
+			}
 
-				if err := SetState(conn, channel, state); err != nil {
-					log.Printf("unable to save state for channel %s: %+v", channel, err)
-					w.WriteHeader(http.StatusInternalServerError)
-					return
+			write := func(tx db.Connection) error {
+				if !changed {
+					return nil
 				}
 
+				return SetState(tx, channel, state)
+			}
+
+			if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+				log.Printf("unable to clear incorrect cells for channel %s: %+v", channel, err)
+				w.WriteHeader(web.StatusCode(err))
+				return
+			}
+
+			if changed {
 				updatedState = &state
 			}
 		}
@@ -242,7 +393,7 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		if updatedState != nil {
 			// Broadcast the updated state to all of the clients, making sure to not
 			// include the answers.
-			updatedState.Puzzle = updatedState.Puzzle.WithoutSolution()
+			updatedState.Puzzle = updatedState.PublicPuzzle()
 
 			registry.Publish(ChannelID(channel), StateEvent(*updatedState))
 		}
@@ -261,44 +412,60 @@ func ToggleStatus(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		conn := pool.Get()
 		defer func() { _ = conn.Close() }()
 
-		state, err := GetState(conn, channel)
-		if err != nil {
-			log.Printf("unable to load state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
+		var state State
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
 
-		now := time.Now()
+			settings, err := GetSettings(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load settings: %v", err)
+			}
 
-		switch state.Status {
-		case model.StatusCreated:
-			log.Printf("unable to toggle status for channel %s, no puzzle selected", channel)
-			w.WriteHeader(http.StatusBadRequest)
-			return
+			now := time.Now()
 
-		case model.StatusSelected:
-			state.Status = model.StatusSolving
-			state.LastStartTime = &now
+			switch state.Status {
+			case model.StatusCreated:
+				return web.Errorf(http.StatusBadRequest, "no puzzle selected")
 
-		case model.StatusPaused:
-			state.Status = model.StatusSolving
-			state.LastStartTime = &now
+			case model.StatusSelected:
+				state.Status = model.StatusSolving
+				state.LastStartTime = &now
+				state.TimeLimit = settings.TimeLimit
 
-		case model.StatusSolving:
-			state.Status = model.StatusPaused
-			total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
-			state.LastStartTime = nil
-			state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+			case model.StatusPaused:
+				state.Status = model.StatusSolving
+				state.LastStartTime = &now
 
-		case model.StatusComplete:
-			log.Printf("unable to toggle status for channel %s, puzzle is already solved", channel)
-			w.WriteHeader(http.StatusBadRequest)
-			return
+			case model.StatusSolving:
+				state.Status = model.StatusPaused
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+			case model.StatusComplete:
+				return web.Errorf(http.StatusBadRequest, "puzzle is already solved")
+
+			case model.StatusGivenUp:
+				return web.Errorf(http.StatusBadRequest, "puzzle was given up")
+
+			case model.StatusExpired:
+				return web.Errorf(http.StatusBadRequest, "puzzle ran out of time")
+			}
+
+			return nil
 		}
 
-		if err := SetState(conn, channel, state); err != nil {
-			log.Printf("unable to save state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
+		write := func(tx db.Connection) error {
+			return SetState(tx, channel, state)
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to toggle status for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
 			return
 		}
 
@@ -306,8 +473,128 @@ func ToggleStatus(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		// making sure to not include the answers.  It's okay to overwrite the
 		// puzzle attribute because we just wrote this state instance to the
 		// database and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutSolution()
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ResetState clears all progress made on the current crossword solve while
+// keeping the puzzle.  The solve is returned to the selected status and its
+// timer is reset.
+func ResetState(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			if state.Status == model.StatusCreated {
+				return web.Errorf(http.StatusBadRequest, "no puzzle selected")
+			}
+
+			state.Reset()
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			return answerlog.Clear(tx, "crossword", channel)
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to reset state for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
+
+		// Broadcast to all of the clients that the puzzle has been reset, making
+		// sure to not include the answers.  It's okay to overwrite the puzzle
+		// attribute because we just wrote this state instance to the database
+		// and will be discarding it immediately publishing.
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// GiveUp ends the current crossword solve without completing it.  The solution
+// is revealed to all of the clients and the attempt is archived as unfinished.
+func GiveUp(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		var answers []model.LoggedAnswer
+		var now time.Time
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			now = time.Now()
+
+			switch state.Status {
+			case model.StatusSelected, model.StatusPaused:
+
+			case model.StatusSolving:
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+			default:
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
+
+			if err := state.RevealSolution(); err != nil {
+				return fmt.Errorf("unable to reveal solution: %v", err)
+			}
+			state.Status = model.StatusGivenUp
+
+			answers, err = answerlog.Get(conn, "crossword", channel)
+			if err != nil {
+				return fmt.Errorf("unable to load answer log: %v", err)
+			}
+
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			return ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now))
+		}
 
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to give up for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
+
+		// Broadcast to all of the clients that the puzzle has been given up on.
+		// The solution is intentionally included so that it can be displayed.
 		registry.Publish(ChannelID(channel), StateEvent(state))
 
 		w.WriteHeader(http.StatusOK)
@@ -315,11 +602,13 @@ func ToggleStatus(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 }
 
 // UpdateAnswer applies an answer to a given clue in the current crossword
-// solve.
+// solve.  The optional user query parameter names the chatter that provided
+// the answer so that their team can be credited with any completed clues.
 func UpdateAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		channel := chi.URLParam(r, "channel")
 		clue := chi.URLParam(r, "clue")
+		user := r.URL.Query().Get("user")
 
 		if r.ContentLength > 1024 {
 			w.WriteHeader(http.StatusRequestEntityTooLarge)
@@ -341,43 +630,119 @@ func UpdateAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		conn := pool.Get()
 		defer func() { _ = conn.Close() }()
 
-		state, err := GetState(conn, channel)
-		if err != nil {
-			log.Printf("unable to load state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
+		var state State
+		var logged model.LoggedAnswer
+		var answers []model.LoggedAnswer
+		var rejected error
+		var scored bool
+		var now time.Time
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
 
-		if state.Status != model.StatusSolving {
-			w.WriteHeader(http.StatusConflict)
-			return
+			// Nothing can be changed once the time limit has been reached, even if
+			// the solve hasn't been marked as expired yet.
+			if state.Status != model.StatusSolving || state.IsOutOfTime(time.Now()) {
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
+
+			settings, err := GetSettings(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load settings: %v", err)
+			}
+
+			roster, err := team.GetRoster(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load team roster: %v", err)
+			}
+
+			// Save the number of correct cells so that we can determine if the answer
+			// made any progress on the solve.
+			correct := state.CountCorrectCells()
+
+			// Rejected answers still count towards the answers submitted during the
+			// solve, so they're logged without changing the state.
+			rejected = state.ApplyAnswer(clue, answer, settings.OnlyAllowCorrectAnswers)
+			if rejected != nil {
+				logged = state.NewLoggedAnswer(user, clue, answer, time.Now())
+				return nil
+			}
+
+			now = time.Now()
+			logged = state.NewLoggedAnswer(user, clue, answer, now)
+			logged.Applied = true
+			logged.Accepted = state.CountCorrectCells() > correct
+			if logged.Accepted {
+				state.LastProgressTime = &now
+			}
+
+			// When chatters have joined teams the solve becomes a race between them,
+			// every clue this answer completed is credited to the answerer's team.
+			scored = false
+			if len(roster) > 0 {
+				name := roster.TeamOf(user)
+				credited, err := state.CreditTeam(name)
+				if err != nil {
+					return fmt.Errorf("unable to credit team %s: %v", name, err)
+				}
+
+				scored = name != "" && credited > 0
+			}
+
+			// If we just solved the puzzle then we should stop the timer.
+			if state.Status == model.StatusComplete {
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+				// The answer log is needed to archive the attempt, it won't contain the
+				// answer that solved the puzzle until it's written.
+				answers, err = answerlog.Get(conn, "crossword", channel)
+				if err != nil {
+					return fmt.Errorf("unable to load answer log: %v", err)
+				}
+				answers = append(answers, logged)
+			}
+
+			return nil
 		}
 
-		settings, err := GetSettings(conn, channel)
-		if err != nil {
-			log.Printf("unable to load settings for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
+		write := func(tx db.Connection) error {
+			if rejected != nil {
+				if err := answerlog.Add(tx, "crossword", channel, logged, StateTTL); err != nil {
+					log.Printf("unable to log answer for channel %s: %+v", channel, err)
+				}
+				return nil
+			}
+
+			if err := answerlog.Add(tx, "crossword", channel, logged, StateTTL); err != nil {
+				return fmt.Errorf("unable to log answer: %v", err)
+			}
+
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			// If we just solved the puzzle then the attempt should be archived.
+			if state.Status == model.StatusComplete {
+				return ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now))
+			}
+
+			return nil
 		}
 
-		if err := state.ApplyAnswer(clue, answer, settings.OnlyAllowCorrectAnswers); err != nil {
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
 			log.Printf("unable to apply answer %s for clue %s for channel %s: %+v", answer, clue, channel, err)
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(web.StatusCode(err))
 			return
 		}
 
-		// If we just solved the puzzle then we should stop the timer.
-		if state.Status == model.StatusComplete {
-			now := time.Now()
-			total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
-			state.LastStartTime = nil
-			state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
-		}
-
-		// Save the updated state.
-		if err := SetState(conn, channel, state); err != nil {
-			log.Printf("unable to save state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
+		if rejected != nil {
+			log.Printf("unable to apply answer %s for clue %s for channel %s: %+v", answer, clue, channel, rejected)
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
@@ -385,20 +750,272 @@ func UpdateAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		// sure to not include the answers.  It's okay to overwrite the puzzle
 		// attribute because we just wrote this state instance to the database
 		// and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutSolution()
+		state.Puzzle = state.PublicPuzzle()
 
 		registry.Publish(ChannelID(channel), StateEvent(state))
 
-		// If we've just finished the solve then send a complete event as well.
+		// If a team scored then let the clients know the new scores.
+		if scored {
+			registry.Publish(ChannelID(channel), ScoreEvent(state.Scores))
+		}
+
+		// If we've just finished the solve then send complete and summary events as well.
 		if state.Status == model.StatusComplete {
 			registry.Publish(ChannelID(channel), CompleteEvent())
+			registry.Publish(ChannelID(channel), SummaryEvent(state.Summary(answers)))
 		}
 
 		w.WriteHeader(http.StatusOK)
 	}
 }
 
-// ShowClue sends an event to all clients of a channel requesting that they
+// CheckAnswer checks the answer to a given clue in the current crossword solve
+// and marks any cells that are filled in with an incorrect value.  Checking is
+// only permitted when the channel's settings allow it.
+func CheckAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+		clue := chi.URLParam(r, "clue")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		read := func(conn db.Connection) error {
+			settings, err := GetSettings(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load settings: %v", err)
+			}
+
+			if !settings.AllowChecks {
+				return web.Errorf(http.StatusForbidden, "checks aren't allowed")
+			}
+
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			// Nothing can be changed once the time limit has been reached, even if
+			// the solve hasn't been marked as expired yet.
+			if state.Status != model.StatusSolving || state.IsOutOfTime(time.Now()) {
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
+
+			if _, err := state.CheckAnswer(clue); err != nil {
+				return web.Errorf(http.StatusBadRequest, "%v", err)
+			}
+
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			return SetState(tx, channel, state)
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to check clue %s for channel %s: %+v", clue, channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
+
+		// Broadcast the updated state to all of the clients, making sure to not
+		// include the answers.
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// PlaceClue places the starting square of a clue's answer in the current
+// diagramless crossword solve.  The square is identified by its 1-based row and
+// column.  Once a clue has been placed answers can be given for it and the part
+// of the grid around the square is discovered.
+func PlaceClue(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+		clue := chi.URLParam(r, "clue")
+
+		row, err := strconv.Atoi(chi.URLParam(r, "row"))
+		if err != nil {
+			log.Printf("unable to parse row for clue %s for channel %s: %+v", clue, channel, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		col, err := strconv.Atoi(chi.URLParam(r, "col"))
+		if err != nil {
+			log.Printf("unable to parse column for clue %s for channel %s: %+v", clue, channel, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			// Nothing can be changed once the time limit has been reached, even if
+			// the solve hasn't been marked as expired yet.
+			if state.Status != model.StatusSolving || state.IsOutOfTime(time.Now()) {
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
+
+			if err := state.PlaceClue(clue, row, col); err != nil {
+				return web.Errorf(http.StatusBadRequest, "%v", err)
+			}
+
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			return SetState(tx, channel, state)
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to place clue %s for channel %s: %+v", clue, channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
+
+		// Broadcast the updated state to all of the clients, making sure to not
+		// include the answers.
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// RevealAnswer reveals the correct answer to a given clue in the current
+// crossword solve.  If a square is specified then only that square of the
+// answer (1-based) is revealed.  Each cell whose value changes as a result of
+// the reveal adds the channel's reveal penalty to the solve's duration.
+// Revealing is only permitted when the channel's settings allow it.
+func RevealAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+		clue := chi.URLParam(r, "clue")
+
+		var square int
+		if s := chi.URLParam(r, "square"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				log.Printf("malformed square (%s): %+v", s, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			square = n
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		var answers []model.LoggedAnswer
+		read := func(conn db.Connection) error {
+			settings, err := GetSettings(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load settings: %v", err)
+			}
+
+			if !settings.AllowReveals {
+				return web.Errorf(http.StatusForbidden, "reveals aren't allowed")
+			}
+
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			// Nothing can be changed once the time limit has been reached, even if
+			// the solve hasn't been marked as expired yet.
+			if state.Status != model.StatusSolving || state.IsOutOfTime(time.Now()) {
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
+
+			var revealed int
+			if square == 0 {
+				revealed, err = state.RevealAnswer(clue)
+			} else {
+				revealed, err = state.RevealSquare(clue, square)
+			}
+			if err != nil {
+				return web.Errorf(http.StatusBadRequest, "%v", err)
+			}
+
+			if revealed > 0 {
+				now := time.Now()
+				state.LastProgressTime = &now
+			}
+
+			// Apply the penalty for the revealed cells.
+			penalty := time.Duration(revealed) * settings.RevealPenalty.Duration
+			state.TotalSolveDuration = model.Duration{Duration: state.TotalSolveDuration.Duration + penalty}
+			state.RevealPenalty = model.Duration{Duration: state.RevealPenalty.Duration + penalty}
+
+			// If we just solved the puzzle then we should stop the timer and load the
+			// answer log so that the attempt can be archived.
+			if state.Status == model.StatusComplete {
+				now := time.Now()
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+				answers, err = answerlog.Get(conn, "crossword", channel)
+				if err != nil {
+					return fmt.Errorf("unable to load answer log: %v", err)
+				}
+			}
+
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			// If we just solved the puzzle then the attempt should be archived.
+			if state.Status == model.StatusComplete {
+				return ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, time.Now()))
+			}
+
+			return nil
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to reveal clue %s for channel %s: %+v", clue, channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
+
+		// Broadcast the updated state to all of the clients, making sure to not
+		// include the answers.
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		// If we've just finished the solve then send complete and summary events as well.
+		if state.Status == model.StatusComplete {
+			registry.Publish(ChannelID(channel), CompleteEvent())
+			registry.Publish(ChannelID(channel), SummaryEvent(state.Summary(answers)))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ShowClue sends an event to all clients of a channel requesting that they
 // update their view to make the specified clue visible.  If the specified clue
 // isn't structured as a proper clue number and direction than an error will be
 // returned.
@@ -419,6 +1036,226 @@ func ShowClue(registry *pubsub.Registry) http.HandlerFunc {
 	}
 }
 
+// GetCurrentState returns the current state of a channel's crossword solve with
+// the solution to the puzzle removed.  The response includes an ETag so that
+// clients polling for the state can avoid downloading it when it hasn't
+// changed.
+func GetCurrentState(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		// The version is loaded before the state so that if the state is written
+		// in between the two the client will just download it again next time.
+		version, err := GetStateVersion(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state version for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to read state for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Return the same payload that would be published for the state, making
+		// sure to not include the solution to the puzzle.
+		state.Puzzle = state.PublicPuzzle()
+
+		if err := web.RenderJSONWithVersion(w, r, version, StateEvent(state).Payload); err != nil {
+			log.Printf("unable to render state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetCurrentSettings returns the crossword settings of a channel.  The response
+// includes an ETag so that clients polling for the settings can avoid
+// downloading them when they haven't changed.
+func GetCurrentSettings(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		settings, err := GetSettings(conn, channel)
+		if err != nil {
+			log.Printf("unable to read settings for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.RenderJSONWithETag(w, r, settings); err != nil {
+			log.Printf("unable to render settings for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetSolution returns a channel's crossword puzzle including its solution.  The
+// solution is only available once the solve has finished.
+func GetSolution(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to read solution for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !state.Status.IsFinished() {
+			log.Printf("unable to read solution for channel %s, status is %s", channel, state.Status)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		render.JSON(w, r, state.Puzzle)
+	}
+}
+
+// GetShareCard returns the share card of the channel's most recently archived
+// crossword attempt as plain text.
+func GetShareCard(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		attempt, err := GetLatestAttempt(conn, channel)
+		if err != nil {
+			log.Printf("unable to read latest attempt for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if attempt == nil || attempt.Card == "" {
+			log.Printf("unable to read share card for channel %s, no archived attempt", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(attempt.Card))
+	}
+}
+
+// GetSnapshot renders the channel's current crossword grid as an SVG or PNG image.
+// The image format is chosen with the format query parameter and the filled in letters can be
+// hidden by setting the letters query parameter to false.
+func GetSnapshot(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		options, err := snapshot.ParseOptions(r)
+		if err != nil {
+			log.Printf("unable to parse snapshot options for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to render snapshot for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Only draw the part of the grid that the channel is allowed to see, for a
+		// diagramless solve that hides the undiscovered structure.
+		state.Puzzle = state.PublicPuzzle()
+
+		if err := snapshot.Write(w, state.Snapshot(options.ShowLetters), options.Format); err != nil {
+			log.Printf("unable to write snapshot for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetPDF returns a printable PDF of the channel's current crossword as a
+// download.  By default the puzzle is blank, setting the filled query
+// parameter to true includes the letters that the channel has filled in.
+func GetPDF(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		var filled bool
+		if param := r.URL.Query().Get("filled"); param != "" {
+			var err error
+			if filled, err = strconv.ParseBool(param); err != nil {
+				log.Printf("unable to parse filled parameter %s for channel %s: %+v", param, channel, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to render pdf for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		filename := "crossword.pdf"
+		if !state.Puzzle.PublishedDate.IsZero() {
+			filename = fmt.Sprintf("crossword-%s.pdf", state.Puzzle.PublishedDate.Format("2006-01-02"))
+		}
+
+		// Only draw the part of the grid that the channel is allowed to see, for a
+		// diagramless solve that hides the undiscovered structure.
+		state.Puzzle = state.PublicPuzzle()
+
+		if err := pdf.Write(w, filename, state.PDF(filled)); err != nil {
+			log.Printf("unable to write pdf for channel %s: %+v", channel, err)
+			return
+		}
+	}
+}
+
 // GetEvents establishes an event stream with a client.  An event stream is
 // server side event stream (SSE) with a client's browser that allows one way
 // communication from the server to the client.  Clients that call into this
@@ -457,7 +1294,7 @@ func GetEvents(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 			return
 		}
 		if state.Puzzle != nil {
-			state.Puzzle = state.Puzzle.WithoutSolution()
+			state.Puzzle = state.PublicPuzzle()
 			stream <- StateEvent(state)
 		}
 
@@ -475,8 +1312,10 @@ func GetEvents(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 	}
 }
 
-// GetAvailableDates returns the available crossword dates across all puzzle
-// sources.
+// GetAvailableDates returns the catalog of available crosswords across all
+// puzzle sources, indexed by the source's identifier.  Sources that are
+// organized by date list their dates, and the local puzzle library lists the
+// entries of its indexed puzzles since they're selected by id.
 func GetAvailableDates() http.HandlerFunc {
 	// Format the given set of dates.
 	format := func(dates []time.Time) []string {
@@ -489,10 +1328,45 @@ func GetAvailableDates() http.HandlerFunc {
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		render.JSON(w, r, map[string][]string{
-			"new_york_times":      format(LoadAvailableNYTDates()),
-			"wall_street_journal": format(LoadAvailableWSJDates()),
-		})
+		catalog := make(map[string]interface{})
+		for _, source := range Sources() {
+			if library, ok := source.(*Library); ok {
+				entries := library.Entries()
+				if entries == nil {
+					entries = []LibraryEntry{}
+				}
+
+				catalog[source.ID()] = entries
+				continue
+			}
+
+			if available := source.AvailableDates(); available != nil {
+				catalog[source.ID()] = format(available)
+			}
+		}
+
+		render.JSON(w, r, catalog)
+	}
+}
+
+// GetLibrary returns the entries of the local puzzle library whose metadata
+// matches the q query parameter, or every entry when it's omitted.  If no
+// library has been configured then a 404 is returned.
+func GetLibrary() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		library, ok := GetSource("library").(*Library)
+		if !ok {
+			log.Printf("unable to read library, no library configured")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		entries := library.Search(r.URL.Query().Get("q"))
+		if entries == nil {
+			entries = []LibraryEntry{}
+		}
+
+		render.JSON(w, r, entries)
 	}
 }
 
@@ -509,18 +1383,50 @@ func SettingsEvent(settings Settings) pubsub.Event {
 }
 
 func StateEvent(state State) pubsub.Event {
+	if state.TimeLimit.Duration > 0 {
+		remaining := model.Duration{Duration: state.RemainingTime(time.Now())}
+		state.TimeRemaining = &remaining
+	}
+
 	return pubsub.Event{
 		Kind:    "state",
 		Payload: state,
 	}
 }
 
+func ExpiredEvent() pubsub.Event {
+	return pubsub.Event{
+		Kind: "expired",
+	}
+}
+
 func CompleteEvent() pubsub.Event {
 	return pubsub.Event{
 		Kind: "complete",
 	}
 }
 
+func SummaryEvent(summary model.Summary) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "summary",
+		Payload: summary,
+	}
+}
+
+func ScoreEvent(scores map[string]int) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "score",
+		Payload: scores,
+	}
+}
+
+func HintEvent(clue string) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "hint",
+		Payload: clue,
+	}
+}
+
 func ShowClueEvent(clue string) pubsub.Event {
 	return pubsub.Event{
 		Kind:    "show_clue",
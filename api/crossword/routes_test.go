@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/answerlog"
+	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
 	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/team"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
 	"github.com/gomodule/redigo/redis"
@@ -18,6 +21,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"path"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
@@ -58,6 +62,42 @@ func TestRoute_UpdateSetting(t *testing.T) {
 	VerifySettings(t, pool, events, func(s Settings) {
 		assert.True(t, s.ShowNotes)
 	})
+
+	response = Channel.PUT("/setting/allow_checks", `true`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.True(t, s.AllowChecks)
+	})
+
+	response = Channel.PUT("/setting/allow_reveals", `true`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.True(t, s.AllowReveals)
+	})
+
+	response = Channel.PUT("/setting/reveal_penalty", `"30s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 30*time.Second, s.RevealPenalty.Duration)
+	})
+
+	response = Channel.PUT("/setting/hint_interval", `"5m0s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 5*time.Minute, s.HintInterval.Duration)
+	})
+
+	response = Channel.PUT("/setting/idle_timeout", `"30m0s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 30*time.Minute, s.IdleTimeout.Duration)
+	})
+
+	response = Channel.PUT("/setting/time_limit", `"15m0s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 15*time.Minute, s.TimeLimit.Duration)
+	})
 }
 
 func TestRoute_UpdateSetting_ClearsIncorrectCells(t *testing.T) {
@@ -111,6 +151,26 @@ func TestRoute_UpdateSetting_JSONError(t *testing.T) {
 			setting: "clue_font_size",
 			json:    `{`,
 		},
+		{
+			name:    "idle_timeout",
+			setting: "idle_timeout",
+			json:    `{`,
+		},
+		{
+			name:    "negative idle_timeout",
+			setting: "idle_timeout",
+			json:    `"-30m"`,
+		},
+		{
+			name:    "time_limit",
+			setting: "time_limit",
+			json:    `{`,
+		},
+		{
+			name:    "negative time_limit",
+			setting: "time_limit",
+			json:    `"-15m"`,
+		},
 		{
 			name:    "invalid setting name",
 			setting: "foo_bar_baz",
@@ -121,6 +181,46 @@ func TestRoute_UpdateSetting_JSONError(t *testing.T) {
 			setting: "show_notes",
 			json:    `{`,
 		},
+		{
+			name:    "allow_checks",
+			setting: "allow_checks",
+			json:    `{`,
+		},
+		{
+			name:    "allow_reveals",
+			setting: "allow_reveals",
+			json:    `{`,
+		},
+		{
+			name:    "reveal_penalty",
+			setting: "reveal_penalty",
+			json:    `{`,
+		},
+		{
+			name:    "negative reveal_penalty",
+			setting: "reveal_penalty",
+			json:    `"-10s"`,
+		},
+		{
+			name:    "hint_interval",
+			setting: "hint_interval",
+			json:    `{`,
+		},
+		{
+			name:    "negative hint_interval",
+			setting: "hint_interval",
+			json:    `"-5m"`,
+		},
+		{
+			name:    "idle_timeout",
+			setting: "idle_timeout",
+			json:    `{`,
+		},
+		{
+			name:    "negative idle_timeout",
+			setting: "idle_timeout",
+			json:    `"-30m"`,
+		},
 	}
 
 	for _, test := range tests {
@@ -209,6 +309,7 @@ func TestRoute_UpdatePuzzle_WallStreetJournal(t *testing.T) {
 	events := NewEventSubscription(t, registry, Channel.name)
 
 	// Force a specific puzzle to be loaded so we don't make a network call.
+	ForcePuzSourcesToBeRegistered(t, "puz-sources.json")
 	ForcePuzzleToBeLoaded(t, "puzzle-wsj-20190102.json")
 
 	response := Channel.PUT("/", `{"wall_street_journal_date": "2019-01-02"}`, router)
@@ -220,6 +321,8 @@ func TestRoute_UpdatePuzzle_WallStreetJournal(t *testing.T) {
 		assert.Equal(t, 0, len(state.DownCluesFilled))
 		assert.Nil(t, state.LastStartTime)
 		assert.Equal(t, 0., state.TotalSolveDuration.Seconds())
+		assert.Equal(t, "The Wall Street Journal", state.Puzzle.Publisher)
+		assert.Equal(t, "2019-01-02", state.Puzzle.PublishedDate.Format("2006-01-02"))
 	})
 }
 
@@ -267,6 +370,40 @@ func TestRoute_UpdatePuzzle_PuzURL(t *testing.T) {
 	})
 }
 
+func TestRoute_UpdatePuzzle_RegisteredSource(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	puzzle := LoadTestPuzzle(t, "xwordinfo-nyt-20181231.json")
+	ForceSourceToBeRegistered(t, TestSource{id: "test", key: "test_key", puzzle: puzzle})
+
+	response := Channel.PUT("/", `{"test_key": "anything"}`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSelected, state.Status)
+		assert.Equal(t, puzzle.Title, state.Puzzle.Title)
+	})
+}
+
+func TestRoute_UpdatePuzzle_Library(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	dir := NewTestLibraryDir(t)
+	CopyTestFile(t, filepath.Join("ipuz", "example-20200102.ipuz"), filepath.Join(dir, "example.ipuz"))
+
+	library := NewLibrary(dir)
+	require.NoError(t, library.Refresh())
+	ForceSourceToBeRegistered(t, library)
+
+	response := Channel.PUT("/", fmt.Sprintf(`{"library_id": "%s"}`, LibraryID("example.ipuz")), router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSelected, state.Status)
+		assert.Equal(t, "Example Puzzle", state.Puzzle.Title)
+	})
+}
+
 func TestRoute_UpdatePuzzle_JSONError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -296,6 +433,110 @@ func TestRoute_UpdatePuzzle_JSONError(t *testing.T) {
 	}
 }
 
+func TestRoute_UploadPuzzle(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	// Upload the puzzle without its optional grids.
+	puzzle := NewUploadedPuzzle()
+	puzzle.Author = ""
+	puzzle.CellClueNumbers = nil
+	puzzle.CellCircles = nil
+	puzzle.CellShades = nil
+
+	bs, err := json.Marshal(puzzle)
+	require.NoError(t, err)
+
+	response := Channel.PUT("/upload", string(bs), router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var report model.ValidationReport
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &report))
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, []string{"puzzle doesn't have an author"}, report.Warnings)
+
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSelected, state.Status)
+		assert.Equal(t, "Crossword uploaded by the streamer", state.Puzzle.Description)
+		assert.Equal(t, "Tiny", state.Puzzle.Title)
+		assert.Equal(t, [][]int{{1, 2, 3}, {4, 0, 0}, {5, 0, 0}}, state.Puzzle.CellClueNumbers)
+	})
+}
+
+func TestRoute_UploadPuzzle_Invalid(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+
+	puzzle := NewUploadedPuzzle()
+	delete(puzzle.CluesDown, 3)
+
+	bs, err := json.Marshal(puzzle)
+	require.NoError(t, err)
+
+	response := Channel.PUT("/upload", string(bs), router)
+	require.Equal(t, http.StatusUnprocessableEntity, response.Code)
+
+	var report model.ValidationReport
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &report))
+	assert.Equal(t, []string{"3 down is missing a clue"}, report.Errors)
+}
+
+func TestRoute_UploadPuzzle_JSONError(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+
+	response := Channel.PUT("/upload", `{"rows": "three"}`, router)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	var report model.ValidationReport
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &report))
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "unable to parse puzzle")
+}
+
+func TestRoute_UploadPuzzle_LinkedClues(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	puzzle := NewUploadedPuzzle()
+	puzzle.CluesAcross[1] = "With 5-Across, feline ribbon"
+	puzzle.CluesAcross[5] = "See 1-Across"
+
+	bs, err := json.Marshal(puzzle)
+	require.NoError(t, err)
+
+	response := Channel.PUT("/upload", string(bs), router)
+	require.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, [][]string{{"1a", "5a"}}, state.Puzzle.LinkedClues)
+	})
+
+	response = Channel.PUT("/status", "", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSolving, state.Status)
+	})
+
+	// A single answer fills both of the linked clues.
+	response = Channel.PUT("/answer/1a", `"CATBOW"`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, []string{"C", "A", "T"}, state.Cells[0])
+		assert.Equal(t, []string{"B", "O", "W"}, state.Cells[2])
+		assert.True(t, state.AcrossCluesFilled[1])
+		assert.True(t, state.AcrossCluesFilled[5])
+	})
+}
+
+func TestRoute_UploadPuzzle_SaveError(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+	ForceErrorDuringStateSave(t, errors.New("forced error"))
+
+	bs, err := json.Marshal(NewUploadedPuzzle())
+	require.NoError(t, err)
+
+	response := Channel.PUT("/upload", string(bs), router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
 func TestRoute_UpdatePuzzle_LoadSaveError(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -339,6 +580,7 @@ func TestRoute_UpdatePuzzle_LoadSaveError(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			router, _, _ := NewTestRouter(t)
+			ForcePuzSourcesToBeRegistered(t, "puz-sources.json")
 
 			if test.forcePuzzleLoadError != nil {
 				ForceErrorDuringPuzzleLoad(t, test.forcePuzzleLoadError)
@@ -409,15 +651,29 @@ func TestRoute_ToggleStatus(t *testing.T) {
 
 func TestRoute_ToggleStatus_Error(t *testing.T) {
 	tests := []struct {
-		name           string
-		initialStatus  model.Status
-		loadStateError error
-		saveStateError error
+		name              string
+		initialStatus     model.Status
+		loadStateError    error
+		saveStateError    error
+		loadSettingsError error
 	}{
 		{
 			name:          "status created",
 			initialStatus: model.StatusCreated,
 		},
+		{
+			name:          "status given up",
+			initialStatus: model.StatusGivenUp,
+		},
+		{
+			name:          "status expired",
+			initialStatus: model.StatusExpired,
+		},
+		{
+			name:              "error loading settings",
+			initialStatus:     model.StatusSelected,
+			loadSettingsError: errors.New("forced error"),
+		},
 		{
 			name:           "error loading state",
 			initialStatus:  model.StatusSelected,
@@ -447,141 +703,507 @@ func TestRoute_ToggleStatus_Error(t *testing.T) {
 				ForceErrorDuringStateSave(t, test.saveStateError)
 			}
 
+			if test.loadSettingsError != nil {
+				ForceErrorDuringSettingsLoad(t, test.loadSettingsError)
+			}
+
 			response := Channel.PUT("/status", "", router)
 			assert.NotEqual(t, http.StatusOK, response.Code)
 		})
 	}
 }
 
-func TestRoute_UpdateAnswer_AllowIncorrectAnswers(t *testing.T) {
-	// This acts as a small integration test of applying answers to a crossword
-	// being solved.
+func TestRoute_ToggleStatus_TimeLimit(t *testing.T) {
 	router, pool, registry := NewTestRouter(t)
 	conn := NewRedisConnection(t, pool)
 	events := NewEventSubscription(t, registry, Channel.name)
 
+	settings := Settings{TimeLimit: model.Duration{Duration: 15 * time.Minute}}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
 	state := NewState(t, "xwordinfo-nyt-20181231.json")
-	state.Status = model.StatusSolving
 	require.NoError(t, SetState(conn, Channel.name, state))
 
-	// Apply a correct across answer.
-	response := Channel.PUT("/answer/1a", `"QANDA"`, router)
-	assert.Equal(t, http.StatusOK, response.Code)
-	VerifyState(t, pool, events, func(state State) {
-		assert.True(t, state.AcrossCluesFilled[1])
-		assert.Equal(t, "Q", state.Cells[0][0])
-		assert.Equal(t, "A", state.Cells[0][1])
-		assert.Equal(t, "N", state.Cells[0][2])
-		assert.Equal(t, "D", state.Cells[0][3])
-		assert.Equal(t, "A", state.Cells[0][4])
-	})
-
-	// Apply a correct down answer.
-	response = Channel.PUT("/answer/1d", `"QTIP"`, router)
+	// Starting the solve should copy the time limit into the state.
+	response := Channel.PUT("/status", ``, router)
 	assert.Equal(t, http.StatusOK, response.Code)
 	VerifyState(t, pool, events, func(state State) {
-		assert.True(t, state.DownCluesFilled[1])
-		assert.Equal(t, "Q", state.Cells[0][0])
-		assert.Equal(t, "T", state.Cells[1][0])
-		assert.Equal(t, "I", state.Cells[2][0])
-		assert.Equal(t, "P", state.Cells[3][0])
+		assert.Equal(t, model.StatusSolving, state.Status)
+		assert.Equal(t, 15*time.Minute, state.TimeLimit.Duration)
 	})
 
-	// Apply an incorrect across answer.
-	response = Channel.PUT("/answer/6a", `"FLOOR"`, router)
-	assert.Equal(t, http.StatusOK, response.Code)
-	VerifyState(t, pool, events, func(state State) {
-		assert.True(t, state.AcrossCluesFilled[6])
-		assert.Equal(t, "F", state.Cells[0][6])
-		assert.Equal(t, "L", state.Cells[0][7])
-		assert.Equal(t, "O", state.Cells[0][8])
-		assert.Equal(t, "O", state.Cells[0][9])
-		assert.Equal(t, "R", state.Cells[0][10])
-	})
+	// Changing the setting mid-solve shouldn't move the deadline.
+	settings.TimeLimit = model.Duration{Duration: time.Hour}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
 
-	// Apply an incorrect down answer.
-	response = Channel.PUT("/answer/11d", `"HEYA"`, router)
+	response = Channel.PUT("/status", ``, router)
 	assert.Equal(t, http.StatusOK, response.Code)
 	VerifyState(t, pool, events, func(state State) {
-		assert.True(t, state.DownCluesFilled[11])
-		assert.Equal(t, "H", state.Cells[0][12])
-		assert.Equal(t, "E", state.Cells[1][12])
-		assert.Equal(t, "Y", state.Cells[2][12])
-		assert.Equal(t, "A", state.Cells[3][12])
+		assert.Equal(t, model.StatusPaused, state.Status)
+		assert.Equal(t, 15*time.Minute, state.TimeLimit.Duration)
 	})
-
-	// Pause the solve.
-	response = Channel.PUT("/status", ``, router)
-	require.Equal(t, http.StatusOK, response.Code)
-
-	// Try to apply an answer.
-	response = Channel.PUT("/answer/6a", `"ATTIC"`, router)
-	assert.Equal(t, http.StatusConflict, response.Code)
 }
 
-func TestRoute_UpdateAnswer_OnlyAllowCorrectAnswers(t *testing.T) {
-	// This acts as a small integration test toggling the status of a crossword
-	// being solved.
+func TestRoute_ResetState(t *testing.T) {
 	router, pool, registry := NewTestRouter(t)
 	conn := NewRedisConnection(t, pool)
 	events := NewEventSubscription(t, registry, Channel.name)
 
-	// Change the settings to only allow correct answers.
-	settings := Settings{OnlyAllowCorrectAnswers: true}
-	require.NoError(t, SetSettings(conn, Channel.name, settings))
-
+	// Set a state that has made some progress on the solve.
+	now := time.Now()
 	state := NewState(t, "xwordinfo-nyt-20181231.json")
 	state.Status = model.StatusSolving
+	state.LastStartTime = &now
+	state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+	_, err := state.RevealAnswer("1d")
+	require.NoError(t, err)
 	require.NoError(t, SetState(conn, Channel.name, state))
 
-	// Apply a correct across answer.
-	response := Channel.PUT("/answer/1a", `"QANDA"`, router)
-	assert.Equal(t, http.StatusOK, response.Code)
-	VerifyState(t, pool, events, func(state State) {
-		assert.True(t, state.AcrossCluesFilled[1])
-		assert.Equal(t, "Q", state.Cells[0][0])
-		assert.Equal(t, "A", state.Cells[0][1])
-		assert.Equal(t, "N", state.Cells[0][2])
-		assert.Equal(t, "D", state.Cells[0][3])
-		assert.Equal(t, "A", state.Cells[0][4])
-	})
-
-	// Apply a correct down answer.
-	response = Channel.PUT("/answer/1d", `"QTIP"`, router)
+	response := Channel.PUT("/reset", ``, router)
 	assert.Equal(t, http.StatusOK, response.Code)
 	VerifyState(t, pool, events, func(state State) {
-		assert.True(t, state.DownCluesFilled[1])
-		assert.Equal(t, "Q", state.Cells[0][0])
-		assert.Equal(t, "T", state.Cells[1][0])
-		assert.Equal(t, "I", state.Cells[2][0])
-		assert.Equal(t, "P", state.Cells[3][0])
+		assert.Equal(t, model.StatusSelected, state.Status)
+		assert.Equal(t, "", state.Cells[0][0])
+		assert.False(t, state.CellsRevealed[0][0])
+		assert.False(t, state.AcrossCluesFilled[1])
+		assert.False(t, state.DownCluesFilled[1])
+		assert.Nil(t, state.LastStartTime)
+		assert.Equal(t, time.Duration(0), state.TotalSolveDuration.Duration)
 	})
-
-	// Apply an incorrect across answer.
-	response = Channel.PUT("/answer/6a", `"FLOOR"`, router)
-	assert.Equal(t, http.StatusBadRequest, response.Code)
-
-	// Apply an incorrect down answer.
-	response = Channel.PUT("/answer/11d", `"HEYA"`, router)
-	assert.Equal(t, http.StatusBadRequest, response.Code)
 }
 
-func TestRoute_UpdateAnswer_SolvedPuzzleStopsTimer(t *testing.T) {
-	// This acts as a small integration test ensuring that the timer stops
-	// counting once the crossword has been solved.
-	router, pool, registry := NewTestRouter(t)
-	conn := NewRedisConnection(t, pool)
-	events := NewEventSubscription(t, registry, Channel.name)
-
-	// Setup a state that has the entire puzzle solved except for the last answer.
-	state := NewState(t, "xwordinfo-nyt-20181231.json")
-	state.Status = model.StatusSolving
-	state.ApplyAnswer("1a", "Q AND A", false)
-	state.ApplyAnswer("6a", "ATTIC", false)
-	state.ApplyAnswer("11a", "HON", false)
-	state.ApplyAnswer("14a", "THIRD", false)
-	state.ApplyAnswer("15a", "LAID ASIDE", false)
-	state.ApplyAnswer("17a", "IM TOO OLD FOR THIS", false)
+func TestRoute_ResetState_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		initialStatus  model.Status
+		loadStateError error
+		saveStateError error
+	}{
+		{
+			name:          "status created",
+			initialStatus: model.StatusCreated,
+		},
+		{
+			name:           "error loading state",
+			initialStatus:  model.StatusSolving,
+			loadStateError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			initialStatus:  model.StatusSolving,
+			saveStateError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.initialStatus
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			if test.loadStateError != nil {
+				ForceErrorDuringStateLoad(t, test.loadStateError)
+			}
+
+			if test.saveStateError != nil {
+				ForceErrorDuringStateSave(t, test.saveStateError)
+			}
+
+			response := Channel.PUT("/reset", "", router)
+			assert.NotEqual(t, http.StatusOK, response.Code)
+		})
+	}
+}
+
+func TestRoute_GiveUp(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialStatus model.Status
+	}{
+		{
+			name:          "selected",
+			initialStatus: model.StatusSelected,
+		},
+		{
+			name:          "paused",
+			initialStatus: model.StatusPaused,
+		},
+		{
+			name:          "solving",
+			initialStatus: model.StatusSolving,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+			events := NewEventSubscription(t, registry, Channel.name)
+
+			start := time.Now().Add(-time.Minute)
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.initialStatus
+			state.LastStartTime = nil
+			if test.initialStatus == model.StatusSolving {
+				state.LastStartTime = &start
+			}
+			require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			response := Channel.PUT("/giveup", ``, router)
+			require.Equal(t, http.StatusOK, response.Code)
+
+			// The state event should include the solution.
+			found := Events(events, "state")
+			require.Equal(t, 1, len(found))
+			state = found[0].Payload.(State)
+			assert.NotNil(t, state.Puzzle.Cells)
+
+			// The solution should have been filled in with any cells that weren't
+			// already correct marked as revealed.
+			state, err := GetState(conn, Channel.name)
+			require.NoError(t, err)
+			assert.Equal(t, model.StatusGivenUp, state.Status)
+			assert.Nil(t, state.LastStartTime)
+			assert.Equal(t, state.Puzzle.Cells, state.Cells)
+			assert.False(t, state.CellsRevealed[0][0])
+			assert.True(t, state.CellsRevealed[1][0])
+			if test.initialStatus == model.StatusSolving {
+				assert.True(t, state.TotalSolveDuration.Duration >= time.Minute)
+			}
+
+			// The attempt should have been archived as unfinished.
+			attempts := LoadArchive(t, pool)
+			require.Equal(t, 1, len(attempts))
+			assert.Equal(t, Channel.name, attempts[0].Channel)
+			assert.Equal(t, model.StatusGivenUp, attempts[0].Status)
+			assert.Equal(t, "New York Times puzzle from 2018-12-31", attempts[0].Description)
+		})
+	}
+}
+
+func TestRoute_GiveUp_Error(t *testing.T) {
+	tests := []struct {
+		name             string
+		initialStatus    model.Status
+		loadStateError   error
+		saveStateError   error
+		saveArchiveError error
+		expected         int
+	}{
+		{
+			name:          "status created",
+			initialStatus: model.StatusCreated,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:          "status complete",
+			initialStatus: model.StatusComplete,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:          "status given up",
+			initialStatus: model.StatusGivenUp,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:           "error loading state",
+			initialStatus:  model.StatusPaused,
+			loadStateError: errors.New("forced error"),
+			expected:       http.StatusNotFound,
+		},
+		{
+			name:           "error saving state",
+			initialStatus:  model.StatusPaused,
+			saveStateError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+		{
+			name:             "error saving archive",
+			initialStatus:    model.StatusPaused,
+			saveArchiveError: errors.New("forced error"),
+			expected:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.initialStatus
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			if test.loadStateError != nil {
+				ForceErrorDuringStateLoad(t, test.loadStateError)
+			}
+
+			if test.saveStateError != nil {
+				ForceErrorDuringStateSave(t, test.saveStateError)
+			}
+
+			if test.saveArchiveError != nil {
+				ForceErrorDuringArchiveSave(t, test.saveArchiveError)
+			}
+
+			response := Channel.PUT("/giveup", "", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_UpdateAnswer_AllowIncorrectAnswers(t *testing.T) {
+	// This acts as a small integration test of applying answers to a crossword
+	// being solved.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Apply a correct across answer.
+	response := Channel.PUT("/answer/1a", `"QANDA"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.True(t, state.AcrossCluesFilled[1])
+		assert.Equal(t, "Q", state.Cells[0][0])
+		assert.Equal(t, "A", state.Cells[0][1])
+		assert.Equal(t, "N", state.Cells[0][2])
+		assert.Equal(t, "D", state.Cells[0][3])
+		assert.Equal(t, "A", state.Cells[0][4])
+	})
+
+	// Apply a correct down answer.
+	response = Channel.PUT("/answer/1d", `"QTIP"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.True(t, state.DownCluesFilled[1])
+		assert.Equal(t, "Q", state.Cells[0][0])
+		assert.Equal(t, "T", state.Cells[1][0])
+		assert.Equal(t, "I", state.Cells[2][0])
+		assert.Equal(t, "P", state.Cells[3][0])
+	})
+
+	// Apply an incorrect across answer.
+	response = Channel.PUT("/answer/6a", `"FLOOR"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.True(t, state.AcrossCluesFilled[6])
+		assert.Equal(t, "F", state.Cells[0][6])
+		assert.Equal(t, "L", state.Cells[0][7])
+		assert.Equal(t, "O", state.Cells[0][8])
+		assert.Equal(t, "O", state.Cells[0][9])
+		assert.Equal(t, "R", state.Cells[0][10])
+	})
+
+	// Apply an incorrect down answer.
+	response = Channel.PUT("/answer/11d", `"HEYA"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.True(t, state.DownCluesFilled[11])
+		assert.Equal(t, "H", state.Cells[0][12])
+		assert.Equal(t, "E", state.Cells[1][12])
+		assert.Equal(t, "Y", state.Cells[2][12])
+		assert.Equal(t, "A", state.Cells[3][12])
+	})
+
+	// Pause the solve.
+	response = Channel.PUT("/status", ``, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	// Try to apply an answer.
+	response = Channel.PUT("/answer/6a", `"ATTIC"`, router)
+	assert.Equal(t, http.StatusConflict, response.Code)
+}
+
+func TestRoute_UpdateAnswer_Teams(t *testing.T) {
+	// This acts as a small integration test of a team solve where each clue is
+	// credited to the team of the chatter that answered it.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	roster := team.Roster{"alice": "red", "bob": "blue"}
+	require.NoError(t, team.SetRoster(conn, Channel.name, roster))
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Alice answers a clue for the red team.
+	response := Channel.PUT("/answer/1a?user=Alice", `"QANDA"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	scores := Events(events, "score")
+	require.Len(t, scores, 1)
+	assert.Equal(t, map[string]int{"red": 1, "blue": 0}, scores[0].Payload)
+
+	// Carol isn't on a team, the clue is credited but nobody scores.
+	response = Channel.PUT("/answer/1d?user=carol", `"QTIP"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Empty(t, Events(events, "score"))
+
+	// Bob answers a clue for the blue team.
+	response = Channel.PUT("/answer/6a?user=bob", `"ATTIC"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	scores = Events(events, "score")
+	require.Len(t, scores, 1)
+	assert.Equal(t, map[string]int{"red": 1, "blue": 1}, scores[0].Payload)
+
+	// Answering an already credited clue again doesn't score.
+	response = Channel.PUT("/answer/1a?user=bob", `"QANDA"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Empty(t, Events(events, "score"))
+
+	state, err := GetState(conn, Channel.name)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"1a": "red", "1d": "", "6a": "blue"}, state.ClueTeams)
+	assert.Equal(t, map[string]int{"red": 1, "blue": 1}, state.Scores)
+}
+
+func TestRoute_UpdateAnswer_Concurrent(t *testing.T) {
+	// Answers that are submitted at the same time are each applied to the latest
+	// state instead of overwriting one another.
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	limit := db.UpdateAttempts
+	db.UpdateAttempts = 1000
+	t.Cleanup(func() { db.UpdateAttempts = limit })
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	now := time.Now()
+	state.LastStartTime = &now
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// The across answers fill in every cell of the grid.
+	answers := make(map[string]string)
+	for num := range state.Puzzle.CluesAcross {
+		clue := fmt.Sprintf("%da", num)
+		xs, ys, err := state.getAnswerCells(clue)
+		require.NoError(t, err)
+
+		var answer strings.Builder
+		for i := range xs {
+			answer.WriteString(state.Puzzle.Cells[ys[i]][xs[i]])
+		}
+		answers[clue] = answer.String()
+	}
+
+	var wg sync.WaitGroup
+	for clue, answer := range answers {
+		wg.Add(1)
+		go func(clue, answer string) {
+			defer wg.Done()
+			response := Channel.PUT("/answer/"+clue, fmt.Sprintf(`"%s"`, answer), router)
+			assert.Equal(t, http.StatusOK, response.Code)
+		}(clue, answer)
+	}
+	wg.Wait()
+
+	state, err := GetState(conn, Channel.name)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusComplete, state.Status)
+	assert.Len(t, state.AcrossCluesFilled, len(answers))
+
+	logged, err := answerlog.Get(conn, "crossword", Channel.name)
+	require.NoError(t, err)
+	assert.Len(t, logged, len(answers))
+
+	// The puzzle was only completed once so only one attempt was archived.
+	attempts, err := redis.Int(conn.Do("LLEN", ArchiveKey(Channel.name)))
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRoute_UpdateAnswer_NoTeams(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Without anyone on a team no scores are kept.
+	response := Channel.PUT("/answer/1a?user=alice", `"QANDA"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Nil(t, state.ClueTeams)
+		assert.Nil(t, state.Scores)
+	})
+}
+
+func TestRoute_UpdateAnswer_OnlyAllowCorrectAnswers(t *testing.T) {
+	// This acts as a small integration test toggling the status of a crossword
+	// being solved.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	// Change the settings to only allow correct answers.
+	settings := Settings{OnlyAllowCorrectAnswers: true}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Apply a correct across answer.
+	response := Channel.PUT("/answer/1a", `"QANDA"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.True(t, state.AcrossCluesFilled[1])
+		assert.Equal(t, "Q", state.Cells[0][0])
+		assert.Equal(t, "A", state.Cells[0][1])
+		assert.Equal(t, "N", state.Cells[0][2])
+		assert.Equal(t, "D", state.Cells[0][3])
+		assert.Equal(t, "A", state.Cells[0][4])
+	})
+
+	// Apply a correct down answer.
+	response = Channel.PUT("/answer/1d", `"QTIP"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.True(t, state.DownCluesFilled[1])
+		assert.Equal(t, "Q", state.Cells[0][0])
+		assert.Equal(t, "T", state.Cells[1][0])
+		assert.Equal(t, "I", state.Cells[2][0])
+		assert.Equal(t, "P", state.Cells[3][0])
+	})
+
+	// Apply an incorrect across answer.
+	response = Channel.PUT("/answer/6a", `"FLOOR"`, router)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	// Apply an incorrect down answer.
+	response = Channel.PUT("/answer/11d", `"HEYA"`, router)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestRoute_UpdateAnswer_SolvedPuzzleStopsTimer(t *testing.T) {
+	// This acts as a small integration test ensuring that the timer stops
+	// counting once the crossword has been solved.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	// Setup a state that has the entire puzzle solved except for the last answer.
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	state.ApplyAnswer("1a", "Q AND A", false)
+	state.ApplyAnswer("6a", "ATTIC", false)
+	state.ApplyAnswer("11a", "HON", false)
+	state.ApplyAnswer("14a", "THIRD", false)
+	state.ApplyAnswer("15a", "LAID ASIDE", false)
+	state.ApplyAnswer("17a", "IM TOO OLD FOR THIS", false)
 	state.ApplyAnswer("19a", "PERU", false)
 	state.ApplyAnswer("20a", "LEAF", false)
 	state.ApplyAnswer("21a", "PEONS", false)
@@ -608,39 +1230,747 @@ func TestRoute_UpdateAnswer_SolvedPuzzleStopsTimer(t *testing.T) {
 	state.ApplyAnswer("64a", "NURSE", false)
 	require.NoError(t, SetState(conn, Channel.name, state))
 
-	// Apply the last answer, but wait a bit first to ensure that a non-zero
-	// amount of time has passed in the solve.
-	time.Sleep(10 * time.Millisecond)
+	// Apply the last answer, but wait a bit first to ensure that a non-zero
+	// amount of time has passed in the solve.
+	time.Sleep(10 * time.Millisecond)
+
+	response := Channel.PUT("/answer/65a", `"OZONE"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		require.Equal(t, model.StatusComplete, state.Status)
+		assert.Nil(t, state.LastStartTime)
+		assert.True(t, state.TotalSolveDuration.Seconds() > 0)
+	})
+
+	// The completed attempt should have been archived.
+	attempts := LoadArchive(t, pool)
+	require.Equal(t, 1, len(attempts))
+	assert.Equal(t, model.StatusComplete, attempts[0].Status)
+	assert.True(t, attempts[0].TotalSolveDuration.Seconds() > 0)
+}
+
+func TestRoute_UpdateAnswer_ArchiveError(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Setup a state that has every cell correct except for the last answer.
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	for y := range state.Cells {
+		copy(state.Cells[y], state.Puzzle.Cells[y])
+	}
+	require.NoError(t, state.ApplyAnswer("65a", "OZONX", false))
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	ForceErrorDuringArchiveSave(t, errors.New("forced error"))
+
+	response := Channel.PUT("/answer/65a", `"OZONE"`, router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestRoute_UpdateAnswer_Summary(t *testing.T) {
+	// This acts as a small integration test ensuring that a summary built from
+	// the submitted answers is published and archived once the crossword has
+	// been solved.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	settings := Settings{OnlyAllowCorrectAnswers: true}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	// Setup a state that has every cell correct except for the last two answers.
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	for y := range state.Cells {
+		copy(state.Cells[y], state.Puzzle.Cells[y])
+	}
+	require.NoError(t, state.ApplyAnswer("64a", "NURS.", false))
+	require.NoError(t, state.ApplyAnswer("65a", ".....", false))
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.PUT("/answer/64a?user=alice", `"NURSE"`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	response = Channel.PUT("/answer/65a?user=bob", `"OZONX"`, router)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	// Both answers should have been logged, but the rejected one shouldn't have
+	// changed the state.
+	published := Events(events, "state")
+	require.Len(t, published, 1)
+
+	answers, err := answerlog.Get(conn, "crossword", Channel.name)
+	require.NoError(t, err)
+	require.Len(t, answers, 2)
+	assert.True(t, answers[0].Applied)
+	assert.True(t, answers[0].Accepted)
+	assert.False(t, answers[1].Applied)
+	assert.False(t, answers[1].Accepted)
+
+	response = Channel.PUT("/answer/65a?user=alice", `"OZONE"`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	found := Events(events, "summary")
+	require.Len(t, found, 1)
+
+	summary := found[0].Payload.(model.Summary)
+	assert.Equal(t, 3, summary.AnswersSubmitted)
+	assert.Equal(t, 2, summary.AnswersAccepted)
+	assert.Equal(t, []model.Contributor{{User: "alice", Answers: 2}}, summary.TopContributors)
+	assert.Equal(t, "alice", summary.FirstBlood)
+	require.NotNil(t, summary.LastClue)
+	assert.Equal(t, "65a", summary.LastClue.Clue)
+	assert.Equal(t, "OZONE", summary.LastClue.Answer)
+	require.NotNil(t, summary.FastestClue)
+
+	// The summary should have been archived with the attempt.
+	attempts := LoadArchive(t, pool)
+	require.Equal(t, 1, len(attempts))
+	require.NotNil(t, attempts[0].Summary)
+	assert.Equal(t, 3, attempts[0].Summary.AnswersSubmitted)
+	assert.Equal(t, 2, attempts[0].Summary.AnswersAccepted)
+	assert.Equal(t, "alice", attempts[0].Summary.FirstBlood)
+}
+
+func TestRoute_UpdateAnswer_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected int
+	}{
+		{
+			name:     "empty answer",
+			json:     `""`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "too long answer",
+			json:     `"` + RandomString(1023) + `"`,
+			expected: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "malformed json",
+			json:     `"`,
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = model.StatusSolving
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			response := Channel.PUT("/answer/1a", test.json, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_UpdateAnswer_OutOfTime(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// The solve has run past its time limit but hasn't been marked as expired
+	// yet, answers should no longer be accepted.
+	start := time.Now().Add(-time.Hour)
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	state.TimeLimit = model.Duration{Duration: 15 * time.Minute}
+	state.LastStartTime = &start
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.PUT("/answer/1a", `"QANDA"`, router)
+	assert.Equal(t, http.StatusConflict, response.Code)
+}
+
+func TestRoute_UpdateAnswer_LoadSaveError(t *testing.T) {
+	tests := []struct {
+		name              string
+		loadSettingsError error
+		loadStateError    error
+		saveStateError    error
+		loadRosterError   error
+	}{
+		{
+			name:              "error loading settings",
+			loadSettingsError: errors.New("forced error"),
+		},
+		{
+			name:           "error loading state",
+			loadStateError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			saveStateError: errors.New("forced error"),
+		},
+		{
+			name:            "error loading team roster",
+			loadRosterError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = model.StatusSolving
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			ForceErrorDuringSettingsLoad(t, test.loadSettingsError)
+			ForceErrorDuringStateLoad(t, test.loadStateError)
+			ForceErrorDuringStateSave(t, test.saveStateError)
+			team.ForceErrorDuringRosterLoad(t, test.loadRosterError)
+
+			response := Channel.PUT("/answer/1a", `"QANDA"`, router)
+			assert.NotEqual(t, http.StatusOK, response.Code)
+		})
+	}
+}
+
+func TestRoute_CheckAnswer(t *testing.T) {
+	// This acts as a small integration test checking an answer that contains
+	// incorrect cells.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	require.NoError(t, SetSettings(conn, Channel.name, Settings{AllowChecks: true}))
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	require.NoError(t, state.ApplyAnswer("1a", "QNORA", false))
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.PUT("/check/1a", "", router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, []bool{false, true, true, true, false}, state.CellsIncorrect[0][:5])
+		assert.Equal(t, "N", state.Cells[0][1])
+		assert.True(t, state.AcrossCluesFilled[1])
+	})
+}
+
+func TestRoute_CheckAnswer_Error(t *testing.T) {
+	tests := []struct {
+		name              string
+		settings          Settings
+		status            model.Status
+		clue              string
+		settingsLoadError error
+		stateLoadError    error
+		stateSaveError    error
+		expected          int
+	}{
+		{
+			name:     "checks not allowed",
+			settings: Settings{AllowChecks: false},
+			status:   model.StatusSolving,
+			clue:     "1a",
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "not solving",
+			settings: Settings{AllowChecks: true},
+			status:   model.StatusPaused,
+			clue:     "1a",
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "invalid clue",
+			settings: Settings{AllowChecks: true},
+			status:   model.StatusSolving,
+			clue:     "2a",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:              "error loading settings",
+			settings:          Settings{AllowChecks: true},
+			status:            model.StatusSolving,
+			clue:              "1a",
+			settingsLoadError: errors.New("forced error"),
+			expected:          http.StatusInternalServerError,
+		},
+		{
+			name:           "error loading state",
+			settings:       Settings{AllowChecks: true},
+			status:         model.StatusSolving,
+			clue:           "1a",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusNotFound,
+		},
+		{
+			name:           "error saving state",
+			settings:       Settings{AllowChecks: true},
+			status:         model.StatusSolving,
+			clue:           "1a",
+			stateSaveError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			require.NoError(t, SetSettings(conn, Channel.name, test.settings))
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.status
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			ForceErrorDuringSettingsLoad(t, test.settingsLoadError)
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+			ForceErrorDuringStateSave(t, test.stateSaveError)
+
+			response := Channel.PUT(fmt.Sprintf("/check/%s", test.clue), "", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_PlaceClue(t *testing.T) {
+	// This acts as a small integration test placing a clue of a diagramless
+	// crossword and ensuring that the hidden structure isn't published.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	state := NewDiagramlessState(t)
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.PUT("/place/6a/1/7", "", router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.True(t, state.CluesPlaced["6a"])
+		assert.True(t, state.CellsDiscovered[0][5])
+		assert.True(t, state.CellsDiscovered[0][6])
+		assert.True(t, state.PublicPuzzle().CellBlocks[0][5])
+		assert.False(t, state.PublicPuzzle().CellBlocks[4][0])
+	})
+}
+
+func TestRoute_PlaceClue_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         model.Status
+		path           string
+		stateLoadError error
+		stateSaveError error
+		expected       int
+	}{
+		{
+			name:     "invalid row",
+			status:   model.StatusSolving,
+			path:     "/place/6a/x/7",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid column",
+			status:   model.StatusSolving,
+			path:     "/place/6a/1/x",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "not solving",
+			status:   model.StatusPaused,
+			path:     "/place/6a/1/7",
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "incorrect square",
+			status:   model.StatusSolving,
+			path:     "/place/6a/2/7",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid clue",
+			status:   model.StatusSolving,
+			path:     "/place/2a/1/2",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:           "error loading state",
+			status:         model.StatusSolving,
+			path:           "/place/6a/1/7",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusNotFound,
+		},
+		{
+			name:           "error saving state",
+			status:         model.StatusSolving,
+			path:           "/place/6a/1/7",
+			stateSaveError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewDiagramlessState(t)
+			state.Status = test.status
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+			ForceErrorDuringStateSave(t, test.stateSaveError)
+
+			response := Channel.PUT(test.path, "", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_RevealAnswer(t *testing.T) {
+	// This acts as a small integration test revealing an answer and a single
+	// square of an answer and ensuring the penalty is applied.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	settings := Settings{
+		AllowReveals:  true,
+		RevealPenalty: model.Duration{Duration: 10 * time.Second},
+	}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	require.NoError(t, state.ApplyAnswer("1a", "Q...A", false))
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Reveal all of 1a, only 3 of its cells were changed.
+	response := Channel.PUT("/reveal/1a", "", router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, []string{"Q", "A", "N", "D", "A"}, state.Cells[0][:5])
+		assert.Equal(t, []bool{true, true, true, true, true}, state.CellsRevealed[0][:5])
+		assert.True(t, state.AcrossCluesFilled[1])
+		assert.Equal(t, 30*time.Second, state.TotalSolveDuration.Duration)
+		assert.Equal(t, 30*time.Second, state.RevealPenalty.Duration)
+	})
+
+	// Reveal the second square of 6a.
+	response = Channel.PUT("/reveal/6a/2", "", router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, []string{"", "T", "", "", ""}, state.Cells[0][6:11])
+		assert.Equal(t, []bool{false, true, false, false, false}, state.CellsRevealed[0][6:11])
+		assert.False(t, state.AcrossCluesFilled[6])
+		assert.Equal(t, 40*time.Second, state.TotalSolveDuration.Duration)
+		assert.Equal(t, 40*time.Second, state.RevealPenalty.Duration)
+	})
+}
+
+func TestRoute_RevealAnswer_Error(t *testing.T) {
+	tests := []struct {
+		name              string
+		settings          Settings
+		status            model.Status
+		url               string
+		settingsLoadError error
+		stateLoadError    error
+		stateSaveError    error
+		expected          int
+	}{
+		{
+			name:     "reveals not allowed",
+			settings: Settings{AllowReveals: false},
+			status:   model.StatusSolving,
+			url:      "/reveal/1a",
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "not solving",
+			settings: Settings{AllowReveals: true},
+			status:   model.StatusSelected,
+			url:      "/reveal/1a",
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "invalid clue",
+			settings: Settings{AllowReveals: true},
+			status:   model.StatusSolving,
+			url:      "/reveal/2a",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "malformed square",
+			settings: Settings{AllowReveals: true},
+			status:   model.StatusSolving,
+			url:      "/reveal/1a/x",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "square out of range",
+			settings: Settings{AllowReveals: true},
+			status:   model.StatusSolving,
+			url:      "/reveal/1a/6",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:              "error loading settings",
+			settings:          Settings{AllowReveals: true},
+			status:            model.StatusSolving,
+			url:               "/reveal/1a",
+			settingsLoadError: errors.New("forced error"),
+			expected:          http.StatusInternalServerError,
+		},
+		{
+			name:           "error loading state",
+			settings:       Settings{AllowReveals: true},
+			status:         model.StatusSolving,
+			url:            "/reveal/1a",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusNotFound,
+		},
+		{
+			name:           "error saving state",
+			settings:       Settings{AllowReveals: true},
+			status:         model.StatusSolving,
+			url:            "/reveal/1a",
+			stateSaveError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			require.NoError(t, SetSettings(conn, Channel.name, test.settings))
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.status
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			ForceErrorDuringSettingsLoad(t, test.settingsLoadError)
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+			ForceErrorDuringStateSave(t, test.stateSaveError)
+
+			response := Channel.PUT(test.url, "", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_ShowClue(t *testing.T) {
+	// This acts as a small integration test requesting clues to be shown and
+	// making sure events are properly emitted.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	// Force a specific puzzle to be loaded so we don't make a network call.
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Request showing an across clue.
+	response := Channel.GET("/show/1a", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	VerifyShowClue(t, events, func(clue string) {
+		assert.Equal(t, "1a", clue)
+	})
+
+	// Request showing a down clue.
+	response = Channel.GET("/show/16d", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	VerifyShowClue(t, events, func(clue string) {
+		assert.Equal(t, "16d", clue)
+	})
+
+	// Request showing a malformed clue.
+	response = Channel.GET("/show/1x", router)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	// Request showing a properly formed, but non-existent clue.  This doesn't
+	// fail because it doesn't mutate the state of the puzzle in any way.
+	response = Channel.GET("/show/999a", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	VerifyShowClue(t, events, func(clue string) {
+		assert.Equal(t, "999a", clue)
+	})
+}
+
+func TestRoute_GetCurrentState(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/state", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	var actual State
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, state.Status, actual.Status)
+	assert.Nil(t, actual.Puzzle.Cells) // Solution should never be sent
+
+	// Asking again with the ETag shouldn't return the state since it hasn't
+	// changed.
+	response = ConditionalGET(path.Join("/crossword", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Empty(t, response.Body.String())
+
+	// Once the state changes it should be returned again with a new ETag.
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response = ConditionalGET(path.Join("/crossword", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.NotEqual(t, etag, response.Header().Get("ETag"))
+}
+
+func TestRoute_GetCurrentState_TimeLimit(t *testing.T) {
+	// The time remaining in a timed solve changes on every request, but the
+	// state's ETag should only change when the state is saved.
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Now()
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &now
+	state.TimeLimit = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/state", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+
+	time.Sleep(10 * time.Millisecond)
+
+	response = ConditionalGET(path.Join("/crossword", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Equal(t, etag, response.Header().Get("ETag"))
+}
+
+func TestRoute_GetCurrentState_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			if test.state != nil {
+				require.NoError(t, SetState(conn, Channel.name, *test.state))
+			}
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/state", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetCurrentSettings(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	settings, err := GetSettings(conn, Channel.name)
+	require.NoError(t, err)
+
+	response := Channel.GET("/settings", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	var actual Settings
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, settings, actual)
+
+	// Asking again with the ETag shouldn't return the settings since they haven't
+	// changed.
+	response = ConditionalGET(path.Join("/crossword", Channel.name, "settings"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+
+	// Once the settings change they should be returned again with a new ETag.
+	settings.TimeLimit = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	response = ConditionalGET(path.Join("/crossword", Channel.name, "settings"), etag, router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.NotEqual(t, etag, response.Header().Get("ETag"))
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, settings, actual)
+}
+
+func TestRoute_GetCurrentSettings_Error(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+	ForceErrorDuringSettingsLoad(t, errors.New("forced error"))
+
+	response := Channel.GET("/settings", router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestRoute_GetSolution(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusGivenUp
+	state.LastStartTime = nil
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/solution", router)
+	require.Equal(t, http.StatusOK, response.Code)
 
-	response := Channel.PUT("/answer/65a", `"OZONE"`, router)
-	assert.Equal(t, http.StatusOK, response.Code)
-	VerifyState(t, pool, events, func(state State) {
-		require.Equal(t, model.StatusComplete, state.Status)
-		assert.Nil(t, state.LastStartTime)
-		assert.True(t, state.TotalSolveDuration.Seconds() > 0)
-	})
+	var puzzle Puzzle
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &puzzle))
+	assert.Equal(t, state.Puzzle.Cells, puzzle.Cells)
 }
 
-func TestRoute_UpdateAnswer_Error(t *testing.T) {
+func TestRoute_GetSolution_Error(t *testing.T) {
 	tests := []struct {
-		name     string
-		json     string
-		expected int
+		name           string
+		state          *State
+		stateLoadError error
+		expected       int
 	}{
 		{
-			name:     "empty answer",
-			json:     `""`,
-			expected: http.StatusBadRequest,
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
 		},
 		{
-			name:     "too long answer",
-			json:     `"` + RandomString(1023) + `"`,
-			expected: http.StatusRequestEntityTooLarge,
+			name:     "solve in progress",
+			expected: http.StatusConflict,
 		},
 		{
-			name:     "malformed json",
-			json:     `"`,
-			expected: http.StatusBadRequest,
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
 		},
 	}
 
@@ -651,32 +1981,99 @@ func TestRoute_UpdateAnswer_Error(t *testing.T) {
 
 			state := NewState(t, "xwordinfo-nyt-20181231.json")
 			state.Status = model.StatusSolving
+			if test.state != nil {
+				state = *test.state
+			}
 			require.NoError(t, SetState(conn, Channel.name, state))
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
 
-			response := Channel.PUT("/answer/1a", test.json, router)
+			response := Channel.GET("/solution", router)
 			assert.Equal(t, test.expected, response.Code)
 		})
 	}
 }
 
-func TestRoute_UpdateAnswer_LoadSaveError(t *testing.T) {
+func TestRoute_GetShareCard(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Archive two attempts, the card of the most recent one should be returned.
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusGivenUp
+	state.LastStartTime = nil
+	state.TotalSolveDuration = model.Duration{Duration: 5 * time.Minute}
+	require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+
+	state.Status = model.StatusComplete
+	state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+
+	response := Channel.GET("/share", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.Equal(t, state.ShareCard(nil), response.Body.String())
+	assert.True(t, strings.HasPrefix(response.Body.String(), "Crossword"))
+	assert.Contains(t, response.Body.String(), "✅ Solved in 10m0s")
+}
+
+func TestRoute_GetShareCard_Error(t *testing.T) {
 	tests := []struct {
-		name              string
-		loadSettingsError error
-		loadStateError    error
-		saveStateError    error
+		name             string
+		archive          bool
+		archiveLoadError error
+		expected         int
 	}{
 		{
-			name:              "error loading settings",
-			loadSettingsError: errors.New("forced error"),
+			name:     "no archived attempts",
+			expected: http.StatusNotFound,
 		},
 		{
-			name:           "error loading state",
-			loadStateError: errors.New("forced error"),
+			name:             "error loading archive",
+			archive:          true,
+			archiveLoadError: errors.New("forced error"),
+			expected:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			if test.archive {
+				state := NewState(t, "xwordinfo-nyt-20181231.json")
+				state.Status = model.StatusComplete
+				require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+			}
+			ForceErrorDuringArchiveLoad(t, test.archiveLoadError)
+
+			response := Channel.GET("/share", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		letters     bool
+	}{
+		{
+			name:        "svg",
+			contentType: "image/svg+xml",
+			letters:     true,
 		},
 		{
-			name:           "error saving state",
-			saveStateError: errors.New("forced error"),
+			name:        "svg without letters",
+			query:       "?letters=false",
+			contentType: "image/svg+xml",
+		},
+		{
+			name:        "png",
+			query:       "?format=png",
+			contentType: "image/png",
 		},
 	}
 
@@ -686,55 +2083,118 @@ func TestRoute_UpdateAnswer_LoadSaveError(t *testing.T) {
 			conn := NewRedisConnection(t, pool)
 
 			state := NewState(t, "xwordinfo-nyt-20181231.json")
-			state.Status = model.StatusSolving
+			require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
 			require.NoError(t, SetState(conn, Channel.name, state))
 
-			ForceErrorDuringSettingsLoad(t, test.loadSettingsError)
-			ForceErrorDuringStateLoad(t, test.loadStateError)
-			ForceErrorDuringStateSave(t, test.saveStateError)
+			response := Channel.GET("/snapshot"+test.query, router)
+			require.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, test.contentType, response.Header().Get("Content-Type"))
 
-			response := Channel.PUT("/answer/1a", `"QANDA"`, router)
-			assert.NotEqual(t, http.StatusOK, response.Code)
+			if test.contentType == "image/svg+xml" {
+				assert.Equal(t, string(state.Snapshot(test.letters).SVG()), response.Body.String())
+			}
 		})
 	}
 }
 
-func TestRoute_ShowClue(t *testing.T) {
-	// This acts as a small integration test requesting clues to be shown and
-	// making sure events are properly emitted.
-	router, pool, registry := NewTestRouter(t)
+func TestRoute_GetSnapshot_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "unsupported format",
+			query:    "?format=gif",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			if test.state != nil {
+				state = *test.state
+			}
+			require.NoError(t, SetState(conn, Channel.name, state))
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/snapshot"+test.query, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetPDF(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
 	conn := NewRedisConnection(t, pool)
-	events := NewEventSubscription(t, registry, Channel.name)
 
-	// Force a specific puzzle to be loaded so we don't make a network call.
 	state := NewState(t, "xwordinfo-nyt-20181231.json")
 	require.NoError(t, SetState(conn, Channel.name, state))
 
-	// Request showing an across clue.
-	response := Channel.GET("/show/1a", router)
+	response := Channel.GET("/pdf?filled=true", router)
 	require.Equal(t, http.StatusOK, response.Code)
-	VerifyShowClue(t, events, func(clue string) {
-		assert.Equal(t, "1a", clue)
-	})
+	assert.Equal(t, "application/pdf", response.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="crossword-2018-12-31.pdf"`, response.Header().Get("Content-Disposition"))
+	assert.Equal(t, state.PDF(true).Bytes(), response.Body.Bytes())
+}
 
-	// Request showing a down clue.
-	response = Channel.GET("/show/16d", router)
-	require.Equal(t, http.StatusOK, response.Code)
-	VerifyShowClue(t, events, func(clue string) {
-		assert.Equal(t, "16d", clue)
-	})
+func TestRoute_GetPDF_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "invalid filled parameter",
+			query:    "?filled=maybe",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
 
-	// Request showing a malformed clue.
-	response = Channel.GET("/show/1x", router)
-	require.Equal(t, http.StatusBadRequest, response.Code)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
 
-	// Request showing a properly formed, but non-existent clue.  This doesn't
-	// fail because it doesn't mutate the state of the puzzle in any way.
-	response = Channel.GET("/show/999a", router)
-	require.Equal(t, http.StatusOK, response.Code)
-	VerifyShowClue(t, events, func(clue string) {
-		assert.Equal(t, "999a", clue)
-	})
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			if test.state != nil {
+				state = *test.state
+			}
+			require.NoError(t, SetState(conn, Channel.name, state))
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/pdf"+test.query, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
 }
 
 func TestRoute_GetEvents(t *testing.T) {
@@ -938,6 +2398,7 @@ func TestRoute_GetAvailableDates(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			router, _, _ := NewTestRouter(t)
+			ForcePuzSourcesToBeRegistered(t, "puz-sources.json")
 
 			response := GET("/crossword/dates", router)
 			assert.Equal(t, http.StatusOK, response.Code)
@@ -954,6 +2415,112 @@ func TestRoute_GetAvailableDates(t *testing.T) {
 	}
 }
 
+func TestRoute_GetAvailableDates_RegisteredSource(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+
+	dates := []time.Time{
+		time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC),
+	}
+	ForceSourceToBeRegistered(t, TestSource{id: "dated", key: "dated_date", dates: dates})
+	ForceSourceToBeRegistered(t, TestSource{id: "undated", key: "undated_key"})
+
+	response := GET("/crossword/dates", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var available map[string][]string
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &available))
+	assert.Equal(t, []string{"2020-01-01", "2020-01-02"}, available["dated"])
+	assert.Contains(t, available, "new_york_times")
+	assert.NotContains(t, available, "undated")
+	assert.NotContains(t, available, "puz_file_url")
+}
+
+func TestRoute_GetAvailableDates_Library(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+
+	dir := NewTestLibraryDir(t)
+	CopyTestFile(t, filepath.Join("puz", "nyt-20081006-nonsquare.puz"), filepath.Join(dir, "nyt", "2008-10-06.puz"))
+	CopyTestFile(t, filepath.Join("ipuz", "example-20200102.ipuz"), filepath.Join(dir, "example.ipuz"))
+
+	library := NewLibrary(dir)
+	require.NoError(t, library.Refresh())
+	ForceSourceToBeRegistered(t, library)
+
+	response := GET("/crossword/dates", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var catalog struct {
+		Library      []LibraryEntry `json:"library"`
+		NewYorkTimes []string       `json:"new_york_times"`
+	}
+	require.NoError(t, render.DecodeJSON(response.Result().Body, &catalog))
+	assert.NotEmpty(t, catalog.NewYorkTimes)
+
+	var ids []string
+	for _, entry := range catalog.Library {
+		ids = append(ids, entry.ID)
+	}
+	assert.Equal(t, []string{LibraryID("example.ipuz"), LibraryID("nyt/2008-10-06.puz")}, ids)
+}
+
+func TestRoute_GetLibrary(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+
+	dir := NewTestLibraryDir(t)
+	CopyTestFile(t, filepath.Join("puz", "nyt-20081006-nonsquare.puz"), filepath.Join(dir, "nyt", "2008-10-06.puz"))
+	CopyTestFile(t, filepath.Join("ipuz", "example-20200102.ipuz"), filepath.Join(dir, "example.ipuz"))
+
+	library := NewLibrary(dir)
+	require.NoError(t, library.Refresh())
+	ForceSourceToBeRegistered(t, library)
+
+	tests := []struct {
+		name      string
+		query     string
+		filenames []string
+	}{
+		{
+			name:      "all entries",
+			query:     "",
+			filenames: []string{"example.ipuz", "nyt/2008-10-06.puz"},
+		},
+		{
+			name:      "search",
+			query:     "?q=blindauer",
+			filenames: []string{"nyt/2008-10-06.puz"},
+		},
+		{
+			name:      "no matches",
+			query:     "?q=sunday",
+			filenames: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := GET("/crossword/library"+test.query, router)
+			require.Equal(t, http.StatusOK, response.Code)
+
+			var entries []LibraryEntry
+			require.NoError(t, render.DecodeJSON(response.Result().Body, &entries))
+
+			filenames := make([]string, 0)
+			for _, entry := range entries {
+				filenames = append(filenames, entry.Filename)
+			}
+			assert.Equal(t, test.filenames, filenames)
+		})
+	}
+}
+
+func TestRoute_GetLibrary_NotConfigured(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+
+	response := GET("/crossword/library", router)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
 // VerifySettings performs test specific verifications on the settings objects
 // in both event and database forms.
 func VerifySettings(t *testing.T, pool *redis.Pool, events <-chan pubsub.Event, fn func(s Settings)) {
@@ -986,7 +2553,11 @@ func VerifyState(t *testing.T, pool *redis.Pool, events <-chan pubsub.Event, fn
 	require.Equal(t, 1, len(found), "incorrect number of events found")
 
 	state := found[0].Payload.(State)
-	assert.Nil(t, state.Puzzle.Cells) // Events should never have the solution
+	if state.Status.IsFinished() {
+		assert.NotNil(t, state.Puzzle.Cells) // Finished solves include the solution
+	} else {
+		assert.Nil(t, state.Puzzle.Cells) // Events should never have the solution
+	}
 	fn(state)
 
 	// Next check that the database has a valid state object
@@ -997,6 +2568,25 @@ func VerifyState(t *testing.T, pool *redis.Pool, events <-chan pubsub.Event, fn
 	fn(state)
 }
 
+// LoadArchive reads all of the attempts that have been archived for the test
+// channel.
+func LoadArchive(t *testing.T, pool *redis.Pool) []model.Attempt {
+	t.Helper()
+
+	conn := NewRedisConnection(t, pool)
+	bss, err := redis.ByteSlices(conn.Do("LRANGE", ArchiveKey(Channel.name), 0, -1))
+	require.NoError(t, err)
+
+	var attempts []model.Attempt
+	for _, bs := range bss {
+		var attempt model.Attempt
+		require.NoError(t, json.Unmarshal(bs, &attempt))
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts
+}
+
 // VerifyShowClue performs common verifications for show clue events.
 func VerifyShowClue(t *testing.T, events <-chan pubsub.Event, fn func(clue string)) {
 	t.Helper()
@@ -1035,6 +2625,16 @@ func GET(url string, router chi.Router) *httptest.ResponseRecorder {
 	return recorder
 }
 
+// ConditionalGET performs a GET request that includes an If-None-Match header
+// with the provided ETag.
+func ConditionalGET(url, etag string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	request.Header.Set("If-None-Match", etag)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
 // ChannelClient is a client that makes requests against the URL of a particular
 // user's channel.
 type ChannelClient struct {
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
-	"github.com/gomodule/redigo/redis"
 )
 
 // Settings represents the optional behaviors that can be enabled or disabled
@@ -23,6 +22,29 @@ type Settings struct {
 
 	// Whether or not notes field should shown.
 	ShowNotes bool `json:"show_notes"`
+
+	// Whether or not chat is permitted to check answers for correctness.
+	AllowChecks bool `json:"allow_checks"`
+
+	// Whether or not chat is permitted to reveal answers.
+	AllowReveals bool `json:"allow_reveals"`
+
+	// The amount of time that is added to the solve for each cell that is
+	// revealed.
+	RevealPenalty model.Duration `json:"reveal_penalty"`
+
+	// How long the solve can go without any progress before a hint is given.  A
+	// value of zero disables hints.
+	HintInterval model.Duration `json:"hint_interval"`
+
+	// How long the solve can go without any answers being submitted before it is
+	// automatically paused.  A value of zero disables automatic pausing.
+	IdleTimeout model.Duration `json:"idle_timeout"`
+
+	// How long the solve may take before it runs out of time.  Changes only
+	// apply to solves that are started afterwards.  A value of zero disables the
+	// time limit.
+	TimeLimit model.Duration `json:"time_limit"`
 }
 
 // ClueVisibility is an enumeration representing which clues should be shown.
@@ -100,7 +122,7 @@ func SettingsKey(name string) string {
 
 // GetSettings will load settings for the provided channel name.  If the
 // settings can't be properly loaded then an error will be returned.
-func GetSettings(conn redis.Conn, channel string) (Settings, error) {
+func GetSettings(conn db.Connection, channel string) (Settings, error) {
 	var settings Settings
 
 	if testSettingsLoadError != nil {
@@ -113,7 +135,7 @@ func GetSettings(conn redis.Conn, channel string) (Settings, error) {
 
 // SetSettings will write settings for the provided channel name.  If the
 // settings can't be properly written then an error will be returned.
-func SetSettings(conn redis.Conn, channel string, settings Settings) error {
+func SetSettings(conn db.Connection, channel string, settings Settings) error {
 	if testSettingsSaveError != nil {
 		return testSettingsSaveError
 	}
@@ -0,0 +1,53 @@
+package crossword
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/snapshot"
+	"math"
+	"strconv"
+)
+
+// The size in pixels of each cell of a crossword snapshot.
+const snapshotCellSize = 36
+
+// Snapshot draws the state's grid as an image.  The image includes the blocks,
+// circles, shades and clue numbers of the puzzle as well as the letters that
+// have been filled in, unless showLetters is false.
+func (s *State) Snapshot(showLetters bool) *snapshot.Image {
+	const size = snapshotCellSize
+	img := snapshot.New(s.Puzzle.Cols*size+1, s.Puzzle.Rows*size+1)
+
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			left, top := float64(x*size), float64(y*size)
+
+			fill := snapshot.White
+			switch {
+			case s.Puzzle.CellBlocks[y][x]:
+				fill = snapshot.Black
+			case s.Puzzle.CellShades != nil && s.Puzzle.CellShades[y][x]:
+				fill = snapshot.LightGray
+			}
+			img.Rect(left, top, size, size, fill, snapshot.Black)
+
+			if s.Puzzle.CellBlocks[y][x] {
+				continue
+			}
+
+			if s.Puzzle.CellCircles != nil && s.Puzzle.CellCircles[y][x] {
+				img.Circle(left+size/2, top+size/2, size/2-1, snapshot.Gray)
+			}
+
+			if number := s.Puzzle.CellClueNumbers[y][x]; number != 0 {
+				img.Text(left+2, top+2, 7, snapshot.AnchorTopLeft, strconv.Itoa(number), snapshot.Black)
+			}
+
+			if letters := s.Cells[y][x]; showLetters && letters != "" {
+				// Rebus entries shrink so that all of their letters fit in the cell.
+				height := math.Min(21, float64(size-4)*7/float64(6*len(letters)))
+				img.Text(left+size/2, top+size/2+3, height, snapshot.AnchorCenter, letters, snapshot.Black)
+			}
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,40 @@
+package crossword
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestState_Snapshot(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+
+	img := state.Snapshot(true)
+	assert.Equal(t, state.Puzzle.Cols*snapshotCellSize+1, img.Width)
+	assert.Equal(t, state.Puzzle.Rows*snapshotCellSize+1, img.Height)
+
+	svg := string(img.SVG())
+	assert.Contains(t, svg, `<rect x="180" y="0" width="36" height="36" fill="#000000" stroke="#000000"/>`)
+	assert.Contains(t, svg, `dominant-baseline="hanging" fill="#000000">1</text>`)
+	assert.Contains(t, svg, `fill="#000000">Q</text>`)
+
+	// Hiding the letters should keep the clue numbers but drop the answers.
+	svg = string(state.Snapshot(false).SVG())
+	assert.Contains(t, svg, `dominant-baseline="hanging" fill="#000000">1</text>`)
+	assert.False(t, strings.Contains(svg, `>Q</text>`))
+
+	_, err := img.PNG()
+	assert.NoError(t, err)
+}
+
+func TestState_Snapshot_CirclesAndShades(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Puzzle.CellCircles[0][0] = true
+	state.Puzzle.CellShades[0][1] = true
+
+	svg := string(state.Snapshot(true).SVG())
+	assert.Contains(t, svg, `<circle cx="18" cy="18" r="17" fill="none" stroke="#808080"/>`)
+	assert.Contains(t, svg, `<rect x="36" y="0" width="36" height="36" fill="#dddddd" stroke="#000000"/>`)
+}
@@ -0,0 +1,73 @@
+package crossword
+
+import (
+	"fmt"
+	"time"
+)
+
+// Source is a place that crossword puzzles can be loaded from.  Each source
+// is identified by a key in the payload used to select a puzzle, the value of
+// that key describes which of the source's puzzles to load.
+type Source interface {
+	// ID returns the unique identifier of the source.  Dated sources have their
+	// available dates listed under this identifier.
+	ID() string
+
+	// Name returns a human readable name for the source.
+	Name() string
+
+	// Key returns the name of the field in a puzzle selection payload whose
+	// value identifies a puzzle from this source.
+	Key() string
+
+	// AvailableDates returns the dates that puzzles are available for.  Sources
+	// that aren't organized by date return nil.
+	AvailableDates() []time.Time
+
+	// Load loads the puzzle identified by the key from the source.  If the puzzle
+	// cannot be loaded or parsed then an error is returned.
+	Load(key string) (*Puzzle, error)
+}
+
+// The registered sources in the order that they were registered.
+var sources []Source
+
+// RegisterSource adds a source to the set of sources that puzzles can be
+// selected from.  Registering two sources with the same identifier or key is
+// a programming error and will panic.
+func RegisterSource(source Source) {
+	for _, existing := range sources {
+		if existing.ID() == source.ID() || existing.Key() == source.Key() {
+			panic(fmt.Sprintf("crossword source %s registered twice", source.ID()))
+		}
+	}
+
+	sources = append(sources, source)
+}
+
+// Sources returns the registered sources in the order that they were
+// registered.
+func Sources() []Source {
+	return sources
+}
+
+// GetSource returns the registered source with the given identifier.  If no
+// such source has been registered then nil is returned.
+func GetSource(id string) Source {
+	for _, source := range sources {
+		if source.ID() == id {
+			return source
+		}
+	}
+
+	return nil
+}
+
+// The sources that are always available.  Sources of .puz files that are
+// published on a schedule are described in a config file and registered when
+// the server starts, see LoadPuzSources.
+func init() {
+	RegisterSource(NewYorkTimesSource{})
+	RegisterSource(PuzFileURLSource{})
+	RegisterSource(PuzFileBytesSource{})
+}
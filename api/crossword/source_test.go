@@ -0,0 +1,54 @@
+package crossword
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSources(t *testing.T) {
+	var ids []string
+	for _, source := range Sources() {
+		ids = append(ids, source.ID())
+	}
+
+	expected := []string{"new_york_times", "puz_file_url", "puz_file_bytes"}
+	assert.Equal(t, expected, ids)
+}
+
+func TestGetSource(t *testing.T) {
+	assert.Equal(t, NewYorkTimesSource{}, GetSource("new_york_times"))
+	assert.Equal(t, PuzFileBytesSource{}, GetSource("puz_file_bytes"))
+	assert.Nil(t, GetSource("unknown"))
+}
+
+func TestRegisterSource_Duplicate(t *testing.T) {
+	original := sources
+	defer func() { sources = original }()
+
+	assert.Panics(t, func() { RegisterSource(NewYorkTimesSource{}) })
+	assert.Panics(t, func() {
+		RegisterSource(TestSource{id: "other", key: NewYorkTimesSource{}.Key()})
+	})
+
+	RegisterSource(TestSource{id: "other", key: "other_key"})
+	require.NotNil(t, GetSource("other"))
+}
+
+// TestSource is a puzzle source that returns a fixed puzzle and set of dates.
+type TestSource struct {
+	id     string
+	key    string
+	dates  []time.Time
+	puzzle *Puzzle
+}
+
+func (s TestSource) ID() string                  { return s.id }
+func (s TestSource) Name() string                { return "test source " + s.id }
+func (s TestSource) Key() string                 { return s.key }
+func (s TestSource) AvailableDates() []time.Time { return s.dates }
+
+func (s TestSource) Load(string) (*Puzzle, error) {
+	return s.puzzle, nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/team"
+	"math/rand"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,6 +25,18 @@ type State struct {
 	// The currently filled in cells of the crossword.
 	Cells [][]string `json:"cells"`
 
+	// Whether or not a cell has been checked and found to contain an incorrect
+	// value.  A cell stops being marked as incorrect as soon as a new value is
+	// written into it.  Like cells the 2D list is first indexed by the row
+	// coordinate of the cell and then by the column coordinate.
+	CellsIncorrect [][]bool `json:"cells_incorrect"`
+
+	// Whether or not a cell has had its value revealed.  Revealed cells always
+	// contain the correct value and cannot be changed.  Like cells the 2D list
+	// is first indexed by the row coordinate of the cell and then by the column
+	// coordinate.
+	CellsRevealed [][]bool `json:"cells_revealed"`
+
 	// Whether or not an across clue with a given clue number has had an answer
 	// filled in.
 	AcrossCluesFilled map[int]bool `json:"across_clues_filled"`
@@ -38,48 +52,120 @@ type State struct {
 
 	// The total time spent on solving the puzzle up to the last start time.
 	TotalSolveDuration model.Duration `json:"total_solve_duration"`
+
+	// The portion of the total solve duration that was added as a penalty for
+	// revealing cells.
+	RevealPenalty model.Duration `json:"reveal_penalty"`
+
+	// The time that a correct value was last added to a cell of the puzzle.  If
+	// no correct values have been added yet then this will be nil.
+	LastProgressTime *time.Time `json:"last_progress_time,omitempty"`
+
+	// The time limit for the solve, copied from the settings when the solve is
+	// started.  A value of zero means that the solve doesn't have a time limit.
+	TimeLimit model.Duration `json:"time_limit"`
+
+	// The amount of time remaining before the time limit is reached as of when
+	// the state was published.  This is only populated for published states of
+	// solves with a time limit, it is never stored.
+	TimeRemaining *model.Duration `json:"time_remaining,omitempty"`
+
+	// The team that has been credited with each correctly answered clue when
+	// chatters in the channel have joined teams, indexed by the clue identifier
+	// (e.g. 1a).  Clues answered by a chatter that isn't on a team are credited
+	// to the empty team name.
+	ClueTeams map[string]string `json:"clue_teams,omitempty"`
+
+	// The number of clues credited to each team, indexed by the team name.  This
+	// is only present when chatters in the channel have joined teams.
+	Scores map[string]int `json:"scores,omitempty"`
+
+	// Whether or not the structure of each cell (whether it's a block and its
+	// clue number) has been discovered during a diagramless solve.  Like cells
+	// the 2D list is first indexed by the row coordinate of the cell and then by
+	// the column coordinate.  This is only present for diagramless puzzles.
+	CellsDiscovered [][]bool `json:"cells_discovered,omitempty"`
+
+	// Whether or not the starting square of a clue's answer has been placed
+	// during a diagramless solve, indexed by the clue identifier (e.g. 1a).
+	// Answers can only be given for clues that have been placed.  This is only
+	// present for diagramless puzzles.
+	CluesPlaced map[string]bool `json:"clues_placed,omitempty"`
 }
 
 // ApplyAnswer applies an answer for a clue to the state.  If the clue cannot
 // be identified or the answer doesn't fit property (too short or too long) then
-// an error will be returned.  If the onlyCorrect parameter is true then only
-// correct cells will be permitted and an error is returned if any part of the
-// answer is incorrect or would remove a correct cell.
+// an error will be returned.  When the clue is linked to other clues an answer
+// that fits the combined length of the linked answers is spread across all of
+// them.  If the onlyCorrect parameter is true then only correct cells will be
+// permitted and an error is returned if any part of the answer is incorrect or
+// would remove a correct cell.
 func (s *State) ApplyAnswer(clue string, answer string, onlyCorrect bool) error {
-	num, direction, err := ParseClue(clue)
+	cells, err := ParseAnswer(answer)
 	if err != nil {
 		return err
 	}
 
-	cells, err := ParseAnswer(answer)
+	xs, ys, err := s.getAnswerCells(clue)
 	if err != nil {
 		return err
 	}
 
-	minX, minY, maxX, maxY, err := s.Puzzle.GetAnswerCoordinates(num, direction)
-	if err != nil {
-		return err
+	// An answer that doesn't fit the clue on its own may fit the clues that it's
+	// linked with.
+	clues := []string{clue}
+	if len(cells) != len(xs) {
+		if group := s.Puzzle.GetLinkedClues(clue); group != nil {
+			lxs, lys, err := s.getLinkedAnswerCells(group)
+			if err != nil {
+				return err
+			}
+
+			if len(cells) == len(lxs) {
+				xs, ys = lxs, lys
+				clues = group
+			}
+		}
+	}
+
+	// In a diagramless solve the answer can't be given until the starting square
+	// of each clue it fills in has been placed.
+	for _, c := range clues {
+		if !s.IsPlaced(c) {
+			return fmt.Errorf("unable to apply answer %s to %s, %s hasn't been placed", answer, clue, c)
+		}
 	}
 
 	// Check to see if our cell values are compatible with the size of the answer.
-	if len(cells) != (maxX-minX)+(maxY-minY)+1 {
+	if len(cells) != len(xs) {
 		return fmt.Errorf("unable to apply answer %s to %s, incompatible sizes", answer, clue)
 	}
 
-	// Determine the way to iterate through the grid.
-	var dx, dy int
-	if direction == "a" {
-		dx = 1
-	} else {
-		dy = 1
+	// Linked answers may cross each other, when they do the answer must agree
+	// with itself.
+	for i := range xs {
+		for j := 0; j < i; j++ {
+			if xs[i] == xs[j] && ys[i] == ys[j] && cells[i] != cells[j] {
+				return fmt.Errorf("unable to apply answer %s to %s, incompatible crossing", answer, clue)
+			}
+		}
+	}
+
+	// Revealed cells are locked, they can't be changed to a different value.
+	s.ensureCellMarks()
+	for i := range xs {
+		x, y := xs[i], ys[i]
+		if s.CellsRevealed[y][x] && cells[i] != s.Cells[y][x] {
+			return fmt.Errorf("unable to apply answer %s to %s, changes revealed value", answer, clue)
+		}
 	}
 
 	// Check to see if the answer is correct when required.
 	if onlyCorrect {
-		for x, y := minX, minY; x <= maxX && y <= maxY; x, y = x+dx, y+dy {
+		for i := range xs {
+			x, y := xs[i], ys[i]
 			existing := s.Cells[y][x]
-			expected := s.Puzzle.Cells[y][x]
-			desired := cells[y-minY+x-minX]
+			desired := cells[i]
 
 			// We can't change a correct value to an incorrect or empty one.
 			if existing != "" && desired != existing {
@@ -87,15 +173,27 @@ func (s *State) ApplyAnswer(clue string, answer string, onlyCorrect bool) error
 			}
 
 			// We can't write an incorrect value into a cell.
-			if desired != "" && desired != expected {
+			if desired != "" && !s.Puzzle.IsCorrect(x, y, desired) {
 				return fmt.Errorf("unable to apply answer %s to %s, incorrect", answer, clue)
 			}
 		}
 	}
 
-	// Write the cells of our answer.
-	for x, y := minX, minY; x <= maxX && y <= maxY; x, y = x+dx, y+dy {
-		s.Cells[y][x] = cells[y-minY+x-minX]
+	// Write the cells of our answer.  Any cell that receives a new value is no
+	// longer known to be incorrect.
+	for i := range xs {
+		x, y := xs[i], ys[i]
+		if s.Cells[y][x] != cells[i] {
+			s.CellsIncorrect[y][x] = false
+		}
+		s.Cells[y][x] = cells[i]
+	}
+
+	// The extent of each answer that was filled in is now known.
+	for _, c := range clues {
+		if err := s.discoverAnswer(c); err != nil {
+			return err
+		}
 	}
 
 	// Now that we've filled in an answer we may have completed one or more clues.
@@ -108,15 +206,7 @@ func (s *State) ApplyAnswer(clue string, answer string, onlyCorrect bool) error
 
 	// Also determine if the puzzle is finished with all correct answers and
 	// update the Status if so.
-	complete := true
-	for y := 0; y < s.Puzzle.Rows; y++ {
-		for x := 0; x < s.Puzzle.Cols; x++ {
-			if s.Cells[y][x] != s.Puzzle.Cells[y][x] {
-				complete = false
-			}
-		}
-	}
-	if complete {
+	if s.isComplete() {
 		s.Status = model.StatusComplete
 	}
 
@@ -131,10 +221,12 @@ func (s *State) ApplyAnswer(clue string, answer string, onlyCorrect bool) error
 // and DownCluesFilled fields will also be updated to indicate any clues that
 // are now unanswered due to cleared cells.
 func (s *State) ClearIncorrectCells() error {
+	s.ensureCellMarks()
 	for y := 0; y < s.Puzzle.Rows; y++ {
 		for x := 0; x < s.Puzzle.Cols; x++ {
-			if s.Cells[y][x] != "" && s.Cells[y][x] != s.Puzzle.Cells[y][x] {
+			if s.Cells[y][x] != "" && !s.Puzzle.IsCorrect(x, y, s.Cells[y][x]) {
 				s.Cells[y][x] = ""
+				s.CellsIncorrect[y][x] = false
 			}
 		}
 	}
@@ -144,6 +236,532 @@ func (s *State) ClearIncorrectCells() error {
 	return s.UpdateFilledClues()
 }
 
+// CheckAnswer checks each filled in cell of the answer for a clue and marks
+// the ones that contain an incorrect value in CellsIncorrect.  The contents of
+// the cells are left unchanged.  The number of incorrect cells found is
+// returned.  If the clue cannot be identified then an error will be returned.
+func (s *State) CheckAnswer(clue string) (int, error) {
+	xs, ys, err := s.getAnswerCells(clue)
+	if err != nil {
+		return 0, err
+	}
+
+	if !s.IsPlaced(clue) {
+		return 0, fmt.Errorf("unable to check %s, it hasn't been placed", clue)
+	}
+
+	s.ensureCellMarks()
+
+	var count int
+	for i := range xs {
+		x, y := xs[i], ys[i]
+		if s.Cells[y][x] != "" && !s.Puzzle.IsCorrect(x, y, s.Cells[y][x]) {
+			s.CellsIncorrect[y][x] = true
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// RevealAnswer fills in the correct value for every cell of the answer for a
+// clue and marks them in CellsRevealed.  The number of cells whose value was
+// changed by the reveal is returned.  If the clue cannot be identified then an
+// error will be returned.
+func (s *State) RevealAnswer(clue string) (int, error) {
+	xs, ys, err := s.getAnswerCells(clue)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for i := range xs {
+		if s.reveal(xs[i], ys[i]) {
+			count++
+		}
+	}
+
+	if err := s.discoverAnswer(clue); err != nil {
+		return 0, err
+	}
+
+	return count, s.afterReveal()
+}
+
+// RevealSquare fills in the correct value for a single cell of the answer for
+// a clue and marks it in CellsRevealed.  The square is identified by its
+// 1-based position within the answer.  The number of cells whose value was
+// changed by the reveal is returned.  If the clue cannot be identified or the
+// square is not part of the answer then an error will be returned.
+func (s *State) RevealSquare(clue string, square int) (int, error) {
+	xs, ys, err := s.getAnswerCells(clue)
+	if err != nil {
+		return 0, err
+	}
+
+	if square < 1 || square > len(xs) {
+		return 0, fmt.Errorf("square %d is not part of the answer to %s", square, clue)
+	}
+
+	var count int
+	if s.reveal(xs[square-1], ys[square-1]) {
+		count++
+	}
+
+	return count, s.afterReveal()
+}
+
+// reveal writes the correct value into a cell and marks it as revealed.  It
+// returns whether or not the value of the cell was changed.
+func (s *State) reveal(x, y int) bool {
+	s.ensureCellMarks()
+
+	// A cell that already contains one of its accepted values keeps it.
+	changed := !s.Puzzle.IsCorrect(x, y, s.Cells[y][x])
+	if changed {
+		s.Cells[y][x] = s.Puzzle.Cells[y][x]
+	}
+	s.CellsIncorrect[y][x] = false
+	s.CellsRevealed[y][x] = true
+	s.discover(x, y)
+
+	return changed
+}
+
+// afterReveal updates the filled clues and status of the solve after one or
+// more cells have been revealed.
+func (s *State) afterReveal() error {
+	if err := s.UpdateFilledClues(); err != nil {
+		return err
+	}
+
+	if s.isComplete() {
+		s.Status = model.StatusComplete
+	}
+
+	return nil
+}
+
+// RevealSolution fills every cell of the puzzle with its correct value.  Cells
+// that didn't already contain the correct value are marked as revealed.  The
+// status of the solve is left unchanged.
+func (s *State) RevealSolution() error {
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if !s.Puzzle.IsCorrect(x, y, s.Cells[y][x]) {
+				s.reveal(x, y)
+			}
+		}
+	}
+
+	return s.UpdateFilledClues()
+}
+
+// Reset clears all progress made on the solve while keeping the puzzle.  The
+// solve is returned to the selected status so that it can be started again.
+func (s *State) Reset() {
+	s.Status = model.StatusSelected
+	s.Cells = make([][]string, s.Puzzle.Rows)
+	for row := 0; row < s.Puzzle.Rows; row++ {
+		s.Cells[row] = make([]string, s.Puzzle.Cols)
+	}
+	s.CellsIncorrect = NewBoolGrid(s.Puzzle.Rows, s.Puzzle.Cols)
+	s.CellsRevealed = NewBoolGrid(s.Puzzle.Rows, s.Puzzle.Cols)
+	s.AcrossCluesFilled = make(map[int]bool)
+	s.DownCluesFilled = make(map[int]bool)
+	s.LastStartTime = nil
+	s.TotalSolveDuration = model.Duration{}
+	s.RevealPenalty = model.Duration{}
+	s.LastProgressTime = nil
+	s.TimeLimit = model.Duration{}
+	s.ClueTeams = nil
+	s.Scores = nil
+	s.CellsDiscovered = nil
+	s.CluesPlaced = nil
+	if s.Puzzle.Diagramless {
+		s.ensureDiscovery()
+	}
+}
+
+// CreditTeam credits the named team with every clue that is correctly answered
+// but hasn't yet been credited to a team.  Each credited clue adds a point to
+// the team's score.  Clues that contain a revealed cell are never credited.  If
+// the team name is empty the clues are still marked as credited, but no points
+// are awarded.  The number of clues that were credited is returned.
+func (s *State) CreditTeam(name string) (int, error) {
+	if s.ClueTeams == nil {
+		s.ClueTeams = make(map[string]string)
+	}
+
+	if s.Scores == nil {
+		s.Scores = make(map[string]int)
+	}
+	for _, n := range team.Names {
+		if _, found := s.Scores[n]; !found {
+			s.Scores[n] = 0
+		}
+	}
+
+	s.ensureCellMarks()
+
+	var credited int
+	credit := func(clue string) error {
+		if _, found := s.ClueTeams[clue]; found {
+			return nil
+		}
+
+		xs, ys, err := s.getAnswerCells(clue)
+		if err != nil {
+			return err
+		}
+
+		for i := range xs {
+			if s.CellsRevealed[ys[i]][xs[i]] || !s.Puzzle.IsCorrect(xs[i], ys[i], s.Cells[ys[i]][xs[i]]) {
+				return nil
+			}
+		}
+
+		s.ClueTeams[clue] = name
+		credited++
+		return nil
+	}
+
+	for num := range s.Puzzle.CluesAcross {
+		if err := credit(fmt.Sprintf("%da", num)); err != nil {
+			return 0, err
+		}
+	}
+
+	for num := range s.Puzzle.CluesDown {
+		if err := credit(fmt.Sprintf("%dd", num)); err != nil {
+			return 0, err
+		}
+	}
+
+	if name != "" {
+		s.Scores[name] += credited
+	}
+
+	return credited, nil
+}
+
+// RevealHint reveals the correct value of a single randomly chosen cell from
+// the least filled in clue of the puzzle.  Only clues that still contain an
+// incorrect or empty cell are considered.  The identifier of the clue that the
+// revealed cell belongs to is returned.  If there are no clues left to provide
+// a hint for then an error will be returned.
+func (s *State) RevealHint() (string, error) {
+	type candidate struct {
+		clue   string
+		xs, ys []int
+	}
+
+	var candidates []candidate
+	least := 1.0
+	consider := func(clue string) error {
+		xs, ys, err := s.getAnswerCells(clue)
+		if err != nil {
+			return err
+		}
+
+		var filled int
+		var correct int
+		for i := range xs {
+			if s.Cells[ys[i]][xs[i]] != "" {
+				filled++
+			}
+			if s.Puzzle.IsCorrect(xs[i], ys[i], s.Cells[ys[i]][xs[i]]) {
+				correct++
+			}
+		}
+
+		// There's nothing to hint if the answer is already correct.
+		if correct == len(xs) {
+			return nil
+		}
+
+		ratio := float64(filled) / float64(len(xs))
+		if ratio < least {
+			candidates = nil
+			least = ratio
+		}
+		if ratio == least {
+			candidates = append(candidates, candidate{clue: clue, xs: xs, ys: ys})
+		}
+
+		return nil
+	}
+
+	for num := range s.Puzzle.CluesAcross {
+		if err := consider(fmt.Sprintf("%da", num)); err != nil {
+			return "", err
+		}
+	}
+	for num := range s.Puzzle.CluesDown {
+		if err := consider(fmt.Sprintf("%dd", num)); err != nil {
+			return "", err
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no clues left to provide a hint for")
+	}
+
+	// Choose a random clue and then a random cell within it that doesn't yet
+	// contain the correct value.
+	chosen := candidates[rand.Intn(len(candidates))]
+
+	var indices []int
+	for i := range chosen.xs {
+		x, y := chosen.xs[i], chosen.ys[i]
+		if !s.Puzzle.IsCorrect(x, y, s.Cells[y][x]) {
+			indices = append(indices, i)
+		}
+	}
+
+	index := indices[rand.Intn(len(indices))]
+	s.reveal(chosen.xs[index], chosen.ys[index])
+
+	return chosen.clue, s.afterReveal()
+}
+
+// CountCorrectCells returns the number of cells of the puzzle that are filled
+// in with their correct value.
+func (s *State) CountCorrectCells() int {
+	var count int
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if !s.Puzzle.CellBlocks[y][x] && s.Puzzle.IsCorrect(x, y, s.Cells[y][x]) {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// CountRevealedCells returns the number of cells of the puzzle that have had
+// their value revealed.
+func (s *State) CountRevealedCells() int {
+	var count int
+	for _, row := range s.CellsRevealed {
+		for _, revealed := range row {
+			if revealed {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// PublicPuzzle returns the puzzle of the state in a form that's suitable to
+// send to clients.  While the solve is in progress the solution is removed,
+// but once the solve has finished the solution is included so that it can
+// be displayed.
+func (s *State) PublicPuzzle() *Puzzle {
+	if s.Status.IsFinished() {
+		return s.Puzzle
+	}
+
+	puzzle := s.Puzzle.WithoutSolution()
+	if s.Puzzle.Diagramless {
+		s.hideUndiscoveredStructure(puzzle)
+	}
+
+	return puzzle
+}
+
+// PercentComplete returns the percentage of the cells of the puzzle that are
+// filled in with their correct value.
+func (s *State) PercentComplete() float64 {
+	var total int
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if !s.Puzzle.CellBlocks[y][x] {
+				total++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(s.CountCorrectCells()) / float64(total)
+}
+
+// PercentFilled returns the percentage of the cells of the puzzle that are
+// filled in with any value, correct or not.
+func (s *State) PercentFilled() float64 {
+	var filled, total int
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if s.Puzzle.CellBlocks[y][x] {
+				continue
+			}
+
+			total++
+			if s.Cells[y][x] != "" {
+				filled++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(filled) / float64(total)
+}
+
+// LastActivityTime returns the most recent time that the solve was either
+// started, resumed or had a correct value added to it.  If none of these have
+// happened yet then nil is returned.
+func (s *State) LastActivityTime() *time.Time {
+	return model.LastActivityTime(s.LastStartTime, s.LastProgressTime)
+}
+
+// NewLoggedAnswer creates a record of an answer that was submitted by a
+// chatter at the provided time for the solve's answer log.  The answer is
+// neither applied nor accepted, callers set these once the answer has been
+// applied to the state.  The answer's elapsed time doesn't include any reveal
+// penalties so that it reflects when the answer was submitted during the solve.
+func (s *State) NewLoggedAnswer(user, clue, answer string, now time.Time) model.LoggedAnswer {
+	return model.LoggedAnswer{
+		User:        user,
+		Clue:        clue,
+		Answer:      answer,
+		ElapsedTime: model.Duration{Duration: s.ElapsedTime(now) - s.RevealPenalty.Duration},
+	}
+}
+
+// Summary builds the summary of the solve from its log of answers.
+func (s *State) Summary(answers []model.LoggedAnswer) model.Summary {
+	return model.Summarize(answers, s.TotalSolveDuration)
+}
+
+// ElapsedTime returns the total amount of time that has been spent solving the
+// puzzle as of the provided time.
+func (s *State) ElapsedTime(now time.Time) time.Duration {
+	return model.ElapsedTime(s.TotalSolveDuration.Duration, s.LastStartTime, now)
+}
+
+// RemainingTime returns the amount of time remaining as of the provided time
+// before the solve's time limit is reached.  If the solve doesn't have a time
+// limit then zero is returned.
+func (s *State) RemainingTime(now time.Time) time.Duration {
+	return model.RemainingTime(s.TimeLimit.Duration, s.ElapsedTime(now))
+}
+
+// IsOutOfTime returns whether or not the solve has a time limit that has been
+// reached as of the provided time.
+func (s *State) IsOutOfTime(now time.Time) bool {
+	return model.IsOutOfTime(s.TimeLimit.Duration, s.ElapsedTime(now))
+}
+
+// HasRevealedCells returns whether or not any cell of the puzzle has had its
+// value revealed.
+func (s *State) HasRevealedCells() bool {
+	for _, row := range s.CellsRevealed {
+		for _, revealed := range row {
+			if revealed {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getAnswerCells returns the x and y coordinates of each cell that is part of
+// the answer for a clue in the order the cells appear in the answer.  If the
+// clue cannot be identified then an error will be returned.
+func (s *State) getAnswerCells(clue string) ([]int, []int, error) {
+	num, direction, err := ParseClue(clue)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	minX, minY, maxX, maxY, err := s.Puzzle.GetAnswerCoordinates(num, direction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dx, dy int
+	if direction == "a" {
+		dx = 1
+	} else {
+		dy = 1
+	}
+
+	var xs, ys []int
+	for x, y := minX, minY; x <= maxX && y <= maxY; x, y = x+dx, y+dy {
+		xs = append(xs, x)
+		ys = append(ys, y)
+	}
+
+	return xs, ys, nil
+}
+
+// getLinkedAnswerCells returns the x and y coordinates of each cell that is
+// part of the answers for a group of linked clues in the order the cells
+// appear in the combined answer.  If any clue of the group cannot be
+// identified then an error will be returned.
+func (s *State) getLinkedAnswerCells(group []string) ([]int, []int, error) {
+	var xs, ys []int
+	for _, clue := range group {
+		cxs, cys, err := s.getAnswerCells(clue)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		xs = append(xs, cxs...)
+		ys = append(ys, cys...)
+	}
+
+	return xs, ys, nil
+}
+
+// isComplete determines if every cell of the puzzle has been filled in with
+// its correct value.
+func (s *State) isComplete() bool {
+	for y := 0; y < s.Puzzle.Rows; y++ {
+		for x := 0; x < s.Puzzle.Cols; x++ {
+			if !s.Puzzle.IsCorrect(x, y, s.Cells[y][x]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// ensureCellMarks makes sure that the CellsIncorrect and CellsRevealed grids
+// are allocated.  States that were saved before these fields existed won't
+// have them populated.
+func (s *State) ensureCellMarks() {
+	if s.CellsIncorrect == nil {
+		s.CellsIncorrect = NewBoolGrid(s.Puzzle.Rows, s.Puzzle.Cols)
+	}
+
+	if s.CellsRevealed == nil {
+		s.CellsRevealed = NewBoolGrid(s.Puzzle.Rows, s.Puzzle.Cols)
+	}
+}
+
+// NewBoolGrid creates a 2D list of booleans with the provided dimensions that
+// are all initially false.  The list is first indexed by the row coordinate
+// and then by the column coordinate.
+func NewBoolGrid(rows, cols int) [][]bool {
+	grid := make([][]bool, rows)
+	for row := 0; row < rows; row++ {
+		grid[row] = make([]bool, cols)
+	}
+
+	return grid
+}
+
 // UpdateFilledClues looks at each clue in the puzzle and determines if a
 // complete answer has been provided for the clue, if so then the corresponding
 // entry in AcrossCluesFilled or DownCluesFilled will be set to true.  This
@@ -301,14 +919,38 @@ func SetState(conn db.Connection, channel string, state State) error {
 		return testStateSaveError
 	}
 
-	return db.SetWithTTL(conn, StateKey(channel), state, StateTTL)
+	if err := db.SetWithTTL(conn, StateKey(channel), state, StateTTL); err != nil {
+		return err
+	}
+
+	return db.IncrementVersion(conn, StateVersionKey(channel), StateTTL)
+}
+
+// StateVersionKey returns the key that should be used in redis to store the
+// version of a particular crossword solve's state.  The version is increased
+// every time the state is written.
+func StateVersionKey(name string) string {
+	return fmt.Sprintf("%s:crossword:version", name)
+}
+
+// GetStateVersion loads the version of the state for a crossword solve from
+// redis.  If the state has never been written then zero will be returned.  The
+// version should be loaded before the state so that it is never newer than the
+// state it's used with.
+func GetStateVersion(conn db.Connection, channel string) (int64, error) {
+	if testStateLoadError != nil {
+		return 0, testStateLoadError
+	}
+
+	return db.GetVersion(conn, StateVersionKey(channel))
 }
 
 // GetAllChannels returns a slice of model.Channel instances for each crossword
 // that contains state in the database.  If there are no active channels then an
 // empty slice is returned.  This method does not update the expiration times
-// of any state instance.
-func GetAllChannels(conn db.Connection) ([]model.Channel, error) {
+// of any state instance.  The elapsed time of each solve is computed as of the
+// provided time.
+func GetAllChannels(conn db.Connection, now time.Time) ([]model.Channel, error) {
 	keys, err := db.ScanKeys(conn, StateKey("*"))
 	if err != nil {
 		return nil, err
@@ -328,24 +970,23 @@ func GetAllChannels(conn db.Connection) ([]model.Channel, error) {
 			return nil, fmt.Errorf("unable to convert value to State: %v", value)
 		}
 
-		var description string
-		var publisher string
-		var published time.Time
+		channel := model.Channel{
+			Name:        name,
+			Status:      state.Status,
+			ElapsedTime: model.Duration{Duration: state.ElapsedTime(now)},
+		}
+
 		if state.Puzzle != nil {
-			description = state.Puzzle.Description
-			publisher = state.Puzzle.Publisher
-			published = state.Puzzle.PublishedDate
+			channel.Description = state.Puzzle.Description
+			channel.Puzzle = model.PuzzleSource{
+				Publisher:     state.Puzzle.Publisher,
+				PublishedDate: state.Puzzle.PublishedDate,
+			}
+			channel.PercentCorrect = state.PercentComplete()
+			channel.PercentFilled = state.PercentFilled()
 		}
 
-		channels = append(channels, model.Channel{
-			Name:        name,
-			Status:      state.Status,
-			Description: description,
-			Puzzle: model.PuzzleSource{
-				Publisher:     publisher,
-				PublishedDate: published,
-			},
-		})
+		channels = append(channels, channel)
 	}
 
 	sort.Slice(channels, func(i, j int) bool {
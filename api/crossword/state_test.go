@@ -582,6 +582,165 @@ func TestState_ApplyAnswer_Error(t *testing.T) {
 	}
 }
 
+func TestState_ApplyAnswer_LinkedClues(t *testing.T) {
+	tests := []struct {
+		name   string
+		linked [][]string
+		clue   string
+		answer string
+		verify func(*testing.T, State)
+	}{
+		{
+			name:   "first clue of group",
+			linked: [][]string{{"1a", "6a"}},
+			clue:   "1a",
+			answer: "Q AND A ATTIC",
+			verify: func(t *testing.T, state State) {
+				assert.Equal(t, []string{"Q", "A", "N", "D", "A", "", "A", "T", "T", "I", "C"}, state.Cells[0][:11])
+				assert.True(t, state.AcrossCluesFilled[1])
+				assert.True(t, state.AcrossCluesFilled[6])
+			},
+		},
+		{
+			name:   "second clue of group",
+			linked: [][]string{{"1a", "6a"}},
+			clue:   "6a",
+			answer: "Q AND A ATTIC",
+			verify: func(t *testing.T, state State) {
+				assert.Equal(t, []string{"Q", "A", "N", "D", "A", "", "A", "T", "T", "I", "C"}, state.Cells[0][:11])
+			},
+		},
+		{
+			name:   "answer for a single clue of group",
+			linked: [][]string{{"1a", "6a"}},
+			clue:   "6a",
+			answer: "ATTIC",
+			verify: func(t *testing.T, state State) {
+				assert.Equal(t, []string{"", "", "", "", "", "", "A", "T", "T", "I", "C"}, state.Cells[0][:11])
+			},
+		},
+		{
+			name:   "crossing clues",
+			linked: [][]string{{"1a", "1d"}},
+			clue:   "1a",
+			answer: "Q AND A QTIP",
+			verify: func(t *testing.T, state State) {
+				assert.Equal(t, []string{"Q", "A", "N", "D", "A"}, state.Cells[0][:5])
+				assert.Equal(t, "T", state.Cells[1][0])
+				assert.Equal(t, "I", state.Cells[2][0])
+				assert.Equal(t, "P", state.Cells[3][0])
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Puzzle.LinkedClues = test.linked
+
+			err := state.ApplyAnswer(test.clue, test.answer, true)
+			require.NoError(t, err)
+			test.verify(t, state)
+		})
+	}
+}
+
+func TestState_ApplyAnswer_LinkedClues_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		linked [][]string
+		clue   string
+		answer string
+	}{
+		{
+			name:   "answer too short",
+			linked: [][]string{{"1a", "6a"}},
+			clue:   "1a",
+			answer: "Q AND A ATTI",
+		},
+		{
+			name:   "clue not in group",
+			linked: [][]string{{"1a", "6a"}},
+			clue:   "1d",
+			answer: "Q AND A ATTIC",
+		},
+		{
+			name:   "incompatible crossing",
+			linked: [][]string{{"1a", "1d"}},
+			clue:   "1a",
+			answer: "Q AND A XTIP",
+		},
+		{
+			name:   "invalid clue in group",
+			linked: [][]string{{"1a", "199a"}},
+			clue:   "1a",
+			answer: "Q AND A ATTIC",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Puzzle.LinkedClues = test.linked
+
+			err := state.ApplyAnswer(test.clue, test.answer, false)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestState_ApplyAnswer_Alternatives(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	state.Puzzle.CellAlternatives = NewAlternativesGrid(state.Puzzle.Rows, state.Puzzle.Cols)
+	state.Puzzle.CellAlternatives[0][0] = []string{"Z"}
+
+	// Fill in everything except for the first across answer.
+	for y := 0; y < state.Puzzle.Rows; y++ {
+		copy(state.Cells[y], state.Puzzle.Cells[y])
+	}
+	for x := 0; x < 5; x++ {
+		state.Cells[0][x] = ""
+	}
+
+	// The alternative is accepted when only correct answers are allowed.
+	require.NoError(t, state.ApplyAnswer("1a", "ZANDA", true))
+	assert.Equal(t, "Z", state.Cells[0][0])
+
+	// And the puzzle is complete with it.
+	assert.Equal(t, model.StatusComplete, state.Status)
+	assert.Equal(t, 100., state.PercentComplete())
+
+	count, err := state.CheckAnswer("1d")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestState_ApplyAnswer_RebusFirstLetter(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181227-rebus.json")
+
+	// Answer the clue using only the first letter of the CON rebus.
+	require.NoError(t, state.ApplyAnswer("30a", "AERIALREC", true))
+	assert.Equal(t, "C", state.Cells[6][8])
+
+	count, err := state.CheckAnswer("30a")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestState_RevealAnswer_Alternatives(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Puzzle.CellAlternatives = NewAlternativesGrid(state.Puzzle.Rows, state.Puzzle.Cols)
+	state.Puzzle.CellAlternatives[0][0] = []string{"Z"}
+	require.NoError(t, state.ApplyAnswer("1a", "Z....", false))
+
+	// A cell containing an alternative isn't changed by a reveal.
+	count, err := state.RevealAnswer("1a")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.Equal(t, []string{"Z", "A", "N", "D", "A"}, state.Cells[0][:5])
+}
+
 func TestState_ClearIncorrectCells(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -714,6 +873,379 @@ func TestState_ClearIncorrectCells(t *testing.T) {
 	}
 }
 
+func TestState_CheckAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		setup    map[string]string
+		clue     string
+		expected int
+		verify   func(*testing.T, State)
+	}{
+		{
+			name:     "correct answer",
+			filename: "xwordinfo-nyt-20181231.json",
+			setup: map[string]string{
+				"1a": "QANDA",
+			},
+			clue:     "1a",
+			expected: 0,
+			verify: func(t *testing.T, state State) {
+				assert.Equal(t, []bool{false, false, false, false, false}, state.CellsIncorrect[0][:5])
+			},
+		},
+		{
+			name:     "partially incorrect answer",
+			filename: "xwordinfo-nyt-20181231.json",
+			setup: map[string]string{
+				"1d": "QTOP",
+			},
+			clue:     "1d",
+			expected: 1,
+			verify: func(t *testing.T, state State) {
+				assert.False(t, state.CellsIncorrect[0][0])
+				assert.False(t, state.CellsIncorrect[1][0])
+				assert.True(t, state.CellsIncorrect[2][0])
+				assert.False(t, state.CellsIncorrect[3][0])
+				assert.Equal(t, "O", state.Cells[2][0])
+			},
+		},
+		{
+			name:     "empty cells aren't incorrect",
+			filename: "xwordinfo-nyt-20181231.json",
+			setup: map[string]string{
+				"1a": "X...X",
+			},
+			clue:     "1a",
+			expected: 2,
+			verify: func(t *testing.T, state State) {
+				assert.Equal(t, []bool{true, false, false, false, true}, state.CellsIncorrect[0][:5])
+			},
+		},
+		{
+			name:     "only cells of the clue are checked",
+			filename: "xwordinfo-nyt-20181231.json",
+			setup: map[string]string{
+				"1a": "XXXXX",
+			},
+			clue:     "1d",
+			expected: 1,
+			verify: func(t *testing.T, state State) {
+				assert.Equal(t, []bool{true, false, false, false, false}, state.CellsIncorrect[0][:5])
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewState(t, test.filename)
+			for clue, answer := range test.setup {
+				require.NoError(t, state.ApplyAnswer(clue, answer, false))
+			}
+
+			count, err := state.CheckAnswer(test.clue)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, count)
+			test.verify(t, state)
+		})
+	}
+}
+
+func TestState_CheckAnswer_ClearedByNewValue(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	require.NoError(t, state.ApplyAnswer("1a", "XXXXX", false))
+
+	_, err := state.CheckAnswer("1a")
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, true, true, true}, state.CellsIncorrect[0][:5])
+
+	// Only cells whose values change lose their incorrect mark.
+	require.NoError(t, state.ApplyAnswer("1a", "QXNXA", false))
+	assert.Equal(t, []bool{false, true, false, true, false}, state.CellsIncorrect[0][:5])
+}
+
+func TestState_RevealAnswer(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	require.NoError(t, state.ApplyAnswer("1a", "QXN..", false))
+	_, err := state.CheckAnswer("1a")
+	require.NoError(t, err)
+
+	count, err := state.RevealAnswer("1a")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []string{"Q", "A", "N", "D", "A"}, state.Cells[0][:5])
+	assert.Equal(t, []bool{true, true, true, true, true}, state.CellsRevealed[0][:5])
+	assert.Equal(t, []bool{false, false, false, false, false}, state.CellsIncorrect[0][:5])
+	assert.True(t, state.AcrossCluesFilled[1])
+	assert.True(t, state.HasRevealedCells())
+
+	// Revealed cells can no longer be changed.
+	assert.Error(t, state.ApplyAnswer("1a", "XANDA", false))
+	assert.Error(t, state.ApplyAnswer("1a", "QAND.", false))
+	assert.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+}
+
+func TestState_RevealSquare(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	assert.False(t, state.HasRevealedCells())
+
+	count, err := state.RevealSquare("1d", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "I", state.Cells[2][0])
+	assert.True(t, state.CellsRevealed[2][0])
+	assert.False(t, state.CellsRevealed[1][0])
+	assert.False(t, state.DownCluesFilled[1])
+	assert.True(t, state.HasRevealedCells())
+
+	// Revealing an already correct square doesn't change it.
+	count, err = state.RevealSquare("1d", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestState_Reveal_Status(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+
+	for num := range state.Puzzle.CluesAcross {
+		_, err := state.RevealAnswer(fmt.Sprintf("%da", num))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, model.StatusComplete, state.Status)
+}
+
+func TestState_Reveal_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		clue   string
+		square int
+	}{
+		{
+			name: "malformed clue",
+			clue: "1x",
+		},
+		{
+			name: "invalid clue",
+			clue: "2a",
+		},
+		{
+			name:   "square before start of answer",
+			clue:   "1a",
+			square: -1,
+		},
+		{
+			name:   "square after end of answer",
+			clue:   "1a",
+			square: 6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+
+			var err error
+			if test.square == 0 {
+				_, err = state.RevealAnswer(test.clue)
+			} else {
+				_, err = state.RevealSquare(test.clue, test.square)
+			}
+			assert.Error(t, err)
+
+			_, err = state.CheckAnswer(test.clue)
+			if test.square == 0 {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestState_RevealHint(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+
+	// Fill in every answer except for 1a, the hint must come from it or from one
+	// of the down clues that cross it.
+	for num := range state.Puzzle.CluesAcross {
+		if num == 1 {
+			continue
+		}
+		_, err := state.RevealAnswer(fmt.Sprintf("%da", num))
+		require.NoError(t, err)
+	}
+	before := state.CountCorrectCells()
+
+	clue, err := state.RevealHint()
+	require.NoError(t, err)
+	assert.Contains(t, []string{"1a", "1d", "2d", "3d", "4d", "5d"}, clue)
+	assert.Equal(t, before+1, state.CountCorrectCells())
+	assert.Equal(t, 1, countTrue(state.CellsRevealed[0][:5]))
+}
+
+func TestState_RevealHint_Complete(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+
+	for num := range state.Puzzle.CluesAcross {
+		_, err := state.RevealAnswer(fmt.Sprintf("%da", num))
+		require.NoError(t, err)
+	}
+
+	_, err := state.RevealHint()
+	assert.Error(t, err)
+}
+
+func TestState_CountCorrectCells(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	assert.Equal(t, 0, state.CountCorrectCells())
+
+	require.NoError(t, state.ApplyAnswer("1a", "QXNXA", false))
+	assert.Equal(t, 3, state.CountCorrectCells())
+
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+	assert.Equal(t, 5, state.CountCorrectCells())
+}
+
+func TestState_CountRevealedCells(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	assert.Equal(t, 0, state.CountRevealedCells())
+
+	_, err := state.RevealAnswer("1a")
+	require.NoError(t, err)
+	assert.Equal(t, 5, state.CountRevealedCells())
+
+	_, err = state.RevealSquare("6a", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 6, state.CountRevealedCells())
+}
+
+func TestState_CountRevealedCells_NoMarks(t *testing.T) {
+	// States saved before cells could be revealed don't have the revealed grid.
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.CellsRevealed = nil
+	assert.Equal(t, 0, state.CountRevealedCells())
+}
+
+func TestState_PublicPuzzle(t *testing.T) {
+	tests := []struct {
+		status   model.Status
+		expected bool
+	}{
+		{status: model.StatusSelected, expected: false},
+		{status: model.StatusPaused, expected: false},
+		{status: model.StatusSolving, expected: false},
+		{status: model.StatusComplete, expected: true},
+		{status: model.StatusGivenUp, expected: true},
+		{status: model.StatusExpired, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status.String(), func(t *testing.T) {
+			state := NewState(t, "xwordinfo-nyt-20181231.json")
+			state.Status = test.status
+
+			puzzle := state.PublicPuzzle()
+			assert.Equal(t, test.expected, puzzle.Cells != nil)
+			assert.NotNil(t, state.Puzzle.Cells) // The state's puzzle is unchanged
+		})
+	}
+}
+
+func TestState_PercentComplete(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	assert.Equal(t, 0.0, state.PercentComplete())
+
+	// This puzzle has 187 cells that aren't blocks.
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+	assert.InDelta(t, 100*5.0/187, state.PercentComplete(), 1e-9)
+
+	state.Cells = state.Puzzle.Cells
+	assert.Equal(t, 100.0, state.PercentComplete())
+}
+
+func TestState_PercentFilled(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	assert.Equal(t, 0.0, state.PercentFilled())
+
+	// This puzzle has 187 cells that aren't blocks.  Incorrect values count as
+	// being filled in.
+	require.NoError(t, state.ApplyAnswer("1a", "QANDX", false))
+	assert.InDelta(t, 100*5.0/187, state.PercentFilled(), 1e-9)
+	assert.InDelta(t, 100*4.0/187, state.PercentComplete(), 1e-9)
+
+	state.Cells = state.Puzzle.Cells
+	assert.Equal(t, 100.0, state.PercentFilled())
+}
+
+func countTrue(bs []bool) int {
+	var count int
+	for _, b := range bs {
+		if b {
+			count++
+		}
+	}
+	return count
+}
+
+func TestState_CreditTeam(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+
+	// Nothing has been answered yet.
+	credited, err := state.CreditTeam("red")
+	require.NoError(t, err)
+	assert.Equal(t, 0, credited)
+	assert.Equal(t, map[string]int{"red": 0, "blue": 0}, state.Scores)
+
+	require.NoError(t, state.ApplyAnswer("1a", "QANDA", false))
+	credited, err = state.CreditTeam("red")
+	require.NoError(t, err)
+	assert.Equal(t, 1, credited)
+
+	// A clue that was already credited isn't credited again.
+	credited, err = state.CreditTeam("blue")
+	require.NoError(t, err)
+	assert.Equal(t, 0, credited)
+
+	// Clues answered by someone without a team are credited without points.
+	require.NoError(t, state.ApplyAnswer("1d", "QTIP", false))
+	credited, err = state.CreditTeam("")
+	require.NoError(t, err)
+	assert.Equal(t, 1, credited)
+
+	// Clues with revealed cells are never credited.
+	_, err = state.RevealAnswer("6a")
+	require.NoError(t, err)
+	credited, err = state.CreditTeam("blue")
+	require.NoError(t, err)
+	assert.Equal(t, 0, credited)
+
+	assert.Equal(t, map[string]string{"1a": "red", "1d": ""}, state.ClueTeams)
+	assert.Equal(t, map[string]int{"red": 1, "blue": 0}, state.Scores)
+
+	// Resetting the state clears the scores.
+	state.Reset()
+	assert.Nil(t, state.ClueTeams)
+	assert.Nil(t, state.Scores)
+}
+
+func TestState_NewLoggedAnswer(t *testing.T) {
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.LastStartTime = nil
+	state.TotalSolveDuration = model.Duration{Duration: 5 * time.Minute}
+	state.RevealPenalty = model.Duration{Duration: time.Minute}
+
+	// Reveal penalties aren't part of the time the answer was submitted at.
+	logged := state.NewLoggedAnswer("alice", "1a", "QANDA", time.Now())
+	assert.Equal(t, "alice", logged.User)
+	assert.Equal(t, "1a", logged.Clue)
+	assert.Equal(t, "QANDA", logged.Answer)
+	assert.False(t, logged.Applied)
+	assert.False(t, logged.Accepted)
+	assert.Equal(t, 4*time.Minute, logged.ElapsedTime.Duration)
+}
+
 func TestParseClue(t *testing.T) {
 	tests := []struct {
 		clue        string
@@ -981,16 +1513,39 @@ func TestGetAllChannels(t *testing.T) {
 				}
 
 				state.Status = create.status
+				state.LastStartTime = nil
 				require.NoError(t, SetState(conn, create.name, state))
 			}
 
-			channels, err := GetAllChannels(conn)
+			channels, err := GetAllChannels(conn, time.Now())
 			require.NoError(t, err)
 			assert.ElementsMatch(t, test.expected, channels)
 		})
 	}
 }
 
+func TestGetAllChannels_Progress(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Minute)
+
+	state := NewState(t, "xwordinfo-nyt-20181231.json")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &start
+	state.TotalSolveDuration = model.Duration{Duration: 2 * time.Minute}
+	require.NoError(t, state.ApplyAnswer("1a", "QANDX", false))
+	require.NoError(t, SetState(conn, "channel", state))
+
+	channels, err := GetAllChannels(conn, now)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.InDelta(t, 100*4.0/187, channels[0].PercentCorrect, 1e-9)
+	assert.InDelta(t, 100*5.0/187, channels[0].PercentFilled, 1e-9)
+	assert.Equal(t, 3*time.Minute, channels[0].ElapsedTime.Duration)
+}
+
 func TestGetAllChannels_Error(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1025,7 +1580,7 @@ func TestGetAllChannels_Error(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, err := GetAllChannels(test.connection)
+			_, err := GetAllChannels(test.connection, time.Now())
 			assert.Error(t, err)
 			assert.Equal(t, "forced error", err.Error())
 		})
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -38,6 +39,12 @@ var testStateLoadError error = nil
 // A cached error to use instead of writing state to the database.
 var testStateSaveError error = nil
 
+// A cached error to use instead of reading an attempt from the archive.
+var testArchiveLoadError error = nil
+
+// A cached error to use instead of writing an attempt to the archive.
+var testArchiveSaveError error = nil
+
 // load will read a file from the testdata directory.
 func load(t *testing.T, filename string) io.ReadCloser {
 	t.Helper()
@@ -50,7 +57,13 @@ func load(t *testing.T, filename string) io.ReadCloser {
 
 	// It wasn't present, we might be running from a parent directory.  Try
 	// crossword/testdata/{filename}.
-	f, err := os.Open(filepath.Join("crossword", "testdata", filename))
+	if f, err := os.Open(filepath.Join("crossword", "testdata", filename)); err == nil {
+		return f
+	}
+
+	// It still wasn't present, we might be running from a sibling directory.  Try
+	// ../crossword/testdata/{filename}.
+	f, err := os.Open(filepath.Join("..", "crossword", "testdata", filename))
 	require.NoError(t, err)
 
 	return f
@@ -90,6 +103,79 @@ func ForcePuzzleToBeLoaded(t *testing.T, filename string) {
 	t.Cleanup(func() { testPuzzle = nil })
 }
 
+// ForceSourceToBeRegistered registers an additional puzzle source for the
+// duration of a test.
+func ForceSourceToBeRegistered(t *testing.T, source Source) {
+	t.Helper()
+
+	original := sources
+	sources = append(append([]Source(nil), sources...), source)
+	t.Cleanup(func() { sources = original })
+}
+
+// LoadTestPuzSource loads the .puz source with the given identifier from a
+// config file in the testdata directory.
+func LoadTestPuzSource(t *testing.T, filename, id string) *PuzSource {
+	t.Helper()
+
+	in := load(t, filename)
+	defer func() { _ = in.Close() }()
+
+	sources, err := ParsePuzSources(in)
+	require.NoError(t, err)
+
+	for _, source := range sources {
+		if source.ID() == id {
+			return source
+		}
+	}
+
+	require.Failf(t, "missing .puz source", "no source with id %s in %s", id, filename)
+	return nil
+}
+
+// ForcePuzSourcesToBeRegistered registers the .puz sources described in a
+// config file from the testdata directory for the duration of a test.
+func ForcePuzSourcesToBeRegistered(t *testing.T, filename string) {
+	t.Helper()
+
+	in := load(t, filename)
+	defer func() { _ = in.Close() }()
+
+	sources, err := ParsePuzSources(in)
+	require.NoError(t, err)
+
+	for _, source := range sources {
+		ForceSourceToBeRegistered(t, source)
+	}
+}
+
+// NewTestLibraryDir creates an empty directory for a puzzle library that's
+// removed when the test completes.
+func NewTestLibraryDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "library")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return dir
+}
+
+// CopyTestFile copies a file from the testdata directory to a destination
+// path, creating any missing parent directories.
+func CopyTestFile(t *testing.T, filename, dest string) {
+	t.Helper()
+
+	in := load(t, filename)
+	defer func() { _ = in.Close() }()
+
+	bs, err := ioutil.ReadAll(in)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(dest), 0755))
+	require.NoError(t, ioutil.WriteFile(dest, bs, 0644))
+}
+
 // ForceErrorDuringLoad sets up an error to be returned when an attempt is made
 // to load a puzzle.
 func ForceErrorDuringPuzzleLoad(t *testing.T, err error) {
@@ -135,6 +221,24 @@ func ForceErrorDuringStateSave(t *testing.T, err error) {
 	t.Cleanup(func() { testStateSaveError = nil })
 }
 
+// ForceErrorDuringArchiveLoad sets up an error to be returned when an attempt
+// is made to read an attempt from the archive.
+func ForceErrorDuringArchiveLoad(t *testing.T, err error) {
+	t.Helper()
+
+	testArchiveLoadError = err
+	t.Cleanup(func() { testArchiveLoadError = nil })
+}
+
+// ForceErrorDuringArchiveSave sets up an error to be returned when an attempt
+// is made to save an attempt to the archive.
+func ForceErrorDuringArchiveSave(t *testing.T, err error) {
+	t.Helper()
+
+	testArchiveSaveError = err
+	t.Cleanup(func() { testArchiveSaveError = nil })
+}
+
 // NewTestRouter will return a router configured with a redis pool and pubsub
 // registry and wired together along with all of the routes for a spelling bee
 // puzzle.
@@ -203,6 +307,8 @@ func NewState(t *testing.T, filename string) State {
 		Status:            model.StatusSelected,
 		Puzzle:            puzzle,
 		Cells:             cells,
+		CellsIncorrect:    NewBoolGrid(puzzle.Rows, puzzle.Cols),
+		CellsRevealed:     NewBoolGrid(puzzle.Rows, puzzle.Cols),
 		AcrossCluesFilled: make(map[int]bool),
 		DownCluesFilled:   make(map[int]bool),
 		LastStartTime:     &now,
@@ -0,0 +1,368 @@
+package crossword
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The largest number of rows or columns that an uploaded crossword may have.
+const MaxUploadSize = 50
+
+// The shortest answer length that doesn't cause a warning when validating.
+const MinAnswerLength = 3
+
+// A regular expression that matches the valid contents of a cell, either a
+// single letter or a rebus of several letters and digits.
+var validCell = regexp.MustCompile(`^[A-Z0-9]+$`)
+
+// Normalize fills in the optional parts of a crossword that was uploaded by a
+// user.  Missing circles and shades are treated as not being present, missing
+// clue numbers are derived from the blocks and cell values and their
+// alternatives are uppercased.
+func (p *Puzzle) Normalize() {
+	if p.CellCircles == nil {
+		p.CellCircles = NewBoolGrid(p.Rows, p.Cols)
+	}
+	if p.CellShades == nil {
+		p.CellShades = NewBoolGrid(p.Rows, p.Cols)
+	}
+
+	for _, row := range p.Cells {
+		for x := range row {
+			row[x] = strings.ToUpper(strings.TrimSpace(row[x]))
+		}
+	}
+	for _, row := range p.CellAlternatives {
+		for _, alternatives := range row {
+			for i := range alternatives {
+				alternatives[i] = strings.ToUpper(strings.TrimSpace(alternatives[i]))
+			}
+		}
+	}
+
+	if p.CellClueNumbers == nil && len(p.CellBlocks) == p.Rows {
+		p.CellClueNumbers = DeriveClueNumbers(p.CellBlocks, p.Cols)
+	}
+}
+
+// DeriveClueNumbers computes the standard numbering of a crossword grid.  A
+// cell is numbered when it begins an across or down answer of at least two
+// letters, numbers are assigned in order from left to right and top to bottom.
+func DeriveClueNumbers(blocks [][]bool, cols int) [][]int {
+	rows := len(blocks)
+	isBlock := func(x, y int) bool {
+		return x < 0 || y < 0 || x >= cols || y >= rows || len(blocks[y]) != cols || blocks[y][x]
+	}
+
+	numbers := make([][]int, rows)
+	var next int
+	for y := 0; y < rows; y++ {
+		numbers[y] = make([]int, cols)
+		for x := 0; x < cols; x++ {
+			if isBlock(x, y) {
+				continue
+			}
+
+			across := isBlock(x-1, y) && !isBlock(x+1, y)
+			down := isBlock(x, y-1) && !isBlock(x, y+1)
+			if across || down {
+				next++
+				numbers[y][x] = next
+			}
+		}
+	}
+
+	return numbers
+}
+
+// Validate checks that a crossword uploaded by a user can be solved.  The grids
+// must match the dimensions of the puzzle, the clue numbers must match the
+// standard numbering of the grid, every answer must have a clue and every cell
+// must be part of both an across and a down answer.  Linked clues must refer
+// to clues of the puzzle.  Incomplete metadata, short answers and an asymmetric
+// or disconnected grid are reported as warnings.
+func (p *Puzzle) Validate() *model.ValidationReport {
+	report := model.NewValidationReport()
+
+	if p.Rows < 1 || p.Cols < 1 || p.Rows > MaxUploadSize || p.Cols > MaxUploadSize {
+		report.Errorf("grid must be between 1x1 and %dx%d, but is %dx%d", MaxUploadSize, MaxUploadSize, p.Cols, p.Rows)
+		return report
+	}
+
+	// Every grid must have the puzzle's dimensions before any of the cells can be
+	// examined.
+	sizes := map[string][]int{
+		"cells":             stringGridSize(p.Cells),
+		"cell_blocks":       boolGridSize(p.CellBlocks),
+		"cell_clue_numbers": intGridSize(p.CellClueNumbers),
+		"cell_circles":      boolGridSize(p.CellCircles),
+		"cell_shades":       boolGridSize(p.CellShades),
+	}
+	for _, name := range []string{"cells", "cell_blocks", "cell_clue_numbers", "cell_circles", "cell_shades"} {
+		if !sameSize(sizes[name], p.Rows, p.Cols) {
+			report.Errorf("%s must have %d rows of %d cells", name, p.Rows, p.Cols)
+		}
+	}
+	if p.CellAlternatives != nil && !sameSize(alternativesGridSize(p.CellAlternatives), p.Rows, p.Cols) {
+		report.Errorf("cell_alternatives must have %d rows of %d cells", p.Rows, p.Cols)
+	}
+	if !report.Valid() {
+		return report
+	}
+
+	for y := 0; y < p.Rows; y++ {
+		for x := 0; x < p.Cols; x++ {
+			cell := p.Cells[y][x]
+			if p.CellBlocks[y][x] && cell != "" {
+				report.Errorf("cell at row %d, column %d is a block but contains %q", y+1, x+1, cell)
+			}
+			if !p.CellBlocks[y][x] && !validCell.MatchString(cell) {
+				report.Errorf("cell at row %d, column %d must contain letters or digits, but contains %q", y+1, x+1, cell)
+			}
+
+			if p.CellAlternatives == nil {
+				continue
+			}
+			for _, alternative := range p.CellAlternatives[y][x] {
+				if p.CellBlocks[y][x] {
+					report.Errorf("cell at row %d, column %d is a block but has alternative %q", y+1, x+1, alternative)
+				} else if !validCell.MatchString(alternative) {
+					report.Errorf("alternative for cell at row %d, column %d must contain letters or digits, but contains %q", y+1, x+1, alternative)
+				}
+			}
+		}
+	}
+
+	numbers := DeriveClueNumbers(p.CellBlocks, p.Cols)
+	for y := 0; y < p.Rows; y++ {
+		for x := 0; x < p.Cols; x++ {
+			if p.CellClueNumbers[y][x] != numbers[y][x] {
+				report.Errorf("cell at row %d, column %d is numbered %d but should be numbered %d", y+1, x+1, p.CellClueNumbers[y][x], numbers[y][x])
+			}
+		}
+	}
+
+	// Walk each answer in the grid making sure it has a clue, while recording
+	// which cells are covered by an across and a down answer.
+	inAcross := NewBoolGrid(p.Rows, p.Cols)
+	inDown := NewBoolGrid(p.Rows, p.Cols)
+	validateAnswers := func(direction string, clues map[int]string, dx, dy int, covered [][]bool) {
+		seen := make(map[int]bool)
+		for y := 0; y < p.Rows; y++ {
+			for x := 0; x < p.Cols; x++ {
+				if p.CellBlocks[y][x] || (x-dx >= 0 && y-dy >= 0 && !p.CellBlocks[y-dy][x-dx]) {
+					continue
+				}
+
+				var length int
+				for cx, cy := x, y; cx < p.Cols && cy < p.Rows && !p.CellBlocks[cy][cx]; cx, cy = cx+dx, cy+dy {
+					length++
+				}
+				if length < 2 {
+					continue
+				}
+
+				for i := 0; i < length; i++ {
+					covered[y+i*dy][x+i*dx] = true
+				}
+
+				number := numbers[y][x]
+				seen[number] = true
+
+				clue, ok := clues[number]
+				if !ok {
+					report.Errorf("%d %s is missing a clue", number, direction)
+				} else if strings.TrimSpace(clue) == "" {
+					report.Errorf("%d %s has an empty clue", number, direction)
+				}
+
+				if length < MinAnswerLength {
+					report.Warnf("%d %s is only %d letters long", number, direction, length)
+				}
+			}
+		}
+
+		var extra []int
+		for number := range clues {
+			if !seen[number] {
+				extra = append(extra, number)
+			}
+		}
+		sort.Ints(extra)
+		for _, number := range extra {
+			report.Errorf("%d %s has a clue but no answer in the grid", number, direction)
+		}
+	}
+	validateAnswers("across", p.CluesAcross, 1, 0, inAcross)
+	validateAnswers("down", p.CluesDown, 0, 1, inDown)
+
+	for y := 0; y < p.Rows; y++ {
+		for x := 0; x < p.Cols; x++ {
+			switch {
+			case p.CellBlocks[y][x]:
+			case !inAcross[y][x] && !inDown[y][x]:
+				report.Errorf("cell at row %d, column %d isn't part of any answer", y+1, x+1)
+			case !inAcross[y][x]:
+				report.Errorf("cell at row %d, column %d is unchecked, it's only part of a down answer", y+1, x+1)
+			case !inDown[y][x]:
+				report.Errorf("cell at row %d, column %d is unchecked, it's only part of an across answer", y+1, x+1)
+			}
+		}
+	}
+
+	// Every linked clue must exist and can only be part of a single group.
+	linked := make(map[string]bool)
+	for _, group := range p.LinkedClues {
+		if len(group) < 2 {
+			report.Errorf("linked clues %s must contain at least two clues", strings.Join(group, ", "))
+		}
+
+		for _, clue := range group {
+			num, direction, err := ParseClue(clue)
+			if err != nil {
+				report.Errorf("linked clue %s can't be parsed", clue)
+				continue
+			}
+
+			id := fmt.Sprintf("%d%s", num, direction)
+			if _, _, _, _, err := p.GetAnswerCoordinates(num, direction); err != nil {
+				report.Errorf("linked clue %s doesn't exist", id)
+			}
+			if linked[id] {
+				report.Errorf("linked clue %s is part of more than one group", id)
+			}
+			linked[id] = true
+		}
+	}
+
+	if strings.TrimSpace(p.Title) == "" {
+		report.Warnf("puzzle doesn't have a title")
+	}
+	if strings.TrimSpace(p.Author) == "" {
+		report.Warnf("puzzle doesn't have an author")
+	}
+	if !p.isSymmetric() {
+		report.Warnf("grid isn't rotationally symmetric")
+	}
+	if !p.isConnected() {
+		report.Warnf("grid is split into several disconnected areas")
+	}
+
+	return report
+}
+
+// isSymmetric determines if the blocks of the grid are the same when the grid
+// is rotated 180 degrees.
+func (p *Puzzle) isSymmetric() bool {
+	for y := 0; y < p.Rows; y++ {
+		for x := 0; x < p.Cols; x++ {
+			if p.CellBlocks[y][x] != p.CellBlocks[p.Rows-1-y][p.Cols-1-x] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isConnected determines if every cell that isn't a block can be reached from
+// every other one without crossing a block.
+func (p *Puzzle) isConnected() bool {
+	type point struct{ x, y int }
+
+	var start *point
+	var total int
+	for y := 0; y < p.Rows; y++ {
+		for x := 0; x < p.Cols; x++ {
+			if !p.CellBlocks[y][x] {
+				total++
+				if start == nil {
+					start = &point{x, y}
+				}
+			}
+		}
+	}
+	if start == nil {
+		return true
+	}
+
+	visited := NewBoolGrid(p.Rows, p.Cols)
+	visited[start.y][start.x] = true
+	queue := []point{*start}
+	var count int
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		count++
+
+		for _, next := range []point{
+			{current.x - 1, current.y},
+			{current.x + 1, current.y},
+			{current.x, current.y - 1},
+			{current.x, current.y + 1},
+		} {
+			if next.x < 0 || next.y < 0 || next.x >= p.Cols || next.y >= p.Rows {
+				continue
+			}
+			if p.CellBlocks[next.y][next.x] || visited[next.y][next.x] {
+				continue
+			}
+
+			visited[next.y][next.x] = true
+			queue = append(queue, next)
+		}
+	}
+
+	return count == total
+}
+
+// The sizes of each row of a grid.
+func stringGridSize(grid [][]string) []int {
+	sizes := make([]int, len(grid))
+	for i, row := range grid {
+		sizes[i] = len(row)
+	}
+	return sizes
+}
+
+func boolGridSize(grid [][]bool) []int {
+	sizes := make([]int, len(grid))
+	for i, row := range grid {
+		sizes[i] = len(row)
+	}
+	return sizes
+}
+
+func intGridSize(grid [][]int) []int {
+	sizes := make([]int, len(grid))
+	for i, row := range grid {
+		sizes[i] = len(row)
+	}
+	return sizes
+}
+
+func alternativesGridSize(grid [][][]string) []int {
+	sizes := make([]int, len(grid))
+	for i, row := range grid {
+		sizes[i] = len(row)
+	}
+	return sizes
+}
+
+// sameSize determines if the row sizes of a grid match the given dimensions.
+func sameSize(sizes []int, rows, cols int) bool {
+	if len(sizes) != rows {
+		return false
+	}
+
+	for _, size := range sizes {
+		if size != cols {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,276 @@
+package crossword
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPuzzle_Normalize(t *testing.T) {
+	puzzle := NewUploadedPuzzle()
+	puzzle.Cells[0][0] = " c"
+	puzzle.CellAlternatives = NewAlternativesGrid(3, 3)
+	puzzle.CellAlternatives[0][0] = []string{"b "}
+	puzzle.CellClueNumbers = nil
+	puzzle.CellCircles = nil
+	puzzle.CellShades = nil
+
+	puzzle.Normalize()
+	assert.Equal(t, "C", puzzle.Cells[0][0])
+	assert.Equal(t, []string{"B"}, puzzle.CellAlternatives[0][0])
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 0, 0}, {5, 0, 0}}, puzzle.CellClueNumbers)
+	assert.Equal(t, NewBoolGrid(3, 3), puzzle.CellCircles)
+	assert.Equal(t, NewBoolGrid(3, 3), puzzle.CellShades)
+}
+
+func TestDeriveClueNumbers(t *testing.T) {
+	puzzle := LoadTestPuzzle(t, "xwordinfo-nyt-20181231.json")
+	assert.Equal(t, puzzle.CellClueNumbers, DeriveClueNumbers(puzzle.CellBlocks, puzzle.Cols))
+}
+
+func TestPuzzle_Validate(t *testing.T) {
+	puzzle := NewUploadedPuzzle()
+
+	report := puzzle.Validate()
+	assert.Empty(t, report.Errors)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestPuzzle_Validate_PublishedPuzzle(t *testing.T) {
+	puzzle := LoadTestPuzzle(t, "xwordinfo-nyt-20181231.json")
+
+	report := puzzle.Validate()
+	assert.Empty(t, report.Errors)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestPuzzle_Validate_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(p *Puzzle)
+		expected []string
+	}{
+		{
+			name: "too large",
+			modify: func(p *Puzzle) {
+				p.Rows = 51
+			},
+			expected: []string{"grid must be between 1x1 and 50x50, but is 3x51"},
+		},
+		{
+			name: "grid with wrong number of rows",
+			modify: func(p *Puzzle) {
+				p.CellShades = p.CellShades[:2]
+			},
+			expected: []string{"cell_shades must have 3 rows of 3 cells"},
+		},
+		{
+			name: "grid with wrong number of columns",
+			modify: func(p *Puzzle) {
+				p.Cells[1] = []string{"A", "G"}
+			},
+			expected: []string{"cells must have 3 rows of 3 cells"},
+		},
+		{
+			name: "alternatives with wrong number of rows",
+			modify: func(p *Puzzle) {
+				p.CellAlternatives = NewAlternativesGrid(2, 3)
+			},
+			expected: []string{"cell_alternatives must have 3 rows of 3 cells"},
+		},
+		{
+			name: "invalid alternative",
+			modify: func(p *Puzzle) {
+				p.CellAlternatives = NewAlternativesGrid(3, 3)
+				p.CellAlternatives[0][0] = []string{"B", "?"}
+			},
+			expected: []string{`alternative for cell at row 1, column 1 must contain letters or digits, but contains "?"`},
+		},
+		{
+			name: "invalid cell",
+			modify: func(p *Puzzle) {
+				p.Cells[1][1] = "?"
+			},
+			expected: []string{`cell at row 2, column 2 must contain letters or digits, but contains "?"`},
+		},
+		{
+			name: "block with a letter",
+			modify: func(p *Puzzle) {
+				p.CellBlocks[2][2] = true
+				p.CellBlocks[0][0] = true
+				p.Cells[0][0] = ""
+				p.CellClueNumbers = DeriveClueNumbers(p.CellBlocks, p.Cols)
+				p.CluesAcross = map[int]string{1: "a", 3: "b", 4: "c"}
+				p.CluesDown = map[int]string{1: "d", 2: "e", 3: "f"}
+			},
+			expected: []string{`cell at row 3, column 3 is a block but contains "W"`},
+		},
+		{
+			name: "inconsistent clue numbers",
+			modify: func(p *Puzzle) {
+				p.CellClueNumbers[1][0] = 5
+				p.CellClueNumbers[2][0] = 4
+			},
+			expected: []string{
+				"cell at row 2, column 1 is numbered 5 but should be numbered 4",
+				"cell at row 3, column 1 is numbered 4 but should be numbered 5",
+			},
+		},
+		{
+			name: "missing clue",
+			modify: func(p *Puzzle) {
+				delete(p.CluesAcross, 4)
+			},
+			expected: []string{"4 across is missing a clue"},
+		},
+		{
+			name: "empty clue",
+			modify: func(p *Puzzle) {
+				p.CluesDown[2] = " "
+			},
+			expected: []string{"2 down has an empty clue"},
+		},
+		{
+			name: "clue without an answer",
+			modify: func(p *Puzzle) {
+				p.CluesDown[4] = "Extra"
+			},
+			expected: []string{"4 down has a clue but no answer in the grid"},
+		},
+		{
+			name: "linked clue that doesn't exist",
+			modify: func(p *Puzzle) {
+				p.LinkedClues = [][]string{{"1a", "6a"}}
+			},
+			expected: []string{"linked clue 6a doesn't exist"},
+		},
+		{
+			name: "linked clue in several groups",
+			modify: func(p *Puzzle) {
+				p.LinkedClues = [][]string{{"1a", "5a"}, {"5a", "1d"}}
+			},
+			expected: []string{"linked clue 5a is part of more than one group"},
+		},
+		{
+			name: "linked clues without a group",
+			modify: func(p *Puzzle) {
+				p.LinkedClues = [][]string{{"1a"}}
+			},
+			expected: []string{"linked clues 1a must contain at least two clues"},
+		},
+		{
+			name: "unchecked cell",
+			modify: func(p *Puzzle) {
+				p.CellBlocks[1][1] = true
+				p.CellBlocks[1][2] = true
+				p.Cells[1][1] = ""
+				p.Cells[1][2] = ""
+				p.CellClueNumbers = DeriveClueNumbers(p.CellBlocks, p.Cols)
+				p.CluesAcross = map[int]string{1: "a", 2: "b"}
+				p.CluesDown = map[int]string{1: "c"}
+			},
+			expected: []string{
+				"cell at row 1, column 2 is unchecked, it's only part of an across answer",
+				"cell at row 1, column 3 is unchecked, it's only part of an across answer",
+				"cell at row 2, column 1 is unchecked, it's only part of a down answer",
+				"cell at row 3, column 2 is unchecked, it's only part of an across answer",
+				"cell at row 3, column 3 is unchecked, it's only part of an across answer",
+			},
+		},
+		{
+			name: "unreachable cell",
+			modify: func(p *Puzzle) {
+				p.CellBlocks[0][1] = true
+				p.CellBlocks[1][0] = true
+				p.CellBlocks[1][2] = true
+				p.CellBlocks[2][1] = true
+				p.Cells[0][1] = ""
+				p.Cells[1][0] = ""
+				p.Cells[1][2] = ""
+				p.Cells[2][1] = ""
+				p.CellClueNumbers = DeriveClueNumbers(p.CellBlocks, p.Cols)
+				p.CluesAcross = map[int]string{}
+				p.CluesDown = map[int]string{}
+			},
+			expected: []string{
+				"cell at row 1, column 1 isn't part of any answer",
+				"cell at row 1, column 3 isn't part of any answer",
+				"cell at row 2, column 2 isn't part of any answer",
+				"cell at row 3, column 1 isn't part of any answer",
+				"cell at row 3, column 3 isn't part of any answer",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			puzzle := NewUploadedPuzzle()
+			test.modify(puzzle)
+
+			report := puzzle.Validate()
+			assert.Equal(t, test.expected, report.Errors)
+		})
+	}
+}
+
+func TestPuzzle_Validate_Warnings(t *testing.T) {
+	puzzle := NewUploadedPuzzle()
+	puzzle.Title = ""
+	puzzle.Author = ""
+
+	// Blocking the top left corner makes the grid asymmetric and leaves two short
+	// answers.
+	puzzle.CellBlocks[0][0] = true
+	puzzle.Cells[0][0] = ""
+	puzzle.CellClueNumbers = DeriveClueNumbers(puzzle.CellBlocks, puzzle.Cols)
+	puzzle.CluesAcross = map[int]string{1: "a", 3: "b", 4: "c"}
+	puzzle.CluesDown = map[int]string{1: "d", 2: "e", 3: "f"}
+
+	report := puzzle.Validate()
+	require.Empty(t, report.Errors)
+	assert.Equal(t, []string{
+		"1 across is only 2 letters long",
+		"3 down is only 2 letters long",
+		"puzzle doesn't have a title",
+		"puzzle doesn't have an author",
+		"grid isn't rotationally symmetric",
+	}, report.Warnings)
+}
+
+func TestPuzzle_Validate_Disconnected(t *testing.T) {
+	puzzle := &Puzzle{
+		Rows:            3,
+		Cols:            7,
+		Title:           "Title",
+		Author:          "Author",
+		Cells:           [][]string{{"A", "B", "C", "", "D", "E", "F"}, {"G", "H", "I", "", "J", "K", "L"}, {"M", "N", "O", "", "P", "Q", "R"}},
+		CellBlocks:      [][]bool{{false, false, false, true, false, false, false}, {false, false, false, true, false, false, false}, {false, false, false, true, false, false, false}},
+		CellClueNumbers: [][]int{{1, 2, 3, 0, 4, 5, 6}, {7, 0, 0, 0, 8, 0, 0}, {9, 0, 0, 0, 10, 0, 0}},
+		CluesAcross:     map[int]string{1: "a", 4: "b", 7: "c", 8: "d", 9: "e", 10: "f"},
+		CluesDown:       map[int]string{1: "g", 2: "h", 3: "i", 4: "j", 5: "k", 6: "l"},
+	}
+	puzzle.CellCircles = NewBoolGrid(3, 7)
+	puzzle.CellShades = NewBoolGrid(3, 7)
+
+	report := puzzle.Validate()
+	require.Empty(t, report.Errors)
+	assert.Equal(t, []string{"grid is split into several disconnected areas"}, report.Warnings)
+}
+
+// NewUploadedPuzzle returns a small valid crossword in the form it would be
+// uploaded in.
+func NewUploadedPuzzle() *Puzzle {
+	return &Puzzle{
+		Rows:            3,
+		Cols:            3,
+		Title:           "Tiny",
+		Author:          "Jane Doe",
+		Cells:           [][]string{{"C", "A", "T"}, {"A", "G", "O"}, {"B", "O", "W"}},
+		CellBlocks:      NewBoolGrid(3, 3),
+		CellClueNumbers: [][]int{{1, 2, 3}, {4, 0, 0}, {5, 0, 0}},
+		CellCircles:     NewBoolGrid(3, 3),
+		CellShades:      NewBoolGrid(3, 3),
+		CluesAcross:     map[int]string{1: "Feline", 4: "In the past", 5: "Ribbon"},
+		CluesDown:       map[int]string{1: "Taxi", 2: "Board game", 3: "Pull behind"},
+	}
+}
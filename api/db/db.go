@@ -2,6 +2,7 @@ package db
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/gomodule/redigo/redis"
 	"reflect"
 	"sort"
@@ -105,6 +106,132 @@ func SetWithTTL(c Connection, key string, data interface{}, ttl time.Duration) e
 	return err
 }
 
+// Append will add the provided entry to the end of the list stored in the
+// database for the provided key.  If the list doesn't exist yet then it will be
+// created.  If the entry can't be marshalled to JSON or is unable to be written
+// to the database for some reason then an error will be returned.
+func Append(c Connection, key string, data interface{}) error {
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do("RPUSH", key, bs)
+	return err
+}
+
+// GetList will load and unmarshal every entry of the list stored in the
+// database for the provided key.  For each entry a new instance of the same type
+// as kind will be created and the json value will be unmarshalled into it.  If
+// the list isn't present in the database then an empty slice will be returned.
+// If an entry can't be properly unmarshalled then a json error will be returned.
+func GetList(c Connection, key string, kind interface{}) ([]interface{}, error) {
+	bss, err := redis.ByteSlices(c.Do("LRANGE", key, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, 0, len(bss))
+	for _, bs := range bss {
+		// Like GetAll we need to unmarshal into a pointer to the underlying type of
+		// kind, otherwise we'd end up with a map[string]interface{}.
+		ptr := reflect.New(reflect.TypeOf(kind))
+
+		if err := json.Unmarshal(bs, ptr.Interface()); err != nil {
+			return nil, err
+		}
+
+		values = append(values, reflect.Indirect(ptr).Interface())
+	}
+
+	return values, nil
+}
+
+// AddSorted will add the provided entry to the sorted set stored in the
+// database for the provided key with the provided score.  If the sorted set
+// doesn't exist yet then it will be created.  If the entry can't be marshalled
+// to JSON or is unable to be written to the database for some reason then an
+// error will be returned.
+func AddSorted(c Connection, key string, score float64, data interface{}) error {
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do("ZADD", key, score, bs)
+	return err
+}
+
+// GetSorted will load and unmarshal every entry of the sorted set stored in the
+// database for the provided key in order of their scores.  For each entry a new
+// instance of the same type as kind will be created and the json value will be
+// unmarshalled into it.  If the sorted set isn't present in the database then an
+// empty slice will be returned.  If an entry can't be properly unmarshalled then
+// a json error will be returned.
+func GetSorted(c Connection, key string, kind interface{}) ([]interface{}, error) {
+	bss, err := redis.ByteSlices(c.Do("ZRANGE", key, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, 0, len(bss))
+	for _, bs := range bss {
+		ptr := reflect.New(reflect.TypeOf(kind))
+
+		if err := json.Unmarshal(bs, ptr.Interface()); err != nil {
+			return nil, err
+		}
+
+		values = append(values, reflect.Indirect(ptr).Interface())
+	}
+
+	return values, nil
+}
+
+// GetLast will load and unmarshal the last entry of the list stored in the
+// database for the provided key into the provided object.  If the list isn't
+// present in the database then no error will be returned and the provided
+// object will be left untouched.  If the entry can't be properly unmarshalled
+// then a json error will be returned.
+func GetLast(c Connection, key string, data interface{}) error {
+	bs, err := redis.Bytes(c.Do("LINDEX", key, -1))
+	if err == redis.ErrNil {
+		// There weren't any entries in the list for this key.  This is okay.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bs, &data)
+}
+
+// GetVersion will load the version number stored in the database for the
+// provided key.  If no version has been stored for the key then zero will be
+// returned.
+func GetVersion(c Connection, key string) (int64, error) {
+	version, err := redis.Int64(c.Do("GET", key))
+	if err == redis.ErrNil {
+		// There isn't a version for this key yet.  This is okay.
+		return 0, nil
+	}
+
+	return version, err
+}
+
+// IncrementVersion will increase the version number stored in the database for
+// the provided key by one and set a TTL on it.  If the version doesn't exist yet
+// then it will be created.  An error will be returned if the version is unable
+// to be written to the database for some reason.
+func IncrementVersion(c Connection, key string, ttl time.Duration) error {
+	if _, err := c.Do("INCR", key); err != nil {
+		return err
+	}
+
+	_, err := c.Do("EXPIRE", key, int(ttl.Seconds()))
+	return err
+}
+
 // ScanKeys will scan the database for keys that match the provided key (with
 // wildcards).  Each matching key will be returned or an error returned if
 // the database couldn't be scanned for some reason.
@@ -139,3 +266,91 @@ func ScanKeys(c Connection, key string) ([]string, error) {
 	sort.Strings(keys)
 	return keys, nil
 }
+
+// UpdateAttempts determines how many times Update will try to apply its changes
+// before giving up because the watched key keeps being modified concurrently.
+var UpdateAttempts = 5
+
+// ErrConflict is returned by Update when its changes couldn't be applied
+// because the watched key was modified by another connection on every attempt.
+var ErrConflict = errors.New("too many concurrent modifications")
+
+// Update performs a read-modify-write of the provided key as a transaction.
+// The key is watched and then read is called to load whatever it needs using a
+// connection that executes commands immediately.  Afterwards write is called to
+// make its changes using tx.  Commands given to tx are queued instead of being
+// executed, so write must not read anything through it, and they're only
+// applied if the key wasn't modified by another connection in the meantime.
+// Otherwise both phases are run again so that the changes can be redone using
+// the newly written data.  If write doesn't issue any commands then nothing is
+// executed.  If either phase returns an error then the writes are discarded and
+// the error is returned.
+func Update(conn redis.Conn, key string, read func(conn Connection) error, write func(tx Connection) error) error {
+	for attempt := 0; attempt < UpdateAttempts; attempt++ {
+		if _, err := conn.Do("WATCH", key); err != nil {
+			return err
+		}
+
+		if err := read(conn); err != nil {
+			_, _ = conn.Do("UNWATCH")
+			return err
+		}
+
+		tx := &transaction{conn: conn}
+		if err := write(tx); err != nil {
+			if tx.started {
+				_, _ = conn.Do("DISCARD")
+			} else {
+				_, _ = conn.Do("UNWATCH")
+			}
+			return err
+		}
+
+		if !tx.started {
+			_, err := conn.Do("UNWATCH")
+			return err
+		}
+
+		// A transaction always contains at least one command, so when no replies
+		// come back the watched key was modified and we need to try again.
+		replies, err := redis.Values(conn.Do("EXEC"))
+		if err == redis.ErrNil || (err == nil && len(replies) == 0) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, reply := range replies {
+			if err, ok := reply.(redis.Error); ok {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return ErrConflict
+}
+
+// transaction is a Connection that queues every command it's given as part of
+// a MULTI block instead of executing it immediately.
+type transaction struct {
+	conn    redis.Conn
+	started bool
+}
+
+func (t *transaction) Do(command string, args ...interface{}) (interface{}, error) {
+	if !t.started {
+		if err := t.conn.Send("MULTI"); err != nil {
+			return nil, err
+		}
+		t.started = true
+	}
+
+	if err := t.conn.Send(command, args...); err != nil {
+		return nil, err
+	}
+
+	return "QUEUED", nil
+}
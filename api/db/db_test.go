@@ -475,6 +475,473 @@ func TestSetWithTTL_Error(t *testing.T) {
 	}
 }
 
+func TestAppend(t *testing.T) {
+	type Entry struct {
+		Id int `json:"id"`
+	}
+
+	tests := []struct {
+		name     string
+		initial  []Entry // Entries that should already be in the list.
+		entry    Entry   // The entry to append.
+		expected []string
+	}{
+		{
+			name:     "missing list",
+			entry:    Entry{1},
+			expected: []string{`{"id":1}`},
+		},
+		{
+			name:     "existing list",
+			initial:  []Entry{{0}},
+			entry:    Entry{1},
+			expected: []string{`{"id":0}`, `{"id":1}`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+
+			// Write all of the initial entries into the database.
+			for _, entry := range test.initial {
+				bs, err := json.Marshal(entry)
+				require.NoError(t, err)
+
+				_, err = server.Push("key", string(bs))
+				require.NoError(t, err)
+			}
+
+			// Append the entry we care about.
+			err := Append(conn, "key", test.entry)
+
+			// Verify we appended our entry.
+			require.NoError(t, err)
+			server.CheckList(t, "key", test.expected...)
+		})
+	}
+}
+
+func TestAppend_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		connection ConnectionFunc
+		data       interface{} // The data to append.
+		expected   error
+	}{
+		{
+			name: "json.Marshal error",
+			data: make(chan int), // Channels are not able to be marshalled to JSON.
+		},
+		{
+			name: "conn.Do error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				return nil, errors.New("forced error")
+			},
+			expected: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, conn := NewMiniredis(t)
+
+			// If we weren't provided a connection to use, then use the one connected
+			// to the miniredis server.
+			var connection Connection = test.connection
+			if test.connection == nil {
+				connection = conn
+			}
+
+			err := Append(connection, "key", test.data)
+
+			// Verify we got the error we expected.
+			assert.Error(t, err)
+			if test.expected != nil {
+				assert.Equal(t, test.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetList(t *testing.T) {
+	type Entry struct {
+		Id int `json:"id"`
+	}
+
+	tests := []struct {
+		name     string
+		initial  []string // Entries that should already be in the list.
+		expected []interface{}
+	}{
+		{
+			name:     "missing list",
+			expected: []interface{}{},
+		},
+		{
+			name:     "existing list",
+			initial:  []string{`{"id":0}`, `{"id":1}`},
+			expected: []interface{}{Entry{0}, Entry{1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+
+			// Write all of the initial entries into the database.
+			for _, entry := range test.initial {
+				_, err := server.Push("key", entry)
+				require.NoError(t, err)
+			}
+
+			values, err := GetList(conn, "key", Entry{})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestGetList_Error(t *testing.T) {
+	type Entry struct {
+		Id int `json:"id"`
+	}
+
+	tests := []struct {
+		name       string
+		connection ConnectionFunc
+		initial    []string // Entries that should already be in the list.
+		expected   error
+	}{
+		{
+			name:    "json.Unmarshal error",
+			initial: []string{`{"id":"one"}`},
+		},
+		{
+			name: "conn.Do error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				return nil, errors.New("forced error")
+			},
+			expected: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+
+			// Write all of the initial entries into the database.
+			for _, entry := range test.initial {
+				_, err := server.Push("key", entry)
+				require.NoError(t, err)
+			}
+
+			// If we weren't provided a connection to use, then use the one connected
+			// to the miniredis server.
+			var connection Connection = test.connection
+			if test.connection == nil {
+				connection = conn
+			}
+
+			_, err := GetList(connection, "key", Entry{})
+
+			// Verify we got the error we expected.
+			assert.Error(t, err)
+			if test.expected != nil {
+				assert.Equal(t, test.expected, err)
+			}
+		})
+	}
+}
+
+func TestAddSorted_GetSorted(t *testing.T) {
+	type Entry struct {
+		Id int `json:"id"`
+	}
+
+	_, conn := NewMiniredis(t)
+
+	// Nothing has been added yet.
+	values, err := GetSorted(conn, "key", Entry{})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{}, values)
+
+	// Entries should be returned in order of their scores, not the order they
+	// were added in.
+	require.NoError(t, AddSorted(conn, "key", 2, Entry{2}))
+	require.NoError(t, AddSorted(conn, "key", 1, Entry{1}))
+	require.NoError(t, AddSorted(conn, "key", 3, Entry{3}))
+
+	values, err = GetSorted(conn, "key", Entry{})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{Entry{1}, Entry{2}, Entry{3}}, values)
+}
+
+func TestAddSorted_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		connection ConnectionFunc
+		data       interface{}
+		expected   error
+	}{
+		{
+			name: "json.Marshal error",
+			data: make(chan int), // Channels are not able to be marshalled to JSON.
+		},
+		{
+			name: "conn.Do error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				return nil, errors.New("forced error")
+			},
+			expected: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, conn := NewMiniredis(t)
+
+			// If we weren't provided a connection to use, then use the one connected
+			// to the miniredis server.
+			var connection Connection = test.connection
+			if test.connection == nil {
+				connection = conn
+			}
+
+			err := AddSorted(connection, "key", 1, test.data)
+
+			// Verify we got the error we expected.
+			assert.Error(t, err)
+			if test.expected != nil {
+				assert.Equal(t, test.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetSorted_Error(t *testing.T) {
+	type Entry struct {
+		Id int `json:"id"`
+	}
+
+	tests := []struct {
+		name       string
+		connection ConnectionFunc
+		initial    []string // Entries that should already be in the sorted set.
+		expected   error
+	}{
+		{
+			name:    "json.Unmarshal error",
+			initial: []string{`{"id":"one"}`},
+		},
+		{
+			name: "conn.Do error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				return nil, errors.New("forced error")
+			},
+			expected: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+
+			// Write all of the initial entries into the database.
+			for i, entry := range test.initial {
+				_, err := server.ZAdd("key", float64(i), entry)
+				require.NoError(t, err)
+			}
+
+			// If we weren't provided a connection to use, then use the one connected
+			// to the miniredis server.
+			var connection Connection = test.connection
+			if test.connection == nil {
+				connection = conn
+			}
+
+			_, err := GetSorted(connection, "key", Entry{})
+
+			// Verify we got the error we expected.
+			assert.Error(t, err)
+			if test.expected != nil {
+				assert.Equal(t, test.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetLast(t *testing.T) {
+	type Entry struct {
+		Id int `json:"id"`
+	}
+
+	tests := []struct {
+		name     string
+		initial  []string // Entries that should already be in the list.
+		expected Entry
+	}{
+		{
+			name:     "missing list",
+			expected: Entry{Id: -1},
+		},
+		{
+			name:     "single entry",
+			initial:  []string{`{"id":0}`},
+			expected: Entry{0},
+		},
+		{
+			name:     "multiple entries",
+			initial:  []string{`{"id":0}`, `{"id":1}`},
+			expected: Entry{1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+
+			// Write all of the initial entries into the database.
+			for _, entry := range test.initial {
+				_, err := server.Push("key", entry)
+				require.NoError(t, err)
+			}
+
+			entry := Entry{Id: -1}
+			require.NoError(t, GetLast(conn, "key", &entry))
+			assert.Equal(t, test.expected, entry)
+		})
+	}
+}
+
+func TestGetLast_Error(t *testing.T) {
+	type Entry struct {
+		Id int `json:"id"`
+	}
+
+	tests := []struct {
+		name       string
+		connection ConnectionFunc
+		initial    []string // Entries that should already be in the list.
+		expected   error
+	}{
+		{
+			name:    "json.Unmarshal error",
+			initial: []string{`{"id":0}`, `{"id":"one"}`},
+		},
+		{
+			name: "conn.Do error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				return nil, errors.New("forced error")
+			},
+			expected: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+
+			// Write all of the initial entries into the database.
+			for _, entry := range test.initial {
+				_, err := server.Push("key", entry)
+				require.NoError(t, err)
+			}
+
+			// If we weren't provided a connection to use, then use the one connected
+			// to the miniredis server.
+			var connection Connection = test.connection
+			if test.connection == nil {
+				connection = conn
+			}
+
+			var entry Entry
+			err := GetLast(connection, "key", &entry)
+
+			// Verify we got the error we expected.
+			assert.Error(t, err)
+			if test.expected != nil {
+				assert.Equal(t, test.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetVersion_IncrementVersion(t *testing.T) {
+	server, conn := NewMiniredis(t)
+
+	// Nothing has been written yet.
+	version, err := GetVersion(conn, "key")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+
+	require.NoError(t, IncrementVersion(conn, "key", time.Hour))
+	require.NoError(t, IncrementVersion(conn, "key", time.Hour))
+
+	version, err = GetVersion(conn, "key")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+	assert.Equal(t, time.Hour, server.TTL("key"))
+}
+
+func TestGetVersion_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		connection ConnectionFunc
+	}{
+		{
+			name: "conn.Do error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				return nil, errors.New("forced error")
+			},
+		},
+		{
+			name: "non-integer version",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				return []byte("abc"), nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GetVersion(test.connection, "key")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIncrementVersion_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		connection ConnectionFunc
+	}{
+		{
+			name: "INCR error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				return nil, errors.New("forced error")
+			},
+		},
+		{
+			name: "EXPIRE error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				if command == "EXPIRE" {
+					return nil, errors.New("forced error")
+				}
+				return int64(1), nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := IncrementVersion(test.connection, "key", time.Hour)
+			assert.Error(t, err)
+			assert.Equal(t, "forced error", err.Error())
+		})
+	}
+}
+
 func TestScanKeys(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -587,6 +1054,138 @@ func (cf ConnectionFunc) Do(command string, args ...interface{}) (interface{}, e
 	return cf(command, args...)
 }
 
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  string // The initial value of the key.
+		writes   int    // How many times the key is concurrently modified.
+		value    string // The value fn writes, empty when nothing is written.
+		expected string // The expected value of the key afterwards.
+		attempts int    // The expected number of times fn is called.
+	}{
+		{
+			name:     "no writes",
+			initial:  "initial",
+			expected: "initial",
+			attempts: 1,
+		},
+		{
+			name:     "write",
+			initial:  "initial",
+			value:    "updated",
+			expected: "updated",
+			attempts: 1,
+		},
+		{
+			name:     "concurrent modification",
+			initial:  "initial",
+			writes:   1,
+			value:    "updated",
+			expected: "updated",
+			attempts: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+			require.NoError(t, server.Set("key", test.initial))
+
+			var attempts int
+			read := func(conn Connection) error {
+				attempts++
+
+				// Simulate another connection writing the key after it was read.
+				if attempts <= test.writes {
+					require.NoError(t, server.Set("key", "concurrent"))
+				}
+
+				return nil
+			}
+
+			write := func(tx Connection) error {
+				if test.value == "" {
+					return nil
+				}
+
+				_, err := tx.Do("SET", "key", test.value)
+				return err
+			}
+
+			err := Update(conn, "key", read, write)
+			require.NoError(t, err)
+			assert.Equal(t, test.attempts, attempts)
+			server.CheckGet(t, "key", test.expected)
+		})
+	}
+}
+
+func TestUpdate_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		read     func(server *miniredis.Miniredis, conn Connection) error
+		write    func(tx Connection) error
+		expected error
+	}{
+		{
+			name: "read error",
+			read: func(server *miniredis.Miniredis, conn Connection) error {
+				return errors.New("forced error")
+			},
+			write: func(tx Connection) error {
+				_, err := tx.Do("SET", "key", "updated")
+				return err
+			},
+			expected: errors.New("forced error"),
+		},
+		{
+			name: "write error",
+			read: func(server *miniredis.Miniredis, conn Connection) error {
+				return nil
+			},
+			write: func(tx Connection) error {
+				_, _ = tx.Do("SET", "key", "updated")
+				return errors.New("forced error")
+			},
+			expected: errors.New("forced error"),
+		},
+		{
+			name: "always modified concurrently",
+			read: func(server *miniredis.Miniredis, conn Connection) error {
+				return server.Set("key", "concurrent")
+			},
+			write: func(tx Connection) error {
+				_, err := tx.Do("SET", "key", "updated")
+				return err
+			},
+			expected: ErrConflict,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+			require.NoError(t, server.Set("key", "initial"))
+
+			read := func(conn Connection) error {
+				return test.read(server, conn)
+			}
+
+			err := Update(conn, "key", read, test.write)
+			assert.Equal(t, test.expected, err)
+
+			// None of the writes should have been applied.
+			value, _ := server.Get("key")
+			assert.NotEqual(t, "updated", value)
+
+			// The connection should still be usable outside of a transaction.
+			reply, err := redis.String(conn.Do("GET", "key"))
+			require.NoError(t, err)
+			assert.Equal(t, value, reply)
+		})
+	}
+}
+
 func NewMiniredis(t *testing.T) (*miniredis.Miniredis, redis.Conn) {
 	server, err := miniredis.Run()
 	require.NoError(t, err)
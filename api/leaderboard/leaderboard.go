@@ -0,0 +1,209 @@
+package leaderboard
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/acrostic"
+	"github.com/bbeck/puzzles-with-chat/api/archive"
+	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/spellingbee"
+	"sort"
+	"time"
+)
+
+// RevealPenalty is the amount of time added to a channel's solve time on a
+// leaderboard for each cell that it revealed.  It's used in place of the
+// penalty from the channel's own settings so that every channel is ranked the
+// same way.
+var RevealPenalty = 30 * time.Second
+
+// Integration contains the functions needed to follow the archived attempts of
+// a single type of puzzle.
+type Integration struct {
+	ChannelID func(channel string) pubsub.Channel
+}
+
+// Integrations contains the integration for each type of puzzle that has a
+// leaderboard, indexed by the puzzle type.
+var Integrations = map[string]Integration{
+	"acrostic": {
+		ChannelID: acrostic.ChannelID,
+	},
+	"crossword": {
+		ChannelID: crossword.ChannelID,
+	},
+	"spellingbee": {
+		ChannelID: spellingbee.ChannelID,
+	},
+}
+
+// Leaderboard ranks every channel that completed a particular puzzle.
+type Leaderboard struct {
+	Puzzle  model.PuzzleSource `json:"puzzle"`
+	Entries []Entry            `json:"entries"`
+}
+
+// Entry is the placement of a single channel on a leaderboard.
+type Entry struct {
+	// The place of the channel on the leaderboard starting from 1.  Channels
+	// with the same solve time share the same rank.
+	Rank int `json:"rank"`
+
+	// The name of the channel.
+	Channel string `json:"channel"`
+
+	// The time the channel is ranked by, this includes the penalty for any cells
+	// that were revealed.
+	SolveTime model.Duration `json:"solve_time"`
+
+	// The number of cells the channel revealed while solving the puzzle.
+	CellsRevealed int `json:"cells_revealed"`
+
+	// When the channel finished the puzzle.
+	EndTime time.Time `json:"end_time"`
+}
+
+// SolveTime returns the time that an attempt is ranked by on a leaderboard.
+// The penalties from the channel's own settings are replaced by the leaderboard
+// reveal penalty.
+func SolveTime(attempt model.Attempt) time.Duration {
+	solve := attempt.TotalSolveDuration.Duration - attempt.RevealPenalty.Duration
+	return solve + time.Duration(attempt.CellsRevealed)*RevealPenalty
+}
+
+// GetLeaderboards builds the leaderboards for every puzzle of the provided type
+// that was published on the provided date, one for each publisher.  Only the
+// first completed attempt of each channel that has opted in to leaderboards is
+// ranked.  The leaderboards are ordered by publisher.
+func GetLeaderboards(conn db.Connection, kind string, date time.Time) ([]Leaderboard, error) {
+	if testLeaderboardLoadError != nil {
+		return nil, testLeaderboardLoadError
+	}
+
+	if _, ok := Integrations[kind]; !ok {
+		return nil, fmt.Errorf("unsupported puzzle type: %s", kind)
+	}
+
+	attempts, err := archive.GetCompleted(conn, kind, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// The entries of each leaderboard, indexed by publisher and then channel.
+	entries := make(map[string]map[string]Entry)
+	puzzles := make(map[string]model.PuzzleSource)
+
+	// Whether or not each channel appears on leaderboards, indexed by channel.
+	optedIn := make(map[string]bool)
+
+	for _, attempt := range attempts {
+		channel := attempt.Channel
+
+		if _, found := optedIn[channel]; !found {
+			optedIn[channel], err = IsOptedIn(conn, channel)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !optedIn[channel] {
+			continue
+		}
+
+		publisher := attempt.Puzzle.Publisher
+		if entries[publisher] == nil {
+			entries[publisher] = make(map[string]Entry)
+			puzzles[publisher] = attempt.Puzzle
+		}
+
+		// Attempts are ordered by when they finished, so a channel that solves the
+		// same puzzle again keeps its first result.
+		if _, found := entries[publisher][channel]; found {
+			continue
+		}
+
+		entries[publisher][channel] = Entry{
+			Channel:       channel,
+			SolveTime:     model.Duration{Duration: SolveTime(attempt)},
+			CellsRevealed: attempt.CellsRevealed,
+			EndTime:       attempt.EndTime,
+		}
+	}
+
+	leaderboards := make([]Leaderboard, 0, len(entries))
+	for publisher, byChannel := range entries {
+		leaderboard := Leaderboard{
+			Puzzle:  puzzles[publisher],
+			Entries: make([]Entry, 0, len(byChannel)),
+		}
+		for _, entry := range byChannel {
+			leaderboard.Entries = append(leaderboard.Entries, entry)
+		}
+
+		Rank(leaderboard.Entries)
+		leaderboards = append(leaderboards, leaderboard)
+	}
+
+	sort.Slice(leaderboards, func(i, j int) bool {
+		return leaderboards[i].Puzzle.Publisher < leaderboards[j].Puzzle.Publisher
+	})
+
+	return leaderboards, nil
+}
+
+// Rank orders the entries of a leaderboard from fastest to slowest solve time
+// and assigns each entry its rank.  Entries with the same solve time share a
+// rank and are ordered by when they finished.
+func Rank(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.SolveTime.Duration != b.SolveTime.Duration {
+			return a.SolveTime.Duration < b.SolveTime.Duration
+		}
+
+		if !a.EndTime.Equal(b.EndTime) {
+			return a.EndTime.Before(b.EndTime)
+		}
+
+		return a.Channel < b.Channel
+	})
+
+	for i := range entries {
+		if i > 0 && entries[i].SolveTime.Duration == entries[i-1].SolveTime.Duration {
+			entries[i].Rank = entries[i-1].Rank
+			continue
+		}
+
+		entries[i].Rank = i + 1
+	}
+}
+
+// OptInKey returns the key that should be used in redis to store whether or
+// not a particular channel appears on leaderboards.
+func OptInKey(channel string) string {
+	return fmt.Sprintf("%s:leaderboard", channel)
+}
+
+// IsOptedIn loads whether or not a channel appears on leaderboards from redis.
+// Channels appear on leaderboards unless they've opted out.
+func IsOptedIn(conn db.Connection, channel string) (bool, error) {
+	optedIn := true
+
+	if testOptInLoadError != nil {
+		return optedIn, testOptInLoadError
+	}
+
+	err := db.Get(conn, OptInKey(channel), &optedIn)
+	return optedIn, err
+}
+
+// SetOptedIn writes whether or not a channel appears on leaderboards to redis.
+// If the value can't be properly written then an error will be returned.
+func SetOptedIn(conn db.Connection, channel string, optedIn bool) error {
+	if testOptInSaveError != nil {
+		return testOptInSaveError
+	}
+
+	return db.Set(conn, OptInKey(channel), optedIn)
+}
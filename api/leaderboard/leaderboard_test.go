@@ -0,0 +1,223 @@
+package leaderboard
+
+import (
+	"errors"
+	"github.com/bbeck/puzzles-with-chat/api/archive"
+	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/spellingbee"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSolveTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempt  model.Attempt
+		expected time.Duration
+	}{
+		{
+			name: "no reveals",
+			attempt: model.Attempt{
+				TotalSolveDuration: model.Duration{Duration: 10 * time.Minute},
+			},
+			expected: 10 * time.Minute,
+		},
+		{
+			name: "reveals without a channel penalty",
+			attempt: model.Attempt{
+				TotalSolveDuration: model.Duration{Duration: 10 * time.Minute},
+				CellsRevealed:      2,
+			},
+			expected: 11 * time.Minute,
+		},
+		{
+			name: "reveals with a channel penalty",
+			attempt: model.Attempt{
+				TotalSolveDuration: model.Duration{Duration: 15 * time.Minute},
+				CellsRevealed:      2,
+				RevealPenalty:      model.Duration{Duration: 5 * time.Minute},
+			},
+			expected: 11 * time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SolveTime(test.attempt))
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	end := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Channel: "a", SolveTime: model.Duration{Duration: 20 * time.Minute}, EndTime: end},
+		{Channel: "b", SolveTime: model.Duration{Duration: 10 * time.Minute}, EndTime: end.Add(time.Minute)},
+		{Channel: "c", SolveTime: model.Duration{Duration: 10 * time.Minute}, EndTime: end},
+		{Channel: "d", SolveTime: model.Duration{Duration: 30 * time.Minute}, EndTime: end},
+	}
+
+	Rank(entries)
+
+	var channels []string
+	var ranks []int
+	for _, entry := range entries {
+		channels = append(channels, entry.Channel)
+		ranks = append(ranks, entry.Rank)
+	}
+	assert.Equal(t, []string{"c", "b", "a", "d"}, channels)
+	assert.Equal(t, []int{1, 1, 3, 4}, ranks)
+}
+
+func TestGetLeaderboards(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	date := time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2018, time.December, 31, 20, 0, 0, 0, time.UTC)
+	nyt := model.PuzzleSource{Publisher: "The New York Times", PublishedDate: date}
+	wsj := model.PuzzleSource{Publisher: "The Wall Street Journal", PublishedDate: date}
+
+	attempt := func(puzzle model.PuzzleSource, status model.Status, d time.Duration) model.Attempt {
+		return model.Attempt{
+			Status:             status,
+			Puzzle:             puzzle,
+			TotalSolveDuration: model.Duration{Duration: d},
+			EndTime:            end,
+		}
+	}
+
+	save := func(channel string, attempts ...model.Attempt) {
+		for _, attempt := range attempts {
+			attempt.Channel = channel
+			require.NoError(t, crossword.ArchiveAttempt(conn, channel, attempt))
+		}
+	}
+
+	// Channel a solved the NYT puzzle twice, only the first counts.
+	again := attempt(nyt, model.StatusComplete, 5*time.Minute)
+	again.EndTime = end.Add(time.Hour)
+	save("a",
+		attempt(nyt, model.StatusComplete, 20*time.Minute),
+		again,
+	)
+
+	// Channel b gave up on the NYT puzzle, then solved it with a reveal.  It also
+	// solved the WSJ puzzle.
+	revealed := attempt(nyt, model.StatusComplete, 14*time.Minute)
+	revealed.CellsRevealed = 2
+	save("b",
+		attempt(nyt, model.StatusGivenUp, 5*time.Minute),
+		revealed,
+		attempt(wsj, model.StatusComplete, 8*time.Minute),
+	)
+
+	// Channel c solved a NYT puzzle from a different day.
+	other := model.PuzzleSource{Publisher: "The New York Times", PublishedDate: date.AddDate(0, 0, -1)}
+	save("c", attempt(other, model.StatusComplete, time.Minute))
+
+	// Channel d was fastest but has opted out of leaderboards.
+	save("d", attempt(nyt, model.StatusComplete, time.Minute))
+	require.NoError(t, SetOptedIn(conn, "d", false))
+
+	// Channel e solved the spelling bee which has its own leaderboard.
+	require.NoError(t, spellingbee.ArchiveAttempt(conn, "e", attempt(nyt, model.StatusComplete, time.Minute)))
+
+	leaderboards, err := GetLeaderboards(conn, "crossword", date)
+	require.NoError(t, err)
+	assert.Equal(t, []Leaderboard{
+		{
+			Puzzle: nyt,
+			Entries: []Entry{
+				{Rank: 1, Channel: "b", SolveTime: model.Duration{Duration: 15 * time.Minute}, CellsRevealed: 2, EndTime: end},
+				{Rank: 2, Channel: "a", SolveTime: model.Duration{Duration: 20 * time.Minute}, EndTime: end},
+			},
+		},
+		{
+			Puzzle: wsj,
+			Entries: []Entry{
+				{Rank: 1, Channel: "b", SolveTime: model.Duration{Duration: 8 * time.Minute}, EndTime: end},
+			},
+		},
+	}, leaderboards)
+
+	// A date without any solves has no leaderboards.
+	leaderboards, err = GetLeaderboards(conn, "crossword", date.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Empty(t, leaderboards)
+}
+
+func TestGetLeaderboards_Error(t *testing.T) {
+	tests := []struct {
+		name                 string
+		kind                 string
+		leaderboardLoadError error
+		archiveLoadError     error
+		optInLoadError       error
+	}{
+		{
+			name: "unsupported type",
+			kind: "sudoku",
+		},
+		{
+			name:                 "error loading leaderboards",
+			kind:                 "crossword",
+			leaderboardLoadError: errors.New("forced error"),
+		},
+		{
+			name:             "error loading archive",
+			kind:             "crossword",
+			archiveLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error loading opt in",
+			kind:           "crossword",
+			optInLoadError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			date := time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC)
+			attempt := model.Attempt{
+				Channel: "a",
+				Status:  model.StatusComplete,
+				Puzzle:  model.PuzzleSource{PublishedDate: date},
+			}
+			require.NoError(t, crossword.ArchiveAttempt(conn, "a", attempt))
+
+			ForceErrorDuringLeaderboardLoad(t, test.leaderboardLoadError)
+			archive.ForceErrorDuringLoad(t, test.archiveLoadError)
+			ForceErrorDuringOptInLoad(t, test.optInLoadError)
+
+			_, err := GetLeaderboards(conn, test.kind, date)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIsOptedIn(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Channels are opted in by default.
+	optedIn, err := IsOptedIn(conn, "channel")
+	require.NoError(t, err)
+	assert.True(t, optedIn)
+
+	require.NoError(t, SetOptedIn(conn, "channel", false))
+	optedIn, err = IsOptedIn(conn, "channel")
+	require.NoError(t, err)
+	assert.False(t, optedIn)
+
+	require.NoError(t, SetOptedIn(conn, "channel", true))
+	optedIn, err = IsOptedIn(conn, "channel")
+	require.NoError(t, err)
+	assert.True(t, optedIn)
+}
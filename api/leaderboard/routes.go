@@ -0,0 +1,222 @@
+package leaderboard
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/gomodule/redigo/redis"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func RegisterRoutes(r chi.Router, pool *redis.Pool, registry *pubsub.Registry) {
+	r.Route("/leaderboards", func(r chi.Router) {
+		r.Get("/channels/{channel}", GetOptIn(pool))
+		r.Put("/channels/{channel}", UpdateOptIn(pool, registry))
+		r.Get("/{type}/{date}", GetDailyLeaderboards(pool))
+		r.Get("/{type}/{date}/events", GetEvents(pool, registry))
+	})
+}
+
+// GetOptIn returns whether or not a channel appears on leaderboards.
+func GetOptIn(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		optedIn, err := IsOptedIn(conn, channel)
+		if err != nil {
+			log.Printf("unable to load leaderboard opt in for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		render.JSON(w, r, optedIn)
+	}
+}
+
+// UpdateOptIn changes whether or not a channel appears on leaderboards.  The
+// body of the request is a boolean, true to opt in and false to opt out.
+func UpdateOptIn(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		var optedIn bool
+		if err := render.DecodeJSON(r.Body, &optedIn); err != nil {
+			log.Printf("unable to read request body: %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		if err := SetOptedIn(conn, channel, optedIn); err != nil {
+			log.Printf("unable to save leaderboard opt in for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// Let anyone watching a leaderboard know that the channel may have been
+		// added to or removed from it.
+		registry.Publish(ChannelID(channel), OptInEvent(optedIn))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// GetDailyLeaderboards returns the leaderboards for every puzzle of a type that
+// was published on a date, one for each publisher.
+func GetDailyLeaderboards(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		kind, date, ok := ParseParams(w, r)
+		if !ok {
+			return
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		leaderboards, err := GetLeaderboards(conn, kind, date)
+		if err != nil {
+			log.Printf("unable to load %s leaderboards for %s: %+v", kind, date.Format("2006-01-02"), err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		render.JSON(w, r, leaderboards)
+	}
+}
+
+// GetEvents establishes an event stream with a client.  An event stream is
+// server side event stream (SSE) with a client's browser that allows one way
+// communication from the server to the client.  Clients that call into this
+// handler will keep an open connection open to the server waiting to receive
+// the leaderboards for a date whenever a channel finishes one of its puzzles.
+func GetEvents(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		kind, date, ok := ParseParams(w, r)
+		if !ok {
+			return
+		}
+		integration := Integrations[kind]
+
+		// Construct the stream that all events for this particular client will be
+		// placed into.
+		stream := make(chan pubsub.Event, 10)
+		defer close(stream)
+
+		// Setup a connection to redis so that we can read the archived attempts.
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		// Setup a subscription in the registry to be able to see whenever a channel
+		// completes a puzzle of this type or changes whether it appears on
+		// leaderboards.  Either may change the leaderboards.
+		suffix := string(integration.ChannelID(""))
+		events := make(chan pubsub.Event, 10)
+		defer close(events)
+
+		id, err := registry.SubscribeMatching(func(channel pubsub.Channel, event pubsub.Event) bool {
+			return (event.Kind == "complete" && strings.HasSuffix(string(channel), suffix)) ||
+				event.Kind == "opt_in"
+		}, events)
+		defer registry.Unsubscribe(id)
+		if err != nil {
+			log.Printf("unable to subscribe client to %s leaderboards: %+v", kind, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// Get our initial set of leaderboards so that we can send a response to the
+		// client immediately.
+		leaderboards, err := GetLeaderboards(conn, kind, date)
+		if err != nil {
+			log.Printf("unable to load %s leaderboards for %s: %+v", kind, date.Format("2006-01-02"), err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		stream <- LeaderboardEvent(leaderboards)
+
+		// Start a background goroutine for this client that sends updated
+		// leaderboards whenever they change.
+		go func(leaderboards []Leaderboard) {
+			// Use a new connection to redis since this goroutine might live slightly
+			// longer than the GetEvents method call.  This ensures that we don't
+			// attempt to use a connection that's already been closed.
+			conn := pool.Get()
+			defer func() { _ = conn.Close() }()
+
+			for {
+				select {
+				case <-r.Context().Done():
+					// The client disconnected, the goroutine should exit.
+					return
+
+				case <-events:
+					current, err := GetLeaderboards(conn, kind, date)
+					if err != nil {
+						log.Printf("unable to load %s leaderboards for %s: %+v", kind, date.Format("2006-01-02"), err)
+
+						// Don't exit the goroutine here since the client is still connected.
+						// We'll just try again in the future.
+						continue
+					}
+
+					if !reflect.DeepEqual(leaderboards, current) {
+						leaderboards = current
+						stream <- LeaderboardEvent(leaderboards)
+					}
+				}
+			}
+		}(leaderboards)
+
+		pubsub.EmitEvents(r.Context(), w, stream)
+	}
+}
+
+// ParseParams reads the puzzle type and date of a leaderboard from the URL of a
+// request.  If either isn't valid then a bad request response is written and
+// false is returned.
+func ParseParams(w http.ResponseWriter, r *http.Request) (string, time.Time, bool) {
+	kind := chi.URLParam(r, "type")
+	if _, ok := Integrations[kind]; !ok {
+		log.Printf("unsupported leaderboard puzzle type: %s", kind)
+		w.WriteHeader(http.StatusBadRequest)
+		return "", time.Time{}, false
+	}
+
+	date, err := time.Parse("2006-01-02", chi.URLParam(r, "date"))
+	if err != nil {
+		log.Printf("unable to parse leaderboard date: %+v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return "", time.Time{}, false
+	}
+
+	return kind, date, true
+}
+
+func ChannelID(channel string) pubsub.Channel {
+	channel = fmt.Sprintf("%s:leaderboard", channel)
+	return pubsub.Channel(channel)
+}
+
+func LeaderboardEvent(leaderboards []Leaderboard) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "leaderboard",
+		Payload: leaderboards,
+	}
+}
+
+func OptInEvent(optedIn bool) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "opt_in",
+		Payload: optedIn,
+	}
+}
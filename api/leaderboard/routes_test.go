@@ -0,0 +1,403 @@
+package leaderboard
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/go-chi/chi"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRoute_GetOptIn(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Channels are opted in by default.
+	response := GET("/leaderboards/channels/channel", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "true", strings.TrimSpace(response.Body.String()))
+
+	require.NoError(t, SetOptedIn(conn, "channel", false))
+	response = GET("/leaderboards/channels/channel", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "false", strings.TrimSpace(response.Body.String()))
+}
+
+func TestRoute_GetOptIn_Error(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+	ForceErrorDuringOptInLoad(t, errors.New("forced error"))
+
+	response := GET("/leaderboards/channels/channel", router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestRoute_UpdateOptIn(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, "channel")
+
+	response := PUT("/leaderboards/channels/channel", "false", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	optedIn, err := IsOptedIn(conn, "channel")
+	require.NoError(t, err)
+	assert.False(t, optedIn)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, OptInEvent(false), event)
+	default:
+		assert.Fail(t, "no opt in event available")
+	}
+}
+
+func TestRoute_UpdateOptIn_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		optInSaveError error
+		expected       int
+	}{
+		{
+			name:     "invalid body",
+			body:     `"yes"`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:           "error saving opt in",
+			body:           "true",
+			optInSaveError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, _, _ := NewTestRouter(t)
+			ForceErrorDuringOptInSave(t, test.optInSaveError)
+
+			response := PUT("/leaderboards/channels/channel", test.body, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetDailyLeaderboards(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	date := time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC)
+	ArchiveCrossword(t, conn, "a", date, 20*time.Minute)
+	ArchiveCrossword(t, conn, "b", date, 10*time.Minute)
+
+	response := GET("/leaderboards/crossword/2018-12-31", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var leaderboards []Leaderboard
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &leaderboards))
+	require.Len(t, leaderboards, 1)
+	require.Len(t, leaderboards[0].Entries, 2)
+	assert.Equal(t, "b", leaderboards[0].Entries[0].Channel)
+	assert.Equal(t, 1, leaderboards[0].Entries[0].Rank)
+	assert.Equal(t, "a", leaderboards[0].Entries[1].Channel)
+	assert.Equal(t, 2, leaderboards[0].Entries[1].Rank)
+
+	// A date without any solves returns an empty list.
+	response = GET("/leaderboards/crossword/2019-01-01", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "[]", strings.TrimSpace(response.Body.String()))
+}
+
+func TestRoute_GetDailyLeaderboards_Error(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		leaderboardLoadError error
+		expected             int
+	}{
+		{
+			name:     "unsupported type",
+			url:      "/leaderboards/sudoku/2018-12-31",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid date",
+			url:      "/leaderboards/crossword/yesterday",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:                 "error loading leaderboards",
+			url:                  "/leaderboards/crossword/2018-12-31",
+			leaderboardLoadError: errors.New("forced error"),
+			expected:             http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, _, _ := NewTestRouter(t)
+			ForceErrorDuringLeaderboardLoad(t, test.leaderboardLoadError)
+
+			response := GET(test.url, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetEvents(t *testing.T) {
+	// This acts as a small integration test ensuring that the leaderboard event
+	// stream receives updated leaderboards as channels finish their puzzles.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	date := time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC)
+	ArchiveCrossword(t, conn, "a", date, 20*time.Minute)
+
+	// Connect to the stream, we should immediately receive the leaderboards.
+	flush, stop := SSE("/leaderboards/crossword/2018-12-31/events", router)
+	events := flush()
+	require.Len(t, events, 1)
+	assert.Equal(t, "leaderboard", events[0].Kind)
+	leaderboards := ParseLeaderboards(t, events[0].Payload)
+	require.Len(t, leaderboards, 1)
+	require.Len(t, leaderboards[0].Entries, 1)
+
+	// Channel b finishes the puzzle, it should now be in the lead.
+	ArchiveCrossword(t, conn, "b", date, 10*time.Minute)
+	registry.Publish(crossword.ChannelID("b"), crossword.CompleteEvent())
+
+	events = flush()
+	require.Len(t, events, 1)
+	leaderboards = ParseLeaderboards(t, events[0].Payload)
+	require.Len(t, leaderboards[0].Entries, 2)
+	assert.Equal(t, "b", leaderboards[0].Entries[0].Channel)
+
+	// Channel c finishes a puzzle from a different day, nothing changes so no
+	// event should be sent.
+	ArchiveCrossword(t, conn, "c", date.AddDate(0, 0, -1), time.Minute)
+	registry.Publish(crossword.ChannelID("c"), crossword.CompleteEvent())
+
+	events = flush()
+	assert.Empty(t, events)
+
+	// Channel b opts out of leaderboards and should be removed.
+	response := PUT("/leaderboards/channels/b", "false", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	events = stop()
+	require.Len(t, events, 1)
+	leaderboards = ParseLeaderboards(t, events[0].Payload)
+	require.Len(t, leaderboards[0].Entries, 1)
+	assert.Equal(t, "a", leaderboards[0].Entries[0].Channel)
+}
+
+func TestRoute_GetEvents_Error(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		leaderboardLoadError error
+		expected             int
+	}{
+		{
+			name:     "unsupported type",
+			url:      "/leaderboards/sudoku/2018-12-31/events",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid date",
+			url:      "/leaderboards/crossword/yesterday/events",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:                 "error loading leaderboards",
+			url:                  "/leaderboards/crossword/2018-12-31/events",
+			leaderboardLoadError: errors.New("forced error"),
+			expected:             http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, _, _ := NewTestRouter(t)
+			ForceErrorDuringLeaderboardLoad(t, test.leaderboardLoadError)
+
+			// This won't start a background goroutine to send events because the
+			// request will fail before reaching that part of the code.
+			response := GET(test.url, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+// ArchiveCrossword archives a completed attempt for the channel of the New York
+// Times crossword published on the provided date.
+func ArchiveCrossword(t *testing.T, conn redis.Conn, channel string, date time.Time, d time.Duration) {
+	t.Helper()
+
+	attempt := model.Attempt{
+		Channel: channel,
+		Status:  model.StatusComplete,
+		Puzzle: model.PuzzleSource{
+			Publisher:     "The New York Times",
+			PublishedDate: date,
+		},
+		TotalSolveDuration: model.Duration{Duration: d},
+		EndTime:            date.Add(20 * time.Hour),
+	}
+	require.NoError(t, crossword.ArchiveAttempt(conn, channel, attempt))
+}
+
+func ParseLeaderboards(t *testing.T, v interface{}) []Leaderboard {
+	bs, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	var leaderboards []Leaderboard
+	require.NoError(t, json.Unmarshal(bs, &leaderboards))
+
+	return leaderboards
+}
+
+func GET(url string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func PUT(url, body string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// SSE performs a streaming request to the provided router.  Because the router
+// won't immediately return, this request is done in a background goroutine.
+// When the main thread wishes to read events that have been received thus far
+// the flush method can be called and it will return any queued up events.  When
+// the main thread wishes to close the connection to the router the stop method
+// can be called and it will return any unread events.
+func SSE(url string, router chi.Router) (flush func() []pubsub.Event, stop func() []pubsub.Event) {
+	recorder := CreateTestResponseRecorder()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request := httptest.NewRequest(http.MethodGet, url, nil).WithContext(ctx)
+
+	flush = func() []pubsub.Event {
+		// Give the router a chance to write everything it needs to.
+		time.Sleep(10 * time.Millisecond)
+
+		reader, err := recorder.Body()
+		if err != nil {
+			return nil
+		}
+
+		var events []pubsub.Event
+		for {
+			bs, err := reader.ReadBytes('\n')
+			if err != nil {
+				break
+			}
+
+			if !bytes.HasPrefix(bs, []byte("data:")) {
+				continue
+			}
+
+			var event pubsub.Event
+			json.Unmarshal(bs[5:], &event)
+			events = append(events, event)
+		}
+
+		return events
+	}
+
+	stop = func() []pubsub.Event {
+		// Give the router a chance to write everything it needs to.
+		time.Sleep(10 * time.Millisecond)
+
+		recorder.Close()
+		cancel()
+		return flush()
+	}
+
+	go router.ServeHTTP(recorder, request)
+
+	return flush, stop
+}
+
+// Create a http.ResponseWriter that synchronizes whenever reads or writes
+// happen so that there are no races in a multiple goroutine environment.
+// Additionally implement the http.CloseNotifier interface so that requests can
+// be stopped by tests.
+type TestResponseRecorder struct {
+	sync.Mutex
+	headers http.Header
+	body    *bytes.Buffer
+	close   chan bool
+}
+
+func CreateTestResponseRecorder() *TestResponseRecorder {
+	return &TestResponseRecorder{
+		headers: make(http.Header),
+		body:    new(bytes.Buffer),
+		close:   make(chan bool, 1),
+	}
+}
+
+func (r *TestResponseRecorder) Header() http.Header {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.headers
+}
+
+func (r *TestResponseRecorder) Write(bs []byte) (int, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.body.Write(bs)
+}
+
+func (r *TestResponseRecorder) Body() (*bufio.Reader, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	bs, err := ioutil.ReadAll(r.body)
+	if err != nil {
+		return nil, err
+	}
+	r.body.Reset()
+	return bufio.NewReader(bytes.NewReader(bs)), nil
+}
+
+func (r *TestResponseRecorder) CloseNotify() <-chan bool {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.close
+}
+
+func (r *TestResponseRecorder) Close() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.close <- true
+}
+
+func (r *TestResponseRecorder) WriteHeader(int) {
+	// Not used
+}
@@ -0,0 +1,98 @@
+package leaderboard
+
+import (
+	"github.com/alicebob/miniredis"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/go-chi/chi"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// A cached error to use instead of building leaderboards from the database.
+var testLeaderboardLoadError error = nil
+
+// A cached error to use instead of reading an opt in from the database.
+var testOptInLoadError error = nil
+
+// A cached error to use instead of writing an opt in to the database.
+var testOptInSaveError error = nil
+
+// ForceErrorDuringLeaderboardLoad sets up an error to be returned when an
+// attempt is made to build leaderboards.
+func ForceErrorDuringLeaderboardLoad(t *testing.T, err error) {
+	t.Helper()
+
+	testLeaderboardLoadError = err
+	t.Cleanup(func() { testLeaderboardLoadError = nil })
+}
+
+// ForceErrorDuringOptInLoad sets up an error to be returned when an attempt is
+// made to load whether a channel appears on leaderboards.
+func ForceErrorDuringOptInLoad(t *testing.T, err error) {
+	t.Helper()
+
+	testOptInLoadError = err
+	t.Cleanup(func() { testOptInLoadError = nil })
+}
+
+// ForceErrorDuringOptInSave sets up an error to be returned when an attempt is
+// made to save whether a channel appears on leaderboards.
+func ForceErrorDuringOptInSave(t *testing.T, err error) {
+	t.Helper()
+
+	testOptInSaveError = err
+	t.Cleanup(func() { testOptInSaveError = nil })
+}
+
+// NewTestRouter will return a router configured with a redis pool and pubsub
+// registry and wired together along with all of the routes for leaderboards.
+func NewTestRouter(t *testing.T) (chi.Router, *redis.Pool, *pubsub.Registry) {
+	t.Helper()
+
+	// Setup redis.
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", server.Addr())
+		},
+	}
+
+	// Create the pubsub registry.
+	registry := new(pubsub.Registry)
+
+	// Setup the chi router and wire it up to the redis pool and pubsub registry.
+	router := chi.NewRouter()
+	RegisterRoutes(router, pool, registry)
+
+	return router, pool, registry
+}
+
+// NewRedisConnection will return a connection to the provided connection pool.
+// The returned connection will be configured to automatically close when the
+// test completes.
+func NewRedisConnection(t *testing.T, pool *redis.Pool) redis.Conn {
+	t.Helper()
+
+	conn := pool.Get()
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+// NewEventSubscription will return a channel of events that are subscribed to
+// the leaderboard events of the specified channel.  The subscription will be
+// configured to automatically unsubscribe when the test completes.
+func NewEventSubscription(t *testing.T, registry *pubsub.Registry, channel string) <-chan pubsub.Event {
+	t.Helper()
+
+	events := make(chan pubsub.Event, 10)
+	id, err := registry.Subscribe(ChannelID(channel), events)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { registry.Unsubscribe(id) })
+	return events
+}
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
 	"github.com/bbeck/puzzles-with-chat/api/acrostic"
+	"github.com/bbeck/puzzles-with-chat/api/cache"
 	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/leaderboard"
 	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/race"
 	"github.com/bbeck/puzzles-with-chat/api/spellingbee"
+	"github.com/bbeck/puzzles-with-chat/api/team"
+	"github.com/bbeck/puzzles-with-chat/api/web"
+	"github.com/bbeck/puzzles-with-chat/api/worker"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/gomodule/redigo/redis"
@@ -20,6 +27,31 @@ func main() {
 
 	registry := new(pubsub.Registry)
 
+	// Record or replay the responses of third party sites when asked to.
+	ConfigureHTTPFixtures()
+
+	// Cache the puzzles fetched from third party sites in the database.
+	cache.Enable(pool)
+
+	// Register the crossword sources that publish .puz files on a schedule.
+	RegisterPuzSources()
+
+	// Index the local puzzle library if one has been configured.
+	var library *crossword.Library
+	if dir := os.Getenv("PUZZLE_LIBRARY_DIR"); dir != "" {
+		library = crossword.NewLibrary(dir)
+		if err := library.Refresh(); err != nil {
+			log.Printf("unable to index puzzle library: %+v", err)
+		}
+
+		crossword.RegisterSource(library)
+	}
+
+	// Start the background jobs.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Run(ctx, pool, registry, Jobs(library))
+
 	r := chi.NewRouter()
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
@@ -32,6 +64,14 @@ func main() {
 		acrostic.RegisterRoutes(r, pool, registry)
 		crossword.RegisterRoutes(r, pool, registry)
 		spellingbee.RegisterRoutes(r, pool, registry)
+		race.RegisterRoutes(r, pool, registry)
+		team.RegisterRoutes(r, pool)
+		leaderboard.RegisterRoutes(r, pool, registry)
+
+		// The admin endpoints are only available when a token is configured.
+		if token := os.Getenv("ADMIN_TOKEN"); token != "" {
+			RegisterAdminRoutes(r, pool, token)
+		}
 	})
 
 	// Start the server.
@@ -41,6 +81,74 @@ func main() {
 	}
 }
 
+// Jobs returns the background jobs that give hints to stalled solves, pause
+// idle ones, expire ones that have run out of time and, when a puzzle library
+// is configured, keep its index up to date as files are added.
+func Jobs(library *crossword.Library) []worker.Job {
+	jobs := []worker.Job{
+		{Name: "give crossword hints", Interval: 30 * time.Second, Run: crossword.GiveHints},
+		{Name: "give spelling bee hints", Interval: 30 * time.Second, Run: spellingbee.GiveHints},
+		{Name: "pause idle acrostic solves", Interval: time.Minute, Run: acrostic.PauseIdleSolves},
+		{Name: "pause idle crossword solves", Interval: time.Minute, Run: crossword.PauseIdleSolves},
+		{Name: "pause idle spelling bee solves", Interval: time.Minute, Run: spellingbee.PauseIdleSolves},
+		{Name: "expire acrostic solves", Interval: time.Second, Run: acrostic.ExpireSolves},
+		{Name: "expire crossword solves", Interval: time.Second, Run: crossword.ExpireSolves},
+		{Name: "expire spelling bee solves", Interval: time.Second, Run: spellingbee.ExpireSolves},
+	}
+
+	if library != nil {
+		jobs = append(jobs, worker.Job{
+			Name:     "refresh puzzle library",
+			Interval: time.Minute,
+			Run: func(redis.Conn, *pubsub.Registry, time.Time) error {
+				return library.Refresh()
+			},
+		})
+	}
+
+	return jobs
+}
+
+func RegisterPuzSources() {
+	filename := os.Getenv("PUZ_SOURCES_FILE")
+	if filename == "" {
+		filename = "puz_sources.json"
+	}
+
+	sources, err := crossword.LoadPuzSources(filename)
+	if err != nil {
+		log.Fatalf("unable to load .puz sources: %+v", err)
+	}
+
+	for _, source := range sources {
+		crossword.RegisterSource(source)
+	}
+}
+
+// ConfigureHTTPFixtures makes requests to third party sites use fixture files
+// when the HTTP_FIXTURES_MODE environment variable is set.  In record mode every
+// response is saved to the directory named by HTTP_FIXTURES_DIR, and in replay
+// mode responses are served from that directory without using the network.
+func ConfigureHTTPFixtures() {
+	mode := os.Getenv("HTTP_FIXTURES_MODE")
+	if mode == "" {
+		return
+	}
+
+	dir := os.Getenv("HTTP_FIXTURES_DIR")
+	if dir == "" {
+		dir = "fixtures"
+	}
+
+	transport, err := web.NewFixtureTransport(mode, dir)
+	if err != nil {
+		log.Fatalf("unable to configure http fixtures: %+v", err)
+	}
+
+	log.Printf("using http fixtures from %s in %s mode", dir, mode)
+	web.DefaultHTTPClient.Transport = transport
+}
+
 func NewRedisPool() *redis.Pool {
 	host := os.Getenv("REDIS_HOST")
 	if host == "" {
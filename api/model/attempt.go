@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// Attempt is a record of a channel's attempt at solving a puzzle that has come
+// to an end, either because the puzzle was completed or because the channel
+// gave up on it.  It can be marshalled to/from JSON.
+type Attempt struct {
+	// The name of the channel that made the attempt.
+	Channel string `json:"channel"`
+
+	// The status the attempt ended in.  Attempts that were given up on or ran
+	// out of time have a status of StatusGivenUp or StatusExpired and are
+	// considered unfinished.
+	Status Status `json:"status"`
+
+	// A human readable description of the puzzle that was attempted.
+	Description string `json:"description,omitempty"`
+
+	// The source of the puzzle that was attempted.
+	Puzzle PuzzleSource `json:"puzzle"`
+
+	// The total time spent on the attempt.
+	TotalSolveDuration Duration `json:"total_solve_duration"`
+
+	// The number of cells of the puzzle that had their value revealed during the
+	// attempt.
+	CellsRevealed int `json:"cells_revealed,omitempty"`
+
+	// The portion of the total solve duration that came from the penalties the
+	// channel's settings applied for revealed cells.
+	RevealPenalty Duration `json:"reveal_penalty"`
+
+	// When the attempt came to an end.
+	EndTime time.Time `json:"end_time"`
+
+	// The highlights of the attempt, built from the answers that were submitted
+	// during it.
+	Summary *Summary `json:"summary,omitempty"`
+
+	// A compact text rendering of the attempt that's suitable for sharing.
+	Card string `json:"card,omitempty"`
+}
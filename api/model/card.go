@@ -0,0 +1,38 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ShareCardHeader returns the first line of the share card for an attempt at a
+// puzzle of the named kind from the provided source.
+func ShareCardHeader(kind string, source PuzzleSource) string {
+	parts := []string{kind}
+	if source.Publisher != "" {
+		parts = append(parts, source.Publisher)
+	}
+	if !source.PublishedDate.IsZero() {
+		parts = append(parts, source.PublishedDate.Format("2006-01-02"))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// ShareCardResult returns the line of a share card that describes how an
+// attempt ended and how long it took.
+func ShareCardResult(status Status, total Duration) string {
+	duration := total.Truncate(time.Second).String()
+
+	switch status {
+	case StatusComplete:
+		return fmt.Sprintf("✅ Solved in %s", duration)
+	case StatusGivenUp:
+		return fmt.Sprintf("🏳️ Gave up after %s", duration)
+	case StatusExpired:
+		return fmt.Sprintf("⏰ Ran out of time after %s", duration)
+	default:
+		return fmt.Sprintf("⏳ %s so far", duration)
+	}
+}
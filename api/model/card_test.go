@@ -0,0 +1,78 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestShareCardHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   PuzzleSource
+		expected string
+	}{
+		{
+			name: "publisher and date",
+			source: PuzzleSource{
+				Publisher:     "The New York Times",
+				PublishedDate: time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+			expected: "Crossword · The New York Times · 2018-12-31",
+		},
+		{
+			name: "no publisher",
+			source: PuzzleSource{
+				PublishedDate: time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+			expected: "Crossword · 2018-12-31",
+		},
+		{
+			name:     "no publisher or date",
+			expected: "Crossword",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ShareCardHeader("Crossword", test.source))
+		})
+	}
+}
+
+func TestShareCardResult(t *testing.T) {
+	total := Duration{Duration: 12*time.Minute + 34*time.Second + 567*time.Millisecond}
+
+	tests := []struct {
+		name     string
+		status   Status
+		expected string
+	}{
+		{
+			name:     "complete",
+			status:   StatusComplete,
+			expected: "✅ Solved in 12m34s",
+		},
+		{
+			name:     "given up",
+			status:   StatusGivenUp,
+			expected: "🏳️ Gave up after 12m34s",
+		},
+		{
+			name:     "expired",
+			status:   StatusExpired,
+			expected: "⏰ Ran out of time after 12m34s",
+		},
+		{
+			name:     "solving",
+			status:   StatusSolving,
+			expected: "⏳ 12m34s so far",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ShareCardResult(test.status, total))
+		})
+	}
+}
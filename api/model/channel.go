@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
 
 // Channel is a representation of a channel and the puzzle that is being solved.
 // It can be marshalled to/from JSON.
@@ -9,6 +14,22 @@ type Channel struct {
 	Status      Status       `json:"status"`
 	Description string       `json:"description,omitempty"`
 	Puzzle      PuzzleSource `json:"puzzle"`
+
+	// The percentage of the puzzle's cells that contain the correct letter.  Only
+	// present for crosswords and acrostics.
+	PercentCorrect float64 `json:"percent_correct,omitempty"`
+
+	// The percentage of the puzzle's cells that contain any letter.  Only
+	// present for crosswords and acrostics.
+	PercentFilled float64 `json:"percent_filled,omitempty"`
+
+	// The current score and the maximum possible score of the solve.  Only
+	// present for spelling bees.
+	Score    int `json:"score,omitempty"`
+	MaxScore int `json:"max_score,omitempty"`
+
+	// The total time spent solving the puzzle as of when the channel was loaded.
+	ElapsedTime Duration `json:"elapsed_time"`
 }
 
 // PuzzleSource is a representation of the source of a puzzle that's being
@@ -16,4 +37,22 @@ type Channel struct {
 type PuzzleSource struct {
 	Publisher     string    `json:"publisher"`
 	PublishedDate time.Time `json:"published"`
+
+	// A hash of the contents of the puzzle, see Fingerprint.  This is only
+	// present when the puzzle's contents are being compared with another's.
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// Fingerprint computes a hash of the contents of a puzzle.  Puzzles with the
+// same contents have the same fingerprint regardless of where they came from,
+// even when they don't have a publisher or published date.  If the contents
+// can't be marshalled to JSON then an empty fingerprint is returned.
+func Fingerprint(contents interface{}) string {
+	bs, err := json.Marshal(contents)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:])
 }
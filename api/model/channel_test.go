@@ -0,0 +1,18 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint(map[string]string{"1a": "QANDA"})
+	b := Fingerprint(map[string]string{"1a": "ATTIC"})
+
+	assert.Equal(t, a, Fingerprint(map[string]string{"1a": "QANDA"}))
+	assert.NotEqual(t, a, b)
+	assert.Regexp(t, `^[0-9a-f]{64}$`, a)
+
+	// Contents that can't be marshalled don't have a fingerprint.
+	assert.Equal(t, "", Fingerprint(make(chan int)))
+}
@@ -0,0 +1,10 @@
+package model
+
+// Progress is a representation of how far along a channel is in solving its
+// puzzle.  It can be marshalled to/from JSON.
+type Progress struct {
+	Status          Status       `json:"status"`
+	Puzzle          PuzzleSource `json:"puzzle"`
+	PercentComplete float64      `json:"percent_complete"`
+	ElapsedTime     Duration     `json:"elapsed_time"`
+}
@@ -25,6 +25,12 @@ const (
 
 	// The puzzle that was being solved is complete.
 	StatusComplete
+
+	// The channel gave up on solving the puzzle before it was complete.
+	StatusGivenUp
+
+	// The channel ran out of time before the puzzle was complete.
+	StatusExpired
 )
 
 func (s Status) String() string {
@@ -39,11 +45,21 @@ func (s Status) String() string {
 		return "solving"
 	case StatusComplete:
 		return "complete"
+	case StatusGivenUp:
+		return "given_up"
+	case StatusExpired:
+		return "expired"
 	default:
 		return "unknown"
 	}
 }
 
+// IsFinished determines whether or not a solve with this status has ended,
+// either by being completed, given up on or running out of time.
+func (s Status) IsFinished() bool {
+	return s == StatusComplete || s == StatusGivenUp || s == StatusExpired
+}
+
 func (s Status) MarshalJSON() ([]byte, error) {
 	switch s {
 	case StatusCreated:
@@ -51,6 +67,8 @@ func (s Status) MarshalJSON() ([]byte, error) {
 	case StatusPaused:
 	case StatusSolving:
 	case StatusComplete:
+	case StatusGivenUp:
+	case StatusExpired:
 	default:
 		return nil, fmt.Errorf("unrecognized status: %v", s)
 	}
@@ -75,6 +93,10 @@ func (s *Status) UnmarshalJSON(bs []byte) error {
 		*s = StatusSolving
 	case "complete":
 		*s = StatusComplete
+	case "given_up":
+		*s = StatusGivenUp
+	case "expired":
+		*s = StatusExpired
 	default:
 		return fmt.Errorf("unrecognized status string: %s", str)
 	}
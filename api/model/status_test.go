@@ -38,6 +38,16 @@ func TestStatus_String(t *testing.T) {
 			state:    StatusComplete,
 			expected: "complete",
 		},
+		{
+			name:     "given up",
+			state:    StatusGivenUp,
+			expected: "given_up",
+		},
+		{
+			name:     "expired",
+			state:    StatusExpired,
+			expected: "expired",
+		},
 		{
 			name:     "invalid",
 			state:    Status(17),
@@ -53,6 +63,27 @@ func TestStatus_String(t *testing.T) {
 	}
 }
 
+func TestStatus_IsFinished(t *testing.T) {
+	tests := []struct {
+		state    Status
+		expected bool
+	}{
+		{state: StatusCreated, expected: false},
+		{state: StatusSelected, expected: false},
+		{state: StatusPaused, expected: false},
+		{state: StatusSolving, expected: false},
+		{state: StatusComplete, expected: true},
+		{state: StatusGivenUp, expected: true},
+		{state: StatusExpired, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.state.String(), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.state.IsFinished())
+		})
+	}
+}
+
 func TestStatus_MarshalJSON(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -84,6 +115,16 @@ func TestStatus_MarshalJSON(t *testing.T) {
 			state:    StatusComplete,
 			expected: []byte(`"complete"`),
 		},
+		{
+			name:     "given up",
+			state:    StatusGivenUp,
+			expected: []byte(`"given_up"`),
+		},
+		{
+			name:     "expired",
+			state:    StatusExpired,
+			expected: []byte(`"expired"`),
+		},
 	}
 
 	for _, test := range tests {
@@ -145,6 +186,16 @@ func TestStatus_UnmarshalJSON(t *testing.T) {
 			bs:       []byte(`"complete"`),
 			expected: StatusComplete,
 		},
+		{
+			name:     "given up",
+			bs:       []byte(`"given_up"`),
+			expected: StatusGivenUp,
+		},
+		{
+			name:     "expired",
+			bs:       []byte(`"expired"`),
+			expected: StatusExpired,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// LoggedAnswer is a record of a single answer that was submitted during a
+// solve.  It can be marshalled to/from JSON.
+type LoggedAnswer struct {
+	// The chatter that submitted the answer.  May be empty when the answer
+	// didn't come from a known chatter.
+	User string `json:"user,omitempty"`
+
+	// The clue the answer was for.  May be empty for puzzles that don't have
+	// clues, such as a spelling bee.
+	Clue string `json:"clue,omitempty"`
+
+	// The answer that was submitted.
+	Answer string `json:"answer"`
+
+	// Whether or not the answer was applied to the puzzle.  Answers that are
+	// rejected, for example because they don't fit the clue, are still logged
+	// but aren't applied.
+	Applied bool `json:"applied"`
+
+	// Whether or not the answer was accepted.  An answer is accepted when it was
+	// applied and made progress on the solve.
+	Accepted bool `json:"accepted"`
+
+	// The amount of time that had been spent solving the puzzle when the answer
+	// was submitted.
+	ElapsedTime Duration `json:"elapsed_time"`
+}
+
+// Summary contains the highlights of a finished solve.  It can be marshalled
+// to/from JSON.
+type Summary struct {
+	// The total time spent on the solve.
+	TotalSolveDuration Duration `json:"total_solve_duration"`
+
+	// The number of answers that were submitted during the solve.
+	AnswersSubmitted int `json:"answers_submitted"`
+
+	// The number of submitted answers that were accepted.
+	AnswersAccepted int `json:"answers_accepted"`
+
+	// The chatters with the most accepted answers, ordered from the most to the
+	// fewest accepted answers.
+	TopContributors []Contributor `json:"top_contributors"`
+
+	// The accepted answer that came the quickest after the one before it.
+	FastestClue *SolvedClue `json:"fastest_clue,omitempty"`
+
+	// The last accepted answer of the solve.
+	LastClue *SolvedClue `json:"last_clue,omitempty"`
+
+	// The chatter that provided the first accepted answer of the solve.
+	FirstBlood string `json:"first_blood,omitempty"`
+}
+
+// Contributor is a chatter along with the number of answers they provided that
+// were accepted.
+type Contributor struct {
+	User    string `json:"user"`
+	Answers int    `json:"answers"`
+}
+
+// SolvedClue is an accepted answer along with how long it took to provide it
+// after the previously accepted answer (or the start of the solve).
+type SolvedClue struct {
+	User     string   `json:"user,omitempty"`
+	Clue     string   `json:"clue,omitempty"`
+	Answer   string   `json:"answer"`
+	Duration Duration `json:"duration"`
+}
+
+// MaxTopContributors is the maximum number of contributors included in a
+// summary.
+const MaxTopContributors = 3
+
+// Summarize builds the summary of a solve from the log of answers that were
+// submitted during it and the total time spent on the solve.
+func Summarize(answers []LoggedAnswer, total Duration) Summary {
+	summary := Summary{
+		TotalSolveDuration: total,
+		AnswersSubmitted:   len(answers),
+		TopContributors:    make([]Contributor, 0),
+	}
+
+	counts := make(map[string]int)
+	var previous time.Duration
+	for _, answer := range answers {
+		if !answer.Accepted {
+			continue
+		}
+
+		solved := &SolvedClue{
+			User:     answer.User,
+			Clue:     answer.Clue,
+			Answer:   answer.Answer,
+			Duration: Duration{Duration: answer.ElapsedTime.Duration - previous},
+		}
+		previous = answer.ElapsedTime.Duration
+
+		if summary.AnswersAccepted == 0 {
+			summary.FirstBlood = answer.User
+		}
+		if summary.FastestClue == nil || solved.Duration.Duration < summary.FastestClue.Duration.Duration {
+			summary.FastestClue = solved
+		}
+		summary.LastClue = solved
+		summary.AnswersAccepted++
+
+		if answer.User != "" {
+			counts[answer.User]++
+		}
+	}
+
+	for user, count := range counts {
+		summary.TopContributors = append(summary.TopContributors, Contributor{
+			User:    user,
+			Answers: count,
+		})
+	}
+
+	// Order by the number of answers, breaking ties by name so that the order is
+	// deterministic.
+	sort.Slice(summary.TopContributors, func(i, j int) bool {
+		a, b := summary.TopContributors[i], summary.TopContributors[j]
+		if a.Answers != b.Answers {
+			return a.Answers > b.Answers
+		}
+		return a.User < b.User
+	})
+
+	if len(summary.TopContributors) > MaxTopContributors {
+		summary.TopContributors = summary.TopContributors[:MaxTopContributors]
+	}
+
+	return summary
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	// Helper to build a logged answer that was submitted after the specified
+	// number of seconds of solving.
+	answer := func(user, clue string, accepted bool, seconds int) LoggedAnswer {
+		return LoggedAnswer{
+			User:        user,
+			Clue:        clue,
+			Answer:      "ANSWER",
+			Applied:     accepted,
+			Accepted:    accepted,
+			ElapsedTime: Duration{Duration: time.Duration(seconds) * time.Second},
+		}
+	}
+
+	// Helper to build a solved clue that took the specified number of seconds.
+	solved := func(user, clue string, seconds int) *SolvedClue {
+		return &SolvedClue{
+			User:     user,
+			Clue:     clue,
+			Answer:   "ANSWER",
+			Duration: Duration{Duration: time.Duration(seconds) * time.Second},
+		}
+	}
+
+	total := Duration{Duration: 10 * time.Minute}
+
+	tests := []struct {
+		name     string
+		answers  []LoggedAnswer
+		expected Summary
+	}{
+		{
+			name: "no answers",
+			expected: Summary{
+				TotalSolveDuration: total,
+				TopContributors:    []Contributor{},
+			},
+		},
+		{
+			name: "no accepted answers",
+			answers: []LoggedAnswer{
+				answer("alice", "1a", false, 5),
+				answer("bob", "2d", false, 10),
+			},
+			expected: Summary{
+				TotalSolveDuration: total,
+				AnswersSubmitted:   2,
+				TopContributors:    []Contributor{},
+			},
+		},
+		{
+			name: "single accepted answer",
+			answers: []LoggedAnswer{
+				answer("alice", "1a", true, 30),
+			},
+			expected: Summary{
+				TotalSolveDuration: total,
+				AnswersSubmitted:   1,
+				AnswersAccepted:    1,
+				TopContributors:    []Contributor{{User: "alice", Answers: 1}},
+				FastestClue:        solved("alice", "1a", 30),
+				LastClue:           solved("alice", "1a", 30),
+				FirstBlood:         "alice",
+			},
+		},
+		{
+			name: "rejected answers don't count towards durations",
+			answers: []LoggedAnswer{
+				answer("alice", "1a", true, 30),
+				answer("bob", "2d", false, 35),
+				answer("bob", "2d", true, 50),
+				answer("carol", "3d", true, 90),
+			},
+			expected: Summary{
+				TotalSolveDuration: total,
+				AnswersSubmitted:   4,
+				AnswersAccepted:    3,
+				TopContributors: []Contributor{
+					{User: "alice", Answers: 1},
+					{User: "bob", Answers: 1},
+					{User: "carol", Answers: 1},
+				},
+				FastestClue: solved("bob", "2d", 20),
+				LastClue:    solved("carol", "3d", 40),
+				FirstBlood:  "alice",
+			},
+		},
+		{
+			name: "top contributors are limited",
+			answers: []LoggedAnswer{
+				answer("dave", "1a", true, 10),
+				answer("carol", "2a", true, 30),
+				answer("carol", "3a", true, 50),
+				answer("bob", "4a", true, 55),
+				answer("alice", "5a", true, 70),
+				answer("bob", "6a", true, 90),
+				answer("bob", "7a", true, 120),
+			},
+			expected: Summary{
+				TotalSolveDuration: total,
+				AnswersSubmitted:   7,
+				AnswersAccepted:    7,
+				TopContributors: []Contributor{
+					{User: "bob", Answers: 3},
+					{User: "carol", Answers: 2},
+					{User: "alice", Answers: 1},
+				},
+				FastestClue: solved("bob", "4a", 5),
+				LastClue:    solved("bob", "7a", 30),
+				FirstBlood:  "dave",
+			},
+		},
+		{
+			name: "anonymous answers aren't contributors",
+			answers: []LoggedAnswer{
+				answer("", "1a", true, 10),
+				answer("alice", "2a", true, 30),
+			},
+			expected: Summary{
+				TotalSolveDuration: total,
+				AnswersSubmitted:   2,
+				AnswersAccepted:    2,
+				TopContributors:    []Contributor{{User: "alice", Answers: 1}},
+				FastestClue:        solved("", "1a", 10),
+				LastClue:           solved("alice", "2a", 20),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Summarize(test.answers, total))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// LastActivityTime returns the most recent of the time that a solve was last
+// started or resumed and the time that it last made progress.  If neither of
+// these have happened yet then nil is returned.
+func LastActivityTime(start, progress *time.Time) *time.Time {
+	if progress == nil {
+		return start
+	}
+
+	if start == nil || progress.After(*start) {
+		return progress
+	}
+
+	return start
+}
+
+// ElapsedTime returns the total amount of time that has been spent on a solve
+// as of the provided time.  The total is the time spent up to when the solve
+// was last started, and start is when it was last started or nil if the solve
+// isn't currently running.
+func ElapsedTime(total time.Duration, start *time.Time, now time.Time) time.Duration {
+	if start != nil {
+		total += now.Sub(*start)
+	}
+
+	return total
+}
+
+// RemainingTime returns the amount of time left before a solve that has been
+// running for the elapsed time reaches its time limit.  If the solve doesn't
+// have a time limit then zero is returned.
+func RemainingTime(limit, elapsed time.Duration) time.Duration {
+	if limit <= 0 || elapsed >= limit {
+		return 0
+	}
+
+	return limit - elapsed
+}
+
+// IsOutOfTime returns whether or not a solve that has been running for the
+// elapsed time has a time limit that has been reached.
+func IsOutOfTime(limit, elapsed time.Duration) bool {
+	return limit > 0 && elapsed >= limit
+}
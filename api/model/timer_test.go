@@ -0,0 +1,108 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLastActivityTime(t *testing.T) {
+	earlier := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(5 * time.Minute)
+
+	tests := []struct {
+		name     string
+		start    *time.Time
+		progress *time.Time
+		expected *time.Time
+	}{
+		{
+			name: "neither set",
+		},
+		{
+			name:     "only start time",
+			start:    &earlier,
+			expected: &earlier,
+		},
+		{
+			name:     "only progress time",
+			progress: &earlier,
+			expected: &earlier,
+		},
+		{
+			name:     "progress after start",
+			start:    &earlier,
+			progress: &later,
+			expected: &later,
+		},
+		{
+			name:     "start after progress",
+			start:    &later,
+			progress: &earlier,
+			expected: &later,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, LastActivityTime(test.start, test.progress))
+		})
+	}
+}
+
+func TestRemainingTime(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name              string
+		limit             time.Duration
+		total             time.Duration
+		lastStartTime     *time.Time
+		expectedRemaining time.Duration
+		expectedOutOfTime bool
+	}{
+		{
+			name:          "no time limit",
+			total:         time.Hour,
+			lastStartTime: before(time.Hour),
+		},
+		{
+			name:              "paused",
+			limit:             15 * time.Minute,
+			total:             10 * time.Minute,
+			expectedRemaining: 5 * time.Minute,
+		},
+		{
+			name:              "solving",
+			limit:             15 * time.Minute,
+			total:             5 * time.Minute,
+			lastStartTime:     before(5 * time.Minute),
+			expectedRemaining: 5 * time.Minute,
+		},
+		{
+			name:              "exactly out of time",
+			limit:             15 * time.Minute,
+			total:             5 * time.Minute,
+			lastStartTime:     before(10 * time.Minute),
+			expectedOutOfTime: true,
+		},
+		{
+			name:              "past the time limit",
+			limit:             15 * time.Minute,
+			lastStartTime:     before(time.Hour),
+			expectedOutOfTime: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			elapsed := ElapsedTime(test.total, test.lastStartTime, now)
+			assert.Equal(t, test.expectedRemaining, RemainingTime(test.limit, elapsed))
+			assert.Equal(t, test.expectedOutOfTime, IsOutOfTime(test.limit, elapsed))
+		})
+	}
+}
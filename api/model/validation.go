@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+	"github.com/go-chi/render"
+	"net/http"
+)
+
+// ValidationReport describes the problems found when validating a puzzle that
+// was uploaded by a user.  Errors are problems that prevent the puzzle from
+// being solved and cause it to be rejected.  Warnings are problems that are
+// worth fixing but don't prevent the puzzle from being solved.
+type ValidationReport struct {
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+// NewValidationReport creates an empty report.
+func NewValidationReport() *ValidationReport {
+	return &ValidationReport{
+		Errors:   make([]string, 0),
+		Warnings: make([]string, 0),
+	}
+}
+
+// Errorf adds an error to the report.
+func (r *ValidationReport) Errorf(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// Warnf adds a warning to the report.
+func (r *ValidationReport) Warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Valid returns true if the report doesn't contain any errors.
+func (r *ValidationReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Render writes the report to a response with the given status code.
+func (r *ValidationReport) Render(w http.ResponseWriter, req *http.Request, status int) {
+	render.Status(req, status)
+	render.JSON(w, req, r)
+}
@@ -0,0 +1,169 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/text/encoding/charmap"
+	"strings"
+)
+
+// The dimensions in points of a US letter sized page.
+const (
+	PageWidth  = 612.0
+	PageHeight = 792.0
+)
+
+// The size in points of the blank space around the edges of each page.
+const Margin = 36.0
+
+// Font is one of the fonts that can be used to draw text in a document.  Only
+// the standard Helvetica fonts are supported since every PDF reader has them
+// available without needing to embed them into the document.
+type Font string
+
+const (
+	Regular Font = "F1"
+	Bold    Font = "F2"
+)
+
+// The names of the base fonts of each of the supported fonts.
+var baseFonts = map[Font]string{
+	Regular: "Helvetica",
+	Bold:    "Helvetica-Bold",
+}
+
+// Document is a PDF document made up of pages of drawing operations.
+type Document struct {
+	Pages []*Page
+}
+
+// New creates a new, empty document.
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage adds a new blank page to the end of the document and returns it.
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.Pages = append(d.Pages, page)
+	return page
+}
+
+// Bytes serializes the document into the PDF file format.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	// object writes an object to the buffer, recording its offset for the
+	// cross-reference table.  Objects are numbered in the order they're written.
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// The catalog, page tree and fonts are objects 1 through 4, and then each
+	// page is followed by its content stream.
+	kids := make([]string, len(d.Pages))
+	for i := range d.Pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.Pages)))
+	object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", baseFonts[Regular]))
+	object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", baseFonts[Bold]))
+
+	for i, page := range d.Pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, Regular, Bold, 6+2*i,
+		))
+
+		content := page.content.String()
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n", len(offsets)+1)
+	buf.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// Page is a single page of a document.  Coordinates on a page are measured in
+// points from the top left corner of the page.
+type Page struct {
+	content bytes.Buffer
+}
+
+// FillRect draws a rectangle filled with the specified gray level, where 0 is
+// black and 1 is white.
+func (p *Page) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "%.2f g %.2f %.2f %.2f %.2f re f 0 g\n", gray, x, PageHeight-y-h, w, h)
+}
+
+// StrokeRect draws the outline of a rectangle using a line of the specified
+// width.
+func (p *Page) StrokeRect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%.2f w %.2f %.2f %.2f %.2f re S\n", width, x, PageHeight-y-h, w, h)
+}
+
+// Line draws a straight line between two points using a line of the specified
+// width.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// Circle draws the outline of a circle using a line of the specified width.
+func (p *Page) Circle(cx, cy, r, width float64) {
+	// PDFs don't have a circle operator, so the circle is approximated by four
+	// cubic Bézier curves, one for each quadrant.
+	const kappa = 0.5523
+	k := kappa * r
+	cy = PageHeight - cy
+
+	fmt.Fprintf(&p.content, "%.2f w %.2f %.2f m ", width, cx+r, cy)
+	fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx+r, cy+k, cx+k, cy+r, cx, cy+r)
+	fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx-k, cy+r, cx-r, cy+k, cx-r, cy)
+	fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx-r, cy-k, cx-k, cy-r, cx, cy-r)
+	fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f %.2f %.2f c S\n", cx+k, cy-r, cx+r, cy-k, cx+r, cy)
+}
+
+// Text draws a line of text with its baseline starting at the specified point.
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PageHeight-y, escape(s))
+}
+
+// escape converts a string into the bytes of a PDF literal string.  Characters
+// that aren't part of the Windows-1252 character set used by the fonts are
+// replaced with a question mark.
+func escape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+
+		if b == '(' || b == ')' || b == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(b)
+	}
+
+	return sb.String()
+}
+
+// Header draws a title and a subtitle at the top of the page and returns the
+// vertical position just below them.
+func (p *Page) Header(title, subtitle string) float64 {
+	p.Text(Margin, Margin+16, Bold, 18, title)
+	p.Text(Margin, Margin+32, Regular, 10, subtitle)
+	return Margin + 44
+}
@@ -0,0 +1,69 @@
+package pdf
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := New()
+	page := doc.AddPage()
+	page.FillRect(10, 20, 30, 40, 0.5)
+	page.StrokeRect(10, 20, 30, 40, 1)
+	page.Line(0, 0, 10, 10, 1)
+	page.Circle(50, 50, 5, 1)
+	page.Text(10, 20, Bold, 12, "Hello")
+	doc.AddPage()
+
+	bs := string(doc.Bytes())
+	assert.True(t, strings.HasPrefix(bs, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(bs, "%%EOF\n"))
+	assert.Contains(t, bs, "/Count 2")
+	assert.Contains(t, bs, "0.50 g 10.00 732.00 30.00 40.00 re f 0 g")
+	assert.Contains(t, bs, "BT /F2 12.00 Tf 10.00 772.00 Td (Hello) Tj ET")
+
+	// The cross-reference table should point at the start of each object.
+	matches := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(bs)
+	require.Len(t, matches, 2)
+	xref, err := strconv.Atoi(matches[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(bs[xref:], "xref\n0 9\n"))
+
+	entries := strings.Split(bs[xref:], "\n")[3:11]
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[:10])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(bs[offset:], fmt.Sprintf("%d 0 obj\n", i+1)))
+	}
+
+	// Each stream's length should match its content.
+	for _, match := range regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)\nendstream`).FindAllStringSubmatch(bs, -1) {
+		length, err := strconv.Atoi(match[1])
+		require.NoError(t, err)
+		assert.Equal(t, length, len(match[2]))
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "plain", expected: "plain"},
+		{input: `a (b) \c`, expected: `a \(b\) \\c`},
+		{input: "café", expected: "caf\xe9"},
+		{input: "“quoted”", expected: "\x93quoted\x94"},
+		{input: "日本", expected: "??"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, escape(test.input))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package pdf
+
+// The size in points of the space between the columns of a flow.
+const gutter = 18.0
+
+// Flow lays out content into columns that fill a region of a page from top to
+// bottom and left to right.  When the last column of a page is full a new page
+// is added to the document and the content continues in columns that span the
+// full height of the new page.
+type Flow struct {
+	// The font size of the text of the flow.
+	Size float64
+
+	// The distance in points between the baselines of consecutive lines of text.
+	Leading float64
+
+	// The indentation in points of the lines of a paragraph after its label.
+	Indent float64
+
+	doc     *Document
+	page    *Page
+	columns int
+	column  int
+	top     float64
+	y       float64
+}
+
+// NewFlow creates a flow of the specified number of columns that begins on the
+// last page of the document at the vertical position top.
+func (d *Document) NewFlow(top float64, columns int) *Flow {
+	const size = 9.0
+
+	return &Flow{
+		Size:    size,
+		Leading: size * 1.25,
+		Indent:  TextWidth(Bold, size, "000") + 4,
+		doc:     d,
+		page:    d.Pages[len(d.Pages)-1],
+		columns: columns,
+		top:     top,
+		y:       top,
+	}
+}
+
+// ColumnWidth returns the width in points of each of the flow's columns.
+func (f *Flow) ColumnWidth() float64 {
+	return (PageWidth - 2*Margin - float64(f.columns-1)*gutter) / float64(f.columns)
+}
+
+// Reserve claims a region of the specified height in the flow, moving to the
+// next column or page if the current column doesn't have enough room.  The
+// page and the coordinates of the top left corner of the region are returned.
+func (f *Flow) Reserve(height float64) (*Page, float64, float64) {
+	if f.y+height > PageHeight-Margin && f.y > f.top {
+		f.column++
+		if f.column == f.columns {
+			f.page = f.doc.AddPage()
+			f.column = 0
+			f.top = Margin
+		}
+		f.y = f.top
+	}
+
+	x := Margin + float64(f.column)*(f.ColumnWidth()+gutter)
+	y := f.y
+	f.y += height
+
+	return f.page, x, y
+}
+
+// Heading adds a line of bold text to the flow followed by a small amount of
+// space.
+func (f *Flow) Heading(text string) {
+	// Leave a gap before the heading unless it's at the top of a column.
+	if f.y > f.top {
+		f.y += f.Leading
+	}
+
+	page, x, y := f.Reserve(f.Leading + f.Leading/2)
+	page.Text(x, y+f.Size, Bold, f.Size+1, text)
+}
+
+// Paragraph adds a bold label followed by wrapped text to the flow.  The lines
+// of the text are indented so that they line up after the label.
+func (f *Flow) Paragraph(label, text string) {
+	lines := Wrap(Regular, f.Size, text, f.ColumnWidth()-f.Indent)
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+
+	for i, line := range lines {
+		page, x, y := f.Reserve(f.Leading)
+		if i == 0 {
+			page.Text(x, y+f.Size, Bold, f.Size, label)
+		}
+		page.Text(x+f.Indent, y+f.Size, Regular, f.Size, line)
+	}
+}
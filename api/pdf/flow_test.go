@@ -0,0 +1,46 @@
+package pdf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFlow_Reserve(t *testing.T) {
+	doc := New()
+	doc.AddPage()
+
+	flow := doc.NewFlow(400, 2)
+
+	// The first region starts at the top of the first column.
+	page, x, y := flow.Reserve(100)
+	assert.Equal(t, doc.Pages[0], page)
+	assert.Equal(t, Margin, x)
+	assert.Equal(t, 400.0, y)
+
+	// A region that doesn't fit moves to the next column.
+	page, x, y = flow.Reserve(300)
+	assert.Equal(t, doc.Pages[0], page)
+	assert.Equal(t, Margin+flow.ColumnWidth()+gutter, x)
+	assert.Equal(t, 400.0, y)
+
+	// A region that doesn't fit in the last column moves to a new page that's
+	// used from the top margin.
+	page, x, y = flow.Reserve(300)
+	assert.Len(t, doc.Pages, 2)
+	assert.Equal(t, doc.Pages[1], page)
+	assert.Equal(t, Margin, x)
+	assert.Equal(t, Margin, y)
+}
+
+func TestFlow_Paragraph(t *testing.T) {
+	doc := New()
+	doc.AddPage()
+
+	flow := doc.NewFlow(Margin, 4)
+	flow.Paragraph("12", "A clue that is long enough that it needs to wrap onto more than one line")
+
+	content := doc.Pages[0].content.String()
+	assert.Contains(t, content, "(12) Tj")
+	assert.Contains(t, content, "(A clue that")
+	assert.Greater(t, flow.y, Margin+flow.Leading)
+}
@@ -0,0 +1,54 @@
+package pdf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTextWidth(t *testing.T) {
+	for font, ws := range widths {
+		assert.Len(t, ws, '~'-' '+1, "font %s", font)
+	}
+
+	assert.Equal(t, 0.0, TextWidth(Regular, 10, ""))
+	assert.InDelta(t, 6.67, TextWidth(Regular, 10, "A"), 0.001)
+	assert.InDelta(t, 7.22, TextWidth(Bold, 10, "A"), 0.001)
+	assert.InDelta(t, 2.22+5.56, TextWidth(Regular, 10, "ia"), 0.001)
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    float64
+		expected []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "fits on one line",
+			input:    "a b c",
+			width:    100,
+			expected: []string{"a b c"},
+		},
+		{
+			name:     "wraps between words",
+			input:    "aaa bbb ccc",
+			width:    TextWidth(Regular, 10, "aaa bbb"),
+			expected: []string{"aaa bbb", "ccc"},
+		},
+		{
+			name:     "long word on its own line",
+			input:    "a bbbbbbbbbb c",
+			width:    TextWidth(Regular, 10, "bbb"),
+			expected: []string{"a", "bbbbbbbbbb", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Wrap(Regular, 10, test.input, test.width))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package pdf
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Write serializes the document and writes it to the response as a download
+// with the specified filename.
+func Write(w http.ResponseWriter, filename string, doc *Document) error {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	_, err := w.Write(doc.Bytes())
+	return err
+}
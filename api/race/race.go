@@ -0,0 +1,172 @@
+package race
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/acrostic"
+	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/spellingbee"
+	"github.com/gomodule/redigo/redis"
+	"sort"
+	"time"
+)
+
+// Race links together the solves of several channels that are all solving the
+// same puzzle so that they can be started at the same moment and their
+// progress can be compared against one another.
+type Race struct {
+	// The type of puzzle that is being raced, this is always one of the keys of
+	// the Integrations map.
+	Type string `json:"type"`
+
+	// The puzzle that every channel in the race is solving.
+	Puzzle model.PuzzleSource `json:"puzzle"`
+
+	// The names of the channels that are participating in the race.
+	Channels []string `json:"channels"`
+
+	// The time that the race was started.  If the race hasn't been started yet
+	// then this will be nil.
+	StartTime *time.Time `json:"start_time,omitempty"`
+}
+
+// Integration contains the functions needed to start and follow the solves of
+// a single type of puzzle during a race.
+type Integration struct {
+	ChannelID   func(channel string) pubsub.Channel
+	GetProgress func(conn redis.Conn, channel string, now time.Time) (model.Progress, error)
+	StartSolve  func(conn redis.Conn, registry *pubsub.Registry, channel string, now time.Time) error
+}
+
+// Integrations contains the integration for each type of puzzle that can be
+// raced, indexed by the puzzle type.
+var Integrations = map[string]Integration{
+	"acrostic": {
+		ChannelID:   acrostic.ChannelID,
+		GetProgress: acrostic.GetProgress,
+		StartSolve:  acrostic.StartSolve,
+	},
+	"crossword": {
+		ChannelID:   crossword.ChannelID,
+		GetProgress: crossword.GetProgress,
+		StartSolve:  crossword.StartSolve,
+	},
+	"spellingbee": {
+		ChannelID:   spellingbee.ChannelID,
+		GetProgress: spellingbee.GetProgress,
+		StartSolve:  spellingbee.StartSolve,
+	},
+}
+
+// Entrant is the standing of a single channel within a race.
+type Entrant struct {
+	// The name of the channel.
+	Channel string `json:"channel"`
+
+	// The status of the channel's solve.
+	Status model.Status `json:"status"`
+
+	// The percentage of the puzzle that the channel has correctly completed.
+	PercentComplete float64 `json:"percent_complete"`
+
+	// The amount of time that the channel took to finish the puzzle.  This is
+	// only present once the channel has completed the puzzle.
+	FinishTime *model.Duration `json:"finish_time,omitempty"`
+}
+
+// NewEntrant creates the standing of a channel within a race from the progress
+// of its solve.
+func NewEntrant(channel string, progress model.Progress) Entrant {
+	entrant := Entrant{
+		Channel:         channel,
+		Status:          progress.Status,
+		PercentComplete: progress.PercentComplete,
+	}
+
+	if progress.Status == model.StatusComplete {
+		finish := progress.ElapsedTime
+		entrant.FinishTime = &finish
+	}
+
+	return entrant
+}
+
+// SortEntrants orders the entrants of a race from first to last place.
+// Channels that have finished come first ordered by how long they took to
+// finish, followed by the remaining channels ordered by how much of the puzzle
+// they've completed.  Ties are broken by the name of the channel.
+func SortEntrants(entrants []Entrant) {
+	sort.Slice(entrants, func(i, j int) bool {
+		a, b := entrants[i], entrants[j]
+		if (a.FinishTime != nil) != (b.FinishTime != nil) {
+			return a.FinishTime != nil
+		}
+
+		if a.FinishTime != nil && a.FinishTime.Duration != b.FinishTime.Duration {
+			return a.FinishTime.Duration < b.FinishTime.Duration
+		}
+
+		if a.PercentComplete != b.PercentComplete {
+			return a.PercentComplete > b.PercentComplete
+		}
+
+		return a.Channel < b.Channel
+	})
+}
+
+// GetStandings determines the standing of each channel in a race as of the
+// provided time.  The returned entrants are ordered from first to last place.
+func GetStandings(conn redis.Conn, race Race, now time.Time) ([]Entrant, error) {
+	integration, ok := Integrations[race.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported puzzle type: %s", race.Type)
+	}
+
+	entrants := make([]Entrant, 0, len(race.Channels))
+	for _, channel := range race.Channels {
+		progress, err := integration.GetProgress(conn, channel, now)
+		if err != nil {
+			return nil, err
+		}
+
+		entrants = append(entrants, NewEntrant(channel, progress))
+	}
+
+	SortEntrants(entrants)
+	return entrants, nil
+}
+
+// RaceKey returns the key that should be used in redis to store a particular
+// race.
+func RaceKey(name string) string {
+	return fmt.Sprintf("race:%s", name)
+}
+
+// RaceTTL determines how long a race should remain in redis after it was last
+// updated.
+var RaceTTL = 24 * time.Hour
+
+// GetRace loads a race from redis.  If the race can't be loaded then an error
+// will be returned.  If there is no race then an empty race will be returned.
+func GetRace(conn db.Connection, name string) (Race, error) {
+	var race Race
+
+	if testRaceLoadError != nil {
+		return race, testRaceLoadError
+	}
+
+	err := db.Get(conn, RaceKey(name), &race)
+	return race, err
+}
+
+// SetRace writes a race to redis.  If the race can't be properly written then
+// an error will be returned.
+func SetRace(conn db.Connection, name string, race Race) error {
+	if testRaceSaveError != nil {
+		return testRaceSaveError
+	}
+
+	return db.SetWithTTL(conn, RaceKey(name), race, RaceTTL)
+}
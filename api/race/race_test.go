@@ -0,0 +1,201 @@
+package race
+
+import (
+	"errors"
+	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNewEntrant(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress model.Progress
+		expected Entrant
+	}{
+		{
+			name: "solving",
+			progress: model.Progress{
+				Status:          model.StatusSolving,
+				PercentComplete: 50,
+				ElapsedTime:     model.Duration{Duration: 5 * time.Minute},
+			},
+			expected: Entrant{
+				Channel:         "channel",
+				Status:          model.StatusSolving,
+				PercentComplete: 50,
+			},
+		},
+		{
+			name: "complete",
+			progress: model.Progress{
+				Status:          model.StatusComplete,
+				PercentComplete: 100,
+				ElapsedTime:     model.Duration{Duration: 5 * time.Minute},
+			},
+			expected: Entrant{
+				Channel:         "channel",
+				Status:          model.StatusComplete,
+				PercentComplete: 100,
+				FinishTime:      &model.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		{
+			name: "given up",
+			progress: model.Progress{
+				Status:          model.StatusGivenUp,
+				PercentComplete: 100,
+				ElapsedTime:     model.Duration{Duration: 5 * time.Minute},
+			},
+			expected: Entrant{
+				Channel:         "channel",
+				Status:          model.StatusGivenUp,
+				PercentComplete: 100,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NewEntrant("channel", test.progress))
+		})
+	}
+}
+
+func TestSortEntrants(t *testing.T) {
+	finish := func(d time.Duration) *model.Duration {
+		return &model.Duration{Duration: d}
+	}
+
+	entrants := []Entrant{
+		{Channel: "a", PercentComplete: 10},
+		{Channel: "b", PercentComplete: 100, FinishTime: finish(20 * time.Minute)},
+		{Channel: "c", PercentComplete: 50},
+		{Channel: "d", PercentComplete: 100, FinishTime: finish(10 * time.Minute)},
+		{Channel: "e", PercentComplete: 50},
+	}
+
+	SortEntrants(entrants)
+
+	var channels []string
+	for _, entrant := range entrants {
+		channels = append(channels, entrant.Channel)
+	}
+	assert.Equal(t, []string{"d", "b", "c", "e", "a"}, channels)
+}
+
+func TestGetStandings(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Channel a has finished the puzzle while channel b is still working on it.
+	a := crossword.NewState(t, "xwordinfo-nyt-20181231.json")
+	a.Status = model.StatusComplete
+	a.LastStartTime = nil
+	a.TotalSolveDuration = model.Duration{Duration: 20 * time.Minute}
+	a.Cells = a.Puzzle.Cells
+	require.NoError(t, crossword.SetState(conn, "a", a))
+
+	b := crossword.NewState(t, "xwordinfo-nyt-20181231.json")
+	b.Status = model.StatusSolving
+	require.NoError(t, b.ApplyAnswer("1a", "QANDA", false))
+	require.NoError(t, crossword.SetState(conn, "b", b))
+
+	race := Race{Type: "crossword", Channels: []string{"b", "a"}}
+	entrants, err := GetStandings(conn, race, now)
+	require.NoError(t, err)
+	require.Len(t, entrants, 2)
+
+	assert.Equal(t, "a", entrants[0].Channel)
+	assert.Equal(t, model.StatusComplete, entrants[0].Status)
+	assert.Equal(t, 100.0, entrants[0].PercentComplete)
+	assert.Equal(t, &model.Duration{Duration: 20 * time.Minute}, entrants[0].FinishTime)
+
+	assert.Equal(t, "b", entrants[1].Channel)
+	assert.Equal(t, model.StatusSolving, entrants[1].Status)
+	assert.Equal(t, b.PercentComplete(), entrants[1].PercentComplete)
+	assert.Nil(t, entrants[1].FinishTime)
+}
+
+func TestGetStandings_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		race           Race
+		stateLoadError error
+	}{
+		{
+			name: "unsupported type",
+			race: Race{Type: "sudoku", Channels: []string{"a", "b"}},
+		},
+		{
+			name:           "error loading state",
+			race:           Race{Type: "crossword", Channels: []string{"a", "b"}},
+			stateLoadError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			if test.stateLoadError != nil {
+				crossword.ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+
+			_, err := GetStandings(conn, test.race, time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGetRace(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// A race that doesn't exist is empty.
+	race, err := GetRace(conn, "race")
+	require.NoError(t, err)
+	assert.Equal(t, Race{}, race)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	expected := Race{
+		Type: "crossword",
+		Puzzle: model.PuzzleSource{
+			Publisher:     "The New York Times",
+			PublishedDate: time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+		Channels:  []string{"a", "b"},
+		StartTime: &now,
+	}
+	require.NoError(t, SetRace(conn, "race", expected))
+
+	race, err = GetRace(conn, "race")
+	require.NoError(t, err)
+	assert.Equal(t, expected, race)
+}
+
+func TestGetRace_Error(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	ForceErrorDuringRaceLoad(t, errors.New("forced error"))
+
+	_, err := GetRace(conn, "race")
+	assert.Error(t, err)
+}
+
+func TestSetRace_Error(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	ForceErrorDuringRaceSave(t, errors.New("forced error"))
+
+	err := SetRace(conn, "race", Race{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,341 @@
+package race
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/gomodule/redigo/redis"
+	"log"
+	"net/http"
+	"time"
+)
+
+func RegisterRoutes(r chi.Router, pool *redis.Pool, registry *pubsub.Registry) {
+	r.Route("/races/{race}", func(r chi.Router) {
+		r.Put("/", CreateRace(pool, registry))
+		r.Put("/start", StartRace(pool, registry))
+		r.Get("/events", GetEvents(pool, registry))
+	})
+}
+
+// CreateRace links together the solves of several channels into a race.  Every
+// channel must have the same puzzle selected and not have started solving it
+// yet.  A race that has already been started cannot be changed.
+func CreateRace(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "race")
+
+		var request struct {
+			Type     string   `json:"type"`
+			Channels []string `json:"channels"`
+		}
+		if err := render.DecodeJSON(r.Body, &request); err != nil {
+			log.Printf("unable to read request body: %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		integration, ok := Integrations[request.Type]
+		if !ok {
+			log.Printf("unable to create race %s, unsupported puzzle type: %s", name, request.Type)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(request.Channels) < 2 {
+			log.Printf("unable to create race %s, at least two channels are required", name)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		seen := make(map[string]bool)
+		for _, channel := range request.Channels {
+			if channel == "" || seen[channel] {
+				log.Printf("unable to create race %s, invalid channel: %q", name, channel)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			seen[channel] = true
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		existing, err := GetRace(conn, name)
+		if err != nil {
+			log.Printf("unable to load race %s: %+v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if existing.StartTime != nil {
+			log.Printf("unable to create race %s, it has already been started", name)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		// Make sure every channel is ready to race on the same puzzle.
+		now := time.Now()
+		entrants := make([]Entrant, 0, len(request.Channels))
+
+		var puzzle *model.PuzzleSource
+		for _, channel := range request.Channels {
+			progress, err := integration.GetProgress(conn, channel, now)
+			if err != nil {
+				log.Printf("unable to load progress for channel %s: %+v", channel, err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if progress.Status != model.StatusSelected {
+				log.Printf("unable to create race %s, channel %s has status %s", name, channel, progress.Status)
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			if puzzle == nil {
+				puzzle = &progress.Puzzle
+			} else if !SamePuzzle(*puzzle, progress.Puzzle) {
+				log.Printf("unable to create race %s, channel %s is solving a different puzzle", name, channel)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			entrants = append(entrants, NewEntrant(channel, progress))
+		}
+
+		race := Race{
+			Type:     request.Type,
+			Puzzle:   *puzzle,
+			Channels: request.Channels,
+		}
+
+		if err := SetRace(conn, name, race); err != nil {
+			log.Printf("unable to save race %s: %+v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		SortEntrants(entrants)
+		registry.Publish(ChannelID(name), RaceEvent(race, entrants))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// StartRace starts the solves of every channel in a race at the same moment.
+// Every channel must still have the race's puzzle selected and not have started
+// solving it yet.
+func StartRace(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "race")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		race, err := GetRace(conn, name)
+		if err != nil {
+			log.Printf("unable to load race %s: %+v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		integration, ok := Integrations[race.Type]
+		if !ok {
+			log.Printf("unable to start race %s, it doesn't exist", name)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if race.StartTime != nil {
+			log.Printf("unable to start race %s, it has already been started", name)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		// Check every channel before starting any of them so that a single channel
+		// that isn't ready doesn't leave the race partially started.
+		now := time.Now()
+		for _, channel := range race.Channels {
+			progress, err := integration.GetProgress(conn, channel, now)
+			if err != nil {
+				log.Printf("unable to load progress for channel %s: %+v", channel, err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if progress.Status != model.StatusSelected || !SamePuzzle(race.Puzzle, progress.Puzzle) {
+				log.Printf("unable to start race %s, channel %s is no longer ready", name, channel)
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+
+		for _, channel := range race.Channels {
+			if err := integration.StartSolve(conn, registry, channel, now); err != nil {
+				log.Printf("unable to start solve for channel %s: %+v", channel, err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		race.StartTime = &now
+		if err := SetRace(conn, name, race); err != nil {
+			log.Printf("unable to save race %s: %+v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		entrants, err := GetStandings(conn, race, now)
+		if err != nil {
+			log.Printf("unable to load standings for race %s: %+v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		registry.Publish(ChannelID(name), RaceEvent(race, entrants))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// GetEvents establishes an event stream with a client.  An event stream is
+// server side event stream (SSE) with a client's browser that allows one way
+// communication from the server to the client.  Clients that call into this
+// handler will keep an open connection open to the server waiting to receive
+// events as the standings of the race change.
+func GetEvents(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "race")
+
+		// Construct the stream that all events for this particular client will be
+		// placed into.
+		stream := make(chan pubsub.Event, 10)
+		defer close(stream)
+
+		// Setup a connection to redis so that we can read the race and the current
+		// progress of each of its channels.
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		race, err := GetRace(conn, name)
+		if err != nil {
+			log.Printf("unable to load race %s: %+v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		integration, ok := Integrations[race.Type]
+		if !ok {
+			log.Printf("unable to stream race %s, it doesn't exist", name)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Setup a subscription in the registry to be able to see updates to the
+		// race itself as well as all state update events for the channels in the
+		// race.  The state updates let us know when the standings may have changed.
+		channels := make(map[pubsub.Channel]bool)
+		for _, channel := range race.Channels {
+			channels[integration.ChannelID(channel)] = true
+		}
+
+		events := make(chan pubsub.Event, 10)
+		defer close(events)
+
+		id, err := registry.SubscribeMatching(func(channel pubsub.Channel, event pubsub.Event) bool {
+			return channel == ChannelID(name) || (event.Kind == "state" && channels[channel])
+		}, events)
+		defer registry.Unsubscribe(id)
+		if err != nil {
+			log.Printf("unable to subscribe client to race %s: %+v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// Send the current standings so that the client has something to show
+		// immediately.
+		entrants, err := GetStandings(conn, race, time.Now())
+		if err != nil {
+			log.Printf("unable to load standings for race %s: %+v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		stream <- RaceEvent(race, entrants)
+
+		// Start a background goroutine for this client that sends updated standings
+		// whenever one of the channels in the race makes progress.
+		go func(race Race) {
+			// Use a new connection to redis since this goroutine might live slightly
+			// longer than the GetEvents method call.  This ensures that we don't
+			// attempt to use a connection that's already been closed.
+			conn := pool.Get()
+			defer func() { _ = conn.Close() }()
+
+			for {
+				select {
+				case <-r.Context().Done():
+					// The client disconnected, the goroutine should exit.
+					return
+
+				case event := <-events:
+					if event.Kind == "race" {
+						// The race itself changed, the event already has the standings.
+						if standings, ok := event.Payload.(Standings); ok {
+							race = standings.Race
+						}
+						stream <- event
+						continue
+					}
+
+					// A channel in the race has updated its state, recompute the
+					// standings.
+					entrants, err := GetStandings(conn, race, time.Now())
+					if err != nil {
+						log.Printf("unable to load standings for race %s: %+v", name, err)
+
+						// Don't exit the goroutine here since the client is still connected.
+						// We'll just try again in the future.
+						continue
+					}
+
+					stream <- RaceEvent(race, entrants)
+				}
+			}
+		}(race)
+
+		pubsub.EmitEvents(r.Context(), w, stream)
+	}
+}
+
+// SamePuzzle determines whether or not two puzzle sources refer to the same
+// puzzle.  Puzzles are compared by their contents since uploaded puzzles and
+// puzzles from the library don't have a publisher or published date.  Puzzles
+// without a fingerprint are never the same as any other puzzle.
+func SamePuzzle(a, b model.PuzzleSource) bool {
+	return a.Fingerprint != "" && a.Fingerprint == b.Fingerprint
+}
+
+func ChannelID(name string) pubsub.Channel {
+	name = fmt.Sprintf("%s:race", name)
+	return pubsub.Channel(name)
+}
+
+// Standings is the payload of a race event, it contains the race along with the
+// standing of each of its channels from first to last place.
+type Standings struct {
+	Race     Race      `json:"race"`
+	Entrants []Entrant `json:"entrants"`
+}
+
+func RaceEvent(race Race, entrants []Entrant) pubsub.Event {
+	return pubsub.Event{
+		Kind: "race",
+		Payload: Standings{
+			Race:     race,
+			Entrants: entrants,
+		},
+	}
+}
@@ -0,0 +1,604 @@
+package race
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bbeck/puzzles-with-chat/api/crossword"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/go-chi/chi"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRoute_CreateRace(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, "race")
+
+	SelectCrossword(t, conn, "a", "xwordinfo-nyt-20181231.json")
+	SelectCrossword(t, conn, "b", "xwordinfo-nyt-20181231.json")
+
+	response := PUT("/races/race", `{"type": "crossword", "channels": ["a", "b"]}`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	race, err := GetRace(conn, "race")
+	require.NoError(t, err)
+	assert.Equal(t, "crossword", race.Type)
+	assert.Equal(t, []string{"a", "b"}, race.Channels)
+	assert.Equal(t, "The New York Times", race.Puzzle.Publisher)
+	assert.Equal(t, time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC), race.Puzzle.PublishedDate)
+	assert.NotEmpty(t, race.Puzzle.Fingerprint)
+	assert.Nil(t, race.StartTime)
+
+	require.Len(t, events, 1)
+	event := <-events
+	assert.Equal(t, "race", event.Kind)
+
+	standings := event.Payload.(Standings)
+	assert.Equal(t, race, standings.Race)
+	assert.Equal(t, []Entrant{
+		{Channel: "a", Status: model.StatusSelected},
+		{Channel: "b", Status: model.StatusSelected},
+	}, standings.Entrants)
+}
+
+func TestRoute_CreateRace_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		json           string
+		modify         func(state *crossword.State)
+		started        bool
+		raceLoadError  error
+		raceSaveError  error
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "invalid json",
+			json:     `{"type": "crossword", "channels": ["a", "b"]`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "unsupported type",
+			json:     `{"type": "sudoku", "channels": ["a", "b"]}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "one channel",
+			json:     `{"type": "crossword", "channels": ["a"]}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "duplicate channel",
+			json:     `{"type": "crossword", "channels": ["a", "a"]}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "empty channel",
+			json:     `{"type": "crossword", "channels": ["a", ""]}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "different puzzle",
+			json: `{"type": "crossword", "channels": ["a", "b"]}`,
+			modify: func(s *crossword.State) {
+				s.Puzzle.CluesAcross[1] = "A different clue"
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "channel already solving",
+			json:     `{"type": "crossword", "channels": ["a", "b"]}`,
+			modify:   func(s *crossword.State) { s.Status = model.StatusSolving },
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "channel without puzzle",
+			json:     `{"type": "crossword", "channels": ["a", "c"]}`,
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "race already started",
+			json:     `{"type": "crossword", "channels": ["a", "b"]}`,
+			started:  true,
+			expected: http.StatusConflict,
+		},
+		{
+			name:          "error loading race",
+			json:          `{"type": "crossword", "channels": ["a", "b"]}`,
+			raceLoadError: errors.New("forced error"),
+			expected:      http.StatusInternalServerError,
+		},
+		{
+			name:          "error saving race",
+			json:          `{"type": "crossword", "channels": ["a", "b"]}`,
+			raceSaveError: errors.New("forced error"),
+			expected:      http.StatusInternalServerError,
+		},
+		{
+			name:           "error loading state",
+			json:           `{"type": "crossword", "channels": ["a", "b"]}`,
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			SelectCrossword(t, conn, "a", "xwordinfo-nyt-20181231.json")
+			b := SelectCrossword(t, conn, "b", "xwordinfo-nyt-20181231.json")
+			if test.modify != nil {
+				test.modify(&b)
+				require.NoError(t, crossword.SetState(conn, "b", b))
+			}
+
+			if test.started {
+				now := time.Now()
+				race := Race{Type: "crossword", Channels: []string{"a", "b"}, StartTime: &now}
+				require.NoError(t, SetRace(conn, "race", race))
+			}
+
+			if test.raceLoadError != nil {
+				ForceErrorDuringRaceLoad(t, test.raceLoadError)
+			}
+			if test.raceSaveError != nil {
+				ForceErrorDuringRaceSave(t, test.raceSaveError)
+			}
+			if test.stateLoadError != nil {
+				crossword.ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+
+			response := PUT("/races/race", test.json, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_StartRace(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	SelectCrossword(t, conn, "a", "xwordinfo-nyt-20181231.json")
+	SelectCrossword(t, conn, "b", "xwordinfo-nyt-20181231.json")
+
+	response := PUT("/races/race", `{"type": "crossword", "channels": ["a", "b"]}`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	events := NewEventSubscription(t, registry, "race")
+	stateA := crossword.NewEventSubscription(t, registry, "a")
+	stateB := crossword.NewEventSubscription(t, registry, "b")
+
+	response = PUT("/races/race/start", "", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	race, err := GetRace(conn, "race")
+	require.NoError(t, err)
+	require.NotNil(t, race.StartTime)
+
+	// Both channels should have been started at the same moment as the race.
+	for _, channel := range []string{"a", "b"} {
+		state, err := crossword.GetState(conn, channel)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusSolving, state.Status)
+		require.NotNil(t, state.LastStartTime)
+		assert.True(t, race.StartTime.Equal(*state.LastStartTime))
+	}
+
+	require.Len(t, stateA, 1)
+	require.Len(t, stateB, 1)
+
+	require.Len(t, events, 1)
+	event := <-events
+	assert.Equal(t, "race", event.Kind)
+
+	standings := event.Payload.(Standings)
+	assert.NotNil(t, standings.Race.StartTime)
+	assert.Equal(t, []Entrant{
+		{Channel: "a", Status: model.StatusSolving},
+		{Channel: "b", Status: model.StatusSolving},
+	}, standings.Entrants)
+}
+
+func TestRoute_StartRace_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		race           *Race
+		modify         func(state *crossword.State)
+		raceLoadError  error
+		raceSaveError  error
+		stateLoadError error
+		stateSaveError error
+		expected       int
+	}{
+		{
+			name:     "no race",
+			expected: http.StatusNotFound,
+		},
+		{
+			name: "race already started",
+			race: &Race{
+				Type:      "crossword",
+				Channels:  []string{"a", "b"},
+				StartTime: &time.Time{},
+			},
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "channel already solving",
+			race:     &Race{Type: "crossword", Channels: []string{"a", "b"}},
+			modify:   func(s *crossword.State) { s.Status = model.StatusSolving },
+			expected: http.StatusConflict,
+		},
+		{
+			name: "channel changed puzzle",
+			race: &Race{Type: "crossword", Channels: []string{"a", "b"}},
+			modify: func(s *crossword.State) {
+				s.Puzzle.CluesAcross[1] = "A different clue"
+			},
+			expected: http.StatusConflict,
+		},
+		{
+			name:          "error loading race",
+			race:          &Race{Type: "crossword", Channels: []string{"a", "b"}},
+			raceLoadError: errors.New("forced error"),
+			expected:      http.StatusInternalServerError,
+		},
+		{
+			name:          "error saving race",
+			race:          &Race{Type: "crossword", Channels: []string{"a", "b"}},
+			raceSaveError: errors.New("forced error"),
+			expected:      http.StatusInternalServerError,
+		},
+		{
+			name:           "error loading state",
+			race:           &Race{Type: "crossword", Channels: []string{"a", "b"}},
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+		{
+			name:           "error saving state",
+			race:           &Race{Type: "crossword", Channels: []string{"a", "b"}},
+			stateSaveError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			a := SelectCrossword(t, conn, "a", "xwordinfo-nyt-20181231.json")
+			b := SelectCrossword(t, conn, "b", "xwordinfo-nyt-20181231.json")
+			if test.modify != nil {
+				test.modify(&b)
+				require.NoError(t, crossword.SetState(conn, "b", b))
+			}
+
+			if test.race != nil {
+				test.race.Puzzle = model.PuzzleSource{
+					Publisher:     a.Puzzle.Publisher,
+					PublishedDate: a.Puzzle.PublishedDate,
+					Fingerprint:   a.Puzzle.Fingerprint(),
+				}
+				require.NoError(t, SetRace(conn, "race", *test.race))
+			}
+
+			if test.raceLoadError != nil {
+				ForceErrorDuringRaceLoad(t, test.raceLoadError)
+			}
+			if test.raceSaveError != nil {
+				ForceErrorDuringRaceSave(t, test.raceSaveError)
+			}
+			if test.stateLoadError != nil {
+				crossword.ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+			if test.stateSaveError != nil {
+				crossword.ForceErrorDuringStateSave(t, test.stateSaveError)
+			}
+
+			response := PUT("/races/race/start", "", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestSamePuzzle(t *testing.T) {
+	// Uploaded puzzles don't have a publisher or published date, so they can
+	// only be told apart by their contents.
+	a := crossword.NewState(t, "xwordinfo-nyt-20181231.json").Puzzle
+	a.Publisher = ""
+	a.PublishedDate = time.Time{}
+
+	b := crossword.NewState(t, "xwordinfo-nyt-20181231.json").Puzzle
+	b.Publisher = ""
+	b.PublishedDate = time.Time{}
+
+	c := crossword.NewState(t, "xwordinfo-nyt-20181231.json").Puzzle
+	c.Publisher = ""
+	c.PublishedDate = time.Time{}
+	c.CluesAcross[1] = "A different clue"
+
+	source := func(p *crossword.Puzzle) model.PuzzleSource {
+		return model.PuzzleSource{Fingerprint: p.Fingerprint()}
+	}
+
+	assert.True(t, SamePuzzle(source(a), source(b)))
+	assert.False(t, SamePuzzle(source(a), source(c)))
+
+	// Puzzles without a fingerprint are never the same.
+	assert.False(t, SamePuzzle(model.PuzzleSource{}, model.PuzzleSource{}))
+}
+
+func TestRoute_GetEvents(t *testing.T) {
+	// This acts as a small integration test ensuring that the race event stream
+	// receives updated standings as the channels in the race make progress.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	SelectCrossword(t, conn, "a", "xwordinfo-nyt-20181231.json")
+	SelectCrossword(t, conn, "b", "xwordinfo-nyt-20181231.json")
+	SelectCrossword(t, conn, "c", "xwordinfo-nyt-20181231.json")
+
+	response := PUT("/races/race", `{"type": "crossword", "channels": ["a", "b"]}`, router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	// Connect to the stream, we should immediately receive the standings.
+	flush, stop := SSE("/races/race/events", router)
+	events := flush()
+	require.Len(t, events, 1)
+	assert.Equal(t, "race", events[0].Kind)
+	standings := ParseStandings(t, events[0].Payload)
+	assert.Nil(t, standings.Race.StartTime)
+	require.Len(t, standings.Entrants, 2)
+
+	// Start the race, the state of each channel changes as well as the race so
+	// we'll receive several sets of standings.
+	response = PUT("/races/race/start", "", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	events = flush()
+	require.NotEmpty(t, events)
+	standings = ParseStandings(t, events[len(events)-1].Payload)
+	assert.NotNil(t, standings.Race.StartTime)
+	assert.Equal(t, model.StatusSolving, standings.Entrants[0].Status)
+	assert.Equal(t, model.StatusSolving, standings.Entrants[1].Status)
+
+	// Channel b makes some progress, it should now be in the lead.
+	b, err := crossword.GetState(conn, "b")
+	require.NoError(t, err)
+	require.NoError(t, b.ApplyAnswer("1a", "QANDA", false))
+	require.NoError(t, crossword.SetState(conn, "b", b))
+	registry.Publish(crossword.ChannelID("b"), crossword.StateEvent(b))
+
+	events = flush()
+	require.Len(t, events, 1)
+	standings = ParseStandings(t, events[0].Payload)
+	assert.Equal(t, "b", standings.Entrants[0].Channel)
+	assert.Equal(t, b.PercentComplete(), standings.Entrants[0].PercentComplete)
+	assert.Equal(t, "a", standings.Entrants[1].Channel)
+
+	// Channel c isn't in the race so its progress shouldn't cause any events.
+	registry.Publish(crossword.ChannelID("c"), crossword.StateEvent(b))
+	events = stop()
+	assert.Empty(t, events)
+}
+
+func TestRoute_GetEvents_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		race           *Race
+		raceLoadError  error
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no race",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:          "error loading race",
+			race:          &Race{Type: "crossword", Channels: []string{"a", "b"}},
+			raceLoadError: errors.New("forced error"),
+			expected:      http.StatusInternalServerError,
+		},
+		{
+			name:           "error loading state",
+			race:           &Race{Type: "crossword", Channels: []string{"a", "b"}},
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			if test.race != nil {
+				require.NoError(t, SetRace(conn, "race", *test.race))
+			}
+
+			if test.raceLoadError != nil {
+				ForceErrorDuringRaceLoad(t, test.raceLoadError)
+			}
+			if test.stateLoadError != nil {
+				crossword.ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+
+			// This won't start a background goroutine to send events because the
+			// request will fail before reaching that part of the code.
+			response := GET("/races/race/events", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+// SelectCrossword writes a crossword state for the channel that has the puzzle
+// from the provided file selected, but not yet started.
+func SelectCrossword(t *testing.T, conn redis.Conn, channel, filename string) crossword.State {
+	t.Helper()
+
+	state := crossword.NewState(t, filename)
+	state.Status = model.StatusSelected
+	state.LastStartTime = nil
+	require.NoError(t, crossword.SetState(conn, channel, state))
+
+	return state
+}
+
+func ParseStandings(t *testing.T, v interface{}) Standings {
+	bs, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	var standings Standings
+	require.NoError(t, json.Unmarshal(bs, &standings))
+
+	return standings
+}
+
+func GET(url string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func PUT(url, body string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// SSE performs a streaming request to the provided router.  Because the router
+// won't immediately return, this request is done in a background goroutine.
+// When the main thread wishes to read events that have been received thus far
+// the flush method can be called and it will return any queued up events.  When
+// the main thread wishes to close the connection to the router the stop method
+// can be called and it will return any unread events.
+func SSE(url string, router chi.Router) (flush func() []pubsub.Event, stop func() []pubsub.Event) {
+	recorder := CreateTestResponseRecorder()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request := httptest.NewRequest(http.MethodGet, url, nil).WithContext(ctx)
+
+	flush = func() []pubsub.Event {
+		// Give the router a chance to write everything it needs to.
+		time.Sleep(10 * time.Millisecond)
+
+		reader, err := recorder.Body()
+		if err != nil {
+			return nil
+		}
+
+		var events []pubsub.Event
+		for {
+			bs, err := reader.ReadBytes('\n')
+			if err != nil {
+				break
+			}
+
+			if !bytes.HasPrefix(bs, []byte("data:")) {
+				continue
+			}
+
+			var event pubsub.Event
+			json.Unmarshal(bs[5:], &event)
+			events = append(events, event)
+		}
+
+		return events
+	}
+
+	stop = func() []pubsub.Event {
+		// Give the router a chance to write everything it needs to.
+		time.Sleep(10 * time.Millisecond)
+
+		recorder.Close()
+		cancel()
+		return flush()
+	}
+
+	go router.ServeHTTP(recorder, request)
+
+	return flush, stop
+}
+
+// Create a http.ResponseWriter that synchronizes whenever reads or writes
+// happen so that there are no races in a multiple goroutine environment.
+// Additionally implement the http.CloseNotifier interface so that requests can
+// be stopped by tests.
+type TestResponseRecorder struct {
+	sync.Mutex
+	headers http.Header
+	body    *bytes.Buffer
+	close   chan bool
+}
+
+func CreateTestResponseRecorder() *TestResponseRecorder {
+	return &TestResponseRecorder{
+		headers: make(http.Header),
+		body:    new(bytes.Buffer),
+		close:   make(chan bool, 1),
+	}
+}
+
+func (r *TestResponseRecorder) Header() http.Header {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.headers
+}
+
+func (r *TestResponseRecorder) Write(bs []byte) (int, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.body.Write(bs)
+}
+
+func (r *TestResponseRecorder) Body() (*bufio.Reader, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	bs, err := ioutil.ReadAll(r.body)
+	if err != nil {
+		return nil, err
+	}
+	r.body.Reset()
+	return bufio.NewReader(bytes.NewReader(bs)), nil
+}
+
+func (r *TestResponseRecorder) CloseNotify() <-chan bool {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.close
+}
+
+func (r *TestResponseRecorder) Close() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.close <- true
+}
+
+func (r *TestResponseRecorder) WriteHeader(int) {
+	// Not used
+}
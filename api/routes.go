@@ -1,6 +1,8 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/acrostic"
 	"github.com/bbeck/puzzles-with-chat/api/crossword"
 	"github.com/bbeck/puzzles-with-chat/api/model"
@@ -10,6 +12,8 @@ import (
 	"github.com/gomodule/redigo/redis"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -21,9 +25,17 @@ func RegisterRoutes(r chi.Router, pool *redis.Pool, registry *pubsub.Registry) {
 // GetChannels establishes a SSE based stream with a client that contains the
 // list of active channels across all puzzle types.  Events will be periodically
 // sent to the stream containing the list of active channels, even if the list
-// doesn't change.
+// doesn't change.  The channels can be filtered using query parameters, see
+// ParseChannelFilter for the supported parameters.
 func GetChannels(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		filter, err := ParseChannelFilter(r.URL.Query())
+		if err != nil {
+			log.Printf("unable to parse channel filter: %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		// Construct the stream that all events for this particular client will be
 		// placed into.
 		stream := make(chan pubsub.Event, 10)
@@ -53,6 +65,7 @@ func GetChannels(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		channels = filter.Apply(channels)
 
 		// Send the initial set of channels.
 		stream <- ChannelsEvent(channels)
@@ -83,6 +96,9 @@ func GetChannels(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 						continue
 					}
 
+					// Only the channels the client is interested in should be considered
+					// when determining if anything has changed.
+					current = filter.Apply(current)
 					if Changed(channels, current) {
 						channels = current
 						stream <- ChannelsEvent(channels)
@@ -101,6 +117,7 @@ func GetChannels(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 						// We'll just try again in the future.
 						continue
 					}
+					channels = filter.Apply(channels)
 
 					stream <- ChannelsEvent(channels)
 				}
@@ -111,8 +128,98 @@ func GetChannels(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 	}
 }
 
+// ChannelFilter restricts which active channels are sent to a client.  Each
+// field holds the values that are allowed, an empty field allows every value.
+type ChannelFilter struct {
+	Types      map[string]bool
+	Statuses   map[model.Status]bool
+	Publishers map[string]bool
+	Names      map[string]bool
+}
+
+// ParseChannelFilter builds a filter from the query parameters of a request.
+// The supported parameters are type, status, publisher and channel, each of
+// which may be repeated to allow several values.  If a puzzle type or status
+// isn't recognized then an error is returned.
+func ParseChannelFilter(query url.Values) (ChannelFilter, error) {
+	var filter ChannelFilter
+
+	for _, kind := range query["type"] {
+		switch kind {
+		case "acrostic", "crossword", "spellingbee":
+		default:
+			return filter, fmt.Errorf("unrecognized puzzle type: %s", kind)
+		}
+
+		if filter.Types == nil {
+			filter.Types = make(map[string]bool)
+		}
+		filter.Types[kind] = true
+	}
+
+	for _, str := range query["status"] {
+		var status model.Status
+		if err := json.Unmarshal([]byte(strconv.Quote(str)), &status); err != nil {
+			return filter, err
+		}
+
+		if filter.Statuses == nil {
+			filter.Statuses = make(map[model.Status]bool)
+		}
+		filter.Statuses[status] = true
+	}
+
+	for _, publisher := range query["publisher"] {
+		if filter.Publishers == nil {
+			filter.Publishers = make(map[string]bool)
+		}
+		filter.Publishers[publisher] = true
+	}
+
+	for _, name := range query["channel"] {
+		if filter.Names == nil {
+			filter.Names = make(map[string]bool)
+		}
+		filter.Names[name] = true
+	}
+
+	return filter, nil
+}
+
+// Apply returns the active channels that are allowed by the filter.  Puzzle
+// types that aren't allowed are removed entirely while allowed puzzle types are
+// kept even when none of their channels are.
+func (f ChannelFilter) Apply(channels map[string][]model.Channel) map[string][]model.Channel {
+	filtered := make(map[string][]model.Channel)
+	for kind, cs := range channels {
+		if len(f.Types) > 0 && !f.Types[kind] {
+			continue
+		}
+
+		filtered[kind] = make([]model.Channel, 0)
+		for _, channel := range cs {
+			if len(f.Statuses) > 0 && !f.Statuses[channel.Status] {
+				continue
+			}
+
+			if len(f.Publishers) > 0 && !f.Publishers[channel.Puzzle.Publisher] {
+				continue
+			}
+
+			if len(f.Names) > 0 && !f.Names[channel.Name] {
+				continue
+			}
+
+			filtered[kind] = append(filtered[kind], channel)
+		}
+	}
+
+	return filtered
+}
+
 // Changed compares two sets of active channels and determines if anything has
-// changed or not.
+// changed or not.  The elapsed times of the channels aren't compared since they
+// differ every time the channels are loaded while a solve is in progress.
 func Changed(before, after map[string][]model.Channel) bool {
 	if len(before) != len(after) {
 		return true
@@ -129,7 +236,9 @@ func Changed(before, after map[string][]model.Channel) bool {
 		}
 
 		for _, b := range bs {
-			if seen[b.Name] != b {
+			a, ok := seen[b.Name]
+			a.ElapsedTime = b.ElapsedTime
+			if !ok || a != b {
 				return false
 			}
 		}
@@ -147,24 +256,27 @@ func Changed(before, after map[string][]model.Channel) bool {
 }
 
 // GetActiveChannels loads from the database all channels that have states and
-// returns them indexed by the puzzle type that they belong to.  If for some
+// returns them indexed by the puzzle type that they belong to.  The elapsed
+// time of each channel is computed as of when it was loaded.  If for some
 // reason a channel can't be loaded from the database then an error is returned.
 func GetActiveChannels(conn redis.Conn) (map[string][]model.Channel, error) {
 	if testActiveChannelsLoadError != nil {
 		return nil, testActiveChannelsLoadError
 	}
 
-	acrostics, err := acrostic.GetAllChannels(conn)
+	now := time.Now()
+
+	acrostics, err := acrostic.GetAllChannels(conn, now)
 	if err != nil {
 		return nil, err
 	}
 
-	crosswords, err := crossword.GetAllChannels(conn)
+	crosswords, err := crossword.GetAllChannels(conn, now)
 	if err != nil {
 		return nil, err
 	}
 
-	spellingbees, err := spellingbee.GetAllChannels(conn)
+	spellingbees, err := spellingbee.GetAllChannels(conn, now)
 	if err != nil {
 		return nil, err
 	}
@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"sync"
 	"testing"
 	"time"
@@ -44,6 +45,7 @@ func TestRoute_GetChannels(t *testing.T) {
 	// Start a crossword.
 	state1 := crossword.NewState(t, "xwordinfo-nyt-20181231.json")
 	state1.Status = model.StatusSolving
+	state1.LastStartTime = nil
 	require.NoError(t, crossword.SetState(conn, "channel1", state1))
 
 	// Now reconnect to the stream and we should receive one active channel.
@@ -69,6 +71,7 @@ func TestRoute_GetChannels(t *testing.T) {
 	// Start a spelling bee on another channel.
 	state2 := spellingbee.NewState(t, "nytbee-20180729.json")
 	state2.Status = model.StatusSolving
+	state2.LastStartTime = nil
 	require.NoError(t, spellingbee.SetState(conn, "channel2", state2))
 
 	// Now we expect there to be 2 channels in the stream.
@@ -97,12 +100,14 @@ func TestRoute_GetChannels(t *testing.T) {
 				Publisher:     "The New York Times",
 				PublishedDate: time.Date(2018, time.July, 29, 0, 0, 0, 0, time.UTC),
 			},
+			MaxScore: 297,
 		},
 	}, payload["spellingbee"])
 
 	// Start an acrostic on a third channel.
 	state3 := acrostic.NewState(t, "xwordinfo-nyt-20200524.json")
 	state3.Status = model.StatusSolving
+	state3.LastStartTime = nil
 	require.NoError(t, acrostic.SetState(conn, "channel3", state3))
 
 	// Now we expect there to be 3 channels in the stream.
@@ -131,6 +136,7 @@ func TestRoute_GetChannels(t *testing.T) {
 				Publisher:     "The New York Times",
 				PublishedDate: time.Date(2018, time.July, 29, 0, 0, 0, 0, time.UTC),
 			},
+			MaxScore: 297,
 		},
 	}, payload["spellingbee"])
 	assert.ElementsMatch(t, []model.Channel{
@@ -208,6 +214,7 @@ func TestRoute_GetChannels(t *testing.T) {
 	// Now update the state of the first channel in the database and send an event
 	// saying that it was updated.
 	state1 = crossword.NewState(t, "xwordinfo-nyt-20181227-rebus.json")
+	state1.LastStartTime = nil
 	require.NoError(t, crossword.SetState(conn, "channel1", state1))
 	registry.Publish(crossword.ChannelID("channel1"), crossword.StateEvent(state1))
 
@@ -232,15 +239,78 @@ func TestRoute_GetChannels(t *testing.T) {
 	assert.Empty(t, payload["acrostic"])
 }
 
+func TestRoute_GetChannels_Filtered(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Start crosswords in two channels, only one of which is solving.
+	state1 := crossword.NewState(t, "xwordinfo-nyt-20181231.json")
+	state1.Status = model.StatusSolving
+	state1.LastStartTime = nil
+	require.NoError(t, crossword.SetState(conn, "channel1", state1))
+
+	state2 := crossword.NewState(t, "xwordinfo-nyt-20181231.json")
+	state2.Status = model.StatusPaused
+	state2.LastStartTime = nil
+	require.NoError(t, crossword.SetState(conn, "channel2", state2))
+
+	// Start a spelling bee in a third channel that's also solving.
+	state3 := spellingbee.NewState(t, "nytbee-20180729.json")
+	state3.Status = model.StatusSolving
+	state3.LastStartTime = nil
+	require.NoError(t, spellingbee.SetState(conn, "channel3", state3))
+
+	// Connect to the stream asking for only solving crosswords.
+	flush, stop := SSE("/channels?type=crossword&status=solving", router)
+	events := flush()
+	require.Equal(t, 1, len(events))
+
+	payload := ParsePayload(t, events[0].Payload)
+	require.Len(t, payload, 1)
+	require.Len(t, payload["crossword"], 1)
+	assert.Equal(t, "channel1", payload["crossword"][0].Name)
+
+	// An update to a channel that's filtered out shouldn't send an event.
+	require.NoError(t, state2.ApplyAnswer("1a", "QANDA", false))
+	require.NoError(t, crossword.SetState(conn, "channel2", state2))
+	registry.Publish(crossword.ChannelID("channel2"), crossword.StateEvent(state2))
+
+	events = flush()
+	assert.Empty(t, events)
+
+	// But an update to a channel that's included should.
+	require.NoError(t, state1.ApplyAnswer("1a", "QANDA", false))
+	require.NoError(t, crossword.SetState(conn, "channel1", state1))
+	registry.Publish(crossword.ChannelID("channel1"), crossword.StateEvent(state1))
+
+	events = stop()
+	require.Equal(t, 1, len(events))
+
+	payload = ParsePayload(t, events[0].Payload)
+	require.Len(t, payload["crossword"], 1)
+	assert.Equal(t, "channel1", payload["crossword"][0].Name)
+	assert.Equal(t, state1.PercentComplete(), payload["crossword"][0].PercentCorrect)
+}
+
 func TestRoute_GetChannels_Error(t *testing.T) {
 	tests := []struct {
 		name                    string
+		url                     string
 		loadActiveChannelsError error
 	}{
 		{
 			name:                    "error loading channel names",
+			url:                     "/channels",
 			loadActiveChannelsError: errors.New("forced error"),
 		},
+		{
+			name: "invalid type filter",
+			url:  "/channels?type=sudoku",
+		},
+		{
+			name: "invalid status filter",
+			url:  "/channels?status=finished",
+		},
 	}
 
 	for _, test := range tests {
@@ -257,12 +327,172 @@ func TestRoute_GetChannels_Error(t *testing.T) {
 
 			// This won't start a background goroutine to send events because the
 			// request will fail before reaching that part of the code.
-			response := GET("/channels", router)
+			response := GET(test.url, router)
 			assert.NotEqual(t, http.StatusOK, response.Code)
 		})
 	}
 }
 
+func TestParseChannelFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected ChannelFilter
+	}{
+		{
+			name: "no filters",
+		},
+		{
+			name:  "type filter",
+			query: "type=crossword&type=acrostic",
+			expected: ChannelFilter{
+				Types: map[string]bool{"acrostic": true, "crossword": true},
+			},
+		},
+		{
+			name:  "status filter",
+			query: "status=solving&status=paused",
+			expected: ChannelFilter{
+				Statuses: map[model.Status]bool{model.StatusSolving: true, model.StatusPaused: true},
+			},
+		},
+		{
+			name:  "publisher filter",
+			query: "publisher=The+New+York+Times",
+			expected: ChannelFilter{
+				Publishers: map[string]bool{"The New York Times": true},
+			},
+		},
+		{
+			name:  "channel filter",
+			query: "channel=a&channel=b",
+			expected: ChannelFilter{
+				Names: map[string]bool{"a": true, "b": true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			require.NoError(t, err)
+
+			filter, err := ParseChannelFilter(query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, filter)
+		})
+	}
+}
+
+func TestParseChannelFilter_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{
+			name:  "invalid type",
+			query: "type=sudoku",
+		},
+		{
+			name:  "invalid status",
+			query: "status=finished",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			require.NoError(t, err)
+
+			_, err = ParseChannelFilter(query)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestChannelFilter_Apply(t *testing.T) {
+	nyt := model.PuzzleSource{Publisher: "The New York Times"}
+	wsj := model.PuzzleSource{Publisher: "The Wall Street Journal"}
+
+	channels := map[string][]model.Channel{
+		"acrostic": {
+			{Name: "a", Status: model.StatusSolving, Puzzle: nyt},
+		},
+		"crossword": {
+			{Name: "a", Status: model.StatusPaused, Puzzle: nyt},
+			{Name: "b", Status: model.StatusSolving, Puzzle: wsj},
+			{Name: "c", Status: model.StatusSolving, Puzzle: nyt},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		filter   ChannelFilter
+		expected map[string][]model.Channel
+	}{
+		{
+			name:     "no filter",
+			expected: channels,
+		},
+		{
+			name: "type",
+			filter: ChannelFilter{
+				Types: map[string]bool{"acrostic": true},
+			},
+			expected: map[string][]model.Channel{
+				"acrostic": channels["acrostic"],
+			},
+		},
+		{
+			name: "status",
+			filter: ChannelFilter{
+				Statuses: map[model.Status]bool{model.StatusPaused: true},
+			},
+			expected: map[string][]model.Channel{
+				"acrostic":  {},
+				"crossword": {channels["crossword"][0]},
+			},
+		},
+		{
+			name: "publisher",
+			filter: ChannelFilter{
+				Publishers: map[string]bool{"The Wall Street Journal": true},
+			},
+			expected: map[string][]model.Channel{
+				"acrostic":  {},
+				"crossword": {channels["crossword"][1]},
+			},
+		},
+		{
+			name: "channel",
+			filter: ChannelFilter{
+				Names: map[string]bool{"a": true},
+			},
+			expected: map[string][]model.Channel{
+				"acrostic":  channels["acrostic"],
+				"crossword": {channels["crossword"][0]},
+			},
+		},
+		{
+			name: "multiple",
+			filter: ChannelFilter{
+				Types:      map[string]bool{"crossword": true},
+				Statuses:   map[model.Status]bool{model.StatusSolving: true},
+				Publishers: map[string]bool{"The New York Times": true},
+			},
+			expected: map[string][]model.Channel{
+				"crossword": {channels["crossword"][2]},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.filter.Apply(channels))
+		})
+	}
+}
+
 func TestChanged(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -333,6 +563,26 @@ func TestChanged(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "channel progress changed",
+			before: map[string][]model.Channel{
+				"crossword": {{Name: "channel", Status: model.StatusSolving, PercentFilled: 10}},
+			},
+			after: map[string][]model.Channel{
+				"crossword": {{Name: "channel", Status: model.StatusSolving, PercentFilled: 20}},
+			},
+			expected: true,
+		},
+		{
+			name: "channel elapsed time changed",
+			before: map[string][]model.Channel{
+				"crossword": {{Name: "channel", Status: model.StatusSolving, ElapsedTime: model.Duration{Duration: time.Minute}}},
+			},
+			after: map[string][]model.Channel{
+				"crossword": {{Name: "channel", Status: model.StatusSolving, ElapsedTime: model.Duration{Duration: 2 * time.Minute}}},
+			},
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
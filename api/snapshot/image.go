@@ -0,0 +1,326 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+)
+
+// Colors that are commonly used when drawing puzzles.
+var (
+	Black     = color.RGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xFF}
+	White     = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+	Gray      = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xFF}
+	LightGray = color.RGBA{R: 0xDD, G: 0xDD, B: 0xDD, A: 0xFF}
+	Yellow    = color.RGBA{R: 0xF7, G: 0xDA, B: 0x21, A: 0xFF}
+	None      = color.RGBA{}
+)
+
+// Anchor describes which point of a piece of text its coordinates refer to.
+type Anchor int
+
+const (
+	// AnchorTopLeft positions text by the top left corner of its bounding box.
+	AnchorTopLeft Anchor = iota
+
+	// AnchorTopRight positions text by the top right corner of its bounding box.
+	AnchorTopRight
+
+	// AnchorCenter positions text by the center of its bounding box.
+	AnchorCenter
+)
+
+// Point is a location within an image.
+type Point struct {
+	X, Y float64
+}
+
+// Image is a simple vector image made up of shapes and text that can be
+// rendered either as an SVG document or rasterized into a PNG.  Shapes are
+// drawn in the order that they are added to the image.
+type Image struct {
+	Width, Height int
+	shapes        []shape
+}
+
+// shape is a single element of an image that knows how to render itself into
+// both of the supported formats.
+type shape interface {
+	svg(sb *strings.Builder)
+	rasterize(img *image.RGBA)
+}
+
+// New creates a new image of the specified dimensions with a white background.
+func New(width, height int) *Image {
+	img := &Image{Width: width, Height: height}
+	img.Rect(0, 0, float64(width), float64(height), White, None)
+	return img
+}
+
+// Rect adds a rectangle to the image.  Either the fill or stroke may be None
+// to omit it.
+func (i *Image) Rect(x, y, w, h float64, fill, stroke color.RGBA) {
+	i.shapes = append(i.shapes, rect{x, y, w, h, fill, stroke})
+}
+
+// Circle adds an unfilled circle to the image.
+func (i *Image) Circle(cx, cy, r float64, stroke color.RGBA) {
+	i.shapes = append(i.shapes, circle{cx, cy, r, stroke})
+}
+
+// Polygon adds a closed polygon to the image.  Either the fill or stroke may
+// be None to omit it.
+func (i *Image) Polygon(points []Point, fill, stroke color.RGBA) {
+	i.shapes = append(i.shapes, polygon{points, fill, stroke})
+}
+
+// Text adds a line of text to the image.  The size is the height in pixels of
+// the text's capital letters.
+func (i *Image) Text(x, y, size float64, anchor Anchor, s string, fill color.RGBA) {
+	i.shapes = append(i.shapes, text{x, y, size, anchor, strings.ToUpper(s), fill})
+}
+
+// SVG renders the image as an SVG document.
+func (i *Image) SVG() []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, i.Width, i.Height, i.Width, i.Height)
+	for _, s := range i.shapes {
+		s.svg(&sb)
+	}
+	sb.WriteString("</svg>")
+
+	return []byte(sb.String())
+}
+
+// PNG rasterizes the image and encodes it as a PNG.
+func (i *Image) PNG() ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, i.Width, i.Height))
+	for _, s := range i.shapes {
+		s.rasterize(img)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("unable to encode png: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type rect struct {
+	x, y, w, h   float64
+	fill, stroke color.RGBA
+}
+
+func (r rect) svg(sb *strings.Builder) {
+	fmt.Fprintf(sb, `<rect x="%g" y="%g" width="%g" height="%g" fill="%s" stroke="%s"/>`, r.x, r.y, r.w, r.h, hex(r.fill), hex(r.stroke))
+}
+
+func (r rect) rasterize(img *image.RGBA) {
+	x0, y0 := round(r.x), round(r.y)
+	x1, y1 := round(r.x+r.w), round(r.y+r.h)
+
+	if r.fill.A != 0 {
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				set(img, x, y, r.fill)
+			}
+		}
+	}
+
+	if r.stroke.A != 0 {
+		for x := x0; x <= x1; x++ {
+			set(img, x, y0, r.stroke)
+			set(img, x, y1, r.stroke)
+		}
+		for y := y0; y <= y1; y++ {
+			set(img, x0, y, r.stroke)
+			set(img, x1, y, r.stroke)
+		}
+	}
+}
+
+type circle struct {
+	cx, cy, r float64
+	stroke    color.RGBA
+}
+
+func (c circle) svg(sb *strings.Builder) {
+	fmt.Fprintf(sb, `<circle cx="%g" cy="%g" r="%g" fill="none" stroke="%s"/>`, c.cx, c.cy, c.r, hex(c.stroke))
+}
+
+func (c circle) rasterize(img *image.RGBA) {
+	for y := int(c.cy - c.r - 1); y <= int(c.cy+c.r+1); y++ {
+		for x := int(c.cx - c.r - 1); x <= int(c.cx+c.r+1); x++ {
+			d := math.Hypot(float64(x)+0.5-c.cx, float64(y)+0.5-c.cy)
+			if math.Abs(d-c.r) <= 0.5 {
+				set(img, x, y, c.stroke)
+			}
+		}
+	}
+}
+
+type polygon struct {
+	points       []Point
+	fill, stroke color.RGBA
+}
+
+func (p polygon) svg(sb *strings.Builder) {
+	points := make([]string, len(p.points))
+	for i, point := range p.points {
+		points[i] = fmt.Sprintf("%g,%g", point.X, point.Y)
+	}
+
+	fmt.Fprintf(sb, `<polygon points="%s" fill="%s" stroke="%s"/>`, strings.Join(points, " "), hex(p.fill), hex(p.stroke))
+}
+
+func (p polygon) rasterize(img *image.RGBA) {
+	if len(p.points) == 0 {
+		return
+	}
+
+	if p.fill.A != 0 {
+		minX, minY, maxX, maxY := p.points[0].X, p.points[0].Y, p.points[0].X, p.points[0].Y
+		for _, point := range p.points {
+			minX, maxX = math.Min(minX, point.X), math.Max(maxX, point.X)
+			minY, maxY = math.Min(minY, point.Y), math.Max(maxY, point.Y)
+		}
+
+		for y := int(minY); y <= int(maxY); y++ {
+			for x := int(minX); x <= int(maxX); x++ {
+				if p.contains(float64(x)+0.5, float64(y)+0.5) {
+					set(img, x, y, p.fill)
+				}
+			}
+		}
+	}
+
+	if p.stroke.A != 0 {
+		for i := range p.points {
+			a, b := p.points[i], p.points[(i+1)%len(p.points)]
+			line(img, a, b, p.stroke)
+		}
+	}
+}
+
+// contains determines if a point is inside of the polygon using the even-odd
+// rule.
+func (p polygon) contains(x, y float64) bool {
+	inside := false
+	for i, j := 0, len(p.points)-1; i < len(p.points); j, i = i, i+1 {
+		a, b := p.points[i], p.points[j]
+		if (a.Y > y) != (b.Y > y) && x < (b.X-a.X)*(y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+type text struct {
+	x, y, size float64
+	anchor     Anchor
+	s          string
+	fill       color.RGBA
+}
+
+func (t text) svg(sb *strings.Builder) {
+	// The SVG font size is the height of the em box, capital letters are
+	// roughly 70% of that.
+	size := t.size / 0.7
+
+	x, y := t.x, t.y
+	var anchor, baseline string
+	switch t.anchor {
+	case AnchorTopLeft:
+		anchor, baseline = "start", "hanging"
+	case AnchorTopRight:
+		anchor, baseline = "end", "hanging"
+	case AnchorCenter:
+		anchor, baseline = "middle", "central"
+	}
+
+	fmt.Fprintf(sb, `<text x="%g" y="%g" font-family="sans-serif" font-size="%g" text-anchor="%s" dominant-baseline="%s" fill="%s">%s</text>`,
+		x, y, size, anchor, baseline, hex(t.fill), html.EscapeString(t.s))
+}
+
+func (t text) rasterize(img *image.RGBA) {
+	// Each glyph pixel is scaled to a square block so that the glyph's height
+	// matches the requested size.
+	scale := round(t.size / glyphHeight)
+	if scale < 1 {
+		scale = 1
+	}
+
+	n := len([]rune(t.s))
+	width := n*(glyphWidth+1)*scale - scale
+	height := glyphHeight * scale
+
+	x0, y0 := round(t.x), round(t.y)
+	switch t.anchor {
+	case AnchorTopRight:
+		x0 -= width
+	case AnchorCenter:
+		x0 -= width / 2
+		y0 -= height / 2
+	}
+
+	for i, r := range []rune(t.s) {
+		glyph := glyphs[r]
+		for gy, row := range glyph {
+			for gx, c := range row {
+				if c == ' ' {
+					continue
+				}
+
+				px := x0 + (i*(glyphWidth+1)+gx)*scale
+				py := y0 + gy*scale
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						set(img, px+dx, py+dy, t.fill)
+					}
+				}
+			}
+		}
+	}
+}
+
+// line draws a one pixel wide line between two points.
+func line(img *image.RGBA, a, b Point, c color.RGBA) {
+	steps := int(math.Max(math.Abs(b.X-a.X), math.Abs(b.Y-a.Y)))
+	if steps == 0 {
+		set(img, round(a.X), round(a.Y), c)
+		return
+	}
+
+	for i := 0; i <= steps; i++ {
+		f := float64(i) / float64(steps)
+		set(img, round(a.X+f*(b.X-a.X)), round(a.Y+f*(b.Y-a.Y)), c)
+	}
+}
+
+// set sets a single pixel of the image, ignoring pixels that are out of bounds.
+func set(img *image.RGBA, x, y int, c color.RGBA) {
+	if image.Pt(x, y).In(img.Rect) {
+		img.SetRGBA(x, y, c)
+	}
+}
+
+// round rounds a coordinate to the nearest pixel.
+func round(f float64) int {
+	return int(math.Round(f))
+}
+
+// hex formats a color for use in an SVG document.
+func hex(c color.RGBA) string {
+	if c.A == 0 {
+		return "none"
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
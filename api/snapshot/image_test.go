@@ -0,0 +1,54 @@
+package snapshot
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestImage_SVG(t *testing.T) {
+	img := New(20, 10)
+	img.Rect(1, 2, 3, 4, Black, None)
+	img.Circle(5, 5, 2, Gray)
+	img.Polygon([]Point{{0, 0}, {4, 0}, {2, 3}}, Yellow, Black)
+	img.Text(10, 5, 7, AnchorCenter, "a<b", Black)
+
+	svg := string(img.SVG())
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="20" height="10" viewBox="0 0 20 10">`))
+	assert.True(t, strings.HasSuffix(svg, `</svg>`))
+	assert.Contains(t, svg, `<rect x="0" y="0" width="20" height="10" fill="#ffffff" stroke="none"/>`)
+	assert.Contains(t, svg, `<rect x="1" y="2" width="3" height="4" fill="#000000" stroke="none"/>`)
+	assert.Contains(t, svg, `<circle cx="5" cy="5" r="2" fill="none" stroke="#808080"/>`)
+	assert.Contains(t, svg, `<polygon points="0,0 4,0 2,3" fill="#f7da21" stroke="#000000"/>`)
+	assert.Contains(t, svg, `text-anchor="middle"`)
+	assert.Contains(t, svg, `>A&lt;B</text>`)
+}
+
+func TestImage_PNG(t *testing.T) {
+	img := New(20, 10)
+	img.Rect(2, 2, 4, 4, Black, None)
+	img.Polygon([]Point{{10, 0}, {20, 0}, {20, 10}, {10, 10}}, Yellow, None)
+	img.Text(0, 0, 7, AnchorTopLeft, "I", Gray)
+
+	bs, err := img.PNG()
+	require.NoError(t, err)
+
+	decoded, err := png.Decode(bytes.NewReader(bs))
+	require.NoError(t, err)
+	assert.Equal(t, 20, decoded.Bounds().Dx())
+	assert.Equal(t, 10, decoded.Bounds().Dy())
+
+	rgba := func(x, y int) [4]uint32 {
+		r, g, b, a := decoded.At(x, y).RGBA()
+		return [4]uint32{r >> 8, g >> 8, b >> 8, a >> 8}
+	}
+
+	assert.Equal(t, [4]uint32{0xFF, 0xFF, 0xFF, 0xFF}, rgba(8, 8))  // background
+	assert.Equal(t, [4]uint32{0x00, 0x00, 0x00, 0xFF}, rgba(3, 3))  // rect
+	assert.Equal(t, [4]uint32{0xF7, 0xDA, 0x21, 0xFF}, rgba(15, 5)) // polygon
+	assert.Equal(t, [4]uint32{0x80, 0x80, 0x80, 0xFF}, rgba(2, 0))  // top of the I
+	assert.Equal(t, [4]uint32{0xFF, 0xFF, 0xFF, 0xFF}, rgba(0, 1))  // beside the I
+}
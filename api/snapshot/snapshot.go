@@ -0,0 +1,64 @@
+package snapshot
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Options control how a snapshot of a puzzle is rendered.  They're parsed from
+// the query parameters of a snapshot request.
+type Options struct {
+	// The format to render the snapshot in, either "svg" or "png".
+	Format string
+
+	// Whether or not the letters the channel has filled into the puzzle should be
+	// drawn.
+	ShowLetters bool
+}
+
+// ParseOptions reads the options of a snapshot request from its query
+// parameters.  The format parameter defaults to svg and the letters parameter
+// defaults to true.
+func ParseOptions(r *http.Request) (Options, error) {
+	options := Options{Format: "svg", ShowLetters: true}
+
+	query := r.URL.Query()
+	if format := query.Get("format"); format != "" {
+		if format != "svg" && format != "png" {
+			return options, fmt.Errorf("unsupported snapshot format: %s", format)
+		}
+		options.Format = format
+	}
+
+	if letters := query.Get("letters"); letters != "" {
+		show, err := strconv.ParseBool(letters)
+		if err != nil {
+			return options, fmt.Errorf("unable to parse letters parameter %s: %v", letters, err)
+		}
+		options.ShowLetters = show
+	}
+
+	return options, nil
+}
+
+// Write renders an image in the requested format and writes it to the
+// response.
+func Write(w http.ResponseWriter, image *Image, format string) error {
+	var bs []byte
+	switch format {
+	case "png":
+		var err error
+		if bs, err = image.PNG(); err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", "image/png")
+
+	default:
+		bs = image.SVG()
+		w.Header().Set("Content-Type", "image/svg+xml")
+	}
+
+	_, err := w.Write(bs)
+	return err
+}
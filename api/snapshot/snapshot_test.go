@@ -0,0 +1,86 @@
+package snapshot
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected Options
+	}{
+		{
+			name:     "defaults",
+			expected: Options{Format: "svg", ShowLetters: true},
+		},
+		{
+			name:     "png",
+			query:    "?format=png",
+			expected: Options{Format: "png", ShowLetters: true},
+		},
+		{
+			name:     "hide letters",
+			query:    "?format=svg&letters=false",
+			expected: Options{Format: "svg", ShowLetters: false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/snapshot"+test.query, nil)
+
+			options, err := ParseOptions(request)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, options)
+		})
+	}
+}
+
+func TestParseOptions_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{
+			name:  "unsupported format",
+			query: "?format=gif",
+		},
+		{
+			name:  "invalid letters",
+			query: "?letters=maybe",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/snapshot"+test.query, nil)
+
+			_, err := ParseOptions(request)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		format      string
+		contentType string
+	}{
+		{format: "svg", contentType: "image/svg+xml"},
+		{format: "png", contentType: "image/png"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			require.NoError(t, Write(recorder, New(10, 10), test.format))
+			assert.Equal(t, test.contentType, recorder.Header().Get("Content-Type"))
+			assert.NotEmpty(t, recorder.Body.Bytes())
+		})
+	}
+}
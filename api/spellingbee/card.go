@@ -0,0 +1,63 @@
+package spellingbee
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"strings"
+)
+
+// The ranks that can be achieved in a spelling bee along with the percentage of
+// the maximum official score required to achieve each of them.  The ranks are
+// ordered from the highest to the lowest.
+var ranks = []struct {
+	Name    string
+	Percent int
+}{
+	{"Queen Bee", 100},
+	{"Genius", 70},
+	{"Amazing", 50},
+	{"Great", 40},
+	{"Nice", 25},
+	{"Solid", 15},
+	{"Good", 8},
+	{"Moving Up", 5},
+	{"Good Start", 2},
+	{"Beginner", 0},
+}
+
+// Rank returns the name of the rank achieved by the state.  Ranks are based on
+// only the official answers that have been found so that they're comparable
+// between channels regardless of whether unofficial answers are allowed.
+func (s *State) Rank() string {
+	var official []string
+	for _, word := range s.Puzzle.OfficialAnswers {
+		if _, found := s.Words[word]; found {
+			official = append(official, word)
+		}
+	}
+
+	score := s.Puzzle.ComputeScore(official)
+	max := s.Puzzle.MaximumOfficialScore
+	for _, rank := range ranks {
+		if score*100 >= max*rank.Percent {
+			return rank.Name
+		}
+	}
+
+	return ranks[len(ranks)-1].Name
+}
+
+// ShareCard renders the state as a compact text card that's suitable for
+// sharing.  The card includes a header describing the puzzle, the result of the
+// solve and the rank, score and number of words that were found.  The card is
+// built only from the state so the same state always renders the same card.
+func (s *State) ShareCard() string {
+	header := model.ShareCardHeader("Spelling Bee", model.PuzzleSource{
+		Publisher:     "The New York Times",
+		PublishedDate: s.Puzzle.PublishedDate,
+	})
+	result := model.ShareCardResult(s.Status, s.TotalSolveDuration)
+	rank := fmt.Sprintf("🐝 %s · %d points · %d words", s.Rank(), s.Score, len(s.Words))
+
+	return strings.Join([]string{header, result, rank}, "\n")
+}
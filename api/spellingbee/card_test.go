@@ -0,0 +1,67 @@
+package spellingbee
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestState_Rank(t *testing.T) {
+	tests := []struct {
+		name     string
+		words    []string
+		expected string
+	}{
+		{
+			name:     "no words",
+			expected: "Beginner",
+		},
+		{
+			name:     "good start",
+			words:    []string{"COUNT"},
+			expected: "Good Start",
+		},
+		{
+			name:     "unofficial answers don't count",
+			words:    []string{"COUNT", "CONTO", "CORNUTO", "CROTON"},
+			expected: "Good Start",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewState(t, "nytbee-20200408.html")
+			for _, word := range test.words {
+				require.NoError(t, state.ApplyAnswer(word, true))
+			}
+
+			assert.Equal(t, test.expected, state.Rank())
+		})
+	}
+}
+
+func TestState_Rank_QueenBee(t *testing.T) {
+	state := NewState(t, "nytbee-20200408.html")
+	for _, word := range state.Puzzle.OfficialAnswers {
+		require.NoError(t, state.ApplyAnswer(word, false))
+	}
+
+	assert.Equal(t, "Queen Bee", state.Rank())
+}
+
+func TestState_ShareCard(t *testing.T) {
+	state := NewState(t, "nytbee-20200408.json")
+	state.Status = model.StatusComplete
+	state.LastStartTime = nil
+	state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+	for _, word := range state.Puzzle.OfficialAnswers {
+		require.NoError(t, state.ApplyAnswer(word, false))
+	}
+
+	expected := "Spelling Bee · The New York Times · 2020-04-08\n" +
+		"✅ Solved in 10m0s\n" +
+		"🐝 Queen Bee · 183 points · 48 words"
+	assert.Equal(t, expected, state.ShareCard())
+}
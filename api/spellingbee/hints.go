@@ -0,0 +1,55 @@
+package spellingbee
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/worker"
+	"github.com/gomodule/redigo/redis"
+	"time"
+)
+
+// GiveHints gives a hint to every channel's spelling bee solve that has
+// stalled.
+func GiveHints(conn redis.Conn, registry *pubsub.Registry, now time.Time) error {
+	return worker.ForEachChannel(conn, StateKey, func(channel string) error {
+		return GiveHint(conn, registry, channel, now)
+	})
+}
+
+// GiveHint publishes a hint for an unfound word of a channel's spelling bee
+// solve if the channel has hints enabled and the solve hasn't found a word
+// within its configured hint interval.  The solve's last progress time is
+// updated so that hints are given at most once per hint interval.
+func GiveHint(conn redis.Conn, registry *pubsub.Registry, channel string, now time.Time) error {
+	settings, err := GetSettings(conn, channel)
+	if err != nil {
+		return err
+	}
+
+	var state State
+	read := func(conn db.Connection) error {
+		var err error
+		state, err = GetState(conn, channel)
+		return err
+	}
+
+	write := func(tx db.Connection) ([]pubsub.Event, error) {
+		if !worker.IsStalled(state.Status, state.LastActivityTime(), settings.HintInterval.Duration, now) {
+			return nil, nil
+		}
+
+		hint, err := state.GetHint(settings.AllowUnofficialAnswers)
+		if err != nil {
+			return nil, err
+		}
+		state.LastProgressTime = &now
+
+		if err := SetState(tx, channel, state); err != nil {
+			return nil, err
+		}
+
+		return []pubsub.Event{HintEvent(hint)}, nil
+	}
+
+	return worker.Update(conn, registry, ChannelID(channel), StateKey(channel), read, write)
+}
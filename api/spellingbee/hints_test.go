@@ -0,0 +1,183 @@
+package spellingbee
+
+import (
+	"errors"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGiveHint(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name             string
+		status           model.Status
+		interval         time.Duration
+		lastStartTime    *time.Time
+		lastProgressTime *time.Time
+		expectHint       bool
+	}{
+		{
+			name:          "hints disabled",
+			status:        model.StatusSolving,
+			lastStartTime: before(time.Hour),
+		},
+		{
+			name:          "not solving",
+			status:        model.StatusPaused,
+			interval:      5 * time.Minute,
+			lastStartTime: before(time.Hour),
+		},
+		{
+			name:          "started recently",
+			status:        model.StatusSolving,
+			interval:      5 * time.Minute,
+			lastStartTime: before(time.Minute),
+		},
+		{
+			name:             "recent progress",
+			status:           model.StatusSolving,
+			interval:         5 * time.Minute,
+			lastStartTime:    before(time.Hour),
+			lastProgressTime: before(time.Minute),
+		},
+		{
+			name:          "stalled since start",
+			status:        model.StatusSolving,
+			interval:      5 * time.Minute,
+			lastStartTime: before(10 * time.Minute),
+			expectHint:    true,
+		},
+		{
+			name:             "stalled since progress",
+			status:           model.StatusSolving,
+			interval:         5 * time.Minute,
+			lastStartTime:    before(time.Hour),
+			lastProgressTime: before(5 * time.Minute),
+			expectHint:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+			events := NewEventSubscription(t, registry, "channel")
+
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = test.status
+			state.LastStartTime = test.lastStartTime
+			state.LastProgressTime = test.lastProgressTime
+			require.NoError(t, SetState(conn, "channel", state))
+
+			settings := Settings{HintInterval: model.Duration{Duration: test.interval}}
+			require.NoError(t, SetSettings(conn, "channel", settings))
+
+			require.NoError(t, GiveHint(conn, registry, "channel", now))
+
+			state, err := GetState(conn, "channel")
+			require.NoError(t, err)
+			assert.Empty(t, state.Words)
+
+			if !test.expectHint {
+				assert.Equal(t, test.lastProgressTime, state.LastProgressTime)
+				assert.Empty(t, events)
+				return
+			}
+
+			assert.Equal(t, &now, state.LastProgressTime)
+			require.Len(t, events, 1)
+
+			event := <-events
+			assert.Equal(t, "hint", event.Kind)
+
+			hint := event.Payload.(Hint)
+			assert.Len(t, hint.Prefix, 2)
+			assert.True(t, hint.Length >= 4)
+		})
+	}
+}
+
+func TestGiveHint_Error(t *testing.T) {
+	tests := []struct {
+		name              string
+		settingsLoadError error
+		stateLoadError    error
+		stateSaveError    error
+	}{
+		{
+			name:              "error loading settings",
+			settingsLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			stateSaveError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			start := time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC)
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = model.StatusSolving
+			state.LastStartTime = &start
+			require.NoError(t, SetState(conn, "channel", state))
+
+			settings := Settings{HintInterval: model.Duration{Duration: 5 * time.Minute}}
+			require.NoError(t, SetSettings(conn, "channel", settings))
+
+			if test.settingsLoadError != nil {
+				ForceErrorDuringSettingsLoad(t, test.settingsLoadError)
+			}
+			if test.stateLoadError != nil {
+				ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+			if test.stateSaveError != nil {
+				ForceErrorDuringStateSave(t, test.stateSaveError)
+			}
+
+			err := GiveHint(conn, registry, "channel", start.Add(time.Hour))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGiveHints(t *testing.T) {
+	_, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	aEvents := NewEventSubscription(t, registry, "a")
+	bEvents := NewEventSubscription(t, registry, "b")
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Hour)
+
+	for _, channel := range []string{"a", "b"} {
+		state := NewState(t, "nytbee-20200408.html")
+		state.Status = model.StatusSolving
+		state.LastStartTime = &start
+		require.NoError(t, SetState(conn, channel, state))
+	}
+
+	// Only channel a has hints enabled.
+	settings := Settings{HintInterval: model.Duration{Duration: 5 * time.Minute}}
+	require.NoError(t, SetSettings(conn, "a", settings))
+	require.NoError(t, SetSettings(conn, "b", Settings{}))
+
+	require.NoError(t, GiveHints(conn, registry, now))
+	assert.Len(t, aEvents, 1)
+	assert.Empty(t, bEvents)
+}
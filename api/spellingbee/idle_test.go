@@ -0,0 +1,190 @@
+package spellingbee
+
+import (
+	"errors"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPauseIfIdle(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name             string
+		status           model.Status
+		timeout          time.Duration
+		lastStartTime    *time.Time
+		lastProgressTime *time.Time
+		expectPause      bool
+		expectedDuration time.Duration
+	}{
+		{
+			name:          "idle timeout disabled",
+			status:        model.StatusSolving,
+			lastStartTime: before(time.Hour),
+		},
+		{
+			name:          "not solving",
+			status:        model.StatusSelected,
+			timeout:       15 * time.Minute,
+			lastStartTime: before(time.Hour),
+		},
+		{
+			name:          "started recently",
+			status:        model.StatusSolving,
+			timeout:       15 * time.Minute,
+			lastStartTime: before(time.Minute),
+		},
+		{
+			name:             "recent progress",
+			status:           model.StatusSolving,
+			timeout:          15 * time.Minute,
+			lastStartTime:    before(time.Hour),
+			lastProgressTime: before(time.Minute),
+		},
+		{
+			name:             "idle since start",
+			status:           model.StatusSolving,
+			timeout:          15 * time.Minute,
+			lastStartTime:    before(time.Hour),
+			expectPause:      true,
+			expectedDuration: 0,
+		},
+		{
+			name:             "idle since progress",
+			status:           model.StatusSolving,
+			timeout:          15 * time.Minute,
+			lastStartTime:    before(time.Hour),
+			lastProgressTime: before(20 * time.Minute),
+			expectPause:      true,
+			expectedDuration: 40 * time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+			events := NewEventSubscription(t, registry, "channel")
+
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = test.status
+			state.LastStartTime = test.lastStartTime
+			state.LastProgressTime = test.lastProgressTime
+			state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+			require.NoError(t, SetState(conn, "channel", state))
+
+			settings := Settings{IdleTimeout: model.Duration{Duration: test.timeout}}
+			require.NoError(t, SetSettings(conn, "channel", settings))
+
+			require.NoError(t, PauseIfIdle(conn, registry, "channel", now))
+
+			state, err := GetState(conn, "channel")
+			require.NoError(t, err)
+
+			if !test.expectPause {
+				assert.Equal(t, test.status, state.Status)
+				assert.Equal(t, 10*time.Minute, state.TotalSolveDuration.Duration)
+				assert.Empty(t, events)
+				return
+			}
+
+			assert.Equal(t, model.StatusPaused, state.Status)
+			assert.Nil(t, state.LastStartTime)
+			assert.Equal(t, 10*time.Minute+test.expectedDuration, state.TotalSolveDuration.Duration)
+
+			require.Len(t, events, 1)
+			event := <-events
+			assert.Equal(t, "state", event.Kind)
+			assert.Equal(t, model.StatusPaused, event.Payload.(State).Status)
+		})
+	}
+}
+
+func TestPauseIfIdle_Error(t *testing.T) {
+	tests := []struct {
+		name              string
+		settingsLoadError error
+		stateLoadError    error
+		stateSaveError    error
+	}{
+		{
+			name:              "error loading settings",
+			settingsLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			stateSaveError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			start := time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC)
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = model.StatusSolving
+			state.LastStartTime = &start
+			require.NoError(t, SetState(conn, "channel", state))
+
+			settings := Settings{IdleTimeout: model.Duration{Duration: 15 * time.Minute}}
+			require.NoError(t, SetSettings(conn, "channel", settings))
+
+			if test.settingsLoadError != nil {
+				ForceErrorDuringSettingsLoad(t, test.settingsLoadError)
+			}
+			if test.stateLoadError != nil {
+				ForceErrorDuringStateLoad(t, test.stateLoadError)
+			}
+			if test.stateSaveError != nil {
+				ForceErrorDuringStateSave(t, test.stateSaveError)
+			}
+
+			err := PauseIfIdle(conn, registry, "channel", start.Add(time.Hour))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPauseIdleSolves(t *testing.T) {
+	_, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Hour)
+
+	for _, channel := range []string{"a", "b"} {
+		state := NewState(t, "nytbee-20200408.html")
+		state.Status = model.StatusSolving
+		state.LastStartTime = &start
+		require.NoError(t, SetState(conn, channel, state))
+	}
+
+	// Only channel a has an idle timeout configured.
+	settings := Settings{IdleTimeout: model.Duration{Duration: 15 * time.Minute}}
+	require.NoError(t, SetSettings(conn, "a", settings))
+	require.NoError(t, SetSettings(conn, "b", Settings{}))
+
+	require.NoError(t, PauseIdleSolves(conn, registry, now))
+
+	a, err := GetState(conn, "a")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusPaused, a.Status)
+
+	b, err := GetState(conn, "b")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusSolving, b.Status)
+}
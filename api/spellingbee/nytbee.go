@@ -4,7 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
-	"github.com/bbeck/puzzles-with-chat/api/web"
+	"github.com/bbeck/puzzles-with-chat/api/cache"
 	"io"
 	"sort"
 	"strings"
@@ -35,20 +35,19 @@ func LoadFromNYTBee(date string) (*Puzzle, error) {
 	}
 
 	// Load the HTML page for this date from nytbee.com.
+	var puzzle *Puzzle
 	url := fmt.Sprintf("https://nytbee.com/Bee_%04d%02d%02d.html", published.Year(), published.Month(), published.Day())
-	response, err := web.Get(url)
-	if response != nil {
-		defer func() { _ = response.Body.Close() }()
-	}
+	err = cache.Fetch("spellingbee/new_york_times", date, url, nil, func(in io.Reader) error {
+		var err error
+		if puzzle, err = ParseNYTBeeResponse(in); err != nil {
+			return fmt.Errorf("unable to parse nytbee.com response for date %s: %v", published, err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	puzzle, err := ParseNYTBeeResponse(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse nytbee.com response for date %s: %v", published, err)
-	}
-
 	puzzle.Description = fmt.Sprintf("New York Times puzzle from %s", published.Format("2006-01-02"))
 	puzzle.PublishedDate = published
 	return puzzle, nil
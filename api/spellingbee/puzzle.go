@@ -1,6 +1,10 @@
 package spellingbee
 
-import "time"
+import (
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"sort"
+	"time"
+)
 
 // Puzzle represents a spelling bee puzzle.  The puzzle is comprised of a
 // circular grid of 6 letters around a single letter.  The goal is to use the
@@ -41,6 +45,24 @@ type Puzzle struct {
 	NumUnofficialAnswers int `json:"num_unofficial_answers"`
 }
 
+// Fingerprint computes a hash of the letters and official answers of the
+// puzzle.  Puzzles with the same fingerprint are the same spelling bee even if
+// they were loaded from different sources.  The order of the letters and
+// answers doesn't matter.
+func (p *Puzzle) Fingerprint() string {
+	letters := append([]string(nil), p.Letters...)
+	sort.Strings(letters)
+
+	answers := append([]string(nil), p.OfficialAnswers...)
+	sort.Strings(answers)
+
+	return model.Fingerprint(struct {
+		CenterLetter string
+		Letters      []string
+		Answers      []string
+	}{p.CenterLetter, letters, answers})
+}
+
 // WithoutAnswers returns a copy of the puzzle that has the answers removed.
 // This makes the resulting puzzle suitable to pass to a client that shouldn't
 // know the answers to the puzzle.
@@ -218,6 +218,22 @@ func TestPuzzle_WithoutAnswers(t *testing.T) {
 	}
 }
 
+func TestPuzzle_Fingerprint(t *testing.T) {
+	puzzle := LoadTestPuzzle(t, "nytbee-20200408.html")
+	fingerprint := puzzle.Fingerprint()
+	assert.NotEmpty(t, fingerprint)
+
+	// Where the puzzle came from and the order of its letters don't change its
+	// fingerprint.
+	puzzle.PublishedDate = time.Time{}
+	puzzle.Letters[0], puzzle.Letters[1] = puzzle.Letters[1], puzzle.Letters[0]
+	assert.Equal(t, fingerprint, puzzle.Fingerprint())
+
+	// Its answers do.
+	puzzle.OfficialAnswers = puzzle.OfficialAnswers[1:]
+	assert.NotEqual(t, fingerprint, puzzle.Fingerprint())
+}
+
 func TestPuzzle_ComputeScore(t *testing.T) {
 	tests := []struct {
 		name     string
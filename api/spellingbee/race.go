@@ -0,0 +1,75 @@
+package spellingbee
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/gomodule/redigo/redis"
+	"time"
+)
+
+// StartSolve starts a channel's spelling bee solve that has a puzzle selected but
+// hasn't been started yet.  This allows the solves of several channels to be
+// started at the same moment, for example when they're racing each other.
+func StartSolve(conn redis.Conn, registry *pubsub.Registry, channel string, now time.Time) error {
+	settings, err := GetSettings(conn, channel)
+	if err != nil {
+		return err
+	}
+
+	state, err := GetState(conn, channel)
+	if err != nil {
+		return err
+	}
+
+	if state.Status != model.StatusSelected {
+		return fmt.Errorf("unable to start solve with status %s", state.Status)
+	}
+
+	state.Status = model.StatusSolving
+	state.LastStartTime = &now
+	state.TimeLimit = settings.TimeLimit
+
+	if err := SetState(conn, channel, state); err != nil {
+		return err
+	}
+
+	// Broadcast to all of the clients that the puzzle status has been changed,
+	// making sure to not include the solution.
+	state.Puzzle = state.PublicPuzzle()
+
+	registry.Publish(ChannelID(channel), StateEvent(state))
+
+	return nil
+}
+
+// GetProgress determines how far along a channel is in its spelling bee solve as
+// of the provided time.  If the channel doesn't have a puzzle selected then the
+// progress will only contain the status of the solve.
+func GetProgress(conn redis.Conn, channel string, now time.Time) (model.Progress, error) {
+	settings, err := GetSettings(conn, channel)
+	if err != nil {
+		return model.Progress{}, err
+	}
+
+	state, err := GetState(conn, channel)
+	if err != nil {
+		return model.Progress{}, err
+	}
+
+	progress := model.Progress{
+		Status:      state.Status,
+		ElapsedTime: model.Duration{Duration: state.ElapsedTime(now)},
+	}
+
+	if state.Puzzle != nil {
+		progress.Puzzle = model.PuzzleSource{
+			Publisher:     "The New York Times",
+			PublishedDate: state.Puzzle.PublishedDate,
+			Fingerprint:   state.Puzzle.Fingerprint(),
+		}
+		progress.PercentComplete = state.PercentComplete(settings.AllowUnofficialAnswers)
+	}
+
+	return progress, nil
+}
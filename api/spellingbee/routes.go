@@ -3,8 +3,13 @@ package spellingbee
 import (
 	"compress/flate"
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/answerlog"
+	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
 	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/snapshot"
+	"github.com/bbeck/puzzles-with-chat/api/team"
+	"github.com/bbeck/puzzles-with-chat/api/web"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
@@ -22,8 +27,15 @@ func RegisterRoutes(r chi.Router, pool *redis.Pool, registry *pubsub.Registry) {
 		r.Put("/setting/{setting}", UpdateSetting(pool, registry))
 		r.Get("/shuffle", ShuffleLetters(pool, registry))
 		r.Put("/status", ToggleStatus(pool, registry))
+		r.Put("/reset", ResetState(pool, registry))
+		r.Put("/giveup", GiveUp(pool, registry))
 		r.Post("/answer", AddAnswer(pool, registry))
 		r.Get("/events", GetEvents(pool, registry))
+		r.Get("/state", GetCurrentState(pool))
+		r.Get("/settings", GetCurrentSettings(pool))
+		r.Get("/solution", GetSolution(pool))
+		r.Get("/share", GetShareCard(pool))
+		r.Get("/snapshot", GetSnapshot(pool))
 	})
 
 	compressor := middleware.NewCompressor(flate.BestCompression, "application/json")
@@ -81,11 +93,18 @@ func UpdatePuzzle(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 			return
 		}
 
+		// The answers submitted during the previous solve don't apply to this one.
+		if err := answerlog.Clear(conn, "spellingbee", channel); err != nil {
+			log.Printf("unable to clear answer log for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		// Broadcast to all of the clients that the puzzle has been selected, making
 		// sure to not include the answers.  It's okay to overwrite the puzzle
 		// attribute because we just wrote this state instance to the database
 		// and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutAnswers()
+		state.Puzzle = state.PublicPuzzle()
 
 		registry.Publish(ChannelID(channel), StateEvent(state))
 
@@ -142,6 +161,48 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 			}
 			settings.ShowAnswerPlaceholders = value
 
+		case "hint_interval":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse spelling bee hint interval setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid spelling bee hint interval setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.HintInterval = value
+
+		case "idle_timeout":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse spelling bee idle timeout setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid spelling bee idle timeout setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.IdleTimeout = value
+
+		case "time_limit":
+			var value model.Duration
+			if err := render.DecodeJSON(r.Body, &value); err != nil {
+				log.Printf("unable to parse spelling bee time limit setting json %s: %+v", value, err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if value.Duration < 0 {
+				log.Printf("invalid spelling bee time limit setting: %s", value)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			settings.TimeLimit = value
+
 		default:
 			log.Printf("unrecognized spelling bee setting name %s", setting)
 			w.WriteHeader(http.StatusBadRequest)
@@ -159,35 +220,70 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		// requires this.  We do this after the setting is applied so that if there
 		// was an error earlier we don't modify the solve's state.
 		var updatedState *State
+		var answers []model.LoggedAnswer
 		if shouldRebuildWordMap {
-			state, err := GetState(conn, channel)
-			if err != nil {
-				log.Printf("unable to load state for channel %s: %+v", channel, err)
-				w.WriteHeader(http.StatusNotFound)
-				return
-			}
+			var state State
+			var changed bool
+			var now time.Time
+			read := func(conn db.Connection) error {
+				var err error
+				state, err = GetState(conn, channel)
+				if err != nil {
+					return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+				}
+
+				// There's no need to update cells if the puzzle hasn't been selected or
+				// started or has already ended.
+				status := state.Status
+				changed = status != model.StatusCreated && status != model.StatusSelected && status != model.StatusComplete && status != model.StatusGivenUp && status != model.StatusExpired
+				if !changed {
+					return nil
+				}
 
-			// There's no need to update cells if the puzzle hasn't been selected or
-			// started or is already complete.
-			status := state.Status
-			if status != model.StatusCreated && status != model.StatusSelected && status != model.StatusComplete {
 				state.RebuildWordMap(settings.AllowUnofficialAnswers)
 
 				// We may have just solved the puzzle -- if so then we should stop the
-				// timer before saving the state.
+				// timer before saving the state and load the answer log so that the
+				// attempt can be archived.
+				now = time.Now()
 				if state.Status == model.StatusComplete {
-					now := time.Now()
 					total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
 					state.LastStartTime = nil
 					state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+					answers, err = answerlog.Get(conn, "spellingbee", channel)
+					if err != nil {
+						return fmt.Errorf("unable to load answer log: %v", err)
+					}
+				}
+
+				return nil
+			}
+
+			write := func(tx db.Connection) error {
+				if !changed {
+					return nil
 				}
 
-				if err := SetState(conn, channel, state); err != nil {
-					log.Printf("unable to save state for channel %s: %+v", channel, err)
-					w.WriteHeader(http.StatusInternalServerError)
-					return
+				if err := SetState(tx, channel, state); err != nil {
+					return err
 				}
 
+				// If we just solved the puzzle then the attempt should be archived.
+				if state.Status == model.StatusComplete {
+					return ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now))
+				}
+
+				return nil
+			}
+
+			if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+				log.Printf("unable to rebuild word map for channel %s: %+v", channel, err)
+				w.WriteHeader(web.StatusCode(err))
+				return
+			}
+
+			if changed {
 				updatedState = &state
 			}
 		}
@@ -198,14 +294,15 @@ func UpdateSetting(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		if updatedState != nil {
 			// Broadcast the updated state to all of the clients, making sure to not
 			// include the answers.
-			updatedState.Puzzle = updatedState.Puzzle.WithoutAnswers()
+			updatedState.Puzzle = updatedState.PublicPuzzle()
 
 			registry.Publish(ChannelID(channel), StateEvent(*updatedState))
 
 			// Since we updated the state, we may have also just solved the puzzle.
-			// If we did then we should also send a complete message.
+			// If we did then we should also send complete and summary messages.
 			if updatedState.Status == model.StatusComplete {
 				registry.Publish(ChannelID(channel), CompleteEvent())
+				registry.Publish(ChannelID(channel), SummaryEvent(updatedState.Summary(answers)))
 			}
 		}
 
@@ -221,27 +318,35 @@ func ShuffleLetters(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFun
 		conn := pool.Get()
 		defer func() { _ = conn.Close() }()
 
-		state, err := GetState(conn, channel)
-		if err != nil {
-			log.Printf("unable to load state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
+		var state State
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
 
-		if state.Status != model.StatusSolving {
-			w.WriteHeader(http.StatusConflict)
-			return
+			// Nothing can be changed once the time limit has been reached, even if
+			// the solve hasn't been marked as expired yet.
+			if state.Status != model.StatusSolving || state.IsOutOfTime(time.Now()) {
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
+
+			// Shuffle the letters.
+			rand.Shuffle(len(state.Letters), func(i, j int) {
+				state.Letters[i], state.Letters[j] = state.Letters[j], state.Letters[i]
+			})
+
+			return nil
 		}
 
-		// Shuffle the letters.
-		rand.Shuffle(len(state.Letters), func(i, j int) {
-			state.Letters[i], state.Letters[j] = state.Letters[j], state.Letters[i]
-		})
+		write := func(tx db.Connection) error {
+			return SetState(tx, channel, state)
+		}
 
-		// Save the updated state.
-		if err := SetState(conn, channel, state); err != nil {
-			log.Printf("unable to save state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to shuffle letters for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
 			return
 		}
 
@@ -249,7 +354,7 @@ func ShuffleLetters(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFun
 		// sure to not include the answers.  It's okay to overwrite the puzzle
 		// attribute because we just wrote this state instance to the database
 		// and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutAnswers()
+		state.Puzzle = state.PublicPuzzle()
 
 		registry.Publish(ChannelID(channel), StateEvent(state))
 
@@ -267,44 +372,60 @@ func ToggleStatus(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		conn := pool.Get()
 		defer func() { _ = conn.Close() }()
 
-		state, err := GetState(conn, channel)
-		if err != nil {
-			log.Printf("unable to load state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
+		var state State
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
 
-		now := time.Now()
+			settings, err := GetSettings(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load settings: %v", err)
+			}
 
-		switch state.Status {
-		case model.StatusCreated:
-			log.Printf("unable to toggle status for channel %s, no puzzle selected", channel)
-			w.WriteHeader(http.StatusBadRequest)
-			return
+			now := time.Now()
 
-		case model.StatusSelected:
-			state.Status = model.StatusSolving
-			state.LastStartTime = &now
+			switch state.Status {
+			case model.StatusCreated:
+				return web.Errorf(http.StatusBadRequest, "no puzzle selected")
 
-		case model.StatusPaused:
-			state.Status = model.StatusSolving
-			state.LastStartTime = &now
+			case model.StatusSelected:
+				state.Status = model.StatusSolving
+				state.LastStartTime = &now
+				state.TimeLimit = settings.TimeLimit
 
-		case model.StatusSolving:
-			state.Status = model.StatusPaused
-			total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
-			state.LastStartTime = nil
-			state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+			case model.StatusPaused:
+				state.Status = model.StatusSolving
+				state.LastStartTime = &now
 
-		case model.StatusComplete:
-			log.Printf("unable to toggle status for channel %s, puzzle is already solved", channel)
-			w.WriteHeader(http.StatusBadRequest)
-			return
+			case model.StatusSolving:
+				state.Status = model.StatusPaused
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+			case model.StatusComplete:
+				return web.Errorf(http.StatusBadRequest, "puzzle is already solved")
+
+			case model.StatusGivenUp:
+				return web.Errorf(http.StatusBadRequest, "puzzle was given up")
+
+			case model.StatusExpired:
+				return web.Errorf(http.StatusBadRequest, "puzzle ran out of time")
+			}
+
+			return nil
 		}
 
-		if err := SetState(conn, channel, state); err != nil {
-			log.Printf("unable to save state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
+		write := func(tx db.Connection) error {
+			return SetState(tx, channel, state)
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to toggle status for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
 			return
 		}
 
@@ -312,18 +433,139 @@ func ToggleStatus(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc
 		// making sure to not include the answers.  It's okay to overwrite the
 		// puzzle attribute because we just wrote this state instance to the
 		// database and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutAnswers()
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ResetState clears all progress made on the current spelling bee solve while
+// keeping the puzzle.  The solve is returned to the selected status and its
+// timer is reset.
+func ResetState(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			if state.Status == model.StatusCreated {
+				return web.Errorf(http.StatusBadRequest, "no puzzle selected")
+			}
+
+			state.Reset()
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			return answerlog.Clear(tx, "spellingbee", channel)
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to reset state for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
+
+		// Broadcast to all of the clients that the puzzle has been reset, making
+		// sure to not include the answers.  It's okay to overwrite the puzzle
+		// attribute because we just wrote this state instance to the database
+		// and will be discarding it immediately publishing.
+		state.Puzzle = state.PublicPuzzle()
+
+		registry.Publish(ChannelID(channel), StateEvent(state))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// GiveUp ends the current spelling bee solve without completing it.  The
+// answers are revealed to all of the clients and the attempt is archived as
+// unfinished.
+func GiveUp(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		var state State
+		var answers []model.LoggedAnswer
+		var now time.Time
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
+
+			now = time.Now()
+
+			switch state.Status {
+			case model.StatusSelected, model.StatusPaused:
+
+			case model.StatusSolving:
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+			default:
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
+
+			state.Status = model.StatusGivenUp
+
+			answers, err = answerlog.Get(conn, "spellingbee", channel)
+			if err != nil {
+				return fmt.Errorf("unable to load answer log: %v", err)
+			}
+
+			return nil
+		}
+
+		write := func(tx db.Connection) error {
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			return ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now))
+		}
+
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to give up for channel %s: %+v", channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
+		}
 
+		// Broadcast to all of the clients that the puzzle has been given up on.
+		// The answers are intentionally included so that they can be displayed.
 		registry.Publish(ChannelID(channel), StateEvent(state))
 
 		w.WriteHeader(http.StatusOK)
 	}
 }
 
-// AddAnswer applies an answer to the puzzle solve.
+// AddAnswer applies an answer to the puzzle solve.  The optional user query
+// parameter names the chatter that provided the answer so that their team can
+// be credited with the word.
 func AddAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		channel := chi.URLParam(r, "channel")
+		user := r.URL.Query().Get("user")
 
 		if r.ContentLength > 1024 {
 			w.WriteHeader(http.StatusRequestEntityTooLarge)
@@ -345,47 +587,114 @@ func AddAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 		conn := pool.Get()
 		defer func() { _ = conn.Close() }()
 
-		state, err := GetState(conn, channel)
-		if err != nil {
-			log.Printf("unable to load state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
+		var state State
+		var settings Settings
+		var logged model.LoggedAnswer
+		var answers []model.LoggedAnswer
+		var rejected error
+		var scored bool
+		var previous int
+		var now time.Time
+		read := func(conn db.Connection) error {
+			var err error
+			state, err = GetState(conn, channel)
+			if err != nil {
+				return web.Errorf(http.StatusNotFound, "unable to load state: %v", err)
+			}
 
-		if state.Status != model.StatusSolving {
-			w.WriteHeader(http.StatusConflict)
-			return
-		}
+			// Nothing can be changed once the time limit has been reached, even if
+			// the solve hasn't been marked as expired yet.
+			if state.Status != model.StatusSolving || state.IsOutOfTime(time.Now()) {
+				return web.Errorf(http.StatusConflict, "status is %s", state.Status)
+			}
 
-		settings, err := GetSettings(conn, channel)
-		if err != nil {
-			log.Printf("unable to load settings for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
+			settings, err = GetSettings(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load settings: %v", err)
+			}
+
+			roster, err := team.GetRoster(conn, channel)
+			if err != nil {
+				return fmt.Errorf("unable to load team roster: %v", err)
+			}
+
+			// Save the previous score so that we can determine if we crossed the
+			// genius threshold or not.
+			previous = state.Score
+
+			// Rejected answers still count towards the answers submitted during the
+			// solve, so they're logged without changing the state.
+			rejected = state.ApplyAnswer(answer, settings.AllowUnofficialAnswers)
+			if rejected != nil {
+				logged = state.NewLoggedAnswer(user, "", answer, time.Now())
+				return nil
+			}
+
+			now = time.Now()
+			state.LastProgressTime = &now
+
+			logged = state.NewLoggedAnswer(user, "", answer, now)
+			logged.Applied = true
+			logged.Accepted = true
+
+			// When chatters have joined teams the solve becomes a race between them,
+			// the word is credited to the answerer's team.
+			scored = false
+			if len(roster) > 0 {
+				scored = state.CreditTeam(roster.TeamOf(user), answer) > 0
+			}
+
+			// If we just solved the puzzle then we should stop the timer.
+			if state.Status == model.StatusComplete {
+				total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
+				state.LastStartTime = nil
+				state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+
+				// The answer log is needed to archive the attempt, it won't contain the
+				// answer that solved the puzzle until it's written.
+				answers, err = answerlog.Get(conn, "spellingbee", channel)
+				if err != nil {
+					return fmt.Errorf("unable to load answer log: %v", err)
+				}
+				answers = append(answers, logged)
+			}
+
+			return nil
 		}
 
-		// Save the previous score so that we can determine if we crossed the genius
-		// threshold or not.
-		previous := state.Score
+		write := func(tx db.Connection) error {
+			if rejected != nil {
+				if err := answerlog.Add(tx, "spellingbee", channel, logged, StateTTL); err != nil {
+					log.Printf("unable to log answer for channel %s: %+v", channel, err)
+				}
+				return nil
+			}
+
+			if err := answerlog.Add(tx, "spellingbee", channel, logged, StateTTL); err != nil {
+				return fmt.Errorf("unable to log answer: %v", err)
+			}
 
-		if err := state.ApplyAnswer(answer, settings.AllowUnofficialAnswers); err != nil {
-			log.Printf("unable to apply answer %s for channel %s: %+v", answer, channel, err)
-			w.WriteHeader(http.StatusBadRequest)
-			return
+			if err := SetState(tx, channel, state); err != nil {
+				return err
+			}
+
+			// If we just solved the puzzle then the attempt should be archived.
+			if state.Status == model.StatusComplete {
+				return ArchiveAttempt(tx, channel, NewAttempt(channel, state, answers, now))
+			}
+
+			return nil
 		}
 
-		// If we just solved the puzzle then we should stop the timer.
-		if state.Status == model.StatusComplete {
-			now := time.Now()
-			total := state.TotalSolveDuration.Nanoseconds() + now.Sub(*state.LastStartTime).Nanoseconds()
-			state.LastStartTime = nil
-			state.TotalSolveDuration = model.Duration{Duration: time.Duration(total)}
+		if err := db.Update(conn, StateKey(channel), read, write); err != nil {
+			log.Printf("unable to apply answer %s for channel %s: %+v", answer, channel, err)
+			w.WriteHeader(web.StatusCode(err))
+			return
 		}
 
-		// Save the updated state.
-		if err := SetState(conn, channel, state); err != nil {
-			log.Printf("unable to save state for channel %s: %+v", channel, err)
-			w.WriteHeader(http.StatusInternalServerError)
+		if rejected != nil {
+			log.Printf("unable to apply answer %s for channel %s: %+v", answer, channel, rejected)
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
@@ -393,10 +702,15 @@ func AddAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 		// sure to not include the answers.  It's okay to overwrite the puzzle
 		// attribute because we just wrote this state instance to the database
 		// and will be discarding it immediately publishing.
-		state.Puzzle = state.Puzzle.WithoutAnswers()
+		state.Puzzle = state.PublicPuzzle()
 
 		registry.Publish(ChannelID(channel), StateEvent(state))
 
+		// If a team scored then let the clients know the new scores.
+		if scored {
+			registry.Publish(ChannelID(channel), ScoreEvent(state.Scores))
+		}
+
 		// If we've just crossed the threshold for genius then send a genius event
 		// as well.
 		max := float64(state.Puzzle.MaximumOfficialScore)
@@ -408,15 +722,195 @@ func AddAnswer(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 			registry.Publish(ChannelID(channel), GeniusEvent())
 		}
 
-		// If we've just finished the solve then send a complete event as well.
+		// If we've just finished the solve then send complete and summary events as well.
 		if state.Status == model.StatusComplete {
 			registry.Publish(ChannelID(channel), CompleteEvent())
+			registry.Publish(ChannelID(channel), SummaryEvent(state.Summary(answers)))
 		}
 
 		w.WriteHeader(http.StatusCreated)
 	}
 }
 
+// GetCurrentState returns the current state of a channel's spelling bee solve with
+// the answers to the puzzle removed.  The response includes an ETag so that
+// clients polling for the state can avoid downloading it when it hasn't
+// changed.
+func GetCurrentState(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		// The version is loaded before the state so that if the state is written
+		// in between the two the client will just download it again next time.
+		version, err := GetStateVersion(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state version for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to read state for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Return the same payload that would be published for the state, making
+		// sure to not include the answers to the puzzle.
+		state.Puzzle = state.PublicPuzzle()
+
+		if err := web.RenderJSONWithVersion(w, r, version, StateEvent(state).Payload); err != nil {
+			log.Printf("unable to render state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetCurrentSettings returns the spelling bee settings of a channel.  The response
+// includes an ETag so that clients polling for the settings can avoid
+// downloading them when they haven't changed.
+func GetCurrentSettings(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		settings, err := GetSettings(conn, channel)
+		if err != nil {
+			log.Printf("unable to read settings for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.RenderJSONWithETag(w, r, settings); err != nil {
+			log.Printf("unable to render settings for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// Solution contains the answers to a spelling bee puzzle along with the
+// answers that a channel didn't find.
+type Solution struct {
+	OfficialAnswers   []string     `json:"official_answers"`
+	UnofficialAnswers []string     `json:"unofficial_answers"`
+	MissedWords       []MissedWord `json:"missed_words"`
+}
+
+// GetSolution returns the answers to a channel's spelling bee along with the
+// words that were missed.  The answers are only available once the solve has
+// finished.
+func GetSolution(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to read solution for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !state.Status.IsFinished() {
+			log.Printf("unable to read solution for channel %s, status is %s", channel, state.Status)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		render.JSON(w, r, Solution{
+			OfficialAnswers:   state.Puzzle.OfficialAnswers,
+			UnofficialAnswers: state.Puzzle.UnofficialAnswers,
+			MissedWords:       state.UnfoundWords(),
+		})
+	}
+}
+
+// GetShareCard returns the share card of the channel's most recently archived
+// spelling bee attempt as plain text.
+func GetShareCard(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		attempt, err := GetLatestAttempt(conn, channel)
+		if err != nil {
+			log.Printf("unable to read latest attempt for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if attempt == nil || attempt.Card == "" {
+			log.Printf("unable to read share card for channel %s, no archived attempt", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(attempt.Card))
+	}
+}
+
+// GetSnapshot renders the channel's current spelling bee hive as an SVG or PNG image.
+// The image format is chosen with the format query parameter.
+func GetSnapshot(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		options, err := snapshot.ParseOptions(r)
+		if err != nil {
+			log.Printf("unable to parse snapshot options for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		state, err := GetState(conn, channel)
+		if err != nil {
+			log.Printf("unable to read state for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if state.Puzzle == nil {
+			log.Printf("unable to render snapshot for channel %s, no puzzle selected", channel)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err := snapshot.Write(w, state.Snapshot(), options.Format); err != nil {
+			log.Printf("unable to write snapshot for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
 // GetEvents establishes an event stream with a client.  An event stream is
 // server side event stream (SSE) with a client's browser that allows one way
 // communication from the server to the client.  Clients that call into this
@@ -455,7 +949,7 @@ func GetEvents(pool *redis.Pool, registry *pubsub.Registry) http.HandlerFunc {
 			return
 		}
 		if state.Puzzle != nil {
-			state.Puzzle = state.Puzzle.WithoutAnswers()
+			state.Puzzle = state.PublicPuzzle()
 
 			stream <- StateEvent(state)
 		}
@@ -510,18 +1004,55 @@ func SettingsEvent(settings Settings) pubsub.Event {
 }
 
 func StateEvent(state State) pubsub.Event {
+	if state.TimeLimit.Duration > 0 {
+		remaining := model.Duration{Duration: state.RemainingTime(time.Now())}
+		state.TimeRemaining = &remaining
+	}
+
+	// Once the solve has finished let the clients know which words were missed.
+	if state.Status.IsFinished() && state.Puzzle != nil && state.Puzzle.OfficialAnswers != nil {
+		state.MissedWords = state.UnfoundWords()
+	}
+
 	return pubsub.Event{
 		Kind:    "state",
 		Payload: state,
 	}
 }
 
+func ExpiredEvent() pubsub.Event {
+	return pubsub.Event{
+		Kind: "expired",
+	}
+}
+
 func CompleteEvent() pubsub.Event {
 	return pubsub.Event{
 		Kind: "complete",
 	}
 }
 
+func SummaryEvent(summary model.Summary) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "summary",
+		Payload: summary,
+	}
+}
+
+func ScoreEvent(scores map[string]int) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "score",
+		Payload: scores,
+	}
+}
+
+func HintEvent(hint Hint) pubsub.Event {
+	return pubsub.Event{
+		Kind:    "hint",
+		Payload: hint,
+	}
+}
+
 func GeniusEvent() pubsub.Event {
 	return pubsub.Event{
 		Kind: "genius",
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/model"
 	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/bbeck/puzzles-with-chat/api/team"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
 	"github.com/gomodule/redigo/redis"
@@ -139,6 +140,24 @@ func TestRoute_UpdateSetting(t *testing.T) {
 	VerifySettings(t, pool, events, func(s Settings) {
 		assert.True(t, s.ShowAnswerPlaceholders)
 	})
+
+	response = Channel.PUT("/setting/hint_interval", `"5m0s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 5*time.Minute, s.HintInterval.Duration)
+	})
+
+	response = Channel.PUT("/setting/idle_timeout", `"30m0s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 30*time.Minute, s.IdleTimeout.Duration)
+	})
+
+	response = Channel.PUT("/setting/time_limit", `"15m0s"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifySettings(t, pool, events, func(s Settings) {
+		assert.Equal(t, 15*time.Minute, s.TimeLimit.Duration)
+	})
 }
 
 func TestRoute_UpdateSetting_AllowUnofficialAnswers_ClearsAnswers(t *testing.T) {
@@ -285,13 +304,14 @@ func TestRoute_UpdateSetting_AllowUnofficialAnswers_SendsCompleteEvent(t *testin
 	response := Channel.PUT("/setting/allow_unofficial_answers", `false`, router)
 	assert.Equal(t, http.StatusOK, response.Code)
 
-	// We should have received 3 events, a settings event, the state event with
-	// the removed answer and a completed event.
+	// We should have received 4 events, a settings event, the state event with
+	// the removed answer, a completed event and a summary event.
 	events = stop()
-	assert.Equal(t, 3, len(events))
+	assert.Equal(t, 4, len(events))
 	assert.Equal(t, "settings", events[0].Kind)
 	assert.Equal(t, "state", events[1].Kind)
 	assert.Equal(t, "complete", events[2].Kind)
+	assert.Equal(t, "summary", events[3].Kind)
 }
 
 func TestRoute_UpdateSetting_JSONError(t *testing.T) {
@@ -315,6 +335,36 @@ func TestRoute_UpdateSetting_JSONError(t *testing.T) {
 			setting: "show_answer_placeholders",
 			json:    `{`,
 		},
+		{
+			name:    "hint_interval",
+			setting: "hint_interval",
+			json:    `{`,
+		},
+		{
+			name:    "negative hint_interval",
+			setting: "hint_interval",
+			json:    `"-5m"`,
+		},
+		{
+			name:    "idle_timeout",
+			setting: "idle_timeout",
+			json:    `{`,
+		},
+		{
+			name:    "negative idle_timeout",
+			setting: "idle_timeout",
+			json:    `"-30m"`,
+		},
+		{
+			name:    "time_limit",
+			setting: "time_limit",
+			json:    `{`,
+		},
+		{
+			name:    "negative time_limit",
+			setting: "time_limit",
+			json:    `"-15m"`,
+		},
 		{
 			name:    "invalid setting name",
 			setting: "foo_bar_baz",
@@ -515,6 +565,126 @@ func TestRoute_ToggleStatus(t *testing.T) {
 }
 
 func TestRoute_ToggleStatus_Error(t *testing.T) {
+	tests := []struct {
+		name              string
+		initialStatus     model.Status
+		loadStateError    error
+		saveStateError    error
+		loadSettingsError error
+	}{
+		{
+			name:          "status created",
+			initialStatus: model.StatusCreated,
+		},
+		{
+			name:          "status given up",
+			initialStatus: model.StatusGivenUp,
+		},
+		{
+			name:          "status expired",
+			initialStatus: model.StatusExpired,
+		},
+		{
+			name:              "error loading settings",
+			initialStatus:     model.StatusSelected,
+			loadSettingsError: errors.New("forced error"),
+		},
+		{
+			name:           "error loading state",
+			initialStatus:  model.StatusSelected,
+			loadStateError: errors.New("forced error"),
+		},
+		{
+			name:           "error saving state",
+			initialStatus:  model.StatusSelected,
+			saveStateError: errors.New("forced error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = test.initialStatus
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			if test.loadStateError != nil {
+				ForceErrorDuringStateLoad(t, test.loadStateError)
+			}
+
+			if test.saveStateError != nil {
+				ForceErrorDuringStateSave(t, test.saveStateError)
+			}
+
+			if test.loadSettingsError != nil {
+				ForceErrorDuringSettingsLoad(t, test.loadSettingsError)
+			}
+
+			response := Channel.PUT("/status", "", router)
+			assert.NotEqual(t, http.StatusOK, response.Code)
+		})
+	}
+}
+
+func TestRoute_ToggleStatus_TimeLimit(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	settings := Settings{TimeLimit: model.Duration{Duration: 15 * time.Minute}}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	state := NewState(t, "nytbee-20200408.html")
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Starting the solve should copy the time limit into the state.
+	response := Channel.PUT("/status", ``, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSolving, state.Status)
+		assert.Equal(t, 15*time.Minute, state.TimeLimit.Duration)
+	})
+
+	// Changing the setting mid-solve shouldn't move the deadline.
+	settings.TimeLimit = model.Duration{Duration: time.Hour}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	response = Channel.PUT("/status", ``, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusPaused, state.Status)
+		assert.Equal(t, 15*time.Minute, state.TimeLimit.Duration)
+	})
+}
+
+func TestRoute_ResetState(t *testing.T) {
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	// Set a state that has made some progress on the solve.
+	now := time.Now()
+	state := NewState(t, "nytbee-20200408.html")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &now
+	state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, state.ApplyAnswer("COCONUT", false))
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.PUT("/reset", ``, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	VerifyState(t, pool, events, func(state State) {
+		assert.Equal(t, model.StatusSelected, state.Status)
+		assert.Empty(t, state.Words)
+		assert.Equal(t, 0, state.Score)
+		assert.Nil(t, state.LastStartTime)
+		assert.Equal(t, time.Duration(0), state.TotalSolveDuration.Duration)
+	})
+}
+
+func TestRoute_ResetState_Error(t *testing.T) {
 	tests := []struct {
 		name           string
 		initialStatus  model.Status
@@ -527,12 +697,12 @@ func TestRoute_ToggleStatus_Error(t *testing.T) {
 		},
 		{
 			name:           "error loading state",
-			initialStatus:  model.StatusSelected,
+			initialStatus:  model.StatusSolving,
 			loadStateError: errors.New("forced error"),
 		},
 		{
 			name:           "error saving state",
-			initialStatus:  model.StatusSelected,
+			initialStatus:  model.StatusSolving,
 			saveStateError: errors.New("forced error"),
 		},
 	}
@@ -554,12 +724,151 @@ func TestRoute_ToggleStatus_Error(t *testing.T) {
 				ForceErrorDuringStateSave(t, test.saveStateError)
 			}
 
-			response := Channel.PUT("/status", "", router)
+			response := Channel.PUT("/reset", "", router)
 			assert.NotEqual(t, http.StatusOK, response.Code)
 		})
 	}
 }
 
+func TestRoute_GiveUp(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialStatus model.Status
+	}{
+		{
+			name:          "selected",
+			initialStatus: model.StatusSelected,
+		},
+		{
+			name:          "paused",
+			initialStatus: model.StatusPaused,
+		},
+		{
+			name:          "solving",
+			initialStatus: model.StatusSolving,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, registry := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+			events := NewEventSubscription(t, registry, Channel.name)
+
+			start := time.Now().Add(-time.Minute)
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = test.initialStatus
+			state.LastStartTime = nil
+			if test.initialStatus == model.StatusSolving {
+				state.LastStartTime = &start
+			}
+			require.NoError(t, state.ApplyAnswer("COCONUT", false))
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			response := Channel.PUT("/giveup", ``, router)
+			require.Equal(t, http.StatusOK, response.Code)
+
+			// The state event should include the answers.
+			found := Events(events, "state")
+			require.Equal(t, 1, len(found))
+			state = found[0].Payload.(State)
+			assert.NotNil(t, state.Puzzle.OfficialAnswers)
+
+			// As well as the words that were missed.
+			total := len(state.Puzzle.OfficialAnswers) + len(state.Puzzle.UnofficialAnswers)
+			assert.Len(t, state.MissedWords, total-1)
+
+			// The words that were found should be unchanged.
+			state, err := GetState(conn, Channel.name)
+			require.NoError(t, err)
+			assert.Equal(t, model.StatusGivenUp, state.Status)
+			assert.Nil(t, state.LastStartTime)
+			assert.Equal(t, map[string]int{"COCONUT": 0}, state.Words)
+			if test.initialStatus == model.StatusSolving {
+				assert.True(t, state.TotalSolveDuration.Duration >= time.Minute)
+			}
+
+			// The attempt should have been archived as unfinished.
+			attempts := LoadArchive(t, pool)
+			require.Equal(t, 1, len(attempts))
+			assert.Equal(t, Channel.name, attempts[0].Channel)
+			assert.Equal(t, model.StatusGivenUp, attempts[0].Status)
+			assert.Equal(t, "The New York Times", attempts[0].Puzzle.Publisher)
+		})
+	}
+}
+
+func TestRoute_GiveUp_Error(t *testing.T) {
+	tests := []struct {
+		name             string
+		initialStatus    model.Status
+		loadStateError   error
+		saveStateError   error
+		saveArchiveError error
+		expected         int
+	}{
+		{
+			name:          "status created",
+			initialStatus: model.StatusCreated,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:          "status complete",
+			initialStatus: model.StatusComplete,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:          "status given up",
+			initialStatus: model.StatusGivenUp,
+			expected:      http.StatusConflict,
+		},
+		{
+			name:           "error loading state",
+			initialStatus:  model.StatusPaused,
+			loadStateError: errors.New("forced error"),
+			expected:       http.StatusNotFound,
+		},
+		{
+			name:           "error saving state",
+			initialStatus:  model.StatusPaused,
+			saveStateError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+		{
+			name:             "error saving archive",
+			initialStatus:    model.StatusPaused,
+			saveArchiveError: errors.New("forced error"),
+			expected:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = test.initialStatus
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			if test.loadStateError != nil {
+				ForceErrorDuringStateLoad(t, test.loadStateError)
+			}
+
+			if test.saveStateError != nil {
+				ForceErrorDuringStateSave(t, test.saveStateError)
+			}
+
+			if test.saveArchiveError != nil {
+				ForceErrorDuringArchiveSave(t, test.saveArchiveError)
+			}
+
+			response := Channel.PUT("/giveup", "", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
 func TestRoute_AddAnswer_NoUnofficialAnswers(t *testing.T) {
 	// This acts as a small integration test of adding answers to a spelling bee
 	// puzzle being solved.
@@ -699,6 +1008,75 @@ func TestRoute_AddAnswer_SolvedPuzzleStopsTimer(t *testing.T) {
 		assert.Nil(t, state.LastStartTime)
 		assert.True(t, state.TotalSolveDuration.Seconds() > 0)
 	})
+
+	// The completed attempt should have been archived.
+	attempts := LoadArchive(t, pool)
+	require.Equal(t, 1, len(attempts))
+	assert.Equal(t, model.StatusComplete, attempts[0].Status)
+	assert.True(t, attempts[0].TotalSolveDuration.Seconds() > 0)
+}
+
+func TestRoute_AddAnswer_ArchiveError(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Set the state to have all of the words except for one.
+	state := NewState(t, "nytbee-20200408.html")
+	state.Status = model.StatusSolving
+	for _, word := range state.Puzzle.OfficialAnswers {
+		if word != "COCONUT" {
+			require.NoError(t, state.ApplyAnswer(word, false))
+		}
+	}
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	ForceErrorDuringArchiveSave(t, errors.New("forced error"))
+
+	response := Channel.POST("/answer", `"COCONUT"`, router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestRoute_AddAnswer_Summary(t *testing.T) {
+	// This acts as a small integration test ensuring that a summary built from
+	// the submitted answers is published and archived once the puzzle has been
+	// solved.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	// Set the state to have all of the words except for one.
+	state := NewState(t, "nytbee-20200408.html")
+	state.Status = model.StatusSolving
+	for _, word := range state.Puzzle.OfficialAnswers {
+		if word != "COCONUT" {
+			require.NoError(t, state.ApplyAnswer(word, false))
+		}
+	}
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.POST("/answer?user=bob", `"COCONUTS"`, router)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	response = Channel.POST("/answer?user=alice", `"COCONUT"`, router)
+	require.Equal(t, http.StatusCreated, response.Code)
+
+	found := Events(events, "summary")
+	require.Len(t, found, 1)
+
+	summary := found[0].Payload.(model.Summary)
+	assert.Equal(t, 2, summary.AnswersSubmitted)
+	assert.Equal(t, 1, summary.AnswersAccepted)
+	assert.Equal(t, []model.Contributor{{User: "alice", Answers: 1}}, summary.TopContributors)
+	assert.Equal(t, "alice", summary.FirstBlood)
+	require.NotNil(t, summary.LastClue)
+	assert.Equal(t, "COCONUT", summary.LastClue.Answer)
+
+	// The summary should have been archived with the attempt.
+	attempts := LoadArchive(t, pool)
+	require.Equal(t, 1, len(attempts))
+	require.NotNil(t, attempts[0].Summary)
+	assert.Equal(t, 2, attempts[0].Summary.AnswersSubmitted)
+	assert.Equal(t, 1, attempts[0].Summary.AnswersAccepted)
 }
 
 func TestRoute_AddAnswer_GeniusEvent(t *testing.T) {
@@ -746,6 +1124,45 @@ func TestRoute_AddAnswer_GeniusEvent(t *testing.T) {
 	VerifyGeniusEvent(t, events)
 }
 
+func TestRoute_AddAnswer_Teams(t *testing.T) {
+	// This acts as a small integration test of a team solve where each word is
+	// credited to the team of the chatter that found it.
+	router, pool, registry := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+	events := NewEventSubscription(t, registry, Channel.name)
+
+	roster := team.Roster{"alice": "red", "bob": "blue"}
+	require.NoError(t, team.SetRoster(conn, Channel.name, roster))
+
+	state := NewState(t, "nytbee-20200408.html")
+	state.Status = model.StatusSolving
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	// Alice finds a word for the red team.
+	response := Channel.POST("/answer?user=Alice", `"coconut"`, router)
+	assert.Equal(t, http.StatusCreated, response.Code)
+	scores := Events(events, "score")
+	require.Len(t, scores, 1)
+	assert.Equal(t, map[string]int{"red": 7, "blue": 0}, scores[0].Payload)
+
+	// Carol isn't on a team, nobody scores.
+	response = Channel.POST("/answer?user=carol", `"TOUR"`, router)
+	assert.Equal(t, http.StatusCreated, response.Code)
+	assert.Empty(t, Events(events, "score"))
+
+	// Bob finds a pangram for the blue team.
+	response = Channel.POST("/answer?user=bob", `"COUNTRY"`, router)
+	assert.Equal(t, http.StatusCreated, response.Code)
+	scores = Events(events, "score")
+	require.Len(t, scores, 1)
+	assert.Equal(t, map[string]int{"red": 7, "blue": 14}, scores[0].Payload)
+
+	state, err := GetState(conn, Channel.name)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"COCONUT": "red", "COUNTRY": "blue"}, state.WordTeams)
+	assert.Equal(t, map[string]int{"red": 7, "blue": 14}, state.Scores)
+}
+
 func TestRoute_AddAnswer_Error(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -784,12 +1201,30 @@ func TestRoute_AddAnswer_Error(t *testing.T) {
 	}
 }
 
+func TestRoute_AddAnswer_OutOfTime(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// The solve has run past its time limit but hasn't been marked as expired
+	// yet, answers should no longer be accepted.
+	start := time.Now().Add(-time.Hour)
+	state := NewState(t, "nytbee-20200408.html")
+	state.Status = model.StatusSolving
+	state.TimeLimit = model.Duration{Duration: 15 * time.Minute}
+	state.LastStartTime = &start
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.POST("/answer", `"COCONUT"`, router)
+	assert.Equal(t, http.StatusConflict, response.Code)
+}
+
 func TestRoute_AddAnswer_LoadSaveError(t *testing.T) {
 	tests := []struct {
 		name              string
 		loadSettingsError error
 		loadStateError    error
 		saveStateError    error
+		loadRosterError   error
 	}{
 		{
 			name:              "error loading settings",
@@ -803,6 +1238,10 @@ func TestRoute_AddAnswer_LoadSaveError(t *testing.T) {
 			name:           "error saving state",
 			saveStateError: errors.New("forced error"),
 		},
+		{
+			name:            "error loading team roster",
+			loadRosterError: errors.New("forced error"),
+		},
 	}
 
 	for _, test := range tests {
@@ -817,6 +1256,7 @@ func TestRoute_AddAnswer_LoadSaveError(t *testing.T) {
 			ForceErrorDuringSettingsLoad(t, test.loadSettingsError)
 			ForceErrorDuringStateLoad(t, test.loadStateError)
 			ForceErrorDuringStateSave(t, test.saveStateError)
+			team.ForceErrorDuringRosterLoad(t, test.loadRosterError)
 
 			response := Channel.POST("/answer", `"COCONUT"`, router)
 			assert.NotEqual(t, http.StatusOK, response.Code)
@@ -824,6 +1264,337 @@ func TestRoute_AddAnswer_LoadSaveError(t *testing.T) {
 	}
 }
 
+func TestRoute_GetCurrentState(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	state := NewState(t, "nytbee-20200408.html")
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/state", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	var actual State
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, state.Status, actual.Status)
+	assert.Nil(t, actual.Puzzle.OfficialAnswers) // Solution should never be sent
+
+	// Asking again with the ETag shouldn't return the state since it hasn't
+	// changed.
+	response = ConditionalGET(path.Join("/spellingbee", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Empty(t, response.Body.String())
+
+	// Once the state changes it should be returned again with a new ETag.
+	require.NoError(t, state.ApplyAnswer("COCONUT", false))
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response = ConditionalGET(path.Join("/spellingbee", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.NotEqual(t, etag, response.Header().Get("ETag"))
+}
+
+func TestRoute_GetCurrentState_TimeLimit(t *testing.T) {
+	// The time remaining in a timed solve changes on every request, but the
+	// state's ETag should only change when the state is saved.
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Now()
+	state := NewState(t, "nytbee-20200408.html")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &now
+	state.TimeLimit = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/state", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+
+	time.Sleep(10 * time.Millisecond)
+
+	response = ConditionalGET(path.Join("/spellingbee", Channel.name, "state"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Equal(t, etag, response.Header().Get("ETag"))
+}
+
+func TestRoute_GetCurrentState_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			if test.state != nil {
+				require.NoError(t, SetState(conn, Channel.name, *test.state))
+			}
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/state", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetCurrentSettings(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	settings, err := GetSettings(conn, Channel.name)
+	require.NoError(t, err)
+
+	response := Channel.GET("/settings", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	var actual Settings
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, settings, actual)
+
+	// Asking again with the ETag shouldn't return the settings since they haven't
+	// changed.
+	response = ConditionalGET(path.Join("/spellingbee", Channel.name, "settings"), etag, router)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+
+	// Once the settings change they should be returned again with a new ETag.
+	settings.TimeLimit = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, SetSettings(conn, Channel.name, settings))
+
+	response = ConditionalGET(path.Join("/spellingbee", Channel.name, "settings"), etag, router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.NotEqual(t, etag, response.Header().Get("ETag"))
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &actual))
+	assert.Equal(t, settings, actual)
+}
+
+func TestRoute_GetCurrentSettings_Error(t *testing.T) {
+	router, _, _ := NewTestRouter(t)
+	ForceErrorDuringSettingsLoad(t, errors.New("forced error"))
+
+	response := Channel.GET("/settings", router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestRoute_GetSolution(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	state := NewState(t, "nytbee-20200408.html")
+	state.Status = model.StatusGivenUp
+	state.LastStartTime = nil
+	require.NoError(t, SetState(conn, Channel.name, state))
+
+	response := Channel.GET("/solution", router)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var solution Solution
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &solution))
+	assert.Equal(t, state.Puzzle.OfficialAnswers, solution.OfficialAnswers)
+	assert.Equal(t, state.Puzzle.UnofficialAnswers, solution.UnofficialAnswers)
+	assert.Equal(t, state.UnfoundWords(), solution.MissedWords)
+}
+
+func TestRoute_GetSolution_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "solve in progress",
+			expected: http.StatusConflict,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = model.StatusSolving
+			if test.state != nil {
+				state = *test.state
+			}
+			require.NoError(t, SetState(conn, Channel.name, state))
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/solution", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetShareCard(t *testing.T) {
+	router, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// Archive two attempts, the card of the most recent one should be returned.
+	state := NewState(t, "nytbee-20200408.json")
+	state.Status = model.StatusGivenUp
+	state.LastStartTime = nil
+	state.TotalSolveDuration = model.Duration{Duration: 5 * time.Minute}
+	require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+
+	state.Status = model.StatusComplete
+	state.TotalSolveDuration = model.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+
+	response := Channel.GET("/share", router)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.Equal(t, state.ShareCard(), response.Body.String())
+	assert.True(t, strings.HasPrefix(response.Body.String(), "Spelling Bee"))
+	assert.Contains(t, response.Body.String(), "✅ Solved in 10m0s")
+}
+
+func TestRoute_GetShareCard_Error(t *testing.T) {
+	tests := []struct {
+		name             string
+		archive          bool
+		archiveLoadError error
+		expected         int
+	}{
+		{
+			name:     "no archived attempts",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:             "error loading archive",
+			archive:          true,
+			archiveLoadError: errors.New("forced error"),
+			expected:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			if test.archive {
+				state := NewState(t, "nytbee-20200408.json")
+				state.Status = model.StatusComplete
+				require.NoError(t, ArchiveAttempt(conn, Channel.name, NewAttempt(Channel.name, state, nil, time.Now())))
+			}
+			ForceErrorDuringArchiveLoad(t, test.archiveLoadError)
+
+			response := Channel.GET("/share", router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_GetSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+	}{
+		{
+			name:        "svg",
+			contentType: "image/svg+xml",
+		},
+		{
+			name:        "png",
+			query:       "?format=png",
+			contentType: "image/png",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "nytbee-20200408.json")
+			require.NoError(t, SetState(conn, Channel.name, state))
+
+			response := Channel.GET("/snapshot"+test.query, router)
+			require.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, test.contentType, response.Header().Get("Content-Type"))
+
+			if test.contentType == "image/svg+xml" {
+				assert.Equal(t, string(state.Snapshot().SVG()), response.Body.String())
+			}
+		})
+	}
+}
+
+func TestRoute_GetSnapshot_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		state          *State
+		stateLoadError error
+		expected       int
+	}{
+		{
+			name:     "no puzzle selected",
+			state:    &State{Status: model.StatusCreated},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "unsupported format",
+			query:    "?format=gif",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:           "error loading state",
+			stateLoadError: errors.New("forced error"),
+			expected:       http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, pool, _ := NewTestRouter(t)
+			conn := NewRedisConnection(t, pool)
+
+			state := NewState(t, "nytbee-20200408.json")
+			if test.state != nil {
+				state = *test.state
+			}
+			require.NoError(t, SetState(conn, Channel.name, state))
+			ForceErrorDuringStateLoad(t, test.stateLoadError)
+
+			response := Channel.GET("/snapshot"+test.query, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
 func TestRoute_GetEvents(t *testing.T) {
 	// This acts as a small integration test ensuring that the event stream
 	// receives the events put into a registry.
@@ -965,6 +1736,25 @@ func TestRoute_GetAvailableDates(t *testing.T) {
 	}
 }
 
+// LoadArchive reads all of the attempts that have been archived for the test
+// channel.
+func LoadArchive(t *testing.T, pool *redis.Pool) []model.Attempt {
+	t.Helper()
+
+	conn := NewRedisConnection(t, pool)
+	bss, err := redis.ByteSlices(conn.Do("LRANGE", ArchiveKey(Channel.name), 0, -1))
+	require.NoError(t, err)
+
+	var attempts []model.Attempt
+	for _, bs := range bss {
+		var attempt model.Attempt
+		require.NoError(t, json.Unmarshal(bs, &attempt))
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts
+}
+
 // VerifySettings performs test specific verifications on the settings objects
 // in both event and database forms.
 func VerifySettings(t *testing.T, pool *redis.Pool, events <-chan pubsub.Event, fn func(s Settings)) {
@@ -996,10 +1786,14 @@ func VerifyState(t *testing.T, pool *redis.Pool, events <-chan pubsub.Event, fn
 	found := Events(events, "state")
 	require.Equal(t, 1, len(found), "incorrect number of events found")
 
-	// Event should never have the answers
+	// Event should never have the answers until the solve has finished
 	state := found[0].Payload.(State)
-	assert.Nil(t, state.Puzzle.OfficialAnswers)
-	assert.Nil(t, state.Puzzle.UnofficialAnswers)
+	if state.Status.IsFinished() {
+		assert.NotNil(t, state.Puzzle.OfficialAnswers)
+	} else {
+		assert.Nil(t, state.Puzzle.OfficialAnswers)
+		assert.Nil(t, state.Puzzle.UnofficialAnswers)
+	}
 	fn(state)
 
 	// Next check that the database has a valid state object
@@ -1050,6 +1844,16 @@ func GET(url string, router chi.Router) *httptest.ResponseRecorder {
 	return recorder
 }
 
+// ConditionalGET performs a GET request that includes an If-None-Match header
+// with the provided ETag.
+func ConditionalGET(url, etag string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	request.Header.Set("If-None-Match", etag)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
 // ChannelClient is a client that makes requests against the URL of a particular
 // user's channel.
 type ChannelClient struct {
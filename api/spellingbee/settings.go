@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
-	"github.com/gomodule/redigo/redis"
 )
 
 // Settings represents the optional behaviors that can be enabled or disabled
@@ -18,6 +17,19 @@ type Settings struct {
 
 	// What font size words should be rendered with.
 	FontSize model.FontSize `json:"font_size"`
+
+	// How long the solve can go without finding a word before a hint is given.
+	// A value of zero disables hints.
+	HintInterval model.Duration `json:"hint_interval"`
+
+	// How long the solve can go without any answers being submitted before it is
+	// automatically paused.  A value of zero disables automatic pausing.
+	IdleTimeout model.Duration `json:"idle_timeout"`
+
+	// How long the solve may take before it runs out of time.  Changes only
+	// apply to solves that are started afterwards.  A value of zero disables the
+	// time limit.
+	TimeLimit model.Duration `json:"time_limit"`
 }
 
 // SettingsKey returns the key that should be used in redis to store a
@@ -28,7 +40,7 @@ func SettingsKey(name string) string {
 
 // GetSettings will load settings for the provided channel name.  If the
 // settings can't be properly loaded then an error will be returned.
-func GetSettings(conn redis.Conn, channel string) (Settings, error) {
+func GetSettings(conn db.Connection, channel string) (Settings, error) {
 	var settings Settings
 
 	if testSettingsLoadError != nil {
@@ -41,7 +53,7 @@ func GetSettings(conn redis.Conn, channel string) (Settings, error) {
 
 // SetSettings will write settings for the provided channel name.  If the
 // settings can't be properly written then an error will be returned.
-func SetSettings(conn redis.Conn, channel string, settings Settings) error {
+func SetSettings(conn db.Connection, channel string, settings Settings) error {
 	if testSettingsSaveError != nil {
 		return testSettingsSaveError
 	}
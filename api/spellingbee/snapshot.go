@@ -0,0 +1,45 @@
+package spellingbee
+
+import (
+	"github.com/bbeck/puzzles-with-chat/api/snapshot"
+	"image/color"
+	"math"
+)
+
+// The distance in pixels from the center of each hexagon of the hive to its
+// corners.
+const snapshotCellRadius = 40
+
+// Snapshot draws the state's hive as an image.  The center letter is drawn in
+// the middle of the hive with the remaining letters surrounding it in their
+// current order.
+func (s *State) Snapshot() *snapshot.Image {
+	const radius = snapshotCellRadius
+
+	// The distance between the centers of neighboring hexagons, including a
+	// small gap between them.
+	spacing := math.Sqrt(3)*radius + 6
+
+	width := math.Sqrt(3)*spacing + 2*radius + 2
+	height := 2*spacing + math.Sqrt(3)*radius + 2
+	img := snapshot.New(int(math.Ceil(width)), int(math.Ceil(height)))
+
+	cx, cy := width/2, height/2
+	cell := func(x, y float64, letter string, fill color.RGBA) {
+		points := make([]snapshot.Point, 6)
+		for i := range points {
+			angle := float64(i) * math.Pi / 3
+			points[i] = snapshot.Point{X: x + radius*math.Cos(angle), Y: y + radius*math.Sin(angle)}
+		}
+		img.Polygon(points, fill, snapshot.None)
+		img.Text(x, y, 21, snapshot.AnchorCenter, letter, snapshot.Black)
+	}
+
+	cell(cx, cy, s.Puzzle.CenterLetter, snapshot.Yellow)
+	for i, letter := range s.Letters {
+		angle := -math.Pi/2 + float64(i)*math.Pi/3
+		cell(cx+spacing*math.Cos(angle), cy+spacing*math.Sin(angle), letter, snapshot.LightGray)
+	}
+
+	return img
+}
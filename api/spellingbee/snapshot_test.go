@@ -0,0 +1,25 @@
+package spellingbee
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestState_Snapshot(t *testing.T) {
+	state := NewState(t, "nytbee-20200408.json")
+
+	img := state.Snapshot()
+	svg := string(img.SVG())
+
+	// One hexagon for the center letter and one for each of the others.
+	assert.Equal(t, 1+len(state.Letters), strings.Count(svg, "<polygon"))
+	assert.Equal(t, 1, strings.Count(svg, `fill="#f7da21"`))
+	assert.Contains(t, svg, `>`+state.Puzzle.CenterLetter+`</text>`)
+	for _, letter := range state.Letters {
+		assert.Contains(t, svg, `>`+letter+`</text>`)
+	}
+
+	_, err := img.PNG()
+	assert.NoError(t, err)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/api/db"
 	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/team"
+	"math/rand"
 	"sort"
 	"strings"
 	"time"
@@ -37,6 +39,54 @@ type State struct {
 
 	// The total time spent on solving the puzzle up to the last start time.
 	TotalSolveDuration model.Duration `json:"total_solve_duration"`
+
+	// The time that a word was last found.  If no words have been found yet then
+	// this will be nil.
+	LastProgressTime *time.Time `json:"last_progress_time,omitempty"`
+
+	// The time limit for the solve, copied from the settings when the solve is
+	// started.  A value of zero means that the solve doesn't have a time limit.
+	TimeLimit model.Duration `json:"time_limit"`
+
+	// The amount of time remaining before the time limit is reached as of when
+	// the state was published.  This is only populated for published states of
+	// solves with a time limit, it is never stored.
+	TimeRemaining *model.Duration `json:"time_remaining,omitempty"`
+
+	// The team that found each word when chatters in the channel have joined
+	// teams.  Words found by a chatter that isn't on a team aren't present.
+	WordTeams map[string]string `json:"word_teams,omitempty"`
+
+	// The number of points earned by each team, indexed by the team name.  This
+	// is only present when chatters in the channel have joined teams.
+	Scores map[string]int `json:"scores,omitempty"`
+
+	// The answers that weren't found before the solve finished.  This is only
+	// populated for published states of finished solves, it is never stored.
+	MissedWords []MissedWord `json:"missed_words,omitempty"`
+}
+
+// MissedWord is an answer to the puzzle that wasn't found before the solve
+// finished.
+type MissedWord struct {
+	// The answer that was missed.
+	Word string `json:"word"`
+
+	// The number of points the answer was worth.
+	Points int `json:"points"`
+
+	// Whether or not the answer is one of the puzzle's official answers.
+	Official bool `json:"official"`
+}
+
+// Hint describes a word that hasn't yet been found by providing its first two
+// letters and its length.
+type Hint struct {
+	// The first two letters of the word.
+	Prefix string `json:"prefix"`
+
+	// The number of letters in the word.
+	Length int `json:"length"`
 }
 
 // ApplyAnswer applies an answer to the state.  If the answer cannot be applied
@@ -100,12 +150,199 @@ func (s *State) RebuildWordMap(allowUnofficial bool) {
 	// The words may have changed, update the score accordingly.
 	s.Score = s.Puzzle.ComputeScore(keys(s.Words))
 
+	// Teams can't keep the points for words that are no longer permitted.
+	if s.Scores != nil {
+		for word := range s.WordTeams {
+			if _, found := s.Words[word]; !found {
+				delete(s.WordTeams, word)
+			}
+		}
+
+		for name := range s.Scores {
+			s.Scores[name] = 0
+		}
+		for word, name := range s.WordTeams {
+			s.Scores[name] += s.Puzzle.ComputeScore([]string{word})
+		}
+	}
+
 	// Lastly determine if the puzzle is now solved.
 	if len(s.Words) == len(answers) {
 		s.Status = model.StatusComplete
 	}
 }
 
+// GetHint chooses a random word that hasn't yet been found and returns a hint
+// for it.  The allowUnofficial parameter determines whether or not unofficial
+// answers may be chosen.  If every word has already been found then an error
+// is returned.
+func (s *State) GetHint(allowUnofficial bool) (Hint, error) {
+	var answers []string
+	answers = append(answers, s.Puzzle.OfficialAnswers...)
+	if allowUnofficial {
+		answers = append(answers, s.Puzzle.UnofficialAnswers...)
+	}
+
+	var unfound []string
+	for _, answer := range answers {
+		if _, found := s.Words[answer]; !found {
+			unfound = append(unfound, answer)
+		}
+	}
+
+	if len(unfound) == 0 {
+		return Hint{}, errors.New("no words left to provide a hint for")
+	}
+
+	word := unfound[rand.Intn(len(unfound))]
+	return Hint{Prefix: word[:2], Length: len(word)}, nil
+}
+
+// Reset clears all progress made on the solve while keeping the puzzle.  The
+// solve is returned to the selected status so that it can be started again.
+func (s *State) Reset() {
+	s.Status = model.StatusSelected
+	s.Letters = s.Puzzle.Letters
+	s.Words = make(map[string]int)
+	s.Score = 0
+	s.LastStartTime = nil
+	s.TotalSolveDuration = model.Duration{}
+	s.LastProgressTime = nil
+	s.TimeLimit = model.Duration{}
+	s.WordTeams = nil
+	s.Scores = nil
+}
+
+// CreditTeam credits the named team with finding a word, adding the word's
+// points to the team's score.  If the team name is empty then no team is
+// credited, but the scores of every team are still tracked.  The number of
+// points awarded is returned.
+func (s *State) CreditTeam(name string, word string) int {
+	if s.WordTeams == nil {
+		s.WordTeams = make(map[string]string)
+	}
+
+	if s.Scores == nil {
+		s.Scores = make(map[string]int)
+	}
+	for _, n := range team.Names {
+		if _, found := s.Scores[n]; !found {
+			s.Scores[n] = 0
+		}
+	}
+
+	if name == "" {
+		return 0
+	}
+
+	word = strings.ToUpper(word)
+	points := s.Puzzle.ComputeScore([]string{word})
+
+	s.WordTeams[word] = name
+	s.Scores[name] += points
+
+	return points
+}
+
+// LastActivityTime returns the most recent time that the solve was either
+// started, resumed or had a word found.  If none of these have happened yet
+// then nil is returned.
+func (s *State) LastActivityTime() *time.Time {
+	return model.LastActivityTime(s.LastStartTime, s.LastProgressTime)
+}
+
+// PublicPuzzle returns the puzzle of the state in a form that's suitable to
+// send to clients.  While the solve is in progress the answers are removed,
+// but once the solve has finished the answers are included so that they can
+// be displayed.
+func (s *State) PublicPuzzle() *Puzzle {
+	if s.Status.IsFinished() {
+		return s.Puzzle
+	}
+
+	return s.Puzzle.WithoutAnswers()
+}
+
+// UnfoundWords returns the official and unofficial answers to the puzzle that
+// haven't been found in alphabetical order.  The state's puzzle must still
+// include its answers.
+func (s *State) UnfoundWords() []MissedWord {
+	missed := make([]MissedWord, 0)
+	add := func(words []string, official bool) {
+		for _, word := range words {
+			if _, found := s.Words[word]; found {
+				continue
+			}
+
+			missed = append(missed, MissedWord{
+				Word:     word,
+				Points:   s.Puzzle.ComputeScore([]string{word}),
+				Official: official,
+			})
+		}
+	}
+	add(s.Puzzle.OfficialAnswers, true)
+	add(s.Puzzle.UnofficialAnswers, false)
+
+	sort.Slice(missed, func(i, j int) bool {
+		return missed[i].Word < missed[j].Word
+	})
+
+	return missed
+}
+
+// PercentComplete returns the percentage of the answers specified by the
+// allowUnofficial parameter that have been found.
+func (s *State) PercentComplete(allowUnofficial bool) float64 {
+	total := len(s.Puzzle.OfficialAnswers)
+	if allowUnofficial {
+		total += len(s.Puzzle.UnofficialAnswers)
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(len(s.Words)) / float64(total)
+}
+
+// NewLoggedAnswer creates a record of an answer that was submitted by a
+// chatter at the provided time for the solve's answer log.  The answer is
+// neither applied nor accepted, callers set these once the answer has been
+// applied to the state.
+func (s *State) NewLoggedAnswer(user, clue, answer string, now time.Time) model.LoggedAnswer {
+	return model.LoggedAnswer{
+		User:        user,
+		Clue:        clue,
+		Answer:      answer,
+		ElapsedTime: model.Duration{Duration: s.ElapsedTime(now)},
+	}
+}
+
+// Summary builds the summary of the solve from its log of answers.
+func (s *State) Summary(answers []model.LoggedAnswer) model.Summary {
+	return model.Summarize(answers, s.TotalSolveDuration)
+}
+
+// ElapsedTime returns the total amount of time that has been spent solving the
+// puzzle as of the provided time.
+func (s *State) ElapsedTime(now time.Time) time.Duration {
+	return model.ElapsedTime(s.TotalSolveDuration.Duration, s.LastStartTime, now)
+}
+
+// RemainingTime returns the amount of time remaining as of the provided time
+// before the solve's time limit is reached.  If the solve doesn't have a time
+// limit then zero is returned.
+func (s *State) RemainingTime(now time.Time) time.Duration {
+	return model.RemainingTime(s.TimeLimit.Duration, s.ElapsedTime(now))
+}
+
+// IsOutOfTime returns whether or not the solve has a time limit that has been
+// reached as of the provided time.
+func (s *State) IsOutOfTime(now time.Time) bool {
+	return model.IsOutOfTime(s.TimeLimit.Duration, s.ElapsedTime(now))
+}
+
 // StateKey returns the key that should be used in redis to store a particular
 // spelling bee solve's state.
 func StateKey(name string) string {
@@ -138,14 +375,38 @@ func SetState(conn db.Connection, channel string, state State) error {
 		return testStateSaveError
 	}
 
-	return db.SetWithTTL(conn, StateKey(channel), state, StateTTL)
+	if err := db.SetWithTTL(conn, StateKey(channel), state, StateTTL); err != nil {
+		return err
+	}
+
+	return db.IncrementVersion(conn, StateVersionKey(channel), StateTTL)
+}
+
+// StateVersionKey returns the key that should be used in redis to store the
+// version of a particular spelling bee solve's state.  The version is increased
+// every time the state is written.
+func StateVersionKey(name string) string {
+	return fmt.Sprintf("%s:spellingbee:version", name)
+}
+
+// GetStateVersion loads the version of the state for a spelling bee solve from
+// redis.  If the state has never been written then zero will be returned.  The
+// version should be loaded before the state so that it is never newer than the
+// state it's used with.
+func GetStateVersion(conn db.Connection, channel string) (int64, error) {
+	if testStateLoadError != nil {
+		return 0, testStateLoadError
+	}
+
+	return db.GetVersion(conn, StateVersionKey(channel))
 }
 
 // GetAllChannels returns a slice of model.Channel instances for each spelling
 // bee that contains state in the database.  If there are no active channels
 // then an empty slice is returned.  This method does not update the expiration
-// times of any state instance.
-func GetAllChannels(conn db.Connection) ([]model.Channel, error) {
+// times of any state instance.  The elapsed time of each solve is computed as
+// of the provided time.
+func GetAllChannels(conn db.Connection, now time.Time) ([]model.Channel, error) {
 	keys, err := db.ScanKeys(conn, StateKey("*"))
 	if err != nil {
 		return nil, err
@@ -156,6 +417,19 @@ func GetAllChannels(conn db.Connection) ([]model.Channel, error) {
 		return nil, err
 	}
 
+	// The maximum score of a solve depends on whether or not the channel allows
+	// unofficial answers, so load the settings of every channel at once as well.
+	var settingsKeys []string
+	for _, key := range keys {
+		name := strings.Replace(key, StateKey(""), "", 1)
+		settingsKeys = append(settingsKeys, SettingsKey(name))
+	}
+
+	settings, err := db.GetAll(conn, settingsKeys, Settings{})
+	if err != nil {
+		return nil, err
+	}
+
 	channels := make([]model.Channel, 0)
 	for key, value := range values {
 		name := strings.Replace(key, StateKey(""), "", 1)
@@ -165,24 +439,31 @@ func GetAllChannels(conn db.Connection) ([]model.Channel, error) {
 			return nil, fmt.Errorf("unable to convert value to State: %v", value)
 		}
 
-		var description string
-		var publisher string
-		var published time.Time
+		channel := model.Channel{
+			Name:        name,
+			Status:      state.Status,
+			ElapsedTime: model.Duration{Duration: state.ElapsedTime(now)},
+		}
+
 		if state.Puzzle != nil {
-			description = state.Puzzle.Description
-			publisher = "The New York Times"
-			published = state.Puzzle.PublishedDate
+			setting, ok := settings[SettingsKey(name)].(Settings)
+			if !ok {
+				return nil, fmt.Errorf("unable to convert value to Settings: %v", settings[SettingsKey(name)])
+			}
+
+			channel.Description = state.Puzzle.Description
+			channel.Puzzle = model.PuzzleSource{
+				Publisher:     "The New York Times",
+				PublishedDate: state.Puzzle.PublishedDate,
+			}
+			channel.Score = state.Score
+			channel.MaxScore = state.Puzzle.MaximumOfficialScore
+			if setting.AllowUnofficialAnswers {
+				channel.MaxScore = state.Puzzle.MaximumUnofficialScore
+			}
 		}
 
-		channels = append(channels, model.Channel{
-			Name:        name,
-			Status:      state.Status,
-			Description: description,
-			Puzzle: model.PuzzleSource{
-				Publisher:     publisher,
-				PublishedDate: published,
-			},
-		})
+		channels = append(channels, channel)
 	}
 
 	sort.Slice(channels, func(i, j int) bool {
@@ -871,6 +871,123 @@ func TestState_RebuildWordMap_Status(t *testing.T) {
 	}
 }
 
+func TestState_GetHint(t *testing.T) {
+	state := NewState(t, "nytbee-20200408.html")
+
+	// Find every official answer except for one.
+	for _, answer := range state.Puzzle.OfficialAnswers[1:] {
+		require.NoError(t, state.ApplyAnswer(answer, false))
+	}
+
+	missing := state.Puzzle.OfficialAnswers[0]
+	hint, err := state.GetHint(false)
+	require.NoError(t, err)
+	assert.Equal(t, Hint{Prefix: missing[:2], Length: len(missing)}, hint)
+}
+
+func TestState_GetHint_Unofficial(t *testing.T) {
+	state := NewState(t, "nytbee-20200408.html")
+
+	for _, answer := range state.Puzzle.OfficialAnswers {
+		require.NoError(t, state.ApplyAnswer(answer, false))
+	}
+
+	// With every official answer found there's nothing left to hint unless
+	// unofficial answers are allowed.
+	_, err := state.GetHint(false)
+	assert.Error(t, err)
+
+	hint, err := state.GetHint(true)
+	require.NoError(t, err)
+	assert.Len(t, hint.Prefix, 2)
+	assert.True(t, hint.Length >= 4)
+}
+
+func TestState_CreditTeam(t *testing.T) {
+	state := NewState(t, "nytbee-20200408.html")
+
+	require.NoError(t, state.ApplyAnswer("COCONUT", true))
+	assert.Equal(t, 7, state.CreditTeam("red", "coconut"))
+
+	require.NoError(t, state.ApplyAnswer("CONCOCTOR", true))
+	assert.Equal(t, 9, state.CreditTeam("blue", "CONCOCTOR"))
+
+	// Words found by someone without a team aren't credited.
+	require.NoError(t, state.ApplyAnswer("TOUR", true))
+	assert.Equal(t, 0, state.CreditTeam("", "TOUR"))
+
+	assert.Equal(t, map[string]string{"COCONUT": "red", "CONCOCTOR": "blue"}, state.WordTeams)
+	assert.Equal(t, map[string]int{"red": 7, "blue": 9}, state.Scores)
+
+	// Disallowing unofficial answers takes away their points.
+	state.RebuildWordMap(false)
+	assert.Equal(t, map[string]string{"COCONUT": "red"}, state.WordTeams)
+	assert.Equal(t, map[string]int{"red": 7, "blue": 0}, state.Scores)
+
+	// Resetting the state clears the scores.
+	state.Reset()
+	assert.Nil(t, state.WordTeams)
+	assert.Nil(t, state.Scores)
+}
+
+func TestState_PublicPuzzle(t *testing.T) {
+	tests := []struct {
+		status   model.Status
+		expected bool
+	}{
+		{status: model.StatusSelected, expected: false},
+		{status: model.StatusPaused, expected: false},
+		{status: model.StatusSolving, expected: false},
+		{status: model.StatusComplete, expected: true},
+		{status: model.StatusGivenUp, expected: true},
+		{status: model.StatusExpired, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status.String(), func(t *testing.T) {
+			state := NewState(t, "nytbee-20200408.html")
+			state.Status = test.status
+
+			puzzle := state.PublicPuzzle()
+			assert.Equal(t, test.expected, puzzle.OfficialAnswers != nil)
+			assert.NotNil(t, state.Puzzle.OfficialAnswers) // The state's puzzle is unchanged
+		})
+	}
+}
+
+func TestState_UnfoundWords(t *testing.T) {
+	state := NewState(t, "nytbee-20200408.html")
+	require.NoError(t, state.ApplyAnswer("COCONUT", false))
+
+	missed := state.UnfoundWords()
+	require.Len(t, missed, len(state.Puzzle.OfficialAnswers)+len(state.Puzzle.UnofficialAnswers)-1)
+
+	for i, word := range missed {
+		assert.NotEqual(t, "COCONUT", word.Word)
+		assert.Equal(t, state.Puzzle.ComputeScore([]string{word.Word}), word.Points)
+
+		_, official := find(state.Puzzle.OfficialAnswers, word.Word)
+		assert.Equal(t, official, word.Official)
+
+		if i > 0 {
+			assert.True(t, missed[i-1].Word < word.Word)
+		}
+	}
+}
+
+func TestState_PercentComplete(t *testing.T) {
+	state := NewState(t, "nytbee-20200408.html")
+	official := float64(len(state.Puzzle.OfficialAnswers))
+	unofficial := float64(len(state.Puzzle.UnofficialAnswers))
+	assert.Equal(t, 0.0, state.PercentComplete(false))
+
+	require.NoError(t, state.ApplyAnswer("COCONUT", false))
+	assert.InDelta(t, 100/official, state.PercentComplete(false), 1e-9)
+
+	require.NoError(t, state.ApplyAnswer("CONCOCTOR", true))
+	assert.InDelta(t, 200/(official+unofficial), state.PercentComplete(true), 1e-9)
+}
+
 func TestGetAllChannels(t *testing.T) {
 	type ChannelToCreate struct {
 		name     string
@@ -919,6 +1036,7 @@ func TestGetAllChannels(t *testing.T) {
 						Publisher:     "The New York Times",
 						PublishedDate: time.Date(2020, time.April, 8, 0, 0, 0, 0, time.UTC),
 					},
+					MaxScore: 183,
 				},
 			},
 		},
@@ -945,6 +1063,7 @@ func TestGetAllChannels(t *testing.T) {
 						Publisher:     "The New York Times",
 						PublishedDate: time.Date(2018, time.July, 29, 0, 0, 0, 0, time.UTC),
 					},
+					MaxScore: 297,
 				},
 				{
 					Name:        "channel2",
@@ -954,6 +1073,7 @@ func TestGetAllChannels(t *testing.T) {
 						Publisher:     "The New York Times",
 						PublishedDate: time.Date(2020, time.April, 8, 0, 0, 0, 0, time.UTC),
 					},
+					MaxScore: 183,
 				},
 			},
 		},
@@ -972,16 +1092,48 @@ func TestGetAllChannels(t *testing.T) {
 				}
 
 				state.Status = create.status
+				state.LastStartTime = nil
 				require.NoError(t, SetState(conn, create.name, state))
 			}
 
-			channels, err := GetAllChannels(conn)
+			channels, err := GetAllChannels(conn, time.Now())
 			require.NoError(t, err)
 			assert.ElementsMatch(t, test.expected, channels)
 		})
 	}
 }
 
+func TestGetAllChannels_Progress(t *testing.T) {
+	_, pool, _ := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Minute)
+
+	state := NewState(t, "nytbee-20200408.html")
+	state.Status = model.StatusSolving
+	state.LastStartTime = &start
+	state.TotalSolveDuration = model.Duration{Duration: 2 * time.Minute}
+	require.NoError(t, state.ApplyAnswer("COCONUT", false))
+	require.NoError(t, SetState(conn, "official", state))
+	require.NoError(t, SetState(conn, "unofficial", state))
+	require.NoError(t, SetSettings(conn, "unofficial", Settings{AllowUnofficialAnswers: true}))
+
+	channels, err := GetAllChannels(conn, now)
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+
+	assert.Equal(t, "official", channels[0].Name)
+	assert.Equal(t, state.Score, channels[0].Score)
+	assert.Equal(t, state.Puzzle.MaximumOfficialScore, channels[0].MaxScore)
+	assert.Equal(t, 3*time.Minute, channels[0].ElapsedTime.Duration)
+
+	assert.Equal(t, "unofficial", channels[1].Name)
+	assert.Equal(t, state.Score, channels[1].Score)
+	assert.Equal(t, state.Puzzle.MaximumUnofficialScore, channels[1].MaxScore)
+	assert.Equal(t, 3*time.Minute, channels[1].ElapsedTime.Duration)
+}
+
 func TestGetAllChannels_Error(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1011,12 +1163,28 @@ func TestGetAllChannels_Error(t *testing.T) {
 					return nil, fmt.Errorf("unrecognized command: %s", command)
 				}
 			},
+		}, {
+			name: "db.GetAll settings error",
+			connection: func(command string, args ...interface{}) (interface{}, error) {
+				switch command {
+				case "SCAN":
+					values := []interface{}{int64(0), []interface{}{"channel"}}
+					return values, nil
+				case "MGET":
+					if args[0] == SettingsKey("channel") {
+						return nil, errors.New("forced error")
+					}
+					return []interface{}{nil}, nil
+				default:
+					return nil, fmt.Errorf("unrecognized command: %s", command)
+				}
+			},
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, err := GetAllChannels(test.connection)
+			_, err := GetAllChannels(test.connection, time.Now())
 			assert.Error(t, err)
 			assert.Equal(t, "forced error", err.Error())
 		})
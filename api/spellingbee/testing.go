@@ -38,6 +38,12 @@ var testStateLoadError error = nil
 // A cached error to use instead of writing state to the database.
 var testStateSaveError error = nil
 
+// A cached error to use instead of reading an attempt from the archive.
+var testArchiveLoadError error = nil
+
+// A cached error to use instead of writing an attempt to the archive.
+var testArchiveSaveError error = nil
+
 // load will read a file from the testdata directory.
 func load(t *testing.T, filename string) io.ReadCloser {
 	t.Helper()
@@ -135,6 +141,24 @@ func ForceErrorDuringStateSave(t *testing.T, err error) {
 	t.Cleanup(func() { testStateSaveError = nil })
 }
 
+// ForceErrorDuringArchiveLoad sets up an error to be returned when an attempt
+// is made to read an attempt from the archive.
+func ForceErrorDuringArchiveLoad(t *testing.T, err error) {
+	t.Helper()
+
+	testArchiveLoadError = err
+	t.Cleanup(func() { testArchiveLoadError = nil })
+}
+
+// ForceErrorDuringArchiveSave sets up an error to be returned when an attempt
+// is made to save an attempt to the archive.
+func ForceErrorDuringArchiveSave(t *testing.T, err error) {
+	t.Helper()
+
+	testArchiveSaveError = err
+	t.Cleanup(func() { testArchiveSaveError = nil })
+}
+
 // NewTestRouter will return a router configured with a redis pool and pubsub
 // registry and wired together along with all of the routes for a spelling bee
 // puzzle.
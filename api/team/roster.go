@@ -0,0 +1,84 @@
+package team
+
+import (
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"strings"
+	"time"
+)
+
+// The names of the teams that a chatter is allowed to join.
+var Names = []string{"red", "blue"}
+
+// IsValid determines whether or not the provided name is the name of a team
+// that can be joined.
+func IsValid(name string) bool {
+	for _, n := range Names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Roster is a mapping of chatters to the name of the team that they've joined
+// in a channel.  The chatter's name is always stored in lowercase.
+type Roster map[string]string
+
+// Join adds a chatter to a team, removing them from any team that they were
+// previously a member of.
+func (r Roster) Join(user, team string) {
+	r[strings.ToLower(user)] = team
+}
+
+// TeamOf returns the name of the team that the chatter is a member of.  If the
+// chatter hasn't joined a team then the empty string is returned.
+func (r Roster) TeamOf(user string) string {
+	return r[strings.ToLower(user)]
+}
+
+// RosterKey returns the key that should be used in redis to store a particular
+// channel's team roster.
+func RosterKey(name string) string {
+	return fmt.Sprintf("%s:teams", name)
+}
+
+// RosterTTL determines how long a channel's team roster should remain in redis
+// in the absence of anyone joining a team.
+var RosterTTL = 24 * time.Hour
+
+// GetRoster loads the team roster for a channel from redis.  If the roster
+// can't be loaded then an error will be returned.  If there is no roster then
+// an empty roster will be returned.
+func GetRoster(conn db.Connection, channel string) (Roster, error) {
+	roster := make(Roster)
+
+	if testRosterLoadError != nil {
+		return roster, testRosterLoadError
+	}
+
+	err := db.Get(conn, RosterKey(channel), &roster)
+	return roster, err
+}
+
+// SetRoster writes the team roster for a channel to redis.  If the roster
+// can't be properly written then an error will be returned.
+func SetRoster(conn db.Connection, channel string, roster Roster) error {
+	if testRosterSaveError != nil {
+		return testRosterSaveError
+	}
+
+	return db.SetWithTTL(conn, RosterKey(channel), roster, RosterTTL)
+}
+
+// ClearRoster removes the team roster for a channel from redis so that every
+// chatter is no longer a member of any team.
+func ClearRoster(conn db.Connection, channel string) error {
+	if testRosterSaveError != nil {
+		return testRosterSaveError
+	}
+
+	_, err := conn.Do("DEL", RosterKey(channel))
+	return err
+}
@@ -0,0 +1,105 @@
+package team
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		team     string
+		expected bool
+	}{
+		{
+			name:     "red",
+			team:     "red",
+			expected: true,
+		},
+		{
+			name:     "blue",
+			team:     "blue",
+			expected: true,
+		},
+		{
+			name: "unknown team",
+			team: "green",
+		},
+		{
+			name: "uppercase",
+			team: "RED",
+		},
+		{
+			name: "empty",
+			team: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsValid(test.team))
+		})
+	}
+}
+
+func TestRoster_Join(t *testing.T) {
+	roster := make(Roster)
+	assert.Equal(t, "", roster.TeamOf("alice"))
+
+	roster.Join("Alice", "red")
+	assert.Equal(t, "red", roster.TeamOf("alice"))
+	assert.Equal(t, "red", roster.TeamOf("ALICE"))
+
+	// Joining another team switches teams.
+	roster.Join("alice", "blue")
+	assert.Equal(t, "blue", roster.TeamOf("Alice"))
+	assert.Len(t, roster, 1)
+}
+
+func TestGetRoster(t *testing.T) {
+	_, pool := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	// A channel without a roster has an empty one.
+	roster, err := GetRoster(conn, "channel")
+	require.NoError(t, err)
+	assert.Empty(t, roster)
+
+	require.NoError(t, SetRoster(conn, "channel", Roster{"alice": "red", "bob": "blue"}))
+
+	roster, err = GetRoster(conn, "channel")
+	require.NoError(t, err)
+	assert.Equal(t, Roster{"alice": "red", "bob": "blue"}, roster)
+
+	// Rosters are independent between channels.
+	roster, err = GetRoster(conn, "other")
+	require.NoError(t, err)
+	assert.Empty(t, roster)
+}
+
+func TestClearRoster(t *testing.T) {
+	_, pool := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	require.NoError(t, SetRoster(conn, "channel", Roster{"alice": "red"}))
+	require.NoError(t, ClearRoster(conn, "channel"))
+
+	roster, err := GetRoster(conn, "channel")
+	require.NoError(t, err)
+	assert.Empty(t, roster)
+}
+
+func TestRoster_Error(t *testing.T) {
+	_, pool := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	ForceErrorDuringRosterLoad(t, errors.New("forced error"))
+	_, err := GetRoster(conn, "channel")
+	assert.Error(t, err)
+
+	ForceErrorDuringRosterSave(t, errors.New("forced error"))
+	assert.Error(t, SetRoster(conn, "channel", Roster{}))
+	assert.Error(t, ClearRoster(conn, "channel"))
+}
@@ -0,0 +1,78 @@
+package team
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/gomodule/redigo/redis"
+	"log"
+	"net/http"
+)
+
+func RegisterRoutes(r chi.Router, pool *redis.Pool) {
+	r.Route("/teams/{channel}", func(r chi.Router) {
+		r.Delete("/", ClearTeams(pool))
+		r.Put("/{user}", JoinTeam(pool))
+	})
+}
+
+// JoinTeam adds a chatter to one of the teams of a channel.  Once a channel has
+// at least one chatter on a team, puzzles solved in the channel keep track of
+// the score of each team.
+func JoinTeam(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+		user := chi.URLParam(r, "user")
+
+		var name string
+		if err := render.DecodeJSON(r.Body, &name); err != nil {
+			log.Printf("unable to read request body: %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !IsValid(name) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		roster, err := GetRoster(conn, channel)
+		if err != nil {
+			log.Printf("unable to load team roster for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		roster.Join(user, name)
+
+		if err := SetRoster(conn, channel, roster); err != nil {
+			log.Printf("unable to save team roster for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ClearTeams removes every chatter from the teams of a channel.  Puzzles solved
+// in the channel will no longer keep track of team scores until a chatter joins
+// a team again.
+func ClearTeams(pool *redis.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := chi.URLParam(r, "channel")
+
+		conn := pool.Get()
+		defer func() { _ = conn.Close() }()
+
+		if err := ClearRoster(conn, channel); err != nil {
+			log.Printf("unable to clear team roster for channel %s: %+v", channel, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,121 @@
+package team
+
+import (
+	"errors"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRoute_JoinTeam(t *testing.T) {
+	router, pool := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	response := PUT("/teams/channel/Alice", `"red"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	response = PUT("/teams/channel/bob", `"blue"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	roster, err := GetRoster(conn, "channel")
+	require.NoError(t, err)
+	assert.Equal(t, Roster{"alice": "red", "bob": "blue"}, roster)
+
+	// Alice switches teams.
+	response = PUT("/teams/channel/alice", `"blue"`, router)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	roster, err = GetRoster(conn, "channel")
+	require.NoError(t, err)
+	assert.Equal(t, Roster{"alice": "blue", "bob": "blue"}, roster)
+}
+
+func TestRoute_JoinTeam_Error(t *testing.T) {
+	tests := []struct {
+		name            string
+		json            string
+		rosterLoadError error
+		rosterSaveError error
+		expected        int
+	}{
+		{
+			name:     "malformed json",
+			json:     `{`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown team",
+			json:     `"green"`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:            "error loading roster",
+			json:            `"red"`,
+			rosterLoadError: errors.New("forced error"),
+			expected:        http.StatusInternalServerError,
+		},
+		{
+			name:            "error saving roster",
+			json:            `"red"`,
+			rosterSaveError: errors.New("forced error"),
+			expected:        http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, _ := NewTestRouter(t)
+
+			if test.rosterLoadError != nil {
+				ForceErrorDuringRosterLoad(t, test.rosterLoadError)
+			}
+
+			if test.rosterSaveError != nil {
+				ForceErrorDuringRosterSave(t, test.rosterSaveError)
+			}
+
+			response := PUT("/teams/channel/alice", test.json, router)
+			assert.Equal(t, test.expected, response.Code)
+		})
+	}
+}
+
+func TestRoute_ClearTeams(t *testing.T) {
+	router, pool := NewTestRouter(t)
+	conn := NewRedisConnection(t, pool)
+
+	require.NoError(t, SetRoster(conn, "channel", Roster{"alice": "red"}))
+
+	response := DELETE("/teams/channel", router)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	roster, err := GetRoster(conn, "channel")
+	require.NoError(t, err)
+	assert.Empty(t, roster)
+}
+
+func TestRoute_ClearTeams_Error(t *testing.T) {
+	router, _ := NewTestRouter(t)
+	ForceErrorDuringRosterSave(t, errors.New("forced error"))
+
+	response := DELETE("/teams/channel", router)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func PUT(url, body string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func DELETE(url string, router chi.Router) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodDelete, url, nil)
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
@@ -0,0 +1,68 @@
+package team
+
+import (
+	"github.com/alicebob/miniredis"
+	"github.com/go-chi/chi"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// A cached error to use instead of reading a roster from the database.
+var testRosterLoadError error = nil
+
+// A cached error to use instead of writing a roster to the database.
+var testRosterSaveError error = nil
+
+// ForceErrorDuringRosterLoad sets up an error to be returned when an attempt
+// is made to load a team roster.
+func ForceErrorDuringRosterLoad(t *testing.T, err error) {
+	t.Helper()
+
+	testRosterLoadError = err
+	t.Cleanup(func() { testRosterLoadError = nil })
+}
+
+// ForceErrorDuringRosterSave sets up an error to be returned when an attempt
+// is made to save a team roster.
+func ForceErrorDuringRosterSave(t *testing.T, err error) {
+	t.Helper()
+
+	testRosterSaveError = err
+	t.Cleanup(func() { testRosterSaveError = nil })
+}
+
+// NewTestRouter will return a router configured with a redis pool and wired
+// together along with all of the routes for teams.
+func NewTestRouter(t *testing.T) (chi.Router, *redis.Pool) {
+	t.Helper()
+
+	// Setup redis.
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", server.Addr())
+		},
+	}
+
+	// Setup the chi router and wire it up to the redis pool.
+	router := chi.NewRouter()
+	RegisterRoutes(router, pool)
+
+	return router, pool
+}
+
+// NewRedisConnection will return a connection to the provided connection pool.
+// The returned connection will be configured to automatically close when the
+// test completes.
+func NewRedisConnection(t *testing.T, pool *redis.Pool) redis.Conn {
+	t.Helper()
+
+	conn := pool.Get()
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
@@ -0,0 +1,34 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// StatusError is an error encountered while handling a request that should be
+// reported to the client using a particular HTTP status code.
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+func (e StatusError) Error() string {
+	return e.Err.Error()
+}
+
+// Errorf formats an error that should be reported to the client using the
+// provided HTTP status code.
+func Errorf(code int, format string, args ...interface{}) error {
+	return StatusError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// StatusCode returns the HTTP status code that should be reported to the client
+// for an error.  Errors that weren't created by Errorf are reported as internal
+// server errors.
+func StatusCode(err error) int {
+	if e, ok := err.(StatusError); ok {
+		return e.Code
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,38 @@
+package web
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "status error",
+			err:      Errorf(http.StatusConflict, "status is %s", "complete"),
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("forced error"),
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, StatusCode(test.err))
+		})
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	err := Errorf(http.StatusNotFound, "unable to load state: %v", errors.New("forced error"))
+	assert.Equal(t, "unable to load state: forced error", err.Error())
+}
@@ -0,0 +1,75 @@
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ETag computes an entity tag for a JSON body.  The tag is derived from the
+// contents of the body so it changes whenever the value that was marshalled
+// changes.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// VersionETag computes an entity tag for a versioned value.  The tag is derived
+// from the version alone so it only changes when the value is saved again, even
+// if parts of the value are computed when it's rendered.
+func VersionETag(version int64) string {
+	return fmt.Sprintf(`"v%d"`, version)
+}
+
+// RenderJSONWithETag writes a value to a response as JSON along with an ETag
+// header derived from the contents of the body.  If the request's If-None-Match
+// header already contains the ETag then the client's copy of the value is
+// current and a 304 Not Modified response is written without a body.  An error
+// is returned if the value can't be marshalled, in which case nothing will have
+// been written to the response.
+func RenderJSONWithETag(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	render(w, r, ETag(body), body)
+	return nil
+}
+
+// RenderJSONWithVersion writes a value to a response as JSON along with an ETag
+// header derived from the value's version.  This should be used instead of
+// RenderJSONWithETag for values that contain fields computed at the time they're
+// rendered, such as the time remaining in a solve, since the contents of the
+// body would change on every request.  An error is returned if the value can't
+// be marshalled, in which case nothing will have been written to the response.
+func RenderJSONWithVersion(w http.ResponseWriter, r *http.Request, version int64, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	render(w, r, VersionETag(version), body)
+	return nil
+}
+
+// render writes a JSON body to a response along with its ETag, or a 304 Not
+// Modified response if the client already has the current body.
+func render(w http.ResponseWriter, r *http.Request, etag string, body []byte) {
+	w.Header().Set("ETag", etag)
+
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == etag || candidate == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
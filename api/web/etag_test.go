@@ -0,0 +1,120 @@
+package web
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestETag(t *testing.T) {
+	a := ETag([]byte(`{"status":"solving"}`))
+	b := ETag([]byte(`{"status":"paused"}`))
+
+	assert.Equal(t, a, ETag([]byte(`{"status":"solving"}`)))
+	assert.NotEqual(t, a, b)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, a)
+}
+
+func TestRenderJSONWithETag(t *testing.T) {
+	value := map[string]string{"status": "solving"}
+	etag := ETag([]byte(`{"status":"solving"}`))
+
+	tests := []struct {
+		name         string
+		ifNoneMatch  string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "no if-none-match",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"solving"}`,
+		},
+		{
+			name:         "stale if-none-match",
+			ifNoneMatch:  `"stale"`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"solving"}`,
+		},
+		{
+			name:         "matching if-none-match",
+			ifNoneMatch:  etag,
+			expectedCode: http.StatusNotModified,
+		},
+		{
+			name:         "matching one of several if-none-match",
+			ifNoneMatch:  `"stale", ` + etag,
+			expectedCode: http.StatusNotModified,
+		},
+		{
+			name:         "wildcard if-none-match",
+			ifNoneMatch:  "*",
+			expectedCode: http.StatusNotModified,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.ifNoneMatch != "" {
+				request.Header.Set("If-None-Match", test.ifNoneMatch)
+			}
+			recorder := httptest.NewRecorder()
+
+			require.NoError(t, RenderJSONWithETag(recorder, request, value))
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			assert.Equal(t, etag, recorder.Header().Get("ETag"))
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestRenderJSONWithETag_Error(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	recorder := httptest.NewRecorder()
+
+	err := RenderJSONWithETag(recorder, request, func() {})
+	assert.Error(t, err)
+	assert.Empty(t, recorder.Header().Get("ETag"))
+}
+
+func TestVersionETag(t *testing.T) {
+	assert.Equal(t, `"v3"`, VersionETag(3))
+	assert.NotEqual(t, VersionETag(3), VersionETag(4))
+}
+
+func TestRenderJSONWithVersion(t *testing.T) {
+	etag := VersionETag(3)
+
+	// The ETag only depends on the version, not the value.
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	recorder := httptest.NewRecorder()
+	require.NoError(t, RenderJSONWithVersion(recorder, request, 3, map[string]int{"remaining": 10}))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, etag, recorder.Header().Get("ETag"))
+	assert.Equal(t, `{"remaining":10}`, recorder.Body.String())
+
+	request = httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	require.NoError(t, RenderJSONWithVersion(recorder, request, 3, map[string]int{"remaining": 9}))
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+
+	// A new version should return the value again.
+	recorder = httptest.NewRecorder()
+	require.NoError(t, RenderJSONWithVersion(recorder, request, 4, map[string]int{"remaining": 8}))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, VersionETag(4), recorder.Header().Get("ETag"))
+}
+
+func TestRenderJSONWithVersion_Error(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	recorder := httptest.NewRecorder()
+
+	err := RenderJSONWithVersion(recorder, request, 1, func() {})
+	assert.Error(t, err)
+	assert.Empty(t, recorder.Header().Get("ETag"))
+}
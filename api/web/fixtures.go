@@ -0,0 +1,162 @@
+package web
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The modes that fixtures can be used in.
+const (
+	// FixtureModeRecord performs every GET request against the network and saves
+	// the response to a fixture file, replacing any previous recording.
+	FixtureModeRecord = "record"
+
+	// FixtureModeReplay answers every GET request from its fixture file without
+	// using the network.  Requests that haven't been recorded fail.
+	FixtureModeReplay = "replay"
+)
+
+// FixtureTransport is a http.RoundTripper that records responses to fixture
+// files or replays previously recorded responses from them.  The fixtures are
+// plain HTTP responses, one per file, stored in a directory per host so that
+// they can be read and edited by hand.
+//
+// Only GET requests are recorded and replayed, other requests are passed
+// through to the next transport when recording and fail when replaying.
+type FixtureTransport struct {
+	// The directory that fixture files are stored in.
+	Dir string
+
+	// The mode of the transport, either FixtureModeRecord or FixtureModeReplay.
+	Mode string
+
+	// The transport that performs requests when recording.  If nil then
+	// http.DefaultTransport is used.
+	Next http.RoundTripper
+}
+
+// NewFixtureTransport creates a transport that uses the fixtures in a directory
+// in the given mode.  If the mode isn't recognized then an error is returned.
+func NewFixtureTransport(mode, dir string) (*FixtureTransport, error) {
+	if mode != FixtureModeRecord && mode != FixtureModeReplay {
+		return nil, fmt.Errorf("unrecognized fixture mode: %s", mode)
+	}
+
+	return &FixtureTransport{Dir: dir, Mode: mode}, nil
+}
+
+// RoundTrip performs a request by recording or replaying its response.
+func (t *FixtureTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if request.Method != http.MethodGet {
+		if t.Mode == FixtureModeRecord {
+			return next.RoundTrip(request)
+		}
+		return nil, fmt.Errorf("unable to replay %s request for url %s", request.Method, request.URL)
+	}
+
+	filename := filepath.Join(t.Dir, FixtureFilename(request))
+	if t.Mode == FixtureModeReplay {
+		return replay(filename, request)
+	}
+
+	response, err := next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := record(filename, response); err != nil {
+		return nil, fmt.Errorf("unable to record response for url %s: %v", request.URL, err)
+	}
+
+	return replay(filename, request)
+}
+
+// Characters that aren't safe to use in a fixture filename.
+var unsafeFilenameCharacters = regexp.MustCompile(`[^A-Za-z0-9._=-]+`)
+
+// FixtureFilename returns the path of the fixture file for a request relative
+// to the fixture directory.  The file is named after the path and query of the
+// URL and placed in a directory named after its host, for example a request
+// for https://nytbee.com/Bee_20200408.html is stored in
+// nytbee.com/Bee_20200408.html-fdf45f2f.http.  Since unsafe characters are
+// replaced the name ends with a short hash of the request's method and URL so
+// that different requests never share a fixture.
+func FixtureFilename(request *http.Request) string {
+	name := strings.TrimPrefix(request.URL.EscapedPath(), "/")
+	if request.URL.RawQuery != "" {
+		name = name + "?" + request.URL.RawQuery
+	}
+
+	name = unsafeFilenameCharacters.ReplaceAllString(name, "_")
+	if name == "" {
+		name = "index"
+	}
+
+	sum := sha256.Sum256([]byte(request.Method + " " + request.URL.String()))
+	name = name + "-" + hex.EncodeToString(sum[:4])
+
+	host := unsafeFilenameCharacters.ReplaceAllString(request.URL.Host, "_")
+	return filepath.Join(host, name+".http")
+}
+
+// record writes a response, including its entire body, to a fixture file.
+func record(filename string, response *http.Response) error {
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	// Save the body with an explicit length so that the fixture contains it as
+	// it was received instead of in chunks.
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	response.ContentLength = int64(len(body))
+	response.TransferEncoding = nil
+	response.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	bs, err := httputil.DumpResponse(response, true)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filename, bs, 0644)
+}
+
+// replay reads the response to a request from a fixture file.
+func replay(filename string, request *http.Request) (*http.Response, error) {
+	bs, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no recorded response for url %s in %s", request.URL, filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(bs)), request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read recorded response %s: %v", filename, err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,177 @@
+package web
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFixtureTransport_RecordAndReplay(t *testing.T) {
+	dir := NewTestFixtureDir(t)
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html>" + r.URL.Query().Get("date") + "</html>"))
+	}))
+	defer server.Close()
+
+	url := server.URL + "/puzzle?date=2020-01-02"
+
+	// Recording performs the request and returns its response.
+	recorder := &http.Client{Transport: &FixtureTransport{Dir: dir, Mode: FixtureModeRecord}}
+	response, err := GetWithClient(recorder, url, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "<html>2020-01-02</html>", ReadBody(t, response))
+	assert.Equal(t, 1, requests)
+
+	// The fixture contains the raw response.
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	bs, err := ioutil.ReadFile(filepath.Join(dir, FixtureFilename(request)))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(bs), "HTTP/1.1 200 OK\r\n"))
+	assert.True(t, strings.HasSuffix(string(bs), "\r\n\r\n<html>2020-01-02</html>"))
+
+	// Replaying returns the recorded response without making a request, even
+	// once the server is gone.
+	server.Close()
+
+	replayer := &http.Client{Transport: &FixtureTransport{Dir: dir, Mode: FixtureModeReplay}}
+	response, err = GetWithClient(replayer, url, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "<html>2020-01-02</html>", ReadBody(t, response))
+	assert.Equal(t, "text/html", response.Header.Get("Content-Type"))
+	assert.Equal(t, 1, requests)
+}
+
+func TestFixtureTransport_RecordErrorResponse(t *testing.T) {
+	dir := NewTestFixtureDir(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	recorder := &http.Client{Transport: &FixtureTransport{Dir: dir, Mode: FixtureModeRecord}}
+	_, err := GetWithClient(recorder, server.URL+"/missing", nil)
+	assert.Error(t, err)
+
+	// The error is replayed as well.
+	server.Close()
+
+	replayer := &http.Client{Transport: &FixtureTransport{Dir: dir, Mode: FixtureModeReplay}}
+	response, err := GetWithClient(replayer, server.URL+"/missing", nil)
+	assert.Error(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestFixtureTransport_ReplayMissing(t *testing.T) {
+	replayer := &http.Client{Transport: &FixtureTransport{Dir: NewTestFixtureDir(t), Mode: FixtureModeReplay}}
+
+	_, err := GetWithClient(replayer, "https://example.com/missing", nil)
+	assert.Error(t, err)
+}
+
+func TestFixtureTransport_ReplayNonGet(t *testing.T) {
+	replayer := &http.Client{Transport: &FixtureTransport{Dir: NewTestFixtureDir(t), Mode: FixtureModeReplay}}
+
+	_, err := PostWithClient(replayer, "https://example.com/", strings.NewReader("body"))
+	assert.Error(t, err)
+}
+
+func TestNewFixtureTransport(t *testing.T) {
+	transport, err := NewFixtureTransport("replay", "fixtures")
+	require.NoError(t, err)
+	assert.Equal(t, FixtureModeReplay, transport.Mode)
+	assert.Equal(t, "fixtures", transport.Dir)
+
+	_, err = NewFixtureTransport("rewind", "fixtures")
+	assert.Error(t, err)
+}
+
+func TestFixtureFilename(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "https://nytbee.com/Bee_20200408.html",
+			expected: "nytbee.com/Bee_20200408.html-fdf45f2f.http",
+		},
+		{
+			url:      "https://www.xwordinfo.com/JSON/Data.aspx?date=2018-12-31",
+			expected: "www.xwordinfo.com/JSON_Data.aspx_date=2018-12-31-21edd95c.http",
+		},
+		{
+			url:      "http://localhost:8080/",
+			expected: "localhost_8080/index-6a9acd9a.http",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, test.url, nil)
+			assert.Equal(t, filepath.FromSlash(test.expected), FixtureFilename(request))
+		})
+	}
+}
+
+func TestFixtureFilename_Distinct(t *testing.T) {
+	// Requests whose names are the same once unsafe characters are replaced are
+	// still stored in different fixtures.
+	tests := []struct {
+		name string
+		urls []string
+	}{
+		{
+			name: "path",
+			urls: []string{"https://example.com/a/b", "https://example.com/a_b"},
+		},
+		{
+			name: "query",
+			urls: []string{"https://example.com/?x=1&y", "https://example.com/?x=1_y"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := httptest.NewRequest(http.MethodGet, test.urls[0], nil)
+			b := httptest.NewRequest(http.MethodGet, test.urls[1], nil)
+			assert.NotEqual(t, FixtureFilename(a), FixtureFilename(b))
+		})
+	}
+
+	get := httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+	head := httptest.NewRequest(http.MethodHead, "https://example.com/", nil)
+	assert.NotEqual(t, FixtureFilename(get), FixtureFilename(head))
+}
+
+// NewTestFixtureDir creates an empty directory for fixtures that's removed
+// when the test completes.
+func NewTestFixtureDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "fixtures")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return dir
+}
+
+// ReadBody reads and closes the body of a response.
+func ReadBody(t *testing.T, response *http.Response) string {
+	t.Helper()
+	defer func() { _ = response.Body.Close() }()
+
+	bs, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	return string(bs)
+}
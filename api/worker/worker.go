@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/gomodule/redigo/redis"
+	"log"
+	"strings"
+	"time"
+)
+
+// A Job is a task that is run periodically in the background, for example
+// giving hints to every channel whose solve has stalled.
+type Job struct {
+	// A description of what the job does, used when logging its errors.
+	Name string
+
+	// How often the job should be run.
+	Interval time.Duration
+
+	// The function that performs the job.
+	Run func(conn redis.Conn, registry *pubsub.Registry, now time.Time) error
+}
+
+// Tick determines how often Run checks whether any of its jobs are due.  A job
+// can't be run more often than this.
+var Tick = time.Second
+
+// Run runs each of the provided jobs once per its interval.  Jobs that are due
+// at the same time are run one after another using a single connection from the
+// pool.  If a job fails its error is logged and it's run again once its next
+// interval has elapsed.  This method blocks until the provided context is done.
+func Run(ctx context.Context, pool *redis.Pool, registry *pubsub.Registry, jobs []Job) {
+	ticker := time.NewTicker(Tick)
+	defer ticker.Stop()
+
+	next := make([]time.Time, len(jobs))
+	for i, job := range jobs {
+		next[i] = time.Now().Add(job.Interval)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case now := <-ticker.C:
+			var due []Job
+			for i, job := range jobs {
+				if !now.Before(next[i]) {
+					due = append(due, job)
+					next[i] = now.Add(job.Interval)
+				}
+			}
+
+			if len(due) == 0 {
+				continue
+			}
+
+			conn := pool.Get()
+			for _, job := range due {
+				if err := job.Run(conn, registry, now); err != nil {
+					log.Printf("unable to %s: %+v", job.Name, err)
+				}
+			}
+			_ = conn.Close()
+		}
+	}
+}
+
+// ForEachChannel calls fn for every channel that has a key in the database
+// matching the provided key function, typically a puzzle type's StateKey.  If
+// fn fails for a channel the error is logged and the remaining channels are
+// still processed.
+func ForEachChannel(conn redis.Conn, key func(string) string, fn func(channel string) error) error {
+	keys, err := db.ScanKeys(conn, key("*"))
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		channel := strings.Replace(k, key(""), "", 1)
+		if err := fn(channel); err != nil {
+			log.Printf("unable to process %s: %+v", k, err)
+		}
+	}
+
+	return nil
+}
+
+// Update performs a read-modify-write of a channel's key without losing any
+// changes made to it concurrently, for example by a request handler recording an
+// answer.  The read function loads the data using conn and then write makes its
+// changes using tx, if the key is modified before the writes are applied then
+// both are called again with the latest data.  The events returned by the final
+// call of write are only published to the channel once its writes have been
+// applied.
+func Update(conn redis.Conn, registry *pubsub.Registry, channel pubsub.Channel, key string, read func(conn db.Connection) error, write func(tx db.Connection) ([]pubsub.Event, error)) error {
+	var events []pubsub.Event
+	err := db.Update(conn, key, read, func(tx db.Connection) error {
+		var err error
+		events, err = write(tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		registry.Publish(channel, event)
+	}
+
+	return nil
+}
+
+// IsStalled determines whether a solve has gone at least the provided duration
+// without any activity as of now.  Solves that aren't actively being solved,
+// have never had any activity, or that have a non-positive duration configured
+// are never considered stalled.
+func IsStalled(status model.Status, last *time.Time, d time.Duration, now time.Time) bool {
+	if status != model.StatusSolving || last == nil || d <= 0 {
+		return false
+	}
+
+	return now.Sub(*last) >= d
+}
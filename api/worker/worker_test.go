@@ -0,0 +1,247 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis"
+	"github.com/bbeck/puzzles-with-chat/api/db"
+	"github.com/bbeck/puzzles-with-chat/api/model"
+	"github.com/bbeck/puzzles-with-chat/api/pubsub"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	server, _ := NewMiniredis(t)
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", server.Addr())
+		},
+	}
+
+	tick := Tick
+	Tick = 10 * time.Millisecond
+	t.Cleanup(func() { Tick = tick })
+
+	var frequent, infrequent int
+	jobs := []Job{
+		{
+			Name:     "run frequently",
+			Interval: 10 * time.Millisecond,
+			Run: func(conn redis.Conn, registry *pubsub.Registry, now time.Time) error {
+				frequent++
+				return errors.New("forced error")
+			},
+		},
+		{
+			Name:     "run infrequently",
+			Interval: time.Hour,
+			Run: func(conn redis.Conn, registry *pubsub.Registry, now time.Time) error {
+				infrequent++
+				return nil
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	Run(ctx, pool, new(pubsub.Registry), jobs)
+
+	// A job that fails should still be run again.
+	assert.True(t, frequent > 1)
+	assert.Equal(t, 0, infrequent)
+}
+
+func TestForEachChannel(t *testing.T) {
+	server, conn := NewMiniredis(t)
+	require.NoError(t, server.Set("a:state", "{}"))
+	require.NoError(t, server.Set("b:state", "{}"))
+	require.NoError(t, server.Set("c:settings", "{}"))
+
+	key := func(name string) string { return name + ":state" }
+
+	// An error for one channel shouldn't stop the others from being processed.
+	var channels []string
+	err := ForEachChannel(conn, key, func(channel string) error {
+		channels = append(channels, channel)
+		return errors.New("forced error")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, channels)
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		concurrent bool     // Whether the key is modified while being updated.
+		events     []string // The kinds of the events returned by fn.
+		expected   string   // The expected value of the key afterwards.
+	}{
+		{
+			name:     "no changes",
+			expected: "0",
+		},
+		{
+			name:     "changes",
+			events:   []string{"state"},
+			expected: "1",
+		},
+		{
+			name:       "concurrent modification",
+			concurrent: true,
+			events:     []string{"state"},
+			expected:   "11",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, conn := NewMiniredis(t)
+			require.NoError(t, server.Set("key", "0"))
+
+			registry := new(pubsub.Registry)
+			stream := make(chan pubsub.Event, 10)
+			_, err := registry.Subscribe("channel", stream)
+			require.NoError(t, err)
+
+			var value int
+			read := func(conn db.Connection) error {
+				var err error
+				value, err = redis.Int(conn.Do("GET", "key"))
+				return err
+			}
+
+			modified := false
+			write := func(tx db.Connection) ([]pubsub.Event, error) {
+				// Simulate a request handler changing the key after it was read, the
+				// change should be kept and built upon instead of overwritten.
+				if test.concurrent && !modified {
+					modified = true
+					require.NoError(t, server.Set("key", "10"))
+				}
+
+				if len(test.events) == 0 {
+					return nil, nil
+				}
+
+				if _, err := tx.Do("SET", "key", value+1); err != nil {
+					return nil, err
+				}
+
+				var events []pubsub.Event
+				for _, kind := range test.events {
+					events = append(events, pubsub.Event{Kind: kind})
+				}
+				return events, nil
+			}
+
+			err = Update(conn, registry, "channel", "key", read, write)
+			require.NoError(t, err)
+			server.CheckGet(t, "key", test.expected)
+
+			// Events are only published once.
+			require.Len(t, stream, len(test.events))
+			for _, kind := range test.events {
+				assert.Equal(t, kind, (<-stream).Kind)
+			}
+		})
+	}
+}
+
+func TestUpdate_Error(t *testing.T) {
+	server, conn := NewMiniredis(t)
+	require.NoError(t, server.Set("key", "0"))
+
+	registry := new(pubsub.Registry)
+	stream := make(chan pubsub.Event, 10)
+	_, err := registry.Subscribe("channel", stream)
+	require.NoError(t, err)
+
+	read := func(conn db.Connection) error {
+		return nil
+	}
+
+	write := func(tx db.Connection) ([]pubsub.Event, error) {
+		_, _ = tx.Do("SET", "key", "1")
+		return []pubsub.Event{{Kind: "state"}}, errors.New("forced error")
+	}
+
+	err = Update(conn, registry, "channel", "key", read, write)
+	assert.Error(t, err)
+
+	// Nothing should have been written or published.
+	server.CheckGet(t, "key", "0")
+	assert.Empty(t, stream)
+}
+
+func TestIsStalled(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		status   model.Status
+		last     *time.Time
+		d        time.Duration
+		expected bool
+	}{
+		{
+			name:   "disabled",
+			status: model.StatusSolving,
+			last:   before(time.Hour),
+		},
+		{
+			name:   "not solving",
+			status: model.StatusPaused,
+			last:   before(time.Hour),
+			d:      5 * time.Minute,
+		},
+		{
+			name:   "no activity",
+			status: model.StatusSolving,
+			d:      5 * time.Minute,
+		},
+		{
+			name:   "recent activity",
+			status: model.StatusSolving,
+			last:   before(time.Minute),
+			d:      5 * time.Minute,
+		},
+		{
+			name:     "stalled",
+			status:   model.StatusSolving,
+			last:     before(5 * time.Minute),
+			d:        5 * time.Minute,
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsStalled(test.status, test.last, test.d, now))
+		})
+	}
+}
+
+// NewMiniredis creates a miniredis server along with a connection to it.  Both
+// are closed when the test finishes.
+func NewMiniredis(t *testing.T) (*miniredis.Miniredis, redis.Conn) {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+
+	conn, err := redis.Dial("tcp", server.Addr())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Close()
+	})
+
+	return server, conn
+}
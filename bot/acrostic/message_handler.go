@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/bot/chat"
 	"github.com/bbeck/puzzles-with-chat/bot/web"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"regexp"
 	"time"
 )
@@ -29,18 +32,26 @@ var ShowClueRegexp = regexp.MustCompile(
 	`^!(?i:show)\s+(?P<clue>[A-Za-z])\s*$`,
 )
 
+// A regular expression that matches a message that's asking for the share card
+// of the most recently finished solve to be posted to chat.  There are no
+// capture groups.
+var ShareRegexp = regexp.MustCompile(
+	`^!(?i:share)\s*$`,
+)
+
 type MessageHandler struct {
 	baseURL string
+	chat    chat.Sayer
 }
 
-func NewMessageHandler(host string) *MessageHandler {
+func NewMessageHandler(host string, sayer chat.Sayer) *MessageHandler {
 	url := fmt.Sprintf("http://%s/api/acrostic", host)
-	return &MessageHandler{baseURL: url}
+	return &MessageHandler{baseURL: url, chat: sayer}
 }
 
 // HandleChannelMessage parses a message and if it matches an acrostic command
 // sends it to the appropriate API endpoint.
-func (h *MessageHandler) HandleChannelMessage(channel, status, message string) {
+func (h *MessageHandler) HandleChannelMessage(channel, status, user, message string) {
 	if match := AnswerRegexp.FindStringSubmatch(message); len(match) != 0 {
 		if status != "solving" {
 			return
@@ -55,7 +66,9 @@ func (h *MessageHandler) HandleChannelMessage(channel, status, message string) {
 			return
 		}
 
-		url := fmt.Sprintf("%s/%s/answer/%s", h.baseURL, channel, clue)
+		// The user is included so that their team can be credited with the answer.
+		query := url.Values{"user": {user}}.Encode()
+		url := fmt.Sprintf("%s/%s/answer/%s?%s", h.baseURL, channel, clue, query)
 		response, err := web.PutWithClient(DefaultAcrosticHTTPClient, url, bytes.NewReader(bs))
 		defer func() { _ = response.Body.Close() }()
 		if err != nil {
@@ -75,4 +88,29 @@ func (h *MessageHandler) HandleChannelMessage(channel, status, message string) {
 		}
 		return
 	}
+
+	if match := ShareRegexp.FindStringSubmatch(message); len(match) != 0 {
+		if status != "complete" && status != "given_up" && status != "expired" {
+			return
+		}
+
+		url := fmt.Sprintf("%s/%s/share", h.baseURL, channel)
+		response, err := web.GetWithClient(DefaultAcrosticHTTPClient, url, nil)
+		if response != nil {
+			defer func() { _ = response.Body.Close() }()
+		}
+		if err != nil {
+			log.Printf("error loading share card, url: %s", url)
+			return
+		}
+
+		card, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			log.Printf("error reading share card, url: %s", url)
+			return
+		}
+
+		chat.SayLines(h.chat, channel, string(card))
+		return
+	}
 }
@@ -0,0 +1,28 @@
+package chat
+
+import "strings"
+
+// A Sayer sends messages to the chat of a channel.
+type Sayer interface {
+	Say(channel, message string)
+}
+
+// SayerFunc is an adapter that allows an ordinary function to be used as a
+// Sayer.
+type SayerFunc func(channel, message string)
+
+// Say calls f(channel, message).
+func (f SayerFunc) Say(channel, message string) {
+	f(channel, message)
+}
+
+// SayLines sends each non-empty line of the provided text to the chat of a
+// channel as its own message.  Chat doesn't preserve line breaks within a
+// message so this keeps multi-line text readable.
+func SayLines(sayer Sayer, channel, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			sayer.Say(channel, line)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package chat
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSayLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "single line",
+			text:     "hello",
+			expected: []string{"hello"},
+		},
+		{
+			name:     "multiple lines",
+			text:     "hello\nthere",
+			expected: []string{"hello", "there"},
+		},
+		{
+			name:     "blank lines are skipped",
+			text:     "hello\n\n  \nthere\n",
+			expected: []string{"hello", "there"},
+		},
+		{
+			name: "empty text",
+			text: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var said []string
+			sayer := SayerFunc(func(channel, message string) {
+				assert.Equal(t, "channel", channel)
+				said = append(said, message)
+			})
+
+			SayLines(sayer, "channel", test.text)
+			assert.Equal(t, test.expected, said)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/gempir/go-twitch-irc/v2"
 	"io"
+	"log"
 	"net"
 	"os"
 	"regexp"
@@ -26,6 +27,9 @@ type Client interface {
 
 	// Depart from a channel and stop processing messages from it.
 	Depart(channel string)
+
+	// Say sends a message to the chat of a channel.
+	Say(channel, message string)
 }
 
 type ClientMessageHandler interface {
@@ -87,6 +91,11 @@ type LocalClient struct {
 func (c *LocalClient) Join(...string) {}
 func (c *LocalClient) Depart(string)  {}
 
+// Say logs the message since there isn't an actual chat to send it to.
+func (c *LocalClient) Say(channel, message string) {
+	log.Printf("[%s] %s", channel, message)
+}
+
 // Connect implements a small REPL on a network socket that allows a user to
 // use the connection as a means for providing input into the bot.
 func (c *LocalClient) Connect() error {
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/bot/chat"
 	"github.com/bbeck/puzzles-with-chat/bot/web"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"regexp"
 	"time"
 )
@@ -29,18 +32,47 @@ var ShowClueRegexp = regexp.MustCompile(
 	`^!(?i:show)\s+(?P<clue>[0-9]+[aAdD])\s*$`,
 )
 
+// A regular expression that matches a message that's asking for an answer to
+// be checked for correctness.  Capture group 1 is the clue.
+var CheckRegexp = regexp.MustCompile(
+	`^!(?i:check)\s+([0-9]+[aAdD])\s*$`,
+)
+
+// A regular expression that matches a message that's asking for an answer, or
+// a single square of an answer, to be revealed.  Capture group 1 is the clue
+// and capture group 2 is the optional 1-based square within the answer.
+var RevealRegexp = regexp.MustCompile(
+	`^!(?i:reveal)\s+([0-9]+[aAdD])(?:\s+([0-9]+))?\s*$`,
+)
+
+// A regular expression that matches a message that's placing the starting
+// square of a clue's answer in a diagramless crossword.  Capture group 1 is the
+// clue, capture group 2 is the 1-based row and capture group 3 is the 1-based
+// column of the square.
+var PlaceRegexp = regexp.MustCompile(
+	`^!(?i:place)\s+([0-9]+[aAdD])\s+([0-9]+)(?:\s*,\s*|\s+)([0-9]+)\s*$`,
+)
+
+// A regular expression that matches a message that's asking for the share card
+// of the most recently finished solve to be posted to chat.  There are no
+// capture groups.
+var ShareRegexp = regexp.MustCompile(
+	`^!(?i:share)\s*$`,
+)
+
 type MessageHandler struct {
 	baseURL string
+	chat    chat.Sayer
 }
 
-func NewMessageHandler(host string) *MessageHandler {
+func NewMessageHandler(host string, sayer chat.Sayer) *MessageHandler {
 	url := fmt.Sprintf("http://%s/api/crossword", host)
-	return &MessageHandler{baseURL: url}
+	return &MessageHandler{baseURL: url, chat: sayer}
 }
 
 // HandleChannelMessage parses a message and if it matches a crossword command
 // sends it to the appropriate API endpoint.
-func (h *MessageHandler) HandleChannelMessage(channel, status, message string) {
+func (h *MessageHandler) HandleChannelMessage(channel, status, user, message string) {
 	if match := AnswerRegexp.FindStringSubmatch(message); len(match) != 0 {
 		if status != "solving" {
 			return
@@ -55,7 +87,9 @@ func (h *MessageHandler) HandleChannelMessage(channel, status, message string) {
 			return
 		}
 
-		url := fmt.Sprintf("%s/%s/answer/%s", h.baseURL, channel, clue)
+		// The user is included so that their team can be credited with the answer.
+		query := url.Values{"user": {user}}.Encode()
+		url := fmt.Sprintf("%s/%s/answer/%s?%s", h.baseURL, channel, clue, query)
 		response, err := web.PutWithClient(DefaultCrosswordHTTPClient, url, bytes.NewReader(bs))
 		defer func() { _ = response.Body.Close() }()
 		if err != nil {
@@ -64,6 +98,61 @@ func (h *MessageHandler) HandleChannelMessage(channel, status, message string) {
 		return
 	}
 
+	if match := CheckRegexp.FindStringSubmatch(message); len(match) != 0 {
+		if status != "solving" {
+			return
+		}
+
+		clue := match[1]
+
+		url := fmt.Sprintf("%s/%s/check/%s", h.baseURL, channel, clue)
+		response, err := web.PutWithClient(DefaultCrosswordHTTPClient, url, nil)
+		defer func() { _ = response.Body.Close() }()
+		if err != nil {
+			log.Printf("error checking clue, url: %s", url)
+		}
+		return
+	}
+
+	if match := RevealRegexp.FindStringSubmatch(message); len(match) != 0 {
+		if status != "solving" {
+			return
+		}
+
+		clue := match[1]
+		square := match[2]
+
+		url := fmt.Sprintf("%s/%s/reveal/%s", h.baseURL, channel, clue)
+		if square != "" {
+			url = fmt.Sprintf("%s/%s", url, square)
+		}
+
+		response, err := web.PutWithClient(DefaultCrosswordHTTPClient, url, nil)
+		defer func() { _ = response.Body.Close() }()
+		if err != nil {
+			log.Printf("error revealing clue, url: %s", url)
+		}
+		return
+	}
+
+	if match := PlaceRegexp.FindStringSubmatch(message); len(match) != 0 {
+		if status != "solving" {
+			return
+		}
+
+		clue := match[1]
+		row := match[2]
+		col := match[3]
+
+		url := fmt.Sprintf("%s/%s/place/%s/%s/%s", h.baseURL, channel, clue, row, col)
+		response, err := web.PutWithClient(DefaultCrosswordHTTPClient, url, nil)
+		defer func() { _ = response.Body.Close() }()
+		if err != nil {
+			log.Printf("error placing clue, url: %s", url)
+		}
+		return
+	}
+
 	if match := ShowClueRegexp.FindStringSubmatch(message); len(match) != 0 {
 		clue := match[1]
 
@@ -75,4 +164,29 @@ func (h *MessageHandler) HandleChannelMessage(channel, status, message string) {
 		}
 		return
 	}
+
+	if match := ShareRegexp.FindStringSubmatch(message); len(match) != 0 {
+		if status != "complete" && status != "given_up" && status != "expired" {
+			return
+		}
+
+		url := fmt.Sprintf("%s/%s/share", h.baseURL, channel)
+		response, err := web.GetWithClient(DefaultCrosswordHTTPClient, url, nil)
+		if response != nil {
+			defer func() { _ = response.Body.Close() }()
+		}
+		if err != nil {
+			log.Printf("error loading share card, url: %s", url)
+			return
+		}
+
+		card, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			log.Printf("error reading share card, url: %s", url)
+			return
+		}
+
+		chat.SayLines(h.chat, channel, string(card))
+		return
+	}
 }
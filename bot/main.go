@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"github.com/bbeck/puzzles-with-chat/bot/acrostic"
+	"github.com/bbeck/puzzles-with-chat/bot/chat"
 	"github.com/bbeck/puzzles-with-chat/bot/crossword"
 	"github.com/bbeck/puzzles-with-chat/bot/spellingbee"
+	"github.com/bbeck/puzzles-with-chat/bot/team"
 	"io"
 	"log"
 	"os"
@@ -17,7 +19,14 @@ type ID string
 // A MessageHandler represents an implementation of a bot that processes chat
 // messages from a client in order to play a game in a channel.
 type MessageHandler interface {
-	HandleChannelMessage(channel, status, message string)
+	HandleChannelMessage(channel, status, user, message string)
+}
+
+// A TeamHandler processes the chat messages that chatters use to join a team.
+// Unlike a MessageHandler it isn't tied to an integration, it receives each
+// message sent to a channel that has at least one integration.
+type TeamHandler interface {
+	HandleChannelMessage(channel, user, message string)
 }
 
 func main() {
@@ -26,10 +35,17 @@ func main() {
 		log.Fatal("missing API_HOST environment variable")
 	}
 
+	// Handlers send messages to chat through the client, which can't be created
+	// until the handlers exist.  The client is looked up when a message is sent.
+	var client Client
+	sayer := chat.SayerFunc(func(channel, message string) {
+		client.Say(channel, message)
+	})
+
 	handlers := map[ID]MessageHandler{
-		"acrostic":    acrostic.NewMessageHandler(host),
-		"crossword":   crossword.NewMessageHandler(host),
-		"spellingbee": spellingbee.NewMessageHandler(host),
+		"acrostic":    acrostic.NewMessageHandler(host, sayer),
+		"crossword":   crossword.NewMessageHandler(host, sayer),
+		"spellingbee": spellingbee.NewMessageHandler(host, sayer),
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -38,10 +54,11 @@ func main() {
 	// The message router gets notified whenever an integration is discovered or
 	// changes status.  Then then uses this information to route messages received
 	// from channels to the appropriate message handler(s).
-	router := NewMessageRouter(handlers)
+	router := NewMessageRouter(handlers, team.NewMessageHandler(host))
 
 	// Create a new client that sends messages to the router.
-	client, err := NewClient(router)
+	var err error
+	client, err = NewClient(router)
 	if err != nil {
 		log.Fatalf("unable to create client: %v", err)
 	}
@@ -12,12 +12,15 @@ type MessageRouter struct {
 	// The message handlers for each integration.
 	handlers map[ID]MessageHandler
 
+	// The handler for messages that join teams.
+	teams TeamHandler
+
 	// The status of each channel's integrations.
 	statuses map[string]map[ID]string
 }
 
-func NewMessageRouter(handlers map[ID]MessageHandler) *MessageRouter {
-	return &MessageRouter{handlers: handlers}
+func NewMessageRouter(handlers map[ID]MessageHandler, teams TeamHandler) *MessageRouter {
+	return &MessageRouter{handlers: handlers, teams: teams}
 }
 
 // AddIntegration updates the integration status for the provided channel.
@@ -54,7 +57,9 @@ func (r *MessageRouter) UpdateIntegrationStatus(app ID, channel string, status s
 
 // HandleChannelMessage takes a message that was sent to a channel and passes
 // it onto the handlers for the integrations that are active for the channel.
-func (r *MessageRouter) HandleChannelMessage(channel, _, _, message string) {
+// As long as the channel has an integration the message is also passed onto the
+// team handler.
+func (r *MessageRouter) HandleChannelMessage(channel, _, user, message string) {
 	r.Lock()
 	defer r.Unlock()
 
@@ -62,9 +67,13 @@ func (r *MessageRouter) HandleChannelMessage(channel, _, _, message string) {
 	for app, status := range r.statuses[channel] {
 		handler := r.handlers[app]
 		if handler != nil {
-			handler.HandleChannelMessage(channel, status, message)
+			handler.HandleChannelMessage(channel, status, user, message)
 		}
 	}
+
+	if r.teams != nil && len(r.statuses[channel]) > 0 {
+		r.teams.HandleChannelMessage(channel, user, message)
+	}
 }
 
 func (r *MessageRouter) ensure(channel string) {
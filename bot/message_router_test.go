@@ -30,9 +30,11 @@ func TestNewMessageRouter(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			router := NewMessageRouter(test.handlers)
+			teams := TestTeamHandler{}
+			router := NewMessageRouter(test.handlers, teams)
 
 			assert.Equal(t, test.handlers, router.handlers)
+			assert.Equal(t, teams, router.teams)
 		})
 	}
 }
@@ -205,7 +207,7 @@ func TestMessageRouter_HandleChannelMessage(t *testing.T) {
 				"channel": {"crossword": "solving"},
 			},
 			channel:  "channel",
-			expected: []ID{"crossword"},
+			expected: []ID{"crossword", "teams"},
 		},
 		{
 			name:     "message from channel with multiple apps",
@@ -217,7 +219,7 @@ func TestMessageRouter_HandleChannelMessage(t *testing.T) {
 				},
 			},
 			channel:  "channel",
-			expected: []ID{"acrostic", "crossword"},
+			expected: []ID{"acrostic", "crossword", "teams"},
 		},
 		{
 			name:     "message to different channel not received",
@@ -245,8 +247,13 @@ func TestMessageRouter_HandleChannelMessage(t *testing.T) {
 				}}
 			}
 
+			teams := TestTeamHandler{func() {
+				called = append(called, "teams")
+			}}
+
 			router := &MessageRouter{
 				handlers: handlers,
+				teams:    teams,
 				statuses: test.initial,
 			}
 			router.HandleChannelMessage(test.channel, "userid", "username", "message")
@@ -260,6 +267,14 @@ type TestMessageHandler struct {
 	fn func()
 }
 
-func (h TestMessageHandler) HandleChannelMessage(_, _, _ string) {
+func (h TestMessageHandler) HandleChannelMessage(_, _, _, _ string) {
+	h.fn()
+}
+
+type TestTeamHandler struct {
+	fn func()
+}
+
+func (h TestTeamHandler) HandleChannelMessage(_, _, _ string) {
 	h.fn()
 }
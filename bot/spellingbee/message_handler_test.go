@@ -2,6 +2,7 @@ package spellingbee
 
 import (
 	"fmt"
+	"github.com/bbeck/puzzles-with-chat/bot/chat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"io/ioutil"
@@ -29,6 +30,8 @@ func TestMessageHandler_HandleChannelMessage(t *testing.T) {
 				"solving":  {},
 				"paused":   {},
 				"complete": {},
+				"given_up": {},
+				"expired":  {},
 			},
 		},
 		{
@@ -38,6 +41,8 @@ func TestMessageHandler_HandleChannelMessage(t *testing.T) {
 				"solving":  {"/api/spellingbee/channel/answer", `"railroad"`},
 				"paused":   {},
 				"complete": {},
+				"given_up": {},
+				"expired":  {},
 			},
 		},
 		{
@@ -47,6 +52,8 @@ func TestMessageHandler_HandleChannelMessage(t *testing.T) {
 				"solving":  {"/api/spellingbee/channel/answer", `"railroad"`},
 				"paused":   {},
 				"complete": {},
+				"given_up": {},
+				"expired":  {},
 			},
 		},
 		{
@@ -56,6 +63,8 @@ func TestMessageHandler_HandleChannelMessage(t *testing.T) {
 				"solving":  {"/api/spellingbee/channel/answer", `"railroad"`},
 				"paused":   {},
 				"complete": {},
+				"given_up": {},
+				"expired":  {},
 			},
 		},
 		{
@@ -65,6 +74,8 @@ func TestMessageHandler_HandleChannelMessage(t *testing.T) {
 				"solving":  {"/api/spellingbee/channel/shuffle", ""},
 				"paused":   {},
 				"complete": {},
+				"given_up": {},
+				"expired":  {},
 			},
 		},
 		{
@@ -74,6 +85,8 @@ func TestMessageHandler_HandleChannelMessage(t *testing.T) {
 				"solving":  {"/api/spellingbee/channel/shuffle", ""},
 				"paused":   {},
 				"complete": {},
+				"given_up": {},
+				"expired":  {},
 			},
 		},
 	}
@@ -97,8 +110,8 @@ func TestMessageHandler_HandleChannelMessage(t *testing.T) {
 				parsed, err := url.Parse(server.URL)
 				require.NoError(t, err)
 
-				handler := NewMessageHandler(parsed.Host)
-				handler.HandleChannelMessage("channel", status, test.message)
+				handler := NewMessageHandler(parsed.Host, nil)
+				handler.HandleChannelMessage("channel", status, "user", test.message)
 
 				assert.Equal(t, expected.path, path)
 				assert.Equal(t, expected.body, body)
@@ -106,3 +119,73 @@ func TestMessageHandler_HandleChannelMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestMessageHandler_HandleChannelMessage_User(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.WriteHeader(200)
+
+		query = r.URL.Query()
+	}))
+	defer server.Close()
+
+	parsed, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	// The user that provided an answer is sent along with it so that their team
+	// can be credited.
+	handler := NewMessageHandler(parsed.Host, nil)
+	handler.HandleChannelMessage("channel", "solving", "Some User", "!coconut")
+
+	assert.Equal(t, "Some User", query.Get("user"))
+}
+
+func TestMessageHandler_HandleChannelMessage_Share(t *testing.T) {
+	tests := []struct {
+		status   string
+		code     int
+		expected []string
+	}{
+		{status: "selected"},
+		{status: "paused"},
+		{status: "solving"},
+		{status: "complete", code: 200, expected: []string{"header", "result"}},
+		{status: "given_up", code: 200, expected: []string{"header", "result"}},
+		{status: "expired", code: 200, expected: []string{"header", "result"}},
+		{status: "complete", code: 404},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s status (%d)", test.status, test.code), func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(test.code)
+				if test.code == 200 {
+					_, _ = w.Write([]byte("header\nresult\n"))
+				}
+			}))
+			defer server.Close()
+
+			parsed, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			var said []string
+			sayer := chat.SayerFunc(func(channel, message string) {
+				assert.Equal(t, "channel", channel)
+				said = append(said, message)
+			})
+
+			handler := NewMessageHandler(parsed.Host, sayer)
+			handler.HandleChannelMessage("channel", test.status, "user", "!share")
+
+			if test.code != 0 {
+				assert.Equal(t, "/api/spellingbee/channel/share", path)
+			} else {
+				assert.Equal(t, "", path)
+			}
+			assert.Equal(t, test.expected, said)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package team
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/bbeck/puzzles-with-chat/bot/web"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// The HTTP client to use when communicating with the api service from the
+// team integration.
+var DefaultTeamHTTPClient = &http.Client{
+	Timeout: 1 * time.Second,
+}
+
+// A regular expression that matches a message that's asking to join a team.
+// Capture group 1 is the name of the team.
+var JoinRegexp = regexp.MustCompile(
+	`^!(?i:join)\s+((?i:red|blue))\s*$`,
+)
+
+type MessageHandler struct {
+	baseURL string
+}
+
+func NewMessageHandler(host string) *MessageHandler {
+	url := fmt.Sprintf("http://%s/api/teams", host)
+	return &MessageHandler{baseURL: url}
+}
+
+// HandleChannelMessage parses a message and if it matches a team command sends
+// it to the appropriate API endpoint.
+func (h *MessageHandler) HandleChannelMessage(channel, user, message string) {
+	if match := JoinRegexp.FindStringSubmatch(message); len(match) != 0 {
+		name := strings.ToLower(match[1])
+
+		bs, err := json.Marshal(name)
+		if err != nil {
+			log.Printf("unable to marshal team (%s) to json: %v", name, err)
+			return
+		}
+
+		user = url.PathEscape(user)
+		url := fmt.Sprintf("%s/%s/%s", h.baseURL, channel, user)
+		response, err := web.PutWithClient(DefaultTeamHTTPClient, url, bytes.NewReader(bs))
+		defer func() { _ = response.Body.Close() }()
+		if err != nil {
+			log.Printf("error joining team, url: %s, team: %s\n", url, name)
+		}
+		return
+	}
+}
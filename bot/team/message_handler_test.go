@@ -0,0 +1,83 @@
+package team
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestMessageHandler_HandleChannelMessage(t *testing.T) {
+	tests := []struct {
+		name         string
+		message      string // the message the channel received
+		expectedPath string
+		expectedBody string
+	}{
+		{
+			name:    "not a command",
+			message: "hello there",
+		},
+		{
+			name:         "join red",
+			message:      "!join red",
+			expectedPath: "/api/teams/channel/user",
+			expectedBody: `"red"`,
+		},
+		{
+			name:         "join blue",
+			message:      "!join blue",
+			expectedPath: "/api/teams/channel/user",
+			expectedBody: `"blue"`,
+		},
+		{
+			name:         "mixed case",
+			message:      "!JOIN Red",
+			expectedPath: "/api/teams/channel/user",
+			expectedBody: `"red"`,
+		},
+		{
+			name:         "trailing whitespace",
+			message:      "!join blue  ",
+			expectedPath: "/api/teams/channel/user",
+			expectedBody: `"blue"`,
+		},
+		{
+			name:    "unknown team",
+			message: "!join green",
+		},
+		{
+			name:    "missing team",
+			message: "!join",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var path, body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				w.WriteHeader(200)
+
+				bs, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				path = r.URL.Path
+				body = string(bs)
+			}))
+			defer server.Close()
+
+			parsed, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			handler := NewMessageHandler(parsed.Host)
+			handler.HandleChannelMessage("channel", "user", test.message)
+
+			assert.Equal(t, test.expectedPath, path)
+			assert.Equal(t, test.expectedBody, body)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/bbeck/puzzles-with-chat/controller/sse"
 	"github.com/bbeck/puzzles-with-chat/controller/web"
 	"log"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -37,7 +38,17 @@ func main() {
 		cancel()
 	}()
 
-	events := sse.Open(ctx, fmt.Sprintf("http://%s/api/channels", host))
+	// Only subscribe to the crosswords of the channels that we control so that
+	// we aren't woken up by updates to any other channel.
+	query := url.Values{}
+	query.Add("type", "crossword")
+	for channel, enabled := range channels {
+		if enabled {
+			query.Add("channel", channel)
+		}
+	}
+
+	events := sse.Open(ctx, fmt.Sprintf("http://%s/api/channels?%s", host, query.Encode()))
 	actions := make(chan SwitchPuzzle, 10)
 
 	log.Printf("controlling channels: %v\n", channels)
@@ -110,7 +121,9 @@ func HandlePayload(payload Payload, actions chan<- SwitchPuzzle) error {
 			continue
 		}
 
-		if channel.Status != "complete" {
+		// Completed, given up and expired solves have all ended and can move on
+		// to the next puzzle.
+		if channel.Status != "complete" && channel.Status != "given_up" && channel.Status != "expired" {
 			continue
 		}
 
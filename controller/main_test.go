@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestHandlePayload(t *testing.T) {
+	tests := []struct {
+		name      string
+		channel   string
+		publisher string
+		status    string
+		advance   bool
+	}{
+		{
+			name:      "solving",
+			channel:   "bbeck",
+			publisher: "The New York Times",
+			status:    "solving",
+		},
+		{
+			name:      "paused",
+			channel:   "bbeck",
+			publisher: "The New York Times",
+			status:    "paused",
+		},
+		{
+			name:      "complete",
+			channel:   "bbeck",
+			publisher: "The New York Times",
+			status:    "complete",
+			advance:   true,
+		},
+		{
+			name:      "given up",
+			channel:   "bbeck",
+			publisher: "The New York Times",
+			status:    "given_up",
+			advance:   true,
+		},
+		{
+			name:      "expired",
+			channel:   "bbeck",
+			publisher: "The New York Times",
+			status:    "expired",
+			advance:   true,
+		},
+		{
+			name:      "other publisher",
+			channel:   "bbeck",
+			publisher: "The Wall Street Journal",
+			status:    "complete",
+		},
+		{
+			name:      "disabled channel",
+			channel:   "aidanwould",
+			publisher: "The New York Times",
+			status:    "complete",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload := NewPayload(t, test.channel, test.publisher, test.status, "2020-01-02")
+
+			actions := make(chan SwitchPuzzle, 1)
+			require.NoError(t, HandlePayload(payload, actions))
+
+			if !test.advance {
+				assert.Empty(t, actions)
+				return
+			}
+
+			require.Len(t, actions, 1)
+			action := <-actions
+			assert.Equal(t, test.channel, action.Channel)
+			assert.Equal(t, test.publisher, action.Publisher)
+			assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), action.Date)
+		})
+	}
+}
+
+// NewPayload builds the payload of a channels event containing a single
+// crossword channel.
+func NewPayload(t *testing.T, channel, publisher, status, published string) Payload {
+	t.Helper()
+
+	bs, err := json.Marshal(map[string]interface{}{
+		"crossword": []interface{}{
+			map[string]interface{}{
+				"name":   channel,
+				"status": status,
+				"puzzle": map[string]interface{}{
+					"publisher": publisher,
+					"published": published + "T00:00:00Z",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(bs, &payload))
+	return payload
+}
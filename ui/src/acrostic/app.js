@@ -1,7 +1,8 @@
 import React from "react";
 import {EventStream} from "common/event-stream";
 import {Fireworks} from "common/fireworks";
-import {Nav, StartPauseButton} from "common/nav";
+import {SolveSummary} from "common/summary";
+import {GiveUpButton, Nav, ResetButton, StartPauseButton} from "common/nav";
 import {PuzzleDropdown, SettingsDropdown, ViewsDropdown} from "acrostic/nav";
 import {AcrosticView} from "acrostic/view";
 
@@ -10,6 +11,8 @@ export function AcrosticApp(props) {
   const [settings, setSettings] = React.useState({
     clue_font_size: "normal",
     only_allow_correct_answers: false,
+    idle_timeout: "0s",
+    time_limit: "0s",
   });
 
   // The current state of the app for the current channel.
@@ -22,6 +25,10 @@ export function AcrosticApp(props) {
   // Whether or not we're currently showing fireworks.
   const [showFireworks, setShowFireworks] = React.useState(false);
 
+  // The summary of the most recently completed solve, shown along with the
+  // fireworks.
+  const [summary, setSummary] = React.useState(null);
+
   // Events for the puzzle being solved by the channel.
   const [stream] = React.useState(
     new EventStream(`/api/acrostic/${props.channel}/events`)
@@ -86,6 +93,20 @@ export function AcrosticApp(props) {
           setTimeout(() => setShowFireworks(false), 20000);
           break;
 
+        case "summary":
+          setSummary(event.payload);
+          break;
+
+        case "score":
+          // The state event that accompanies this event already contains the
+          // updated scores.
+          break;
+
+        case "expired":
+          // The solve ran out of time, the state event that accompanies this
+          // event has everything needed to show it.
+          break;
+
         case "ping":
           break;
 
@@ -93,18 +114,30 @@ export function AcrosticApp(props) {
           console.log("unhandled event:", event);
       }
     });
-  }, [setSettings, stream, setState, setShowFireworks]);
+  }, [setSettings, stream, setState, setShowFireworks, setSummary]);
 
   // Toggle the status.
   const toggleStatus = () => {
     return fetch(`/api/acrostic/${props.channel}/status`, {method: "PUT"});
   }
 
+  // Reset the puzzle, clearing all progress.
+  const reset = () => {
+    return fetch(`/api/acrostic/${props.channel}/reset`, {method: "PUT"});
+  }
+
+  // Give up on the puzzle, revealing the solution.
+  const giveUp = () => {
+    return fetch(`/api/acrostic/${props.channel}/giveup`, {method: "PUT"});
+  }
+
   return (
     <>
       <Nav puzzle="Acrostics" view={props.view} error={error}>
         <ul className="navbar-nav ml-auto">
           <StartPauseButton puzzle="acrostic" status={state.status} onClick={toggleStatus}/>
+          <ResetButton status={state.status} onClick={reset}/>
+          <GiveUpButton status={state.status} onClick={giveUp}/>
           <ViewsDropdown channel={props.channel}/>
           <SettingsDropdown channel={props.channel} settings={settings}/>
           <PuzzleDropdown channel={props.channel} setErrorMessage={setError}/>
@@ -119,6 +152,7 @@ export function AcrosticApp(props) {
         clearQuote={clearQuote}
       />
       {showFireworks && <Fireworks/>}
+      {showFireworks && <SolveSummary summary={summary}/>}
     </>
   );
 }
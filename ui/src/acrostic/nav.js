@@ -127,6 +127,40 @@ export function SettingsDropdown(props) {
               <button type="button" className={settings.clue_font_size === "xlarge" ? "btn btn-success" : "btn btn-dark"} onClick={update("clue_font_size", "xlarge")}>Extra Large</button>
             </div>
           </div>
+          <div className="dropdown-divider"/>
+          <div className="dropdown-item">
+            <div className="lead">Auto-pause</div>
+            <div>
+              <small className="text-muted">
+                This setting determines how long the solve can go without any
+                answers before it is automatically paused.  Time spent idle is
+                not counted towards the solve.
+              </small>
+            </div>
+            <div className="btn-group" role="group">
+              <button type="button" className={settings.idle_timeout === "0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("idle_timeout", "0s")}>Off</button>
+              <button type="button" className={settings.idle_timeout === "15m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("idle_timeout", "15m0s")}>15 Minutes</button>
+              <button type="button" className={settings.idle_timeout === "30m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("idle_timeout", "30m0s")}>30 Minutes</button>
+              <button type="button" className={settings.idle_timeout === "1h0m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("idle_timeout", "1h0m0s")}>1 Hour</button>
+            </div>
+          </div>
+          <div className="dropdown-divider"/>
+          <div className="dropdown-item">
+            <div className="lead">Time limit</div>
+            <div>
+              <small className="text-muted">
+                This setting determines how long chat has to finish the puzzle.
+                When the time runs out the solve ends.  Changes take effect the
+                next time a puzzle is started.
+              </small>
+            </div>
+            <div className="btn-group" role="group">
+              <button type="button" className={settings.time_limit === "0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("time_limit", "0s")}>Off</button>
+              <button type="button" className={settings.time_limit === "15m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("time_limit", "15m0s")}>15 Minutes</button>
+              <button type="button" className={settings.time_limit === "30m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("time_limit", "30m0s")}>30 Minutes</button>
+              <button type="button" className={settings.time_limit === "1h0m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("time_limit", "1h0m0s")}>1 Hour</button>
+            </div>
+          </div>
         </form>
       </div>
     </li>
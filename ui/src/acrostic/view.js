@@ -2,7 +2,7 @@ import React from "react";
 import "bootstrap/dist/js/bootstrap.bundle.min";
 import "bootstrap/dist/css/bootstrap.min.css";
 import "acrostic/view.css";
-import {Timer, TwitchChat} from "../common/view";
+import {Scoreboard, Timer, TwitchChat} from "../common/view";
 
 export function AcrosticView({state, settings, channel, view, quote, clearQuote}) {
   const puzzle = state && state.puzzle;
@@ -40,7 +40,9 @@ export function AcrosticView({state, settings, channel, view, quote, clearQuote}
           description={puzzle.description}
           last_start_time={state.last_start_time}
           total_solve_duration={state.total_solve_duration}
+          time_limit={state.time_limit}
         />
+        <Scoreboard scores={state.scores}/>
         <Grid puzzle={puzzle} cells={state.cells} view={view} quote={quote} clearQuote={clearQuote}/>
         <Clues
           puzzle={puzzle}
@@ -56,13 +58,14 @@ export function AcrosticView({state, settings, channel, view, quote, clearQuote}
   );
 }
 
-function Header({description, last_start_time, total_solve_duration}) {
+function Header({description, last_start_time, total_solve_duration, time_limit}) {
   return (
     <div className="header">
       <div className="description">{description}</div>
       <Timer
         last_start_time={last_start_time}
         total_solve_duration={total_solve_duration}
+        time_limit={time_limit}
       />
     </div>
   );
@@ -107,6 +110,10 @@ function Grid({puzzle, cells, view, quote, clearQuote}) {
     />
   );
 
+  // The solution is only included once the solve has finished.  When it's
+  // present the cells that weren't correctly filled in show the answer instead.
+  const solution = puzzle.cells;
+
   const boxes = [];
   for (let cy = 0; cy < puzzle.rows; cy++) {
     for (let cx = 0; cx < puzzle.cols; cx++) {
@@ -115,6 +122,9 @@ function Grid({puzzle, cells, view, quote, clearQuote}) {
       const content = cells[cy][cx] || "";
       const isBlock = puzzle.cell_blocks[cy][cx];
       const isFilled = view === "progress" && content !== "";
+      const answer = (solution && solution[cy][cx]) || "";
+      const isMissed = view !== "progress" && answer !== "" && content !== answer;
+      const shown = isMissed ? answer : content;
       const className = isBlock ? "cell block" : isFilled ? "cell filled" : "cell";
       const x = cx * s;
       const y = cy * s;
@@ -124,8 +134,8 @@ function Grid({puzzle, cells, view, quote, clearQuote}) {
           <rect x={x} y={y} width={s} height={s} className={className}/>
           <text x={x} y={y} className="number">{number}</text>
           <text x={x+s} y={y} className="letter">{letter}</text>
-          <text x={x} y={y} className="content" data-length={content.length}>
-            {view !== "progress" ? content : ""}
+          <text x={x} y={y} className={isMissed ? "content missed" : "content"} data-length={shown.length}>
+            {view !== "progress" ? shown : ""}
           </text>
         </g>
       );
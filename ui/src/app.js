@@ -123,13 +123,27 @@ function ActiveChannelList(props) {
       case "complete":
         status = "FINISHED";
         break;
+      case "given_up":
+        status = "GAVE UP";
+        break;
+      case "expired":
+        status = "OUT OF TIME";
+        break;
       default:
         status = "UNKNOWN";
         break;
     }
 
+    // Once a puzzle has been selected include how far along the solve is.
+    let progress = "";
+    if (channel.description && channel.max_score) {
+      progress = `, ${channel.score || 0} of ${channel.max_score} points`;
+    } else if (channel.description) {
+      progress = `, ${Math.floor(channel.percent_correct || 0)}% correct`;
+    }
+
     const description = channel.description
-      ? `${channel.description} (${status})`
+      ? `${channel.description} (${status}${progress})`
       : status;
 
     links.push(
@@ -74,7 +74,7 @@ export function StartPauseButton(props) {
     message = "Unpause";
   } else if (status === "solving") {
     message = "Pause";
-  } else if (status === undefined || status === "created" || status === "complete") {
+  } else if (status === undefined || status === "created" || status === "complete" || status === "given_up" || status === "expired") {
     return null;
   }
 
@@ -85,6 +85,44 @@ export function StartPauseButton(props) {
   );
 }
 
+export function ResetButton(props) {
+  const status = props.status;
+  if (status === undefined || status === "created") {
+    return null;
+  }
+
+  const onClick = () => {
+    if (window.confirm("Are you sure you want to reset the puzzle?  All progress will be lost.")) {
+      props.onClick();
+    }
+  };
+
+  return (
+    <form className="form-inline nav-item">
+      <button className="btn btn-dark" type="button" onClick={onClick}>Reset</button>
+    </form>
+  );
+}
+
+export function GiveUpButton(props) {
+  const status = props.status;
+  if (status !== "selected" && status !== "paused" && status !== "solving") {
+    return null;
+  }
+
+  const onClick = () => {
+    if (window.confirm("Are you sure you want to give up?  The solution will be revealed.")) {
+      props.onClick();
+    }
+  };
+
+  return (
+    <form className="form-inline nav-item">
+      <button className="btn btn-danger" type="button" onClick={onClick}>Give Up</button>
+    </form>
+  );
+}
+
 export function DateChooser(props) {
   const [selectedDate, setSelectedDate] = React.useState(null);
 
@@ -0,0 +1,84 @@
+import React from "react";
+import {pad, parseDuration} from "common/view";
+import "./summary.css";
+
+// SolveSummary shows the highlights of a solve once it has been completed.  It
+// sits on top of the fireworks that celebrate the completion.
+export function SolveSummary({summary}) {
+  if (!summary) {
+    return null;
+  }
+
+  return (
+    <div className="summary">
+      <h2>Solved in {format(summary.total_solve_duration)}</h2>
+      <dl>
+        <dt>Answers</dt>
+        <dd>{summary.answers_accepted} accepted of {summary.answers_submitted} submitted</dd>
+
+        {
+          summary.first_blood && (
+            <>
+              <dt>First blood</dt>
+              <dd>{summary.first_blood}</dd>
+            </>
+          )
+        }
+
+        {
+          summary.top_contributors.length > 0 && (
+            <>
+              <dt>Top contributors</dt>
+              <dd>
+                <ol>
+                  {
+                    summary.top_contributors.map(({user, answers}) => (
+                      <li key={user}>{user} ({answers})</li>
+                    ))
+                  }
+                </ol>
+              </dd>
+            </>
+          )
+        }
+
+        {
+          summary.fastest_clue && (
+            <>
+              <dt>Fastest answer</dt>
+              <dd><SolvedClue clue={summary.fastest_clue}/></dd>
+            </>
+          )
+        }
+
+        {
+          summary.last_clue && (
+            <>
+              <dt>Last answer</dt>
+              <dd><SolvedClue clue={summary.last_clue}/></dd>
+            </>
+          )
+        }
+      </dl>
+    </div>
+  );
+}
+
+function SolvedClue({clue}) {
+  return (
+    <>
+      {clue.clue && `${clue.clue}: `}{clue.answer}
+      {clue.user && ` by ${clue.user}`} in {format(clue.duration)}
+    </>
+  );
+}
+
+// Format a duration string as a number of hours, minutes and seconds.
+function format(duration) {
+  const total = parseDuration(duration);
+  const hours = Math.floor(total / 3600);
+  const minutes = Math.floor(total % 3600 / 60);
+  const seconds = Math.floor(total % 60);
+
+  return `${hours}h ${pad(minutes)}m ${pad(seconds)}s`;
+}
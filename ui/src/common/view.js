@@ -14,11 +14,33 @@ export function TwitchChat({channel}) {
   );
 }
 
-export function Timer({total_solve_duration, last_start_time}) {
+// Scoreboard shows the score of each team when chatters have joined teams to
+// race each other on the puzzle.
+export function Scoreboard({scores}) {
+  if (!scores) {
+    return null;
+  }
+
+  return (
+    <div className="scoreboard">
+      {
+        Object.entries(scores).map(([team, score]) => (
+          <span className={`team team-${team}`} key={team}>{team.toUpperCase()}: {score}</span>
+        ))
+      }
+    </div>
+  );
+}
+
+export function Timer({total_solve_duration, last_start_time, time_limit, asterisk}) {
   // Parse the duration into the total number of seconds that the solve has
   // accumulated prior to this most recent start.
   const prior = parseDuration(total_solve_duration);
 
+  // Parse the time limit into a number of seconds.  When the solve doesn't have
+  // a time limit this will be zero.
+  const limit = parseDuration(time_limit);
+
   // Parse the last start time into the number of seconds after the epoch that
   // we started the solving segment.  If there isn't a last start time then this
   // will be NaN.
@@ -27,10 +49,14 @@ export function Timer({total_solve_duration, last_start_time}) {
   // Render a duration that will self-update showing the number of seconds that
   // the solve has been progressing for.  We do this separately from this
   // component to ensure that we don't re-parse each time the duration updates.
-  return (<Duration prior={prior} started={started}/>);
+  //
+  // When asterisk is set the duration is flagged, for example because some of
+  // the puzzle's answers were revealed instead of solved.  When there is a time
+  // limit the duration counts down towards zero instead of up.
+  return (<Duration prior={prior} started={started} limit={limit} asterisk={asterisk}/>);
 }
 
-function Duration({prior, started}) {
+function Duration({prior, started, limit, asterisk}) {
   const [duration, setDuration] = React.useState("");
 
   // Repeatedly call a callback to recompute the number of seconds that the
@@ -40,7 +66,8 @@ function Duration({prior, started}) {
       ? new Date().getTime() / 1000 - started
       : 0;
 
-    const total = Math.max(Math.round(prior + delta), 0);
+    const elapsed = Math.max(Math.round(prior + delta), 0);
+    const total = limit > 0 ? Math.max(limit - elapsed, 0) : elapsed;
     const hours = Math.floor(total / 3600);
     const minutes = Math.floor(total % 3600 / 60);
     const seconds = Math.floor(total % 60);
@@ -48,12 +75,16 @@ function Duration({prior, started}) {
     setDuration(`${hours}h ${pad(minutes)}m ${pad(seconds)}s`);
   }, 500);
 
-  return <div className="timer">{duration}</div>;
+  return <div className="timer">{duration}{asterisk ? "*" : ""}</div>;
 }
 
 // Parse the provided duration string (e.g. 1h10m3s) into the total number of
 // seconds that the duration contains.
-function parseDuration(duration) {
+export function parseDuration(duration) {
+  if (!duration) {
+    return 0;
+  }
+
   const re = /(?:(?<h>[0-9]+)h)?(?:(?<m>[0-9]+)m)?(?:(?<s>[0-9.]+)s)?/;
   const match = re.exec(duration);
 
@@ -63,7 +94,7 @@ function parseDuration(duration) {
 }
 
 // Pad a number to 2 digits.
-function pad(n) {
+export function pad(n) {
   return (n < 10) ? "0" + n : n;
 }
 
@@ -1,7 +1,8 @@
 import React from "react";
 import {EventStream} from "common/event-stream";
 import {Fireworks} from "common/fireworks";
-import {Nav, StartPauseButton} from "common/nav";
+import {SolveSummary} from "common/summary";
+import {GiveUpButton, Nav, ResetButton, StartPauseButton} from "common/nav";
 import {PuzzleDropdown, SettingsDropdown, ViewsDropdown} from "crossword/nav";
 import {CrosswordView} from "crossword/view";
 
@@ -12,6 +13,12 @@ export function CrosswordApp(props) {
     clue_font_size: "normal",
     only_allow_correct_answers: false,
     show_notes: false,
+    allow_checks: false,
+    allow_reveals: false,
+    reveal_penalty: "0s",
+    hint_interval: "0s",
+    idle_timeout: "0s",
+    time_limit: "0s",
   });
 
   // The current state of the crossword app for the current channel.
@@ -20,6 +27,10 @@ export function CrosswordApp(props) {
   // Whether or not we're currently showing fireworks.
   const [showFireworks, setShowFireworks] = React.useState(false);
 
+  // The summary of the most recently completed solve, shown along with the
+  // fireworks.
+  const [summary, setSummary] = React.useState(null);
+
   // Events for the crossword being solved by the channel.
   const [stream] = React.useState(
     new EventStream(`/api/crossword/${props.channel}/events`)
@@ -74,6 +85,7 @@ export function CrosswordApp(props) {
           break;
 
         case "show_clue":
+        case "hint":
           // This is a bit of a hack since we just reach into the DOM to grab
           // the clue element, but this is just presentation logic and not
           // state, so trying to pull a reference to the clue element from deep
@@ -82,8 +94,17 @@ export function CrosswordApp(props) {
           const clue = document.getElementById(event.payload);
           if (clue !== null) {
             clue.scrollIntoView();
-            clue.classList.add("shown");
-            setTimeout(() => clue.classList.remove("shown"), 2500);
+
+            // When the clue is linked with other clues the whole group is
+            // highlighted together.
+            const ids = clue.dataset.linked ? clue.dataset.linked.split(" ") : [event.payload];
+            for (const id of ids) {
+              const element = document.getElementById(id);
+              if (element !== null) {
+                element.classList.add("shown");
+                setTimeout(() => element.classList.remove("shown"), 2500);
+              }
+            }
           }
           break;
 
@@ -92,6 +113,20 @@ export function CrosswordApp(props) {
           setTimeout(() => setShowFireworks(false), 20000);
           break;
 
+        case "summary":
+          setSummary(event.payload);
+          break;
+
+        case "score":
+          // The state event that accompanies this event already contains the
+          // updated scores.
+          break;
+
+        case "expired":
+          // The solve ran out of time, the state event that accompanies this
+          // event has everything needed to show it.
+          break;
+
         case "ping":
           break;
 
@@ -99,18 +134,30 @@ export function CrosswordApp(props) {
           console.log("unhandled event:", event);
       }
     });
-  }, [setSettings, stream, setState, setShowFireworks]);
+  }, [setSettings, stream, setState, setShowFireworks, setSummary]);
 
   // Toggle the status.
   const toggleStatus = () => {
     return fetch(`/api/crossword/${props.channel}/status`, {method: "PUT"});
   }
 
+  // Reset the puzzle, clearing all progress.
+  const reset = () => {
+    return fetch(`/api/crossword/${props.channel}/reset`, {method: "PUT"});
+  }
+
+  // Give up on the puzzle, revealing the solution.
+  const giveUp = () => {
+    return fetch(`/api/crossword/${props.channel}/giveup`, {method: "PUT"});
+  }
+
   return (
     <>
       <Nav puzzle="Crosswords" view={props.view} error={error}>
         <ul className="navbar-nav ml-auto">
           <StartPauseButton puzzle="crossword" status={state.status} onClick={toggleStatus}/>
+          <ResetButton status={state.status} onClick={reset}/>
+          <GiveUpButton status={state.status} onClick={giveUp}/>
           <ViewsDropdown channel={props.channel}/>
           <SettingsDropdown channel={props.channel} settings={settings}/>
           <PuzzleDropdown channel={props.channel} setErrorMessage={setError}/>
@@ -123,6 +170,7 @@ export function CrosswordApp(props) {
         settings={settings}
       />
       {showFireworks && <Fireworks/>}
+      {showFireworks && <SolveSummary summary={summary}/>}
     </>
   );
 }
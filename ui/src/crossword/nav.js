@@ -157,6 +157,97 @@ export function SettingsDropdown(props) {
             </div>
             <Switch checked={settings.show_notes} onClick={update("show_notes", !settings.show_notes)}/>
           </div>
+          <div className="dropdown-divider"/>
+          <div className="dropdown-item">
+            <div className="lead">Allow checks</div>
+            <div>
+              <small className="text-muted">
+                This setting allows chat to check an answer using&nbsp;
+                <code>!check 12a</code>. Cells with incorrect values will be
+                marked, but left in place.
+              </small>
+            </div>
+            <Switch checked={settings.allow_checks} onClick={update("allow_checks", !settings.allow_checks)}/>
+          </div>
+          <div className="dropdown-divider"/>
+          <div className="dropdown-item">
+            <div className="lead">Allow reveals</div>
+            <div>
+              <small className="text-muted">
+                This setting allows chat to reveal an answer using&nbsp;
+                <code>!reveal 12a</code> or a single square of an answer using&nbsp;
+                <code>!reveal 12a 3</code>. Solves that use reveals will have
+                their time marked with an asterisk.
+              </small>
+            </div>
+            <Switch checked={settings.allow_reveals} onClick={update("allow_reveals", !settings.allow_reveals)}/>
+          </div>
+          <div className="dropdown-divider"/>
+          <div className="dropdown-item">
+            <div className="lead">Reveal penalty</div>
+            <div>
+              <small className="text-muted">
+                This setting determines how much time is added to the solve for
+                each square that is revealed.
+              </small>
+            </div>
+            <div className="btn-group" role="group">
+              <button type="button" className={settings.reveal_penalty === "0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("reveal_penalty", "0s")}>None</button>
+              <button type="button" className={settings.reveal_penalty === "10s" ? "btn btn-success" : "btn btn-dark"} onClick={update("reveal_penalty", "10s")}>10 Seconds</button>
+              <button type="button" className={settings.reveal_penalty === "30s" ? "btn btn-success" : "btn btn-dark"} onClick={update("reveal_penalty", "30s")}>30 Seconds</button>
+              <button type="button" className={settings.reveal_penalty === "1m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("reveal_penalty", "1m0s")}>1 Minute</button>
+            </div>
+          </div>
+          <div className="dropdown-divider"/>
+          <div className="dropdown-item">
+            <div className="lead">Hints</div>
+            <div>
+              <small className="text-muted">
+                This setting determines how long the solve can go without any
+                progress before a letter is revealed as a hint.
+              </small>
+            </div>
+            <div className="btn-group" role="group">
+              <button type="button" className={settings.hint_interval === "0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("hint_interval", "0s")}>Off</button>
+              <button type="button" className={settings.hint_interval === "5m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("hint_interval", "5m0s")}>5 Minutes</button>
+              <button type="button" className={settings.hint_interval === "10m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("hint_interval", "10m0s")}>10 Minutes</button>
+              <button type="button" className={settings.hint_interval === "15m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("hint_interval", "15m0s")}>15 Minutes</button>
+            </div>
+          </div>
+          <div className="dropdown-divider"/>
+          <div className="dropdown-item">
+            <div className="lead">Auto-pause</div>
+            <div>
+              <small className="text-muted">
+                This setting determines how long the solve can go without any
+                answers before it is automatically paused.  Time spent idle is
+                not counted towards the solve.
+              </small>
+            </div>
+            <div className="btn-group" role="group">
+              <button type="button" className={settings.idle_timeout === "0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("idle_timeout", "0s")}>Off</button>
+              <button type="button" className={settings.idle_timeout === "15m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("idle_timeout", "15m0s")}>15 Minutes</button>
+              <button type="button" className={settings.idle_timeout === "30m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("idle_timeout", "30m0s")}>30 Minutes</button>
+              <button type="button" className={settings.idle_timeout === "1h0m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("idle_timeout", "1h0m0s")}>1 Hour</button>
+            </div>
+          </div>
+          <div className="dropdown-divider"/>
+          <div className="dropdown-item">
+            <div className="lead">Time limit</div>
+            <div>
+              <small className="text-muted">
+                This setting determines how long chat has to finish the puzzle.
+                When the time runs out the solve ends.  Changes take effect the
+                next time a puzzle is started.
+              </small>
+            </div>
+            <div className="btn-group" role="group">
+              <button type="button" className={settings.time_limit === "0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("time_limit", "0s")}>Off</button>
+              <button type="button" className={settings.time_limit === "15m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("time_limit", "15m0s")}>15 Minutes</button>
+              <button type="button" className={settings.time_limit === "30m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("time_limit", "30m0s")}>30 Minutes</button>
+              <button type="button" className={settings.time_limit === "1h0m0s" ? "btn btn-success" : "btn btn-dark"} onClick={update("time_limit", "1h0m0s")}>1 Hour</button>
+            </div>
+          </div>
         </form>
       </div>
     </li>
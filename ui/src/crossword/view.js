@@ -1,7 +1,7 @@
 import React from "react";
 import "bootstrap/dist/js/bootstrap.bundle.min";
 import "bootstrap/dist/css/bootstrap.min.css";
-import {Timer, TwitchChat} from "common/view";
+import {Scoreboard, Timer, TwitchChat} from "common/view";
 import "crossword/view.css";
 
 export function CrosswordView(props) {
@@ -55,6 +55,7 @@ export function CrosswordView(props) {
   const status = state.status;
   const last_start_time = state.last_start_time;
   const total_solve_duration = state.total_solve_duration;
+  const time_limit = state.time_limit;
   const settings = props.settings;
   const view = props.view;
   const channel = props.channel;
@@ -68,15 +69,26 @@ export function CrosswordView(props) {
           date={puzzle.published}
           last_start_time={last_start_time}
           total_solve_duration={total_solve_duration}
+          time_limit={time_limit}
+          revealed={hasRevealedCells(state.cells_revealed)}
         />
-        <Grid puzzle={puzzle} cells={state.cells} view={view}/>
-        <Footer/>
+        <Scoreboard scores={state.scores}/>
+        <Grid
+          puzzle={puzzle}
+          cells={state.cells}
+          incorrect={state.cells_incorrect}
+          revealed={state.cells_revealed}
+          discovered={state.cells_discovered}
+          view={view}
+        />
+        <Footer diagramless={puzzle.diagramless}/>
       </div>
       <Clues
         across_clues={puzzle.clues_across}
         across_clues_filled={state.across_clues_filled}
         down_clues={puzzle.clues_down}
         down_clues_filled={state.down_clues_filled}
+        linked_clues={puzzle.linked_clues}
         notes={puzzle.notes}
         clue_font_size={settings.clue_font_size}
         clues_to_show={settings.clues_to_show}
@@ -111,27 +123,57 @@ function Header(props) {
       <Timer
         last_start_time={props.last_start_time}
         total_solve_duration={props.total_solve_duration}
+        time_limit={props.time_limit}
+        asterisk={props.revealed}
       />
     </div>
   );
 }
 
-function Footer() {
+function Footer(props) {
+  // Diagramless puzzles need their answers placed before they can be given, so
+  // the placement command takes the place of the rebus one.
+  let rebus = <div>Answer with a rebus: <code>!12a (gray)goose</code></div>;
+  if (props.diagramless) {
+    rebus = <div>Place a clue at a row and column: <code>!place 12a 1 6</code></div>;
+  }
+
   return (
     <div className="footer">
       <div>Answer a clue: <code>!12a red velvet cake</code></div>
       <div>Partially answer a clue: <code>!12a gr.y goose</code></div>
-      <div>Answer with a rebus: <code>!12a (gray)goose</code></div>
+      {rebus}
       <div>Make a clue visible: <code>!show 10d</code></div>
+      <div>Check an answer: <code>!check 12a</code></div>
+      <div>Reveal a square: <code>!reveal 12a 3</code></div>
     </div>
   );
 }
 
+// Determine if any cell of the puzzle has been revealed.
+function hasRevealedCells(revealed) {
+  return (revealed || []).some(row => row.some(cell => cell));
+}
+
+// Determine if the content of a cell is one of its accepted values.  The first
+// letter of a rebus is accepted as well.
+function isAccepted(content, accepted) {
+  return content !== "" && accepted.some(value => content === value || (value.length > 1 && content === value[0]));
+}
+
 function Grid(props) {
   const puzzle = props.puzzle;
   const contents = props.cells;
+  const incorrect = props.incorrect || [];
+  const revealed = props.revealed || [];
+  const discovered = props.discovered;
   const view = props.view;
 
+  // The solution is only included once the solve has finished.  When it's
+  // present the cells that weren't correctly filled in show the answer instead.
+  const solution = puzzle.cells;
+  const alternatives = puzzle.cell_alternatives;
+
   // Because we're rendering as a SVG we'll make the size of each cell fixed
   // regardless of the width or height of the puzzle.  We'll then change the
   // view box of the SVG to contain the complete puzzle adding padding where
@@ -168,7 +210,16 @@ function Grid(props) {
       const isCircle = puzzle.cell_circles[cy][cx];
       const isShaded = puzzle.cell_shades[cy][cx];
       const isFilled = view === "progress" && content !== "";
-      const className = isBlock ? "cell block" : isFilled ? "cell filled" : isShaded ? "cell shaded" : "cell";
+      const isIncorrect = view !== "progress" && incorrect[cy] && incorrect[cy][cx];
+      const isRevealed = view !== "progress" && revealed[cy] && revealed[cy][cx];
+      const answer = (solution && solution[cy][cx]) || "";
+      const accepted = (alternatives && alternatives[cy][cx]) || [];
+      const isMissed = view !== "progress" && answer !== "" && !isAccepted(content, [answer, ...accepted]);
+      const shown = isMissed ? answer : content;
+      // In a diagramless solve the cells whose structure hasn't been discovered
+      // yet are drawn differently, until the solve finishes and reveals the grid.
+      const isUndiscovered = discovered && !solution && !discovered[cy][cx];
+      const className = isBlock ? "cell block" : isFilled ? "cell filled" : isUndiscovered ? "cell undiscovered" : isShaded ? "cell shaded" : "cell";
       const x = cx * s;
       const y = cy * s;
 
@@ -186,9 +237,11 @@ function Grid(props) {
         <g key={cy * puzzle.cols + cx}>
           <rect x={x} y={y} width={s} height={s} className={className}/>
           {circle}
+          {isIncorrect && <line x1={x} y1={y+s} x2={x+s} y2={y} className="incorrect"/>}
+          {isRevealed && <path d={`M ${x+s} ${y} l 0 ${s/4} l ${-s/4} ${-s/4} z`} className="revealed"/>}
           <text x={x} y={y} className="number">{number}</text>
-          <text x={x} y={y} className="content" data-length={content.length}>
-            {view !== "progress" ? content : ""}
+          <text x={x} y={y} className={isMissed ? "content missed" : "content"} data-length={shown.length}>
+            {view !== "progress" ? shown : ""}
           </text>
         </g>
       );
@@ -231,6 +284,7 @@ function Clues(props) {
   const across_clues_filled = props.across_clues_filled;
   const down_clues = props.down_clues;
   const down_clues_filled = props.down_clues_filled;
+  const linked_clues = props.linked_clues;
   const clue_notes = props.notes || "";
   const clues_to_show = props.clues_to_show;
   const clue_font_size = props.clue_font_size;
@@ -241,7 +295,7 @@ function Clues(props) {
     across = <div className="across">
       <div className="clue-title">Across</div>
       <div id="across-clues" className="clue-list">
-        <ClueList clues={across_clues} filled={across_clues_filled} side="a"/>
+        <ClueList clues={across_clues} filled={across_clues_filled} linked={linked_clues} side="a"/>
       </div>
     </div>;
   }
@@ -251,7 +305,7 @@ function Clues(props) {
     down = <div className="down">
       <div className="clue-title">Down</div>
       <div id="down-clues" className="clue-list">
-        <ClueList clues={down_clues} filled={down_clues_filled} side="d"/>
+        <ClueList clues={down_clues} filled={down_clues_filled} linked={linked_clues} side="d"/>
       </div>
     </div>;
   }
@@ -306,6 +360,15 @@ function ClueList(props) {
   const side = props.side;
   const filled = props.filled || {};
 
+  // Determine the group of linked clues that each clue belongs to so that the
+  // clues of a group can be highlighted together.
+  const groups = {};
+  for (const group of props.linked || []) {
+    for (const id of group) {
+      groups[id] = group;
+    }
+  }
+
   // Make sure to always list the clues in sorted order.
   const numbers = Object.keys(clues);
   numbers.sort(function (a, b) {
@@ -316,8 +379,18 @@ function ClueList(props) {
 
   const items = [];
   for (const number of numbers) {
+    const group = groups[number + side];
+
+    const classNames = [];
+    if (filled[number]) {
+      classNames.push("filled");
+    }
+    if (group) {
+      classNames.push("linked");
+    }
+
     items.push(
-      <li id={number + side} className={filled[number] ? "filled" : ""} key={number}>
+      <li id={number + side} className={classNames.join(" ")} data-linked={group ? group.join(" ") : undefined} key={number}>
         <span className="number">{number}</span>
         <span className="clue" dangerouslySetInnerHTML={{__html: clues[number]}}/>
       </li>
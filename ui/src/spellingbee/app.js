@@ -1,7 +1,8 @@
 import React from "react";
 import {EventStream} from "common/event-stream";
 import {Fireworks} from "common/fireworks";
-import {Nav, StartPauseButton} from "common/nav";
+import {SolveSummary} from "common/summary";
+import {GiveUpButton, Nav, ResetButton, StartPauseButton} from "common/nav";
 import {PuzzleDropdown, SettingsDropdown, ViewsDropdown} from "spellingbee/nav";
 import {SpellingBeeView} from "spellingbee/view";
 
@@ -10,15 +11,25 @@ export function SpellingBeeApp(props) {
   const [settings, setSettings] = React.useState({
     allow_unofficial_answers: false,
     show_answer_placeholders: false,
-    font_size: "normal"
+    font_size: "normal",
+    hint_interval: "0s",
+    idle_timeout: "0s",
+    time_limit: "0s",
   });
 
   // The current state of the spelling bee app for the current channel.
   const [state, setState] = React.useState({});
 
+  // The most recent hint that was given for the puzzle.
+  const [hint, setHint] = React.useState(null);
+
   // Whether or not we're currently showing fireworks.
   const [showFireworks, setShowFireworks] = React.useState(false);
 
+  // The summary of the most recently completed solve, shown along with the
+  // fireworks.
+  const [summary, setSummary] = React.useState(null);
+
   // Events for the crossword being solved by the channel.
   const [stream] = React.useState(
     new EventStream(`/api/spellingbee/${props.channel}/events`)
@@ -57,11 +68,35 @@ export function SpellingBeeApp(props) {
           }
           break;
 
+        case "hint":
+          setHint(event.payload);
+
+          const hintBanner = document.getElementById("hint-banner");
+          if (hintBanner !== null) {
+            hintBanner.classList.add("animate");
+            setTimeout(() => hintBanner.classList.remove("animate"), 3500);
+          }
+          break;
+
         case "complete":
           setShowFireworks(true);
           setTimeout(() => setShowFireworks(false), 20000);
           break;
 
+        case "summary":
+          setSummary(event.payload);
+          break;
+
+        case "score":
+          // The state event that accompanies this event already contains the
+          // updated scores.
+          break;
+
+        case "expired":
+          // The solve ran out of time, the state event that accompanies this
+          // event has everything needed to show it.
+          break;
+
         case "ping":
           break;
 
@@ -69,18 +104,30 @@ export function SpellingBeeApp(props) {
           console.log("unhandled event:", event);
       }
     });
-  }, [setSettings, stream, setState, setShowFireworks]);
+  }, [setSettings, stream, setState, setHint, setShowFireworks, setSummary]);
 
   // Toggle the status.
   const toggleStatus = () => {
     return fetch(`/api/spellingbee/${props.channel}/status`, {method: "PUT"});
   }
 
+  // Reset the puzzle, clearing all progress.
+  const reset = () => {
+    return fetch(`/api/spellingbee/${props.channel}/reset`, {method: "PUT"});
+  }
+
+  // Give up on the puzzle, revealing the solution.
+  const giveUp = () => {
+    return fetch(`/api/spellingbee/${props.channel}/giveup`, {method: "PUT"});
+  }
+
   return (
     <>
       <Nav puzzle="Spelling Bee" view={props.view} error={error}>
         <ul className="navbar-nav ml-auto">
           <StartPauseButton puzzle="spellingbee" status={state.status} onClick={toggleStatus}/>
+          <ResetButton status={state.status} onClick={reset}/>
+          <GiveUpButton status={state.status} onClick={giveUp}/>
           <ViewsDropdown channel={props.channel}/>
           <SettingsDropdown channel={props.channel} settings={settings}/>
           <PuzzleDropdown channel={props.channel} setErrorMessage={setError}/>
@@ -91,8 +138,10 @@ export function SpellingBeeApp(props) {
         view={props.view}
         state={state}
         settings={settings}
+        hint={hint}
       />
       {showFireworks && <Fireworks/>}
+      {showFireworks && <SolveSummary summary={summary}/>}
     </>
   );
 }
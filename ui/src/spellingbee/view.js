@@ -1,10 +1,10 @@
 import React from "react";
 import "bootstrap/dist/js/bootstrap.bundle.min";
 import "bootstrap/dist/css/bootstrap.min.css";
-import {Timer, TwitchChat} from "common/view";
+import {Scoreboard, Timer, TwitchChat} from "common/view";
 import "spellingbee/view.css";
 
-export function SpellingBeeView({channel, view, state, settings}) {
+export function SpellingBeeView({channel, view, state, settings, hint}) {
   if (!state.puzzle) {
     return (
       <div className="jumbotron">
@@ -41,6 +41,24 @@ export function SpellingBeeView({channel, view, state, settings}) {
   const max_score = !settings.allow_unofficial_answers
     ? puzzle.max_official_score
     : puzzle.max_unofficial_score;
+  // Once the puzzle has finished the answers are included so that the words
+  // that weren't found can be shown.  They're sorted to match the indices of
+  // the found words.
+  let answers = null;
+  if (puzzle.official_answers) {
+    answers = [...puzzle.official_answers];
+    if (settings.allow_unofficial_answers && puzzle.unofficial_answers) {
+      answers.push(...puzzle.unofficial_answers);
+    }
+    answers.sort();
+  }
+
+  // The words that were missed come with the number of points they were worth.
+  const missed = {};
+  for (const word of state.missed_words || []) {
+    missed[word.word] = word.points;
+  }
+
   const isGenius = state.score >= Math.round(max_score * 0.7);
   const isQueenBee = state.score === max_score;
 
@@ -49,6 +67,9 @@ export function SpellingBeeView({channel, view, state, settings}) {
       <div id="genius-banner" className="banner">
         GENIUS!<span role="img" aria-label="genius">&nbsp;&#x1f393;</span>
       </div>
+      <div id="hint-banner" className="banner">
+        {hint && `${hint.prefix}${"_".repeat(hint.length - hint.prefix.length)}`}
+      </div>
       <div className="puzzle">
         <Header
           date={puzzle.published}
@@ -57,7 +78,9 @@ export function SpellingBeeView({channel, view, state, settings}) {
           isQueenBee={isQueenBee}
           last_start_time={state.last_start_time}
           total_solve_duration={state.total_solve_duration}
+          time_limit={state.time_limit}
         />
+        <Scoreboard scores={state.scores}/>
         <Grid center={puzzle.center} letters={state.letters}/>
         <Footer/>
       </div>
@@ -66,6 +89,8 @@ export function SpellingBeeView({channel, view, state, settings}) {
         font_size={settings.font_size}
         view={view}
         words={state.words}
+        answers={answers}
+        missed={missed}
         total={total_num_words}
       />
       { view === "player" && <TwitchChat channel={channel}/> }
@@ -95,6 +120,7 @@ function Header(props) {
       <Timer
         last_start_time={props.last_start_time}
         total_solve_duration={props.total_solve_duration}
+        time_limit={props.time_limit}
       />
     </div>
   );
@@ -163,7 +189,7 @@ function Cell(props) {
   );
 }
 
-function WordsList({show_placeholders, font_size, view, words, total}) {
+function WordsList({show_placeholders, font_size, view, words, answers, missed, total}) {
   const isProgress = view === "progress";
   const className = isProgress ? "word filled" : "word";
 
@@ -192,6 +218,21 @@ function WordsList({show_placeholders, font_size, view, words, total}) {
     );
   }
 
+  // If we have the answers then the puzzle has ended without all of the words
+  // being found, show the missed ones.
+  if (answers) {
+    for (let index = 0; index < answers.length; index++) {
+      if (!entries[index]) {
+        entries[index] = (
+          <div className="word missed" key={index}>
+            {answers[index]}
+            {missed[answers[index]] !== undefined && ` (${missed[answers[index]]})`}
+          </div>
+        );
+      }
+    }
+  }
+
   // If we're showing all words, not just the ones that have been provided then
   // we insert placeholder entries into the map.
   if (show_placeholders) {